@@ -0,0 +1,562 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/go-chi/render"
+	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/doppelganger"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/logging/fields"
+	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
+	"github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/operator/proposerconfig"
+	"github.com/ssvlabs/ssv/operator/validator/metadata"
+)
+
+// fullGCTimeout bounds the duration of a FullGC cycle triggered through the admin API.
+const fullGCTimeout = 30 * time.Minute
+
+// LogLevel allows reading and changing the log level at runtime, it's implemented by zap.AtomicLevel.
+type LogLevel interface {
+	Level() zapcore.Level
+	SetLevel(zapcore.Level)
+}
+
+// GarbageCollector runs a storage garbage collection cycle, it's implemented by the storage engines in kv.
+type GarbageCollector interface {
+	FullGC(context.Context) error
+}
+
+// DatabaseBackup writes an online backup of the node database to a file, it's implemented by backup.Online.
+type DatabaseBackup interface {
+	WriteFile(path string) error
+}
+
+// MetadataSyncer fetches and saves the beacon metadata of validators, it's implemented by metadata.Syncer.
+type MetadataSyncer interface {
+	Sync(ctx context.Context, pubKeys []spectypes.ValidatorPK) (metadata.ValidatorMap, error)
+}
+
+// PeerAdmin manages connections with peers, it's implemented by the p2p network.
+type PeerAdmin interface {
+	DisconnectPeer(id peer.ID) error
+	BanPeer(id peer.ID) error
+	UnbanPeer(id peer.ID) bool
+	BannedPeers() []peer.ID
+	AddBan(ban peers.Ban) error
+	RemoveBan(ban peers.Ban) (bool, error)
+	Bans() []peers.Ban
+}
+
+// DoppelgangerOverride overrides the Doppelganger protection state of validators, it's implemented by doppelganger.Provider.
+type DoppelgangerOverride interface {
+	OverrideValidatorState(validatorIndex phase0.ValidatorIndex, safe bool) (doppelganger.ValidatorState, error)
+}
+
+// ProposerConfig reads and replaces the proposer config, it's implemented by proposerconfig.Store.
+type ProposerConfig interface {
+	Config() proposerconfig.Config
+	Update(config proposerconfig.Config) error
+}
+
+// Admin serves operations which change the node state at runtime.
+// It must only be routed behind authentication.
+type Admin struct {
+	Logger         *zap.Logger
+	LogLevel       LogLevel
+	DB             GarbageCollector
+	MetadataSyncer MetadataSyncer
+	Peers          PeerAdmin
+
+	// Doppelganger is optional, overrides are disabled unless it's set.
+	Doppelganger DoppelgangerOverride
+
+	// ProposerConfig is optional, the proposer config can't be read or updated unless it's set.
+	ProposerConfig ProposerConfig
+
+	// Backup and BackupDir are optional, backups are disabled unless both are set.
+	Backup    DatabaseBackup
+	BackupDir string
+
+	gcRunning     atomic.Bool
+	backupRunning atomic.Bool
+}
+
+type logLevelJSON struct {
+	Level string `json:"level"`
+}
+
+func (h *Admin) GetLogLevel(w http.ResponseWriter, r *http.Request) error {
+	return api.Render(w, r, logLevelJSON{Level: h.LogLevel.Level().String()})
+}
+
+func (h *Admin) SetLogLevel(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Level string `json:"level" form:"level"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	level, err := zapcore.ParseLevel(request.Level)
+	if err != nil {
+		return api.BadRequestError(err)
+	}
+
+	previous := h.LogLevel.Level()
+	h.LogLevel.SetLevel(level)
+	h.Logger.Info("log level changed through admin API",
+		zap.Stringer("previous", previous),
+		zap.Stringer("level", level),
+	)
+
+	return api.Render(w, r, logLevelJSON{Level: level.String()})
+}
+
+// FullGC starts a full storage garbage collection cycle in the background,
+// since it can take much longer than the API write timeout.
+func (h *Admin) FullGC(w http.ResponseWriter, r *http.Request) error {
+	if !h.gcRunning.CompareAndSwap(false, true) {
+		err := errors.New("garbage collection is already running")
+		return &api.ErrorResponse{
+			Err:     err,
+			Code:    http.StatusConflict,
+			Status:  http.StatusText(http.StatusConflict),
+			Message: err.Error(),
+		}
+	}
+
+	go func() {
+		defer h.gcRunning.Store(false)
+
+		ctx, cancel := context.WithTimeout(context.Background(), fullGCTimeout)
+		defer cancel()
+
+		start := time.Now()
+		h.Logger.Info("starting full GC cycle through admin API")
+		if err := h.DB.FullGC(ctx); err != nil {
+			h.Logger.Error("full GC cycle failed", zap.Error(err))
+			return
+		}
+		h.Logger.Info("full GC cycle completed", fields.Took(time.Since(start)))
+	}()
+
+	w.WriteHeader(http.StatusAccepted)
+	return nil
+}
+
+type backupJSON struct {
+	File string `json:"file"`
+}
+
+// BackupDB starts writing an online backup of the database into the backup directory in the background.
+// The backup file only appears in the directory once it's complete.
+func (h *Admin) BackupDB(w http.ResponseWriter, r *http.Request) error {
+	if h.Backup == nil || h.BackupDir == "" {
+		err := errors.New("database backups are not configured")
+		return &api.ErrorResponse{
+			Err:     err,
+			Code:    http.StatusNotFound,
+			Status:  http.StatusText(http.StatusNotFound),
+			Message: err.Error(),
+		}
+	}
+	if !h.backupRunning.CompareAndSwap(false, true) {
+		err := errors.New("backup is already running")
+		return &api.ErrorResponse{
+			Err:     err,
+			Code:    http.StatusConflict,
+			Status:  http.StatusText(http.StatusConflict),
+			Message: err.Error(),
+		}
+	}
+
+	path := filepath.Join(h.BackupDir, fmt.Sprintf("ssv-db-%s.bak", time.Now().UTC().Format("20060102T150405Z")))
+
+	go func() {
+		defer h.backupRunning.Store(false)
+
+		start := time.Now()
+		h.Logger.Info("starting database backup through admin API", zap.String("file", path))
+		if err := h.Backup.WriteFile(path); err != nil {
+			h.Logger.Error("database backup failed", zap.String("file", path), zap.Error(err))
+			return
+		}
+		h.Logger.Info("database backup completed", zap.String("file", path), fields.Took(time.Since(start)))
+	}()
+
+	render.Status(r, http.StatusAccepted)
+	return api.Render(w, r, backupJSON{File: path})
+}
+
+type validatorMetadataJSON struct {
+	PubKey api.Hex `json:"public_key"`
+	Index  uint64  `json:"index"`
+	Status string  `json:"status"`
+}
+
+func (h *Admin) SyncValidatorsMetadata(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PubKeys api.HexSlice `json:"pubkeys" form:"pubkeys"`
+	}
+	var response struct {
+		Data []validatorMetadataJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	if len(request.PubKeys) == 0 {
+		return api.BadRequestError(errors.New("at least one public key is required"))
+	}
+
+	pubKeys := make([]spectypes.ValidatorPK, 0, len(request.PubKeys))
+	for _, pk := range request.PubKeys {
+		if len(pk) != len(spectypes.ValidatorPK{}) {
+			return api.BadRequestError(fmt.Errorf("invalid public key length: %d", len(pk)))
+		}
+		pubKeys = append(pubKeys, spectypes.ValidatorPK(pk))
+	}
+
+	validators, err := h.MetadataSyncer.Sync(r.Context(), pubKeys)
+	if err != nil {
+		return api.Error(fmt.Errorf("sync metadata: %w", err))
+	}
+
+	response.Data = []validatorMetadataJSON{}
+	for _, pk := range pubKeys {
+		v, ok := validators[pk]
+		if !ok || v == nil {
+			continue
+		}
+		response.Data = append(response.Data, validatorMetadataJSON{
+			PubKey: pk[:],
+			Index:  uint64(v.Index),
+			Status: v.Status.String(),
+		})
+	}
+	return api.Render(w, r, response)
+}
+
+func (h *Admin) BannedPeers(w http.ResponseWriter, r *http.Request) error {
+	var response struct {
+		Data []peer.ID `json:"data"`
+	}
+	response.Data = h.Peers.BannedPeers()
+	if response.Data == nil {
+		response.Data = []peer.ID{}
+	}
+	return api.Render(w, r, response)
+}
+
+func (h *Admin) DisconnectPeer(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PeerID string `json:"peer_id" form:"peer_id"`
+		Ban    bool   `json:"ban" form:"ban"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	id, err := peer.Decode(request.PeerID)
+	if err != nil {
+		return api.BadRequestError(fmt.Errorf("invalid peer id: %w", err))
+	}
+
+	if request.Ban {
+		if err := h.Peers.BanPeer(id); err != nil {
+			return api.Error(fmt.Errorf("ban peer: %w", err))
+		}
+		h.Logger.Info("peer banned through admin API", fields.PeerID(id))
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	if err := h.Peers.DisconnectPeer(id); err != nil {
+		if errors.Is(err, p2pv1.ErrPeerNotConnected) {
+			return api.ErrNotFound
+		}
+		return api.Error(fmt.Errorf("disconnect peer: %w", err))
+	}
+	h.Logger.Info("peer disconnected through admin API", fields.PeerID(id))
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (h *Admin) UnbanPeer(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PeerID string `json:"peer_id" form:"peer_id"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	id, err := peer.Decode(request.PeerID)
+	if err != nil {
+		return api.BadRequestError(fmt.Errorf("invalid peer id: %w", err))
+	}
+
+	if !h.Peers.UnbanPeer(id) {
+		return api.ErrNotFound
+	}
+	h.Logger.Info("peer unbanned through admin API", fields.PeerID(id))
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+type banJSON struct {
+	PeerID    string     `json:"peer_id,omitempty"`
+	CIDR      string     `json:"cidr,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func banFromBan(ban peers.Ban) banJSON {
+	b := banJSON{
+		CIDR:      ban.CIDR,
+		Reason:    ban.Reason,
+		CreatedAt: ban.CreatedAt,
+	}
+	if ban.PeerID != "" {
+		b.PeerID = ban.PeerID.String()
+	}
+	if !ban.ExpiresAt.IsZero() {
+		b.ExpiresAt = &ban.ExpiresAt
+	}
+	return b
+}
+
+// banTarget parses the peer ID or IP range targeted by a ban request.
+func banTarget(peerID, cidr string) (peers.Ban, error) {
+	ban := peers.Ban{CIDR: cidr}
+	if peerID != "" {
+		id, err := peer.Decode(peerID)
+		if err != nil {
+			return peers.Ban{}, fmt.Errorf("invalid peer id: %w", err)
+		}
+		ban.PeerID = id
+	}
+	return ban.Normalize()
+}
+
+// Bans lists the peers and IP ranges which are banned, including the bans persisted before a restart.
+func (h *Admin) Bans(w http.ResponseWriter, r *http.Request) error {
+	var response struct {
+		Data []banJSON `json:"data"`
+	}
+	response.Data = []banJSON{}
+	for _, ban := range h.Peers.Bans() {
+		response.Data = append(response.Data, banFromBan(ban))
+	}
+	return api.Render(w, r, response)
+}
+
+// AddBan bans a peer or an IP range, permanently unless a duration is given, and disconnects from the peers it applies to.
+func (h *Admin) AddBan(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PeerID   string `json:"peer_id" form:"peer_id"`
+		CIDR     string `json:"cidr" form:"cidr"`
+		Reason   string `json:"reason" form:"reason"`
+		Duration string `json:"duration" form:"duration"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	ban, err := banTarget(request.PeerID, request.CIDR)
+	if err != nil {
+		return api.BadRequestError(err)
+	}
+	ban.Reason = request.Reason
+	ban.CreatedAt = time.Now()
+	if request.Duration != "" {
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil {
+			return api.BadRequestError(fmt.Errorf("invalid duration: %w", err))
+		}
+		if duration <= 0 {
+			return api.BadRequestError(errors.New("duration must be positive"))
+		}
+		ban.ExpiresAt = ban.CreatedAt.Add(duration)
+	}
+
+	if err := h.Peers.AddBan(ban); err != nil {
+		return api.Error(fmt.Errorf("add ban: %w", err))
+	}
+	h.Logger.Info("ban added through admin API",
+		fields.PeerID(ban.PeerID),
+		zap.String("cidr", ban.CIDR),
+		zap.String("reason", ban.Reason),
+		zap.Time("expires_at", ban.ExpiresAt),
+	)
+
+	render.Status(r, http.StatusCreated)
+	return api.Render(w, r, banFromBan(ban))
+}
+
+// RemoveBan lifts the ban of a peer or an IP range.
+func (h *Admin) RemoveBan(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PeerID string `json:"peer_id" form:"peer_id"`
+		CIDR   string `json:"cidr" form:"cidr"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	ban, err := banTarget(request.PeerID, request.CIDR)
+	if err != nil {
+		return api.BadRequestError(err)
+	}
+
+	removed, err := h.Peers.RemoveBan(ban)
+	if err != nil {
+		return api.Error(fmt.Errorf("remove ban: %w", err))
+	}
+	if !removed {
+		return api.ErrNotFound
+	}
+	h.Logger.Info("ban removed through admin API", fields.PeerID(ban.PeerID), zap.String("cidr", ban.CIDR))
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// OverrideDoppelganger marks a validator as safe to sign or restarts its Doppelganger detection.
+// Every override is recorded in the audit log.
+func (h *Admin) OverrideDoppelganger(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Index  uint64 `json:"index" form:"index"`
+		State  string `json:"state" form:"state"`
+		Reason string `json:"reason" form:"reason"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	var safe bool
+	switch request.State {
+	case "safe":
+		safe = true
+	case "unsafe":
+	default:
+		return api.BadRequestError(fmt.Errorf("invalid state %q, must be 'safe' or 'unsafe'", request.State))
+	}
+
+	if h.Doppelganger == nil {
+		return &api.ErrorResponse{
+			Err:     doppelganger.ErrDisabled,
+			Code:    http.StatusNotFound,
+			Status:  http.StatusText(http.StatusNotFound),
+			Message: doppelganger.ErrDisabled.Error(),
+		}
+	}
+
+	state, err := h.Doppelganger.OverrideValidatorState(phase0.ValidatorIndex(request.Index), safe)
+	if err != nil {
+		if errors.Is(err, doppelganger.ErrValidatorNotFound) || errors.Is(err, doppelganger.ErrDisabled) {
+			return &api.ErrorResponse{
+				Err:     err,
+				Code:    http.StatusNotFound,
+				Status:  http.StatusText(http.StatusNotFound),
+				Message: err.Error(),
+			}
+		}
+		return api.Error(fmt.Errorf("override doppelganger state: %w", err))
+	}
+
+	h.Logger.Named(logging.NameAudit).Warn("doppelganger state overridden through admin API",
+		zap.String("action", "doppelganger_override"),
+		fields.ValidatorIndex(phase0.ValidatorIndex(request.Index)),
+		zap.String("state", request.State),
+		zap.String("reason", request.Reason),
+		zap.String("remote_addr", r.RemoteAddr),
+		zap.String("client", clientIdentity(r)),
+	)
+
+	return api.Render(w, r, doppelgangerStateFromState(state))
+}
+
+var errProposerConfigDisabled = errors.New("proposer config file is not configured")
+
+type proposerConfigJSON struct {
+	Config proposerconfig.Config `json:"config"`
+	// Warnings flag the values which must match across the committees of the validators they apply to.
+	Warnings []proposerconfig.Warning `json:"warnings"`
+}
+
+func newProposerConfigJSON(config proposerconfig.Config) proposerConfigJSON {
+	warnings := config.Warnings()
+	if warnings == nil {
+		warnings = []proposerconfig.Warning{}
+	}
+	return proposerConfigJSON{Config: config, Warnings: warnings}
+}
+
+// GetProposerConfig returns the per-owner and per-validator proposer settings.
+func (h *Admin) GetProposerConfig(w http.ResponseWriter, r *http.Request) error {
+	if h.ProposerConfig == nil {
+		return &api.ErrorResponse{
+			Err:     errProposerConfigDisabled,
+			Code:    http.StatusNotFound,
+			Status:  http.StatusText(http.StatusNotFound),
+			Message: errProposerConfigDisabled.Error(),
+		}
+	}
+	return api.Render(w, r, newProposerConfigJSON(h.ProposerConfig.Config()))
+}
+
+// UpdateProposerConfig replaces the proposer config, which applies from the next duties of the validators
+// and is persisted to the proposer config file.
+func (h *Admin) UpdateProposerConfig(w http.ResponseWriter, r *http.Request) error {
+	if h.ProposerConfig == nil {
+		return &api.ErrorResponse{
+			Err:     errProposerConfigDisabled,
+			Code:    http.StatusNotFound,
+			Status:  http.StatusText(http.StatusNotFound),
+			Message: errProposerConfigDisabled.Error(),
+		}
+	}
+
+	var request proposerconfig.Config
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	if err := request.Validate(); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	if err := h.ProposerConfig.Update(request); err != nil {
+		return api.Error(fmt.Errorf("update proposer config: %w", err))
+	}
+
+	response := newProposerConfigJSON(request)
+	h.Logger.Info("proposer config updated through admin API",
+		zap.Int("owners", len(request.Owners)),
+		zap.Int("validators", len(request.Validators)),
+		zap.Int("warnings", len(response.Warnings)),
+	)
+
+	return api.Render(w, r, response)
+}
+
+// clientIdentity returns the subject of the verified client certificate, if any.
+func clientIdentity(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return r.TLS.VerifiedChains[0][0].Subject.String()
+}
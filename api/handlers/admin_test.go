@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/doppelganger"
+	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
+	"github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/operator/proposerconfig"
+	"github.com/ssvlabs/ssv/operator/validator/metadata"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+type mockGarbageCollector struct {
+	calls chan struct{}
+}
+
+func (m *mockGarbageCollector) FullGC(context.Context) error {
+	m.calls <- struct{}{}
+	return nil
+}
+
+type mockDatabaseBackup struct {
+	paths chan string
+}
+
+func (m *mockDatabaseBackup) WriteFile(path string) error {
+	m.paths <- path
+	return nil
+}
+
+type mockMetadataSyncer struct {
+	synced []spectypes.ValidatorPK
+}
+
+func (m *mockMetadataSyncer) Sync(_ context.Context, pubKeys []spectypes.ValidatorPK) (metadata.ValidatorMap, error) {
+	m.synced = append(m.synced, pubKeys...)
+	result := metadata.ValidatorMap{}
+	for i, pk := range pubKeys {
+		result[pk] = &beacon.ValidatorMetadata{Index: 100 + phase0.ValidatorIndex(i), Status: eth2apiv1.ValidatorStateActiveOngoing}
+	}
+	return result, nil
+}
+
+type mockPeerAdmin struct {
+	connected map[peer.ID]bool
+	banned    map[peer.ID]bool
+	bans      []peers.Ban
+}
+
+func (m *mockPeerAdmin) DisconnectPeer(id peer.ID) error {
+	if !m.connected[id] {
+		return p2pv1.ErrPeerNotConnected
+	}
+	delete(m.connected, id)
+	return nil
+}
+
+func (m *mockPeerAdmin) BanPeer(id peer.ID) error {
+	delete(m.connected, id)
+	m.banned[id] = true
+	return nil
+}
+
+func (m *mockPeerAdmin) UnbanPeer(id peer.ID) bool {
+	if !m.banned[id] {
+		return false
+	}
+	delete(m.banned, id)
+	return true
+}
+
+func (m *mockPeerAdmin) BannedPeers() []peer.ID {
+	var ids []peer.ID
+	for id := range m.banned {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (m *mockPeerAdmin) AddBan(ban peers.Ban) error {
+	delete(m.connected, ban.PeerID)
+	m.bans = append(m.bans, ban)
+	return nil
+}
+
+func (m *mockPeerAdmin) RemoveBan(target peers.Ban) (bool, error) {
+	for i, ban := range m.bans {
+		if ban.PeerID == target.PeerID && ban.CIDR == target.CIDR {
+			m.bans = append(m.bans[:i], m.bans[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockPeerAdmin) Bans() []peers.Ban {
+	return m.bans
+}
+
+type mockDoppelgangerOverride struct {
+	overrides map[phase0.ValidatorIndex]bool
+}
+
+func (m *mockDoppelgangerOverride) OverrideValidatorState(validatorIndex phase0.ValidatorIndex, safe bool) (doppelganger.ValidatorState, error) {
+	if _, ok := m.overrides[validatorIndex]; !ok {
+		return doppelganger.ValidatorState{}, doppelganger.ErrValidatorNotFound
+	}
+	m.overrides[validatorIndex] = safe
+
+	state := doppelganger.ValidatorState{ValidatorIndex: validatorIndex, Safe: safe, RemainingEpochs: 2}
+	if safe {
+		state.RemainingEpochs = 0
+		state.SafeReason = doppelganger.SafeReasonOverride
+	}
+	return state, nil
+}
+
+func newTestAdmin() (*Admin, *mockGarbageCollector, *mockMetadataSyncer, *mockPeerAdmin) {
+	gc := &mockGarbageCollector{calls: make(chan struct{}, 1)}
+	syncer := &mockMetadataSyncer{}
+	peers := &mockPeerAdmin{connected: map[peer.ID]bool{}, banned: map[peer.ID]bool{}}
+	return &Admin{
+		Logger:         zap.NewNop(),
+		LogLevel:       zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		DB:             gc,
+		MetadataSyncer: syncer,
+		Peers:          peers,
+	}, gc, syncer, peers
+}
+
+func adminRequest(handler api.HandlerFunc, method, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "/", strings.NewReader(body))
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	api.Handler(handler)(w, r)
+	return w
+}
+
+func TestAdmin_LogLevel(t *testing.T) {
+	h, _, _, _ := newTestAdmin()
+
+	w := adminRequest(h.GetLogLevel, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"level":"info"}`, w.Body.String())
+
+	w = adminRequest(h.SetLogLevel, http.MethodPost, `{"level":"debug"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, zapcore.DebugLevel, h.LogLevel.Level())
+
+	w = adminRequest(h.SetLogLevel, http.MethodPost, `{"level":"loud"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, zapcore.DebugLevel, h.LogLevel.Level())
+}
+
+func TestAdmin_FullGC(t *testing.T) {
+	h, gc, _, _ := newTestAdmin()
+
+	w := adminRequest(h.FullGC, http.MethodPost, "")
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	select {
+	case <-gc.calls:
+	case <-time.After(5 * time.Second):
+		t.Fatal("FullGC wasn't called")
+	}
+	require.Eventually(t, func() bool {
+		return !h.gcRunning.Load()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAdmin_BackupDB(t *testing.T) {
+	h, _, _, _ := newTestAdmin()
+
+	w := adminRequest(h.BackupDB, http.MethodPost, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	backup := &mockDatabaseBackup{paths: make(chan string, 1)}
+	h.Backup = backup
+	h.BackupDir = t.TempDir()
+
+	w = adminRequest(h.BackupDB, http.MethodPost, "")
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var response backupJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, h.BackupDir, filepath.Dir(response.File))
+
+	select {
+	case path := <-backup.paths:
+		require.Equal(t, response.File, path)
+	case <-time.After(5 * time.Second):
+		t.Fatal("WriteFile wasn't called")
+	}
+	require.Eventually(t, func() bool {
+		return !h.backupRunning.Load()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAdmin_SyncValidatorsMetadata(t *testing.T) {
+	h, _, syncer, _ := newTestAdmin()
+
+	pk := spectypes.ValidatorPK{1, 2, 3}
+	body, err := json.Marshal(map[string]any{"pubkeys": []api.Hex{pk[:]}})
+	require.NoError(t, err)
+
+	w := adminRequest(h.SyncValidatorsMetadata, http.MethodPost, string(body))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []spectypes.ValidatorPK{pk}, syncer.synced)
+
+	var response struct {
+		Data []validatorMetadataJSON `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	require.Equal(t, uint64(100), response.Data[0].Index)
+	require.Equal(t, eth2apiv1.ValidatorStateActiveOngoing.String(), response.Data[0].Status)
+
+	w = adminRequest(h.SyncValidatorsMetadata, http.MethodPost, `{"pubkeys":[]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = adminRequest(h.SyncValidatorsMetadata, http.MethodPost, `{"pubkeys":["0102"]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdmin_Peers(t *testing.T) {
+	h, _, _, peers := newTestAdmin()
+
+	id, err := peer.Decode("16Uiu2HAm7dxB1EyhLCF1V4bHo6xXPiSGv4ZNbKU5jiDTvHbN6TTP")
+	require.NoError(t, err)
+	peers.connected[id] = true
+
+	w := adminRequest(h.DisconnectPeer, http.MethodPost, `{"peer_id":"`+id.String()+`"}`)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.False(t, peers.connected[id])
+
+	w = adminRequest(h.DisconnectPeer, http.MethodPost, `{"peer_id":"`+id.String()+`"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = adminRequest(h.DisconnectPeer, http.MethodPost, `{"peer_id":"`+id.String()+`","ban":true}`)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.True(t, peers.banned[id])
+
+	w = adminRequest(h.BannedPeers, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"data":["`+id.String()+`"]}`, w.Body.String())
+
+	w = adminRequest(h.UnbanPeer, http.MethodPost, `{"peer_id":"`+id.String()+`"}`)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.False(t, peers.banned[id])
+
+	w = adminRequest(h.UnbanPeer, http.MethodPost, `{"peer_id":"`+id.String()+`"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = adminRequest(h.DisconnectPeer, http.MethodPost, `{"peer_id":"not-a-peer"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdmin_Bans(t *testing.T) {
+	h, _, _, peerAdmin := newTestAdmin()
+
+	id, err := peer.Decode("16Uiu2HAm7dxB1EyhLCF1V4bHo6xXPiSGv4ZNbKU5jiDTvHbN6TTP")
+	require.NoError(t, err)
+	peerAdmin.connected[id] = true
+
+	w := adminRequest(h.Bans, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"data":[]}`, w.Body.String())
+
+	w = adminRequest(h.AddBan, http.MethodPost, `{"peer_id":"`+id.String()+`","reason":"spam","duration":"24h"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.False(t, peerAdmin.connected[id])
+	require.Len(t, peerAdmin.bans, 1)
+	require.Equal(t, id, peerAdmin.bans[0].PeerID)
+	require.Equal(t, "spam", peerAdmin.bans[0].Reason)
+	require.Equal(t, 24*time.Hour, peerAdmin.bans[0].ExpiresAt.Sub(peerAdmin.bans[0].CreatedAt))
+
+	w = adminRequest(h.AddBan, http.MethodPost, `{"cidr":"10.0.0.1"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.True(t, peerAdmin.bans[1].ExpiresAt.IsZero())
+
+	w = adminRequest(h.Bans, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data []banJSON `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 2)
+	require.Equal(t, id.String(), response.Data[0].PeerID)
+	require.NotNil(t, response.Data[0].ExpiresAt)
+	require.Equal(t, "10.0.0.1/32", response.Data[1].CIDR)
+	require.Nil(t, response.Data[1].ExpiresAt)
+
+	w = adminRequest(h.RemoveBan, http.MethodDelete, `{"peer_id":"`+id.String()+`"}`)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Len(t, peerAdmin.bans, 1)
+
+	w = adminRequest(h.RemoveBan, http.MethodDelete, `{"peer_id":"`+id.String()+`"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	for _, body := range []string{
+		`{}`,
+		`{"peer_id":"` + id.String() + `","cidr":"10.0.0.0/8"}`,
+		`{"cidr":"not-an-ip"}`,
+		`{"peer_id":"not-a-peer"}`,
+		`{"cidr":"10.0.0.0/8","duration":"forever"}`,
+		`{"cidr":"10.0.0.0/8","duration":"-1h"}`,
+	} {
+		w = adminRequest(h.AddBan, http.MethodPost, body)
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestAdmin_OverrideDoppelganger(t *testing.T) {
+	h, _, _, _ := newTestAdmin()
+
+	w := adminRequest(h.OverrideDoppelganger, http.MethodPost, `{"index":1,"state":"safe"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	dg := &mockDoppelgangerOverride{overrides: map[phase0.ValidatorIndex]bool{1: false}}
+	h.Doppelganger = dg
+
+	w = adminRequest(h.OverrideDoppelganger, http.MethodPost, `{"index":1,"state":"safe","reason":"migrated from another node"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"index":1,"state":"safe","remaining_epochs":0,"safe_reason":"override"}`, w.Body.String())
+	require.True(t, dg.overrides[1])
+
+	w = adminRequest(h.OverrideDoppelganger, http.MethodPost, `{"index":1,"state":"unsafe"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"index":1,"state":"unsafe","remaining_epochs":2}`, w.Body.String())
+	require.False(t, dg.overrides[1])
+
+	w = adminRequest(h.OverrideDoppelganger, http.MethodPost, `{"index":2,"state":"safe"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = adminRequest(h.OverrideDoppelganger, http.MethodPost, `{"index":1,"state":"maybe"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdmin_ProposerConfig(t *testing.T) {
+	h, _, _, _ := newTestAdmin()
+
+	w := adminRequest(h.GetProposerConfig, http.MethodGet, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	store, err := proposerconfig.Open(filepath.Join(t.TempDir(), "proposer-config.yaml"))
+	require.NoError(t, err)
+	h.ProposerConfig = store
+
+	w = adminRequest(h.GetProposerConfig, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"config":{"default":{}},"warnings":[]}`, w.Body.String())
+
+	owner := "0x0000000000000000000000000000000000000001"
+	w = adminRequest(h.UpdateProposerConfig, http.MethodPut, `{"default":{"graffiti":"ssv"},"owners":{"`+owner+`":{"gas_limit":36000000}}}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response proposerConfigJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Warnings, 1)
+	require.Equal(t, "gas_limit", response.Warnings[0].Field)
+	require.Equal(t, uint64(36_000_000), store.Config().Owners[owner].GasLimit)
+
+	w = adminRequest(h.UpdateProposerConfig, http.MethodPut, `{"default":{"builder_policy":"mev"}}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "ssv", store.Config().Default.Graffiti)
+}
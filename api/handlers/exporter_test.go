@@ -75,6 +75,14 @@ func (m *mockParticipantStore) GetParticipants(spectypes.ValidatorPK, phase0.Slo
 	return nil, nil
 }
 
+func (m *mockParticipantStore) SaveProof(spectypes.ValidatorPK, phase0.Slot, *qbftstorage.ParticipantsProof) error {
+	return nil
+}
+
+func (m *mockParticipantStore) GetProof(spectypes.ValidatorPK, phase0.Slot) (*qbftstorage.ParticipantsProof, error) {
+	return nil, nil
+}
+
 func (m *mockParticipantStore) Prune(context.Context, *zap.Logger, phase0.Slot) {
 	// no-op.
 }
@@ -2,17 +2,27 @@ package handlers
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
 
 	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/message/validation"
+	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
 	networkpeers "github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/network/records"
 	"github.com/ssvlabs/ssv/nodeprobe"
+	ssvmessage "github.com/ssvlabs/ssv/protocol/v2/message"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 )
 
 const (
@@ -20,10 +30,23 @@ const (
 	healthyInbounds  = 4
 )
 
+var errValidationTraceDisabled = errors.New("message validation trace is disabled")
+
 type TopicIndex interface {
 	PeersByTopic() map[string][]peer.ID
 }
 
+// SubnetsHealthProvider provides the health of the subnets we need for our committees.
+type SubnetsHealthProvider interface {
+	SubnetsHealth() []p2pv1.SubnetHealth
+}
+
+// ValidationTrace provides the recent message validation decisions, it's implemented by validation.DecisionTrace.
+type ValidationTrace interface {
+	Decisions(filter validation.DecisionFilter) []validation.Decision
+	PeerReasons(peerID peer.ID) []validation.PeerReasons
+}
+
 type AllPeersAndTopicsJSON struct {
 	AllPeers     []peer.ID        `json:"all_peers"`
 	PeersByTopic []topicIndexJSON `json:"peers_by_topic"`
@@ -46,6 +69,8 @@ type peerJSON struct {
 	Connectedness string           `json:"connectedness"`
 	Subnets       string           `json:"subnets"`
 	Version       string           `json:"version"`
+	// Capabilities is omitted for peers which don't advertise them.
+	Capabilities *records.Capabilities `json:"capabilities,omitempty"`
 }
 
 type identityJSON struct {
@@ -55,6 +80,20 @@ type identityJSON struct {
 	Version   string   `json:"version"`
 }
 
+type scoreDistributionJSON struct {
+	Min    float64 `json:"min"`
+	Median float64 `json:"median"`
+	Max    float64 `json:"max"`
+}
+
+type subnetHealthJSON struct {
+	Subnet    uint64                `json:"subnet"`
+	Peers     int                   `json:"peers"`
+	MeshPeers int                   `json:"mesh_peers"`
+	Scores    scoreDistributionJSON `json:"scores"`
+	Status    string                `json:"status"`
+}
+
 type healthStatus struct {
 	err error
 }
@@ -87,12 +126,61 @@ func (hc healthCheckJSON) String() string {
 	return string(b)
 }
 
+type validationDecisionJSON struct {
+	Time        time.Time      `json:"time"`
+	PeerID      peer.ID        `json:"peer_id"`
+	MessageID   string         `json:"message_id"`
+	Role        string         `json:"role,omitempty"`
+	Validator   string         `json:"validator,omitempty"`
+	CommitteeID string         `json:"committee_id,omitempty"`
+	Slot        phase0.Slot    `json:"slot"`
+	Round       specqbft.Round `json:"round"`
+	Reason      string         `json:"reason"`
+	Result      string         `json:"result"`
+}
+
+func validationDecisionFromDecision(d validation.Decision) validationDecisionJSON {
+	resp := validationDecisionJSON{
+		Time:      d.Time,
+		PeerID:    d.PeerID,
+		MessageID: hex.EncodeToString([]byte(d.MessageID)),
+		Slot:      d.Slot,
+		Round:     d.Round,
+		Reason:    d.Reason,
+		Result:    "ignore",
+	}
+	if d.Reject {
+		resp.Result = "reject"
+	}
+
+	// Messages which couldn't be decoded have no duty executor.
+	if len(d.DutyExecutorID) > 0 {
+		resp.Role = ssvmessage.RunnerRoleToString(d.Role)
+		if d.Role == spectypes.RoleCommittee && len(d.DutyExecutorID) == 48 {
+			resp.CommitteeID = hex.EncodeToString(d.DutyExecutorID[16:])
+		} else {
+			resp.Validator = hex.EncodeToString(d.DutyExecutorID)
+		}
+	}
+	return resp
+}
+
+type peerReasonsJSON struct {
+	PeerID   peer.ID           `json:"peer_id"`
+	LastSeen time.Time         `json:"last_seen"`
+	Rejected map[string]uint64 `json:"rejected"`
+	Ignored  map[string]uint64 `json:"ignored"`
+}
+
 type Node struct {
 	ListenAddresses []string
 	PeersIndex      networkpeers.Index
 	TopicIndex      TopicIndex
 	Network         network.Network
 	NodeProber      *nodeprobe.Prober
+	ValidationTrace ValidationTrace
+	Shares          registrystorage.Shares
+	SubnetsHealth   SubnetsHealthProvider
 }
 
 func (h *Node) Identity(w http.ResponseWriter, r *http.Request) error {
@@ -167,6 +255,126 @@ func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, resp)
 }
 
+// Subnets returns the health of each subnet we need for our committees, so that
+// weakly covered subnets can be spotted.
+func (h *Node) Subnets(w http.ResponseWriter, r *http.Request) error {
+	var response struct {
+		Data []subnetHealthJSON `json:"data"`
+	}
+	response.Data = []subnetHealthJSON{}
+	for _, health := range h.SubnetsHealth.SubnetsHealth() {
+		response.Data = append(response.Data, subnetHealthJSON{
+			Subnet:    health.Subnet,
+			Peers:     health.Peers,
+			MeshPeers: health.MeshPeers,
+			Scores: scoreDistributionJSON{
+				Min:    health.Scores.Min,
+				Median: health.Scores.Median,
+				Max:    health.Scores.Max,
+			},
+			Status: string(health.Status),
+		})
+	}
+	return api.Render(w, r, response)
+}
+
+// ValidationDecisions returns the recent messages which failed validation, most recent first.
+// Filtering by validator also returns the messages of its committee's duties.
+func (h *Node) ValidationDecisions(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Peer      string  `json:"peer" form:"peer"`
+		Validator api.Hex `json:"validator" form:"validator"`
+		Limit     int     `json:"limit" form:"limit"`
+	}
+	var response struct {
+		Data []validationDecisionJSON `json:"data"`
+	}
+
+	if h.ValidationTrace == nil {
+		return validationTraceDisabledError()
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	if request.Limit < 0 {
+		return api.BadRequestError(fmt.Errorf("limit must not be negative"))
+	}
+
+	filter := validation.DecisionFilter{Limit: request.Limit}
+	if request.Peer != "" {
+		peerID, err := peer.Decode(request.Peer)
+		if err != nil {
+			return api.BadRequestError(fmt.Errorf("invalid peer: %w", err))
+		}
+		filter.PeerID = peerID
+	}
+	if len(request.Validator) > 0 {
+		if len(request.Validator) != len(spectypes.ValidatorPK{}) {
+			return api.BadRequestError(fmt.Errorf("invalid validator public key length: %d", len(request.Validator)))
+		}
+		filter.Validator = request.Validator
+		if share, ok := h.Shares.Get(nil, request.Validator); ok {
+			committeeID := share.CommitteeID()
+			filter.CommitteeID = &committeeID
+		}
+	}
+
+	response.Data = []validationDecisionJSON{}
+	for _, d := range h.ValidationTrace.Decisions(filter) {
+		response.Data = append(response.Data, validationDecisionFromDecision(d))
+	}
+	return api.Render(w, r, response)
+}
+
+// ValidationRejections returns the reasons for which peers' messages failed validation,
+// peers with the most rejected messages first.
+func (h *Node) ValidationRejections(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Peer string `json:"peer" form:"peer"`
+	}
+	var response struct {
+		Data []peerReasonsJSON `json:"data"`
+	}
+
+	if h.ValidationTrace == nil {
+		return validationTraceDisabledError()
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	var peerID peer.ID
+	if request.Peer != "" {
+		var err error
+		peerID, err = peer.Decode(request.Peer)
+		if err != nil {
+			return api.BadRequestError(fmt.Errorf("invalid peer: %w", err))
+		}
+	}
+
+	response.Data = []peerReasonsJSON{}
+	for _, reasons := range h.ValidationTrace.PeerReasons(peerID) {
+		response.Data = append(response.Data, peerReasonsJSON{
+			PeerID:   reasons.PeerID,
+			LastSeen: reasons.LastSeen,
+			Rejected: reasons.Rejected,
+			Ignored:  reasons.Ignored,
+		})
+	}
+	return api.Render(w, r, response)
+}
+
+func validationTraceDisabledError() error {
+	return &api.ErrorResponse{
+		Err:     errValidationTraceDisabled,
+		Code:    http.StatusNotFound,
+		Status:  http.StatusText(http.StatusNotFound),
+		Message: errValidationTraceDisabled.Error(),
+	}
+}
+
 func (h *Node) peers(peers []peer.ID) []peerJSON {
 	resp := make([]peerJSON, len(peers))
 	for i, id := range peers {
@@ -193,6 +401,7 @@ func (h *Node) peers(peers []peer.ID) []peerJSON {
 			continue
 		}
 		resp[i].Version = nodeInfo.Metadata.NodeVersion
+		resp[i].Capabilities = nodeInfo.Metadata.Capabilities
 	}
 	return resp
 }
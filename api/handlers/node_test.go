@@ -19,9 +19,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/message/validation"
 	"github.com/ssvlabs/ssv/network"
 	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
 	"github.com/ssvlabs/ssv/nodeprobe"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
 )
 
 // CreateTestNode builds a test Node using a local network.
@@ -53,10 +55,11 @@ func CreateTestNode(t *testing.T, n int, ctx context.Context) *Node {
 			fmt.Sprintf("tcp://%s:%d", "localhost", 3030),
 			fmt.Sprintf("udp://%s:%d", "localhost", 3030),
 		},
-		PeersIndex: ln.Nodes[0].(p2pv1.PeersIndexProvider).PeersIndex(),
-		Network:    ln.Nodes[0].(p2pv1.HostProvider).Host().Network(),
-		TopicIndex: ln.Nodes[0].(TopicIndex),
-		NodeProber: nodeProber,
+		PeersIndex:    ln.Nodes[0].(p2pv1.PeersIndexProvider).PeersIndex(),
+		Network:       ln.Nodes[0].(p2pv1.HostProvider).Host().Network(),
+		TopicIndex:    ln.Nodes[0].(TopicIndex),
+		NodeProber:    nodeProber,
+		SubnetsHealth: ln.Nodes[0].(SubnetsHealthProvider),
 	}
 }
 
@@ -215,6 +218,22 @@ func TestNodeHandlers(t *testing.T) {
 				require.NoError(t, json.Unmarshal(body, &topics))
 			},
 		},
+		{
+			name:    "subnets",
+			method:  "GET",
+			url:     "/v1/node/subnets",
+			handler: api.Handler(node.Subnets),
+			verify: func(t *testing.T, body []byte) {
+				var subnets struct {
+					Data []subnetHealthJSON `json:"data"`
+				}
+
+				require.NoError(t, json.Unmarshal(body, &subnets))
+				for _, subnet := range subnets.Data {
+					require.Contains(t, []string{"dead", "weak", "healthy"}, subnet.Status)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -263,3 +282,109 @@ func TestHealthCheckJSONString(t *testing.T) {
 	require.Equal(t, float64(0), advanced["outbound_conns"])
 	require.Equal(t, []interface{}{"127.0.0.1:8000"}, advanced["p2p_listen_addresses"])
 }
+
+type mockValidationTrace struct {
+	decisions []validation.Decision
+	reasons   []validation.PeerReasons
+
+	filter validation.DecisionFilter
+	peerID peer.ID
+}
+
+func (m *mockValidationTrace) Decisions(filter validation.DecisionFilter) []validation.Decision {
+	m.filter = filter
+	return m.decisions
+}
+
+func (m *mockValidationTrace) PeerReasons(peerID peer.ID) []validation.PeerReasons {
+	m.peerID = peerID
+	return m.reasons
+}
+
+// TestNodeValidationTrace tests the ValidationDecisions and ValidationRejections methods of the Node handler.
+func TestNodeValidationTrace(t *testing.T) {
+	peerID, err := peer.Decode("16Uiu2HAm7dxB1EyhLCF1V4bHo6xXPiSGv4ZNbKU5jiDTvHbN6TTP")
+	require.NoError(t, err)
+
+	share := &types.SSVShare{Share: spectypes.Share{ValidatorPubKey: spectypes.ValidatorPK{1}}}
+	committeeID := share.CommitteeID()
+	decidedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	trace := &mockValidationTrace{
+		decisions: []validation.Decision{
+			{
+				Time:           decidedAt,
+				PeerID:         peerID,
+				MessageID:      "\x01\x02",
+				DutyExecutorID: append(make([]byte, 16), committeeID[:]...),
+				Role:           spectypes.RoleCommittee,
+				Slot:           10,
+				Round:          2,
+				Reason:         "signer is not leader",
+				Reject:         true,
+			},
+			{
+				Time:   decidedAt,
+				PeerID: peerID,
+				Reason: "pub-sub message is malformed",
+			},
+		},
+		reasons: []validation.PeerReasons{{
+			PeerID:   peerID,
+			LastSeen: decidedAt,
+			Rejected: map[string]uint64{"signer is not leader": 1},
+			Ignored:  map[string]uint64{"pub-sub message is malformed": 1},
+		}},
+	}
+	h := &Node{ValidationTrace: trace, Shares: newMockShares([]*types.SSVShare{share})}
+
+	t.Run("decisions", func(t *testing.T) {
+		target := fmt.Sprintf("/?peer=%s&validator=%s&limit=5", peerID, hex.EncodeToString(share.ValidatorPubKey[:]))
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rr := httptest.NewRecorder()
+		api.Handler(h.ValidationDecisions)(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, validation.DecisionFilter{
+			PeerID:      peerID,
+			Validator:   share.ValidatorPubKey[:],
+			CommitteeID: &committeeID,
+			Limit:       5,
+		}, trace.filter)
+		require.JSONEq(t, fmt.Sprintf(`{"data":[
+			{"time":"2025-01-01T00:00:00Z","peer_id":"%[1]s","message_id":"0102","role":"COMMITTEE",
+			 "committee_id":"%[2]x","slot":10,"round":2,"reason":"signer is not leader","result":"reject"},
+			{"time":"2025-01-01T00:00:00Z","peer_id":"%[1]s","message_id":"","slot":0,"round":0,
+			 "reason":"pub-sub message is malformed","result":"ignore"}
+		]}`, peerID, committeeID[:]), rr.Body.String())
+	})
+
+	t.Run("decisions with invalid peer", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/?peer=invalid", nil)
+		rr := httptest.NewRecorder()
+		api.Handler(h.ValidationDecisions)(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("rejections", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/?peer="+peerID.String(), nil)
+		rr := httptest.NewRecorder()
+		api.Handler(h.ValidationRejections)(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, peerID, trace.peerID)
+		require.JSONEq(t, fmt.Sprintf(`{"data":[{"peer_id":"%s","last_seen":"2025-01-01T00:00:00Z",
+			"rejected":{"signer is not leader":1},"ignored":{"pub-sub message is malformed":1}}]}`, peerID), rr.Body.String())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+		api.Handler((&Node{}).ValidationDecisions)(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = httptest.NewRecorder()
+		api.Handler((&Node{}).ValidationRejections)(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -2,6 +2,10 @@ package handlers
 
 import (
 	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,13 +13,34 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 
+	"github.com/go-chi/chi/v5"
+
 	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/doppelganger"
+	"github.com/ssvlabs/ssv/operator/dutyhistory"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
 	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 )
 
+var (
+	errDutyHistoryDisabled  = errors.New("duty history is disabled")
+	errDoppelgangerDisabled = errors.New("doppelganger protection is disabled")
+)
+
+// DutyHistory provides the recorded duty outcomes of validators.
+type DutyHistory interface {
+	Get(pubKey spectypes.ValidatorPK, from, to phase0.Slot) ([]dutyhistory.Entry, error)
+}
+
+// DoppelgangerStates provides the Doppelganger protection state of validators, it's implemented by doppelganger.Provider.
+type DoppelgangerStates interface {
+	ValidatorStates() []doppelganger.ValidatorState
+}
+
 type Validators struct {
-	Shares registrystorage.Shares
+	Shares       registrystorage.Shares
+	DutyHistory  DutyHistory
+	Doppelganger DoppelgangerStates
 }
 
 func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
@@ -63,6 +88,104 @@ func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, response)
 }
 
+// Duties returns the recorded duty outcomes of a validator within an optional inclusive slot range.
+func (h *Validators) Duties(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		From uint64 `json:"from" form:"from"`
+		To   uint64 `json:"to" form:"to"`
+	}
+	var response struct {
+		Data []dutyhistory.Entry `json:"data"`
+	}
+
+	if h.DutyHistory == nil {
+		return &api.ErrorResponse{
+			Err:     errDutyHistoryDisabled,
+			Code:    http.StatusNotFound,
+			Status:  http.StatusText(http.StatusNotFound),
+			Message: errDutyHistoryDisabled.Error(),
+		}
+	}
+
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(chi.URLParam(r, "pubkey"), "0x"))
+	if err != nil || len(pubKey) != len(spectypes.ValidatorPK{}) {
+		return api.BadRequestError(fmt.Errorf("invalid validator public key"))
+	}
+
+	request.To = math.MaxUint64
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	if request.From > request.To {
+		return api.BadRequestError(fmt.Errorf("'from' must be less than or equal to 'to'"))
+	}
+
+	entries, err := h.DutyHistory.Get(spectypes.ValidatorPK(pubKey), phase0.Slot(request.From), phase0.Slot(request.To))
+	if err != nil {
+		return api.Error(fmt.Errorf("error getting duty history: %w", err))
+	}
+	response.Data = entries
+	return api.Render(w, r, response)
+}
+
+type doppelgangerStateJSON struct {
+	Index           uint64 `json:"index"`
+	State           string `json:"state"`
+	RemainingEpochs uint64 `json:"remaining_epochs"`
+	SafeReason      string `json:"safe_reason,omitempty"`
+}
+
+func doppelgangerStateFromState(state doppelganger.ValidatorState) doppelgangerStateJSON {
+	resp := doppelgangerStateJSON{
+		Index:           uint64(state.ValidatorIndex),
+		State:           "unsafe",
+		RemainingEpochs: uint64(state.RemainingEpochs),
+		SafeReason:      string(state.SafeReason),
+	}
+	if state.Safe {
+		resp.State = "safe"
+	}
+	return resp
+}
+
+// DoppelgangerStates returns the Doppelganger protection state of the node's validators,
+// optionally filtered by validator indices.
+func (h *Validators) DoppelgangerStates(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Indices api.Uint64Slice `json:"indices" form:"indices"`
+	}
+	var response struct {
+		Data []doppelgangerStateJSON `json:"data"`
+	}
+
+	if h.Doppelganger == nil {
+		return &api.ErrorResponse{
+			Err:     errDoppelgangerDisabled,
+			Code:    http.StatusNotFound,
+			Status:  http.StatusText(http.StatusNotFound),
+			Message: errDoppelgangerDisabled.Error(),
+		}
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	indices := make(map[uint64]struct{}, len(request.Indices))
+	for _, index := range request.Indices {
+		indices[index] = struct{}{}
+	}
+
+	response.Data = []doppelgangerStateJSON{}
+	for _, state := range h.Doppelganger.ValidatorStates() {
+		if _, ok := indices[uint64(state.ValidatorIndex)]; len(indices) > 0 && !ok {
+			continue
+		}
+		response.Data = append(response.Data, doppelgangerStateFromState(state))
+	}
+	return api.Render(w, r, response)
+}
+
 func byOwners(owners []api.Hex) registrystorage.SharesFilter {
 	return func(share *types.SSVShare) bool {
 		for _, a := range owners {
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -11,11 +13,14 @@ import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/doppelganger"
+	"github.com/ssvlabs/ssv/operator/dutyhistory"
 	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
 	"github.com/ssvlabs/ssv/registry/storage"
@@ -480,7 +485,12 @@ SharesLoop:
 	return result
 }
 
-func (m *mockShares) Get(_ basedb.Reader, _ []byte) (*types.SSVShare, bool) {
+func (m *mockShares) Get(_ basedb.Reader, pubKey []byte) (*types.SSVShare, bool) {
+	for _, share := range m.shares {
+		if bytes.Equal(share.ValidatorPubKey[:], pubKey) {
+			return share, true
+		}
+	}
 	return nil, false
 }
 
@@ -633,3 +643,160 @@ func TestValidatorsList(t *testing.T) {
 		})
 	}
 }
+
+type mockDutyHistory struct {
+	entries map[spectypes.ValidatorPK][]dutyhistory.Entry
+}
+
+func (m *mockDutyHistory) Get(pubKey spectypes.ValidatorPK, from, to phase0.Slot) ([]dutyhistory.Entry, error) {
+	var entries []dutyhistory.Entry
+	for _, entry := range m.entries[pubKey] {
+		if entry.Slot >= from && entry.Slot <= to {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// TestValidatorsDuties tests the Duties method of the Validators handler.
+func TestValidatorsDuties(t *testing.T) {
+	t.Parallel()
+
+	pk := spectypes.ValidatorPK{1, 2, 3}
+	history := &mockDutyHistory{entries: map[spectypes.ValidatorPK][]dutyhistory.Entry{
+		pk: {
+			{Role: spectypes.BNRoleAttester, Slot: 10, Stage: dutyhistory.StageSubmitted, Rounds: 1},
+			{Role: spectypes.BNRoleProposer, Slot: 20, Stage: dutyhistory.StageStarted, ErrorClass: dutyhistory.ErrorClassStart, Error: "beacon node unavailable"},
+		},
+	}}
+
+	router := chi.NewRouter()
+	router.Get("/v1/validators/{pubkey}/duties", api.Handler((&Validators{DutyHistory: history}).Duties))
+
+	testCases := []struct {
+		name        string
+		pubKey      string
+		queryParams url.Values
+		wantStatus  int
+		wantSlots   []phase0.Slot
+	}{
+		{
+			name:       "all duties",
+			pubKey:     "0x" + hex.EncodeToString(pk[:]),
+			wantStatus: http.StatusOK,
+			wantSlots:  []phase0.Slot{10, 20},
+		},
+		{
+			name:        "slot range",
+			pubKey:      hex.EncodeToString(pk[:]),
+			queryParams: url.Values{"from": []string{"15"}, "to": []string{"25"}},
+			wantStatus:  http.StatusOK,
+			wantSlots:   []phase0.Slot{20},
+		},
+		{
+			name:        "only to",
+			pubKey:      hex.EncodeToString(pk[:]),
+			queryParams: url.Values{"to": []string{"10"}},
+			wantStatus:  http.StatusOK,
+			wantSlots:   []phase0.Slot{10},
+		},
+		{
+			name:        "from after to",
+			pubKey:      hex.EncodeToString(pk[:]),
+			queryParams: url.Values{"from": []string{"30"}, "to": []string{"25"}},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:       "invalid pubkey",
+			pubKey:     "0x0102",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/validators/"+tc.pubKey+"/duties?"+tc.queryParams.Encode(), nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.wantStatus, rr.Code)
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data []dutyhistory.Entry `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+			slots := make([]phase0.Slot, 0, len(response.Data))
+			for _, entry := range response.Data {
+				slots = append(slots, entry.Slot)
+			}
+			require.Equal(t, tc.wantSlots, slots)
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+		api.Handler((&Validators{}).Duties)(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+type mockDoppelgangerStates struct {
+	states []doppelganger.ValidatorState
+}
+
+func (m *mockDoppelgangerStates) ValidatorStates() []doppelganger.ValidatorState {
+	return m.states
+}
+
+// TestValidatorsDoppelganger tests the DoppelgangerStates method of the Validators handler.
+func TestValidatorsDoppelganger(t *testing.T) {
+	t.Parallel()
+
+	h := &Validators{Doppelganger: &mockDoppelgangerStates{states: []doppelganger.ValidatorState{
+		{ValidatorIndex: 1, RemainingEpochs: 2},
+		{ValidatorIndex: 2, Safe: true, SafeReason: doppelganger.SafeReasonQuorum},
+	}}}
+
+	t.Run("all validators", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+		api.Handler(h.DoppelgangerStates)(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"data":[
+			{"index":1,"state":"unsafe","remaining_epochs":2},
+			{"index":2,"state":"safe","remaining_epochs":0,"safe_reason":"quorum"}
+		]}`, rr.Body.String())
+	})
+
+	t.Run("filter by indices", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/?indices=2,3", nil)
+		rr := httptest.NewRecorder()
+		api.Handler(h.DoppelgangerStates)(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"data":[{"index":2,"state":"safe","remaining_epochs":0,"safe_reason":"quorum"}]}`, rr.Body.String())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+		api.Handler((&Validators{}).DoppelgangerStates)(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
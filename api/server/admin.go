@@ -0,0 +1,131 @@
+package server
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/api/handlers"
+)
+
+// AdminOptions configures authentication of the admin route group.
+// At least one of Token and ClientCAFile must be set, and if both are set, requests must pass both checks.
+type AdminOptions struct {
+	// Token is the bearer token admin requests must carry in the Authorization header.
+	Token string
+
+	// TLSCertFile and TLSKeyFile make the whole API server listen with TLS.
+	// They are required if ClientCAFile is set.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// ClientCAFile is a PEM bundle of CAs, admin requests must present a client certificate signed by one of them.
+	ClientCAFile string
+}
+
+func (o AdminOptions) validate() error {
+	if o.Token == "" && o.ClientCAFile == "" {
+		return errors.New("admin API requires a token or a client CA")
+	}
+	if (o.TLSCertFile == "") != (o.TLSKeyFile == "") {
+		return errors.New("both TLS certificate and key must be provided")
+	}
+	if o.ClientCAFile != "" && o.TLSCertFile == "" {
+		return errors.New("client certificate authentication requires TLS certificate and key")
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration of the server, or nil if TLS isn't enabled.
+// Client certificates are verified if given, but only the admin route group requires them.
+func (o AdminOptions) tlsConfig() (*tls.Config, error) {
+	if o.TLSCertFile == "" {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if o.ClientCAFile != "" {
+		pem, err := os.ReadFile(o.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("client CA file contains no valid certificates")
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return cfg, nil
+}
+
+// WithAdmin enables the admin route group under /v1/admin.
+func (s *Server) WithAdmin(admin *handlers.Admin, opts AdminOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	s.admin = admin
+	s.adminOpts = opts
+	return nil
+}
+
+func (s *Server) adminRoutes(router chi.Router) {
+	router.Use(middlewareAdminAuth(s.adminOpts))
+	router.Get("/log-level", api.Handler(s.admin.GetLogLevel))
+	router.Post("/log-level", api.Handler(s.admin.SetLogLevel))
+	router.Post("/db/gc", api.Handler(s.admin.FullGC))
+	router.Post("/db/backup", api.Handler(s.admin.BackupDB))
+	router.Post("/validators/metadata/sync", api.Handler(s.admin.SyncValidatorsMetadata))
+	router.Get("/peers/banned", api.Handler(s.admin.BannedPeers))
+	router.Post("/peers/disconnect", api.Handler(s.admin.DisconnectPeer))
+	router.Post("/peers/unban", api.Handler(s.admin.UnbanPeer))
+	router.Get("/peers/bans", api.Handler(s.admin.Bans))
+	router.Post("/peers/bans", api.Handler(s.admin.AddBan))
+	router.Delete("/peers/bans", api.Handler(s.admin.RemoveBan))
+	router.Post("/doppelganger/override", api.Handler(s.admin.OverrideDoppelganger))
+	router.Get("/proposer-config", api.Handler(s.admin.GetProposerConfig))
+	router.Put("/proposer-config", api.Handler(s.admin.UpdateProposerConfig))
+}
+
+// middlewareAdminAuth rejects requests which don't pass every authentication method configured in opts.
+func middlewareAdminAuth(opts AdminOptions) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if opts.ClientCAFile != "" && (r.TLS == nil || len(r.TLS.VerifiedChains) == 0) {
+				renderUnauthorized(w, r, errors.New("client certificate required"))
+				return
+			}
+			if opts.Token != "" {
+				token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+				if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(opts.Token)) != 1 {
+					renderUnauthorized(w, r, errors.New("invalid or missing bearer token"))
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func renderUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	resp := &api.ErrorResponse{
+		Err:     err,
+		Code:    http.StatusUnauthorized,
+		Status:  http.StatusText(http.StatusUnauthorized),
+		Message: err.Error(),
+	}
+	if err := render.Render(w, r, resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareAdminAuth(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func(opts AdminOptions, modify func(r *http.Request)) int {
+		r := httptest.NewRequest(http.MethodGet, "/v1/admin/log-level", nil)
+		if modify != nil {
+			modify(r)
+		}
+		w := httptest.NewRecorder()
+		middlewareAdminAuth(opts)(next).ServeHTTP(w, r)
+		return w.Code
+	}
+
+	t.Run("token", func(t *testing.T) {
+		opts := AdminOptions{Token: "secret"}
+
+		require.Equal(t, http.StatusUnauthorized, serve(opts, nil))
+		require.Equal(t, http.StatusUnauthorized, serve(opts, func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer wrong")
+		}))
+		require.Equal(t, http.StatusUnauthorized, serve(opts, func(r *http.Request) {
+			r.Header.Set("Authorization", "secret")
+		}))
+		require.Equal(t, http.StatusOK, serve(opts, func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer secret")
+		}))
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		opts := AdminOptions{ClientCAFile: "ca.pem", TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"}
+		verified := func(r *http.Request) {
+			r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+		}
+
+		require.Equal(t, http.StatusUnauthorized, serve(opts, nil))
+		require.Equal(t, http.StatusUnauthorized, serve(opts, func(r *http.Request) {
+			r.TLS = &tls.ConnectionState{}
+		}))
+		require.Equal(t, http.StatusOK, serve(opts, verified))
+
+		opts.Token = "secret"
+		require.Equal(t, http.StatusUnauthorized, serve(opts, verified))
+		require.Equal(t, http.StatusOK, serve(opts, func(r *http.Request) {
+			verified(r)
+			r.Header.Set("Authorization", "Bearer secret")
+		}))
+	})
+}
+
+func TestAdminOptionsValidate(t *testing.T) {
+	require.Error(t, AdminOptions{}.validate())
+	require.Error(t, AdminOptions{Token: "secret", TLSCertFile: "cert.pem"}.validate())
+	require.Error(t, AdminOptions{ClientCAFile: "ca.pem"}.validate())
+	require.NoError(t, AdminOptions{Token: "secret"}.validate())
+	require.NoError(t, AdminOptions{ClientCAFile: "ca.pem", TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"}.validate())
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"fmt"
 	"net/http"
 	"runtime"
 	"time"
@@ -23,6 +24,9 @@ type Server struct {
 	validators *handlers.Validators
 	exporter   *handlers.Exporter
 
+	admin     *handlers.Admin
+	adminOpts AdminOptions
+
 	httpServer *http.Server
 }
 
@@ -56,21 +60,43 @@ func (s *Server) Run() error {
 	router.Get("/v1/node/peers", api.Handler(s.node.Peers))
 	router.Get("/v1/node/topics", api.Handler(s.node.Topics))
 	router.Get("/v1/node/health", api.Handler(s.node.Health))
+	router.Get("/v1/node/subnets", api.Handler(s.node.Subnets))
+	router.Get("/v1/node/validation/decisions", api.Handler(s.node.ValidationDecisions))
+	router.Get("/v1/node/validation/rejections", api.Handler(s.node.ValidationRejections))
 	router.Get("/v1/validators", api.Handler(s.validators.List))
+	router.Get("/v1/validators/doppelganger", api.Handler(s.validators.DoppelgangerStates))
+	router.Get("/v1/validators/{pubkey}/duties", api.Handler(s.validators.Duties))
 
 	// We kept both GET and POST methods to ensure compatibility and avoid breaking changes for clients that may rely on either method
 	router.Get("/v1/exporter/decideds", api.Handler(s.exporter.Decideds))
 	router.Post("/v1/exporter/decideds", api.Handler(s.exporter.Decideds))
 
-	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
+	if s.admin != nil {
+		router.Route("/v1/admin", s.adminRoutes)
+	}
+
+	tlsConfig, err := s.adminOpts.tlsConfig()
+	if err != nil {
+		return fmt.Errorf("setup TLS: %w", err)
+	}
+
+	s.logger.Info("Serving SSV API",
+		zap.String("addr", s.addr),
+		zap.Bool("admin", s.admin != nil),
+		zap.Bool("tls", tlsConfig != nil),
+	)
 
 	s.httpServer = &http.Server{
 		Addr:              s.addr,
 		Handler:           router,
+		TLSConfig:         tlsConfig,
 		ReadHeaderTimeout: 10 * time.Second,
 		ReadTimeout:       12 * time.Second,
 		WriteTimeout:      12 * time.Second,
 	}
+	if tlsConfig != nil {
+		return s.httpServer.ListenAndServeTLS(s.adminOpts.TLSCertFile, s.adminOpts.TLSKeyFile)
+	}
 	return s.httpServer.ListenAndServe()
 }
 
@@ -162,6 +162,9 @@ type GoClient struct {
 
 	withParallelSubmissions bool
 
+	withBestValueProposals    bool
+	bestValueProposalDeadline time.Duration
+
 	subscribersLock      sync.RWMutex
 	headEventSubscribers []subscriber[*apiv1.HeadEvent]
 	supportedTopics      []EventTopic
@@ -199,6 +202,11 @@ func New(
 		longTimeout = DefaultLongTimeout
 	}
 
+	bestValueProposalDeadline := opt.BestValueProposalDeadline
+	if bestValueProposalDeadline == 0 || bestValueProposalDeadline > commonTimeout {
+		bestValueProposalDeadline = commonTimeout
+	}
+
 	client := &GoClient{
 		log:                   logger.Named("consensus_client"),
 		ctx:                   opt.Context,
@@ -217,6 +225,8 @@ func New(
 		longTimeout:                        longTimeout,
 		withWeightedAttestationData:        opt.WithWeightedAttestationData,
 		withParallelSubmissions:            opt.WithParallelSubmissions,
+		withBestValueProposals:             opt.WithBestValueProposals,
+		bestValueProposalDeadline:          bestValueProposalDeadline,
 		weightedAttestationDataSoftTimeout: time.Duration(float64(commonTimeout) / 2.5),
 		weightedAttestationDataHardTimeout: commonTimeout,
 		supportedTopics:                    []EventTopic{EventTopicHead, EventTopicBlock},
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
@@ -47,6 +48,18 @@ var (
 			metricName("sync.distance"),
 			metric.WithUnit("{block}"),
 			metric.WithDescription("consensus client syncing distance which is a delta between highest and current blocks")))
+
+	bestValueProposalsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("proposal.best_value.selected"),
+			metric.WithUnit("{proposal}"),
+			metric.WithDescription("number of best value proposals selected from the consensus client")))
+
+	bestValueProposalMarginHistogram = observability.NewMetric(
+		meter.Float64Histogram(
+			metricName("proposal.best_value.margin"),
+			metric.WithUnit("Gwei"),
+			metric.WithDescription("value of the selected proposal over the next best proposal received")))
 )
 
 func metricName(name string) string {
@@ -75,6 +88,15 @@ func recordRequestDuration(ctx context.Context, routeName, serverAddr, requestMe
 		metric.WithAttributes(attr...))
 }
 
+func recordBestValueProposal(ctx context.Context, serverAddr string, contested bool, margin *big.Int) {
+	bestValueProposalsCounter.Add(ctx, 1, metric.WithAttributes(semconv.ServerAddress(serverAddr)))
+	if !contested {
+		return
+	}
+	marginGwei, _ := new(big.Float).Quo(new(big.Float).SetInt(margin), big.NewFloat(1e9)).Float64()
+	bestValueProposalMarginHistogram.Record(ctx, marginGwei, metric.WithAttributes(semconv.ServerAddress(serverAddr)))
+}
+
 func recordSyncDistance(ctx context.Context, distance phase0.Slot, serverAddr string) {
 	observability.RecordUint64Value(ctx, uint64(distance), syncDistanceGauge.Record, metric.WithAttributes(semconv.ServerAddress(serverAddr)))
 }
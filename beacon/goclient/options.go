@@ -15,6 +15,9 @@ type Options struct {
 	SyncDistanceTolerance       uint64 `yaml:"SyncDistanceTolerance" env:"BEACON_SYNC_DISTANCE_TOLERANCE" env-default:"4" env-description:"Maximum number of slots behind head considered in-sync"`
 	WithWeightedAttestationData bool   `yaml:"WithWeightedAttestationData" env:"WITH_WEIGHTED_ATTESTATION_DATA" env-default:"false" env-description:"Enable attestation data scoring across multiple beacon nodes"`
 	WithParallelSubmissions     bool   `yaml:"WithParallelSubmissions" env:"WITH_PARALLEL_SUBMISSIONS" env-default:"false" env-description:"Enables parallel Attestation and Sync Committee submissions to all Beacon nodes (as opposed to submitting to a single Beacon node via multiclient instance)"`
+	WithBestValueProposals      bool   `yaml:"WithBestValueProposals" env:"WITH_BEST_VALUE_PROPOSALS" env-default:"false" env-description:"Request block proposals from all Beacon nodes and propose the one of the highest consensus and execution value"`
+
+	BestValueProposalDeadline time.Duration `yaml:"BestValueProposalDeadline" env:"BEST_VALUE_PROPOSAL_DEADLINE" env-default:"1s" env-description:"How long to wait for block proposals from all Beacon nodes before selecting the best one received"`
 
 	CommonTimeout time.Duration // Optional.
 	LongTimeout   time.Duration // Optional.
@@ -2,7 +2,9 @@ package goclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"time"
 
@@ -19,6 +21,8 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
 )
 
 // ProposerDuties returns proposer duties for the given epoch.
@@ -49,19 +53,49 @@ func (gc *GoClient) ProposerDuties(ctx context.Context, epoch phase0.Epoch, vali
 
 // GetBeaconBlock returns beacon block by the given slot, graffiti, and randao.
 func (gc *GoClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	return gc.getBeaconBlock(slot, graffitiBytes, randao, nil)
+}
+
+// GetBeaconBlockWithBuilderBoostFactor returns beacon block by the given slot, graffiti, and randao,
+// with the value of builder payloads weighed by the given percentage against the value of local payloads.
+func (gc *GoClient) GetBeaconBlockWithBuilderBoostFactor(slot phase0.Slot, graffitiBytes, randao []byte, builderBoostFactor uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	return gc.getBeaconBlock(slot, graffitiBytes, randao, &builderBoostFactor)
+}
+
+func (gc *GoClient) getBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byte, builderBoostFactor *uint64) (ssz.Marshaler, spec.DataVersion, error) {
 	sig := phase0.BLSSignature{}
 	copy(sig[:], randao[:])
 
 	graffiti := [32]byte{}
 	copy(graffiti[:], graffitiBytes[:])
 
-	reqStart := time.Now()
-	proposalResp, err := gc.multiClient.Proposal(gc.ctx, &api.ProposalOpts{
+	opts := &api.ProposalOpts{
 		Slot:                   slot,
 		RandaoReveal:           sig,
 		Graffiti:               graffiti,
 		SkipRandaoVerification: false,
-	})
+		BuilderBoostFactor:     builderBoostFactor,
+	}
+
+	var (
+		beaconBlock *api.VersionedProposal
+		err         error
+	)
+	if gc.withBestValueProposals && len(gc.clients) > 1 {
+		beaconBlock, err = gc.bestValueProposal(opts)
+	} else {
+		beaconBlock, err = gc.simpleProposal(opts)
+	}
+	if err != nil {
+		return nil, DataVersionNil, err
+	}
+
+	return unwrapProposal(beaconBlock)
+}
+
+func (gc *GoClient) simpleProposal(opts *api.ProposalOpts) (*api.VersionedProposal, error) {
+	reqStart := time.Now()
+	proposalResp, err := gc.multiClient.Proposal(gc.ctx, opts)
 	recordRequestDuration(gc.ctx, "Proposal", gc.multiClient.Address(), http.MethodGet, time.Since(reqStart), err)
 
 	if err != nil {
@@ -69,23 +103,183 @@ func (gc *GoClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byt
 			zap.String("api", "Proposal"),
 			zap.Error(err),
 		)
-		return nil, DataVersionNil, fmt.Errorf("failed to get proposal: %w", err)
+		return nil, fmt.Errorf("failed to get proposal: %w", err)
 	}
 	if proposalResp == nil {
 		gc.log.Error(clNilResponseErrMsg,
 			zap.String("api", "Proposal"),
 		)
-		return nil, DataVersionNil, fmt.Errorf("proposal response is nil")
+		return nil, fmt.Errorf("proposal response is nil")
 	}
 	if proposalResp.Data == nil {
 		gc.log.Error(clNilResponseDataErrMsg,
 			zap.String("api", "Proposal"),
 		)
-		return nil, DataVersionNil, fmt.Errorf("proposal data is nil")
+		return nil, fmt.Errorf("proposal data is nil")
+	}
+
+	return proposalResp.Data, nil
+}
+
+// bestValueProposal requests proposals from all the clients concurrently and returns the one
+// of the highest value received by the best value proposal deadline. If none was received by then,
+// it returns the first one received within the common timeout.
+func (gc *GoClient) bestValueProposal(opts *api.ProposalOpts) (*api.VersionedProposal, error) {
+	logger := gc.log.With(fields.Slot(opts.Slot), zap.Bool("with_best_value_proposals", true))
+
+	ctx, cancel := context.WithTimeout(gc.ctx, gc.commonTimeout)
+	defer cancel()
+
+	deadline := time.NewTimer(gc.bestValueProposalDeadline)
+	defer deadline.Stop()
+
+	started := time.Now()
+
+	respCh := make(chan *proposalResponse, len(gc.clients))
+	for _, client := range gc.clients {
+		go gc.fetchProposal(ctx, client, opts, respCh)
+	}
+
+	var (
+		best, runnerUp *proposalResponse
+		errs           []error
+		pending        = len(gc.clients)
+		deadlineCh     = deadline.C
+		deadlinePassed bool
+	)
+collect:
+	for pending > 0 {
+		select {
+		case resp := <-respCh:
+			pending--
+			if resp.err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", resp.clientAddr, resp.err))
+				continue
+			}
+			logger.Debug("proposal received",
+				zap.String("client_addr", resp.clientAddr),
+				zap.Duration("elapsed", time.Since(started)),
+				zap.Stringer("value", resp.value),
+				zap.Bool("blinded", resp.proposal.Blinded),
+			)
+			switch {
+			case best == nil || resp.betterThan(best):
+				best, runnerUp = resp, best
+			case runnerUp == nil || resp.betterThan(runnerUp):
+				runnerUp = resp
+			}
+			if deadlinePassed {
+				break collect
+			}
+		case <-deadlineCh:
+			if best != nil {
+				break collect
+			}
+			// Wait for the first proposal until the timeout rather than failing the duty.
+			deadlineCh = nil
+			deadlinePassed = true
+		case <-ctx.Done():
+			break collect
+		}
+	}
+
+	if best == nil {
+		logger.Error("no proposals received",
+			zap.Duration("elapsed", time.Since(started)),
+			zap.Int("errored", len(errs)),
+			zap.Int("timed_out", pending),
+			zap.Error(errors.Join(errs...)),
+		)
+		return nil, fmt.Errorf("failed to get proposal from any client: %w", errors.Join(append(errs, ctx.Err())...))
+	}
+
+	margin := new(big.Int)
+	if runnerUp != nil {
+		margin.Sub(best.value, runnerUp.value)
 	}
+	recordBestValueProposal(gc.ctx, best.clientAddr, runnerUp != nil, margin)
+
+	logger.Debug("selected best value proposal",
+		zap.String("client_addr", best.clientAddr),
+		zap.Duration("elapsed", time.Since(started)),
+		zap.Stringer("value", best.value),
+		zap.Stringer("margin", margin),
+		zap.Bool("blinded", best.proposal.Blinded),
+		zap.Int("errored", len(errs)),
+		zap.Int("timed_out", pending),
+	)
 
-	beaconBlock := proposalResp.Data
+	return best.proposal, nil
+}
+
+func (gc *GoClient) fetchProposal(ctx context.Context, client Client, opts *api.ProposalOpts, respCh chan<- *proposalResponse) {
+	addr := client.Address()
+
+	reqStart := time.Now()
+	resp, err := client.Proposal(ctx, opts)
+	recordRequestDuration(ctx, "Proposal", addr, http.MethodGet, time.Since(reqStart), err)
+
+	switch {
+	case err != nil:
+	case resp == nil:
+		err = fmt.Errorf("proposal response is nil")
+	case resp.Data == nil:
+		err = fmt.Errorf("proposal data is nil")
+	default:
+		// Incomplete blocks can't be proposed, so they mustn't win either.
+		if _, _, err = unwrapProposal(resp.Data); err == nil {
+			respCh <- &proposalResponse{
+				clientAddr: addr,
+				proposal:   resp.Data,
+				value:      proposalValue(resp.Data),
+			}
+			return
+		}
+	}
+
+	gc.log.Warn(clResponseErrMsg,
+		zap.String("api", "Proposal"),
+		zap.String("client_addr", addr),
+		fields.Slot(opts.Slot),
+		zap.Error(err),
+	)
+	respCh <- &proposalResponse{
+		clientAddr: addr,
+		err:        err,
+	}
+}
+
+type proposalResponse struct {
+	clientAddr string
+	proposal   *api.VersionedProposal
+	value      *big.Int
+	err        error
+}
+
+// betterThan reports whether r is a better proposal than other: it's of a higher value,
+// or of the same value and full while other is blinded, which spares the relay round trip to unblind it.
+func (r *proposalResponse) betterThan(other *proposalResponse) bool {
+	if c := r.value.Cmp(other.value); c != 0 {
+		return c > 0
+	}
+	return !r.proposal.Blinded && other.proposal.Blinded
+}
+
+// proposalValue returns the total value of the proposal to the proposer in Wei,
+// which is the sum of its consensus layer rewards and its execution layer payment.
+func proposalValue(proposal *api.VersionedProposal) *big.Int {
+	value := new(big.Int)
+	if proposal.ConsensusValue != nil {
+		value.Add(value, proposal.ConsensusValue)
+	}
+	if proposal.ExecutionValue != nil {
+		value.Add(value, proposal.ExecutionValue)
+	}
+	return value
+}
 
+// unwrapProposal returns the block of the proposal, checking that it's complete.
+func unwrapProposal(beaconBlock *api.VersionedProposal) (ssz.Marshaler, spec.DataVersion, error) {
 	if beaconBlock.Blinded {
 		switch beaconBlock.Version {
 		case spec.DataVersionCapella:
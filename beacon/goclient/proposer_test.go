@@ -0,0 +1,161 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+func TestProposalResponse_BetterThan(t *testing.T) {
+	response := func(consensusValue, executionValue int64, blinded bool) *proposalResponse {
+		proposal := &api.VersionedProposal{
+			Blinded:        blinded,
+			ConsensusValue: big.NewInt(consensusValue),
+			ExecutionValue: big.NewInt(executionValue),
+		}
+		return &proposalResponse{proposal: proposal, value: proposalValue(proposal)}
+	}
+
+	t.Run("higher total value wins", func(t *testing.T) {
+		require.True(t, response(10, 100, true).betterThan(response(50, 50, false)))
+		require.False(t, response(50, 50, false).betterThan(response(10, 100, true)))
+	})
+
+	t.Run("full block wins a tie", func(t *testing.T) {
+		require.True(t, response(10, 100, false).betterThan(response(10, 100, true)))
+		require.False(t, response(10, 100, true).betterThan(response(10, 100, false)))
+	})
+
+	t.Run("equal proposals", func(t *testing.T) {
+		require.False(t, response(10, 100, true).betterThan(response(10, 100, true)))
+	})
+
+	t.Run("missing values count as zero", func(t *testing.T) {
+		require.Zero(t, proposalValue(&api.VersionedProposal{}).Sign())
+		require.True(t, response(0, 1, true).betterThan(&proposalResponse{
+			proposal: &api.VersionedProposal{},
+			value:    proposalValue(&api.VersionedProposal{}),
+		}))
+	})
+}
+
+// fakeProposalClient responds to proposal requests with the given proposal or error after the given delay.
+type fakeProposalClient struct {
+	Client
+
+	addr     string
+	delay    time.Duration
+	proposal *api.VersionedProposal
+	err      error
+}
+
+func (c *fakeProposalClient) Address() string {
+	return c.addr
+}
+
+func (c *fakeProposalClient) Proposal(ctx context.Context, _ *api.ProposalOpts) (*api.Response[*api.VersionedProposal], error) {
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &api.Response[*api.VersionedProposal]{Data: c.proposal}, nil
+}
+
+func TestBestValueProposal(t *testing.T) {
+	proposal := func(executionValue int64) *api.VersionedProposal {
+		return &api.VersionedProposal{
+			Version: spec.DataVersionDeneb,
+			Blinded: true,
+			DenebBlinded: &apiv1deneb.BlindedBeaconBlock{
+				Body: &apiv1deneb.BlindedBeaconBlockBody{
+					ExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{},
+				},
+			},
+			ConsensusValue: big.NewInt(0),
+			ExecutionValue: big.NewInt(executionValue),
+		}
+	}
+	newClient := func(clients ...Client) *GoClient {
+		return &GoClient{
+			log:                       logging.TestLogger(t),
+			ctx:                       context.Background(),
+			clients:                   clients,
+			commonTimeout:             time.Second,
+			bestValueProposalDeadline: 100 * time.Millisecond,
+		}
+	}
+
+	t.Run("highest value by the deadline", func(t *testing.T) {
+		gc := newClient(
+			&fakeProposalClient{addr: "low", proposal: proposal(10)},
+			&fakeProposalClient{addr: "high", delay: 20 * time.Millisecond, proposal: proposal(30)},
+			&fakeProposalClient{addr: "late", delay: 500 * time.Millisecond, proposal: proposal(100)},
+		)
+
+		start := time.Now()
+		best, err := gc.bestValueProposal(&api.ProposalOpts{Slot: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, 30, best.ExecutionValue.Int64())
+		require.Less(t, time.Since(start), 500*time.Millisecond, "must not wait for responses after the deadline")
+	})
+
+	t.Run("all responses before the deadline", func(t *testing.T) {
+		gc := newClient(
+			&fakeProposalClient{addr: "low", proposal: proposal(10)},
+			&fakeProposalClient{addr: "high", proposal: proposal(30)},
+		)
+
+		start := time.Now()
+		best, err := gc.bestValueProposal(&api.ProposalOpts{Slot: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, 30, best.ExecutionValue.Int64())
+		require.Less(t, time.Since(start), gc.bestValueProposalDeadline, "must not wait for the deadline once all clients responded")
+	})
+
+	t.Run("first proposal after the deadline", func(t *testing.T) {
+		gc := newClient(
+			&fakeProposalClient{addr: "failing", err: errors.New("unavailable")},
+			&fakeProposalClient{addr: "first", delay: 200 * time.Millisecond, proposal: proposal(10)},
+			&fakeProposalClient{addr: "second", delay: 800 * time.Millisecond, proposal: proposal(100)},
+		)
+
+		start := time.Now()
+		best, err := gc.bestValueProposal(&api.ProposalOpts{Slot: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, 10, best.ExecutionValue.Int64())
+		require.Less(t, time.Since(start), 800*time.Millisecond, "must not wait for more proposals after the deadline")
+	})
+
+	t.Run("all clients errored", func(t *testing.T) {
+		gc := newClient(
+			&fakeProposalClient{addr: "a", err: errors.New("unavailable")},
+			&fakeProposalClient{addr: "b", proposal: &api.VersionedProposal{Version: spec.DataVersionDeneb, Blinded: true}},
+		)
+
+		_, err := gc.bestValueProposal(&api.ProposalOpts{Slot: 1})
+		require.ErrorContains(t, err, "unavailable")
+		require.ErrorContains(t, err, "deneb blinded block contents is nil")
+	})
+
+	t.Run("no response within the timeout", func(t *testing.T) {
+		gc := newClient(&fakeProposalClient{addr: "slow", delay: time.Minute, proposal: proposal(10)})
+		gc.commonTimeout = 200 * time.Millisecond
+
+		_, err := gc.bestValueProposal(&api.ProposalOpts{Slot: 1})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,193 @@
+package operator
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/operator/backup"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+	"github.com/ssvlabs/ssv/utils/cliflag"
+)
+
+const (
+	backupFileFlag    = "file"
+	convertEngineFlag = "engine"
+	convertTargetFlag = "target"
+)
+
+// DBCmd groups the commands operating on the node database.
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Node database operations",
+}
+
+var backupDBCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Writes a backup of the node database to a file. The node must be stopped, use the admin API for online backups",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		filePath := backupFilePath(cmd, logger)
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		db := setupOfflineDB(cmd, logger)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		header, err := backup.WriteFile(db, filePath, networkConfig.NetworkName())
+		if err != nil {
+			logger.Fatal("failed to backup database", zap.Error(err))
+		}
+
+		logger.Info("database backup completed",
+			zap.String("file", filePath),
+			zap.String("network", header.NetworkName),
+			zap.Int("migration_version", header.MigrationVersion),
+		)
+	},
+}
+
+var restoreDBCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores a backup into an empty node database. The node must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		filePath := backupFilePath(cmd, logger)
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		// #nosec G304
+		f, err := os.Open(filePath)
+		if err != nil {
+			logger.Fatal("failed to open backup file", zap.Error(err))
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		db := setupOfflineDB(cmd, logger)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		header, err := backup.Restore(db, f, networkConfig.NetworkName())
+		if err != nil {
+			logger.Fatal("failed to restore database", zap.Error(err))
+		}
+
+		logger.Info("database restore completed",
+			zap.String("file", filePath),
+			zap.String("network", header.NetworkName),
+			zap.Int("migration_version", header.MigrationVersion),
+			zap.Time("created_at", header.CreatedAt),
+		)
+	},
+}
+
+var convertDBCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Copies the node database into a new directory using another storage engine. The node must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		engine, err := cmd.Flags().GetString(convertEngineFlag)
+		if err != nil {
+			logger.Fatal("failed to get engine flag value", zap.Error(err))
+		}
+		targetPath, err := cmd.Flags().GetString(convertTargetFlag)
+		if err != nil {
+			logger.Fatal("failed to get target flag value", zap.Error(err))
+		}
+
+		sourceEngine, err := kv.DetectEngine(cfg.DBOptions.Path)
+		if err != nil {
+			logger.Fatal("failed to detect source database engine", zap.Error(err))
+		}
+		if sourceEngine == "" {
+			logger.Fatal("no database found", zap.String("path", cfg.DBOptions.Path))
+		}
+		if entries, err := os.ReadDir(targetPath); err == nil && len(entries) > 0 {
+			logger.Fatal("target directory is not empty", zap.String("path", targetPath))
+		}
+
+		cfg.DBOptions.Engine = sourceEngine
+		source := setupReadOnlyDB(cmd, logger)
+		defer func() {
+			_ = source.Close()
+		}()
+
+		target, err := kv.Open(logger, basedb.Options{
+			Ctx:    cmd.Context(),
+			Engine: basedb.Engine(engine),
+			Path:   targetPath,
+		})
+		if err != nil {
+			logger.Fatal("could not open target db", zap.Error(err), zap.String("path", targetPath))
+		}
+		defer func() {
+			_ = target.Close()
+		}()
+
+		start := time.Now()
+		count, err := kv.Convert(cmd.Context(), source, target)
+		if err != nil {
+			logger.Fatal("failed to convert database", zap.Error(err), zap.Int64("copied", count))
+		}
+
+		logger.Info("database conversion completed, point the node at the target directory and engine to use it",
+			zap.String("source_engine", string(sourceEngine)),
+			zap.String("target_engine", engine),
+			zap.String("target_path", targetPath),
+			zap.Int64("items", count),
+			zap.Duration("took", time.Since(start)),
+		)
+	},
+}
+
+func backupFilePath(cmd *cobra.Command, logger *zap.Logger) string {
+	filePath, err := cmd.Flags().GetString(backupFileFlag)
+	if err != nil {
+		logger.Fatal("failed to get file flag value", zap.Error(err))
+	}
+	return filePath
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, DBCmd)
+
+	cliflag.AddPersistentStringFlag(backupDBCmd, backupFileFlag, "", "Path to the backup file", true)
+	cliflag.AddPersistentStringFlag(restoreDBCmd, backupFileFlag, "", "Path to the backup file", true)
+
+	DBCmd.AddCommand(backupDBCmd)
+	cliflag.AddPersistentStringFlag(convertDBCmd, convertEngineFlag, string(basedb.EnginePebble),
+		fmt.Sprintf("Storage engine of the converted database (%s or %s)", basedb.EngineBadger, basedb.EnginePebble), false)
+	cliflag.AddPersistentStringFlag(convertDBCmd, convertTargetFlag, "", "Directory to write the converted database to, must be empty", true)
+
+	DBCmd.AddCommand(restoreDBCmd)
+	DBCmd.AddCommand(convertDBCmd)
+}
@@ -0,0 +1,451 @@
+package operator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/migrations"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/message"
+	"github.com/ssvlabs/ssv/storage/kv"
+	"github.com/ssvlabs/ssv/utils/cliflag"
+)
+
+const (
+	inspectJSONFlag = "json"
+	inspectRoleFlag = "role"
+	inspectFromFlag = "from"
+	inspectToFlag   = "to"
+)
+
+// inspectDBCmd groups the commands printing the contents of the node database.
+// The database is opened read-only, so the node must be stopped.
+var inspectDBCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Prints the contents of the node database (read-only)",
+}
+
+type inspectShareJSON struct {
+	PubKey          string   `json:"public_key"`
+	Index           uint64   `json:"index"`
+	Status          string   `json:"status"`
+	ActivationEpoch uint64   `json:"activation_epoch"`
+	ExitEpoch       uint64   `json:"exit_epoch"`
+	Owner           string   `json:"owner"`
+	Committee       []uint64 `json:"committee"`
+	Liquidated      bool     `json:"liquidated"`
+	HasMetadata     bool     `json:"has_metadata"`
+}
+
+var inspectSharesCmd = &cobra.Command{
+	Use:   "shares",
+	Short: "Lists the stored shares with their beacon metadata and liquidation status",
+	Run: func(cmd *cobra.Command, args []string) {
+		inspect(cmd, func(logger *zap.Logger, db kv.DB, nodeStorage operatorstorage.Storage) (any, func(io.Writer)) {
+			shares := nodeStorage.Shares().List(nil)
+			sort.Slice(shares, func(i, j int) bool {
+				return shares[i].ValidatorIndex < shares[j].ValidatorIndex
+			})
+
+			result := make([]inspectShareJSON, 0, len(shares))
+			for _, share := range shares {
+				committee := make([]uint64, 0, len(share.Committee))
+				for _, member := range share.Committee {
+					committee = append(committee, member.Signer)
+				}
+				result = append(result, inspectShareJSON{
+					PubKey:          "0x" + hex.EncodeToString(share.ValidatorPubKey[:]),
+					Index:           uint64(share.ValidatorIndex),
+					Status:          share.Status.String(),
+					ActivationEpoch: uint64(share.ActivationEpoch),
+					ExitEpoch:       uint64(share.ExitEpoch),
+					Owner:           share.OwnerAddress.Hex(),
+					Committee:       committee,
+					Liquidated:      share.Liquidated,
+					HasMetadata:     share.HasBeaconMetadata(),
+				})
+			}
+
+			return result, func(w io.Writer) {
+				_, _ = fmt.Fprintln(w, "PUBKEY\tINDEX\tSTATUS\tOWNER\tCOMMITTEE\tLIQUIDATED")
+				for _, s := range result {
+					_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%t\n", s.PubKey, s.Index, s.Status, s.Owner, joinUint64s(s.Committee), s.Liquidated)
+				}
+			}
+		})
+	},
+}
+
+type inspectOperatorJSON struct {
+	ID        uint64 `json:"id"`
+	Owner     string `json:"owner"`
+	PublicKey string `json:"public_key"`
+}
+
+var inspectOperatorsCmd = &cobra.Command{
+	Use:   "operators",
+	Short: "Lists the stored operators",
+	Run: func(cmd *cobra.Command, args []string) {
+		inspect(cmd, func(logger *zap.Logger, db kv.DB, nodeStorage operatorstorage.Storage) (any, func(io.Writer)) {
+			operators, err := nodeStorage.ListOperators(nil, 0, 0)
+			if err != nil {
+				logger.Fatal("failed to list operators", zap.Error(err))
+			}
+			sort.Slice(operators, func(i, j int) bool {
+				return operators[i].ID < operators[j].ID
+			})
+
+			result := make([]inspectOperatorJSON, 0, len(operators))
+			for _, od := range operators {
+				result = append(result, inspectOperatorJSON{
+					ID:        od.ID,
+					Owner:     od.OwnerAddress.Hex(),
+					PublicKey: string(od.PublicKey),
+				})
+			}
+
+			return result, func(w io.Writer) {
+				_, _ = fmt.Fprintln(w, "ID\tOWNER\tPUBLIC KEY")
+				for _, od := range result {
+					_, _ = fmt.Fprintf(w, "%d\t%s\t%s\n", od.ID, od.Owner, od.PublicKey)
+				}
+			}
+		})
+	},
+}
+
+type inspectRecipientJSON struct {
+	Owner        string  `json:"owner"`
+	FeeRecipient string  `json:"fee_recipient"`
+	Nonce        *uint16 `json:"nonce,omitempty"`
+}
+
+var inspectRecipientsCmd = &cobra.Command{
+	Use:   "recipients",
+	Short: "Lists the stored fee recipients",
+	Run: func(cmd *cobra.Command, args []string) {
+		inspect(cmd, func(logger *zap.Logger, db kv.DB, nodeStorage operatorstorage.Storage) (any, func(io.Writer)) {
+			recipients, err := nodeStorage.ListRecipients(nil)
+			if err != nil {
+				logger.Fatal("failed to list recipients", zap.Error(err))
+			}
+
+			result := make([]inspectRecipientJSON, 0, len(recipients))
+			for _, rd := range recipients {
+				entry := inspectRecipientJSON{
+					Owner:        rd.Owner.Hex(),
+					FeeRecipient: rd.FeeRecipient.String(),
+				}
+				if rd.Nonce != nil {
+					nonce := uint16(*rd.Nonce)
+					entry.Nonce = &nonce
+				}
+				result = append(result, entry)
+			}
+
+			return result, func(w io.Writer) {
+				_, _ = fmt.Fprintln(w, "OWNER\tFEE RECIPIENT\tNONCE")
+				for _, rd := range result {
+					nonce := "-"
+					if rd.Nonce != nil {
+						nonce = fmt.Sprint(*rd.Nonce)
+					}
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", rd.Owner, rd.FeeRecipient, nonce)
+				}
+			}
+		})
+	},
+}
+
+type inspectParticipantsJSON struct {
+	Slot    uint64   `json:"slot"`
+	PubKey  string   `json:"public_key"`
+	Signers []uint64 `json:"signers"`
+}
+
+var inspectParticipantsCmd = &cobra.Command{
+	Use:   "participants",
+	Short: "Lists the decided participants of a role within a slot range (exporter nodes only)",
+	Run: func(cmd *cobra.Command, args []string) {
+		inspect(cmd, func(logger *zap.Logger, db kv.DB, nodeStorage operatorstorage.Storage) (any, func(io.Writer)) {
+			roleName, err := cmd.Flags().GetString(inspectRoleFlag)
+			if err != nil {
+				logger.Fatal("failed to get role flag value", zap.Error(err))
+			}
+			role, err := message.BeaconRoleFromString(strings.ToUpper(roleName))
+			if err != nil {
+				logger.Fatal("invalid role", zap.Error(err))
+			}
+			from, err := cmd.Flags().GetUint64(inspectFromFlag)
+			if err != nil {
+				logger.Fatal("failed to get from flag value", zap.Error(err))
+			}
+			to, err := cmd.Flags().GetUint64(inspectToFlag)
+			if err != nil {
+				logger.Fatal("failed to get to flag value", zap.Error(err))
+			}
+			if from > to {
+				logger.Fatal("'from' must be less than or equal to 'to'")
+			}
+
+			entries, err := ibftstorage.New(db, role).GetAllParticipantsInRange(phase0.Slot(from), phase0.Slot(to))
+			if err != nil {
+				logger.Fatal("failed to get participants", zap.Error(err))
+			}
+
+			result := make([]inspectParticipantsJSON, 0, len(entries))
+			for _, entry := range entries {
+				result = append(result, inspectParticipantsJSON{
+					Slot:    uint64(entry.Slot),
+					PubKey:  "0x" + hex.EncodeToString(entry.PubKey[:]),
+					Signers: entry.Signers,
+				})
+			}
+
+			return result, func(w io.Writer) {
+				_, _ = fmt.Fprintln(w, "SLOT\tPUBKEY\tSIGNERS")
+				for _, p := range result {
+					_, _ = fmt.Fprintf(w, "%d\t%s\t%s\n", p.Slot, p.PubKey, joinUint64s(p.Signers))
+				}
+			}
+		})
+	},
+}
+
+type inspectConfigJSON struct {
+	ConfigLock         *operatorstorage.ConfigLock `json:"config_lock"`
+	LastProcessedBlock *uint64                     `json:"last_processed_block"`
+	OperatorPublicKey  string                      `json:"operator_public_key,omitempty"`
+}
+
+var inspectConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Prints the config lock, the last processed block and the operator public key",
+	Run: func(cmd *cobra.Command, args []string) {
+		inspect(cmd, func(logger *zap.Logger, db kv.DB, nodeStorage operatorstorage.Storage) (any, func(io.Writer)) {
+			var result inspectConfigJSON
+
+			configLock, found, err := operatorstorage.ReadConfig(db)
+			if err != nil {
+				logger.Fatal("failed to read config lock", zap.Error(err))
+			}
+			if found {
+				result.ConfigLock = configLock
+			}
+
+			block, found, err := nodeStorage.GetLastProcessedBlock(nil)
+			if err != nil {
+				logger.Fatal("failed to read last processed block", zap.Error(err))
+			}
+			if found && block.IsUint64() {
+				blockNumber := block.Uint64()
+				result.LastProcessedBlock = &blockNumber
+			}
+
+			publicKey, found, err := nodeStorage.GetPublicKey()
+			if err != nil {
+				logger.Fatal("failed to read operator public key", zap.Error(err))
+			}
+			if found {
+				result.OperatorPublicKey = publicKey
+			}
+
+			return result, func(w io.Writer) {
+				if result.ConfigLock != nil {
+					_, _ = fmt.Fprintf(w, "network\t%s\n", result.ConfigLock.NetworkName)
+					_, _ = fmt.Fprintf(w, "using local events\t%t\n", result.ConfigLock.UsingLocalEvents)
+					_, _ = fmt.Fprintf(w, "using ssv-signer\t%t\n", result.ConfigLock.UsingSSVSigner)
+				} else {
+					_, _ = fmt.Fprintln(w, "config lock\tnot found")
+				}
+				if result.LastProcessedBlock != nil {
+					_, _ = fmt.Fprintf(w, "last processed block\t%d\n", *result.LastProcessedBlock)
+				} else {
+					_, _ = fmt.Fprintln(w, "last processed block\tnot found")
+				}
+				_, _ = fmt.Fprintf(w, "operator public key\t%s\n", result.OperatorPublicKey)
+			}
+		})
+	},
+}
+
+var inspectMigrationsCmd = &cobra.Command{
+	Use:   "migrations",
+	Short: "Lists the migrations known to this binary and whether they were applied",
+	Run: func(cmd *cobra.Command, args []string) {
+		inspect(cmd, func(logger *zap.Logger, db kv.DB, nodeStorage operatorstorage.Storage) (any, func(io.Writer)) {
+			statuses, err := migrations.Statuses(db)
+			if err != nil {
+				logger.Fatal("failed to read migrations", zap.Error(err))
+			}
+
+			return statuses, func(w io.Writer) {
+				_, _ = fmt.Fprintln(w, "MIGRATION\tAPPLIED")
+				for _, status := range statuses {
+					_, _ = fmt.Fprintf(w, "%s\t%t\n", status.Name, status.Applied)
+				}
+			}
+		})
+	},
+}
+
+type inspectPrefixJSON struct {
+	Name   string `json:"name"`
+	Prefix string `json:"prefix"`
+	Keys   int64  `json:"keys"`
+	Size   int64  `json:"size"`
+}
+
+type inspectPrefix struct {
+	name   string
+	prefix []byte
+}
+
+// inspectPrefixes returns the known key prefixes of the node database.
+// Prefixes may overlap, e.g. "operator" includes shares, operators and recipients.
+func inspectPrefixes(nodeStorage operatorstorage.Storage) []inspectPrefix {
+	prefixes := []inspectPrefix{
+		{"operator", operatorstorage.OperatorStoragePrefix},
+		{"shares", append(append([]byte{}, operatorstorage.OperatorStoragePrefix...), "shares_v2/"...)},
+		{"operators", append(append([]byte{}, operatorstorage.OperatorStoragePrefix...), nodeStorage.GetOperatorsPrefix()...)},
+		{"recipients", append(append([]byte{}, operatorstorage.OperatorStoragePrefix...), nodeStorage.GetRecipientsPrefix()...)},
+		{"signer", []byte("signer_data-")},
+		{"migrations", []byte("migrations/")},
+		{"p2p", []byte("p2p-")},
+		{"duty_history", []byte("duty_history/")},
+	}
+	for _, role := range []spectypes.BeaconRole{
+		spectypes.BNRoleAttester,
+		spectypes.BNRoleProposer,
+		spectypes.BNRoleSyncCommittee,
+		spectypes.BNRoleAggregator,
+		spectypes.BNRoleSyncCommitteeContribution,
+		spectypes.BNRoleValidatorRegistration,
+		spectypes.BNRoleVoluntaryExit,
+	} {
+		prefixes = append(prefixes, inspectPrefix{"participants/" + role.String(), ibftstorage.ParticipantsPrefix(role)})
+	}
+	return prefixes
+}
+
+var inspectStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Prints the number of keys and their estimated size per known prefix",
+	Run: func(cmd *cobra.Command, args []string) {
+		inspect(cmd, func(logger *zap.Logger, db kv.DB, nodeStorage operatorstorage.Storage) (any, func(io.Writer)) {
+			var result []inspectPrefixJSON
+			add := func(name string, prefix []byte) {
+				keys, err := db.CountPrefix(prefix)
+				if err != nil {
+					logger.Fatal("failed to count keys", zap.String("prefix", name), zap.Error(err))
+				}
+				size, err := db.SizePrefix(prefix)
+				if err != nil {
+					logger.Fatal("failed to measure size", zap.String("prefix", name), zap.Error(err))
+				}
+				result = append(result, inspectPrefixJSON{
+					Name:   name,
+					Prefix: fmt.Sprintf("%q", prefix),
+					Keys:   keys,
+					Size:   size,
+				})
+			}
+
+			for _, p := range inspectPrefixes(nodeStorage) {
+				add(p.name, p.prefix)
+			}
+			add("total", nil)
+
+			return result, func(w io.Writer) {
+				_, _ = fmt.Fprintln(w, "NAME\tPREFIX\tKEYS\tSIZE")
+				for _, p := range result {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", p.Name, p.Prefix, p.Keys, p.Size)
+				}
+			}
+		})
+	},
+}
+
+// inspect opens the database read-only and prints the result of fn as JSON or as a table.
+func inspect(cmd *cobra.Command, fn func(logger *zap.Logger, db kv.DB, nodeStorage operatorstorage.Storage) (any, func(io.Writer))) {
+	logger, err := setupGlobal()
+	if err != nil {
+		log.Fatal("could not create logger ", err)
+	}
+
+	asJSON, err := cmd.Flags().GetBool(inspectJSONFlag)
+	if err != nil {
+		logger.Fatal("failed to get json flag value", zap.Error(err))
+	}
+
+	networkConfig, err := setupSSVNetwork(logger)
+	if err != nil {
+		logger.Fatal("could not setup network", zap.Error(err))
+	}
+
+	db := setupReadOnlyDB(cmd, logger)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	nodeStorage, err := operatorstorage.NewNodeStorage(networkConfig, logger, db)
+	if err != nil {
+		logger.Fatal("failed to create node storage", zap.Error(err))
+	}
+
+	result, printTable := fn(logger, db, nodeStorage)
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			logger.Fatal("failed to encode result", zap.Error(err))
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	printTable(w)
+	if err := w.Flush(); err != nil {
+		logger.Fatal("failed to print result", zap.Error(err))
+	}
+}
+
+func joinUint64s(values []uint64) string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = fmt.Sprint(v)
+	}
+	return strings.Join(strs, ",")
+}
+
+func init() {
+	inspectDBCmd.PersistentFlags().Bool(inspectJSONFlag, false, "Print the result as JSON")
+
+	cliflag.AddPersistentStringFlag(inspectParticipantsCmd, inspectRoleFlag, "", "Beacon role of the participants (e.g. ATTESTER, PROPOSER, SYNC_COMMITTEE)", true)
+	cliflag.AddPersistentIntFlag(inspectParticipantsCmd, inspectFromFlag, 0, "First slot of the range", true)
+	cliflag.AddPersistentIntFlag(inspectParticipantsCmd, inspectToFlag, 0, "Last slot of the range", true)
+
+	inspectDBCmd.AddCommand(inspectSharesCmd)
+	inspectDBCmd.AddCommand(inspectOperatorsCmd)
+	inspectDBCmd.AddCommand(inspectRecipientsCmd)
+	inspectDBCmd.AddCommand(inspectParticipantsCmd)
+	inspectDBCmd.AddCommand(inspectConfigCmd)
+	inspectDBCmd.AddCommand(inspectMigrationsCmd)
+	inspectDBCmd.AddCommand(inspectStatsCmd)
+
+	DBCmd.AddCommand(inspectDBCmd)
+}
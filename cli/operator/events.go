@@ -0,0 +1,129 @@
+package operator
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/eth/eventhandler"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/operator/snapshot"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/utils/cliflag"
+)
+
+const (
+	rewindToBlockFlag = "to-block"
+	snapshotFileFlag  = "file"
+)
+
+// EventsCmd groups the commands operating on the synced registry contract events.
+var EventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Registry contract events operations",
+}
+
+var rewindEventsCmd = &cobra.Command{
+	Use:   "rewind",
+	Short: "Reverts the registry state to the given block, so that the events after it are synced again on start. The node must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		toBlock, err := cmd.Flags().GetUint64(rewindToBlockFlag)
+		if err != nil {
+			logger.Fatal("failed to get to-block flag value", zap.Error(err))
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		db := setupOfflineDB(cmd, logger)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(networkConfig, logger, db)
+		if err != nil {
+			logger.Fatal("failed to create node storage", zap.Error(err))
+		}
+
+		lastProcessedBlock, err := eventhandler.Rewind(nodeStorage, toBlock)
+		if err != nil {
+			logger.Fatal("failed to rewind registry events", zap.Error(err))
+		}
+		if lastProcessedBlock <= toBlock {
+			logger.Info("nothing to rewind, events were not processed past the given block",
+				zap.Uint64("last_processed_block", lastProcessedBlock))
+			return
+		}
+
+		logger.Info("registry events rewound",
+			fields.FromBlock(toBlock+1),
+			fields.ToBlock(lastProcessedBlock),
+		)
+	},
+}
+
+var exportEventsCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports a snapshot of the registry state synced from the contract events, to seed new nodes with. The node must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		filePath, err := cmd.Flags().GetString(snapshotFileFlag)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		db := setupOfflineDB(cmd, logger)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(networkConfig, logger, db)
+		if err != nil {
+			logger.Fatal("failed to create node storage", zap.Error(err))
+		}
+
+		registrySnapshot, err := snapshot.Export(nodeStorage, networkConfig.NetworkName())
+		if err != nil {
+			logger.Fatal("failed to export registry snapshot", zap.Error(err))
+		}
+		if err := snapshot.WriteFile(filePath, registrySnapshot); err != nil {
+			logger.Fatal("failed to write registry snapshot", zap.Error(err))
+		}
+
+		logger.Info("registry snapshot exported",
+			zap.String("file", filePath),
+			zap.Uint64("last_processed_block", registrySnapshot.LastProcessedBlock),
+			zap.Int("operators", len(registrySnapshot.Operators)),
+			zap.Int("validators", len(registrySnapshot.Shares)),
+		)
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, EventsCmd)
+
+	cliflag.AddPersistentIntFlag(rewindEventsCmd, rewindToBlockFlag, 0, "Block to revert the registry state to, must be within the events journal depth", true)
+
+	cliflag.AddPersistentStringFlag(exportEventsCmd, snapshotFileFlag, "", "Path to write the registry snapshot to", true)
+
+	EventsCmd.AddCommand(rewindEventsCmd)
+	EventsCmd.AddCommand(exportEventsCmd)
+}
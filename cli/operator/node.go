@@ -8,10 +8,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
+	"maps"
 	"math/big"
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -53,14 +55,19 @@ import (
 	"github.com/ssvlabs/ssv/monitoring/metrics"
 	"github.com/ssvlabs/ssv/network"
 	networkcommons "github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/decidedsync"
 	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
 	"github.com/ssvlabs/ssv/networkconfig"
 	"github.com/ssvlabs/ssv/nodeprobe"
 	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/operator"
+	"github.com/ssvlabs/ssv/operator/backup"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/operator/dutyhistory"
+	"github.com/ssvlabs/ssv/operator/proposerconfig"
 	"github.com/ssvlabs/ssv/operator/slotticker"
+	"github.com/ssvlabs/ssv/operator/snapshot"
 	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
 	"github.com/ssvlabs/ssv/operator/validator"
 	"github.com/ssvlabs/ssv/operator/validator/metadata"
@@ -85,6 +92,21 @@ type SSVSignerConfig struct {
 	KeystoreFile         string        `yaml:"KeystoreFile" env:"KEYSTORE_FILE" env-description:"Path to ssv-signer client keystore file"`
 	KeystorePasswordFile string        `yaml:"KeystorePasswordFile" env:"KEYSTORE_PASSWORD_FILE" env-description:"Path to file containing the password for client keystore file"`
 	ServerCertFile       string        `yaml:"ServerCertFile" env:"SERVER_CERT_FILE" env-description:"Path to trusted server certificate file for ssv-signer"`
+	Operator             string        `yaml:"Operator" env:"OPERATOR" env-description:"ID or base64 public key of the operator to use if ssv-signer hosts several operators, its primary operator is used if empty"`
+	OperatorTokenFile    string        `yaml:"OperatorTokenFile" env:"OPERATOR_TOKEN_FILE" env-description:"Path to file containing the token ssv-signer requires to act on behalf of the operator"`
+}
+
+type SSVAPIAdminConfig struct {
+	TokenFile    string `yaml:"TokenFile" env:"TOKEN_FILE" env-description:"Path to file containing the bearer token required by SSV API admin endpoints"`
+	TLSCertFile  string `yaml:"TLSCertFile" env:"TLS_CERT_FILE" env-description:"Path to TLS certificate file, serves the SSV API over TLS"`
+	TLSKeyFile   string `yaml:"TLSKeyFile" env:"TLS_KEY_FILE" env-description:"Path to TLS private key file"`
+	ClientCAFile string `yaml:"ClientCAFile" env:"CLIENT_CA_FILE" env-description:"Path to CA bundle used to verify client certificates for SSV API admin endpoints (mTLS)"`
+	BackupDir    string `yaml:"BackupDir" env:"BACKUP_DIR" env-description:"Directory to write online database backups triggered through SSV API admin endpoints to"`
+}
+
+// enabled returns whether the admin endpoints should be served, which requires at least one authentication method.
+func (c SSVAPIAdminConfig) enabled() bool {
+	return c.TokenFile != "" || c.ClientCAFile != ""
 }
 
 type config struct {
@@ -99,6 +121,7 @@ type config struct {
 	Graffiti                     string                  `yaml:"Graffiti" env:"GRAFFITI" env-description:"Custom graffiti for block proposals" env-default:"ssv.network" `
 	ProposerDelay                time.Duration           `yaml:"ProposerDelay" env:"PROPOSER_DELAY" env-description:"Duration to wait out before requesting Ethereum block to propose if this Operator is proposer-duty Leader (eg. 300ms). See https://github.com/ssvlabs/ssv/blob/main/docs/MEV_CONSIDERATIONS.md#getting-started-with-mev-configuration for detailed instructions on how to use it."`
 	AllowDangerousProposerDelay  bool                    `yaml:"AllowDangerousProposerDelay" env:"ALLOW_DANGEROUS_PROPOSER_DELAY" env-description:"Allow ProposerDelay values higher than 1s (dangerous, may cause missed block proposals)"`
+	ProposerConfigFile           string                  `yaml:"ProposerConfigFile" env:"PROPOSER_CONFIG_FILE" env-description:"Path to YAML file with per-owner and per-validator graffiti, gas limit and builder policy, which can be updated through SSV API admin endpoints"`
 	OperatorPrivateKey           string                  `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key for contract event decryption"`
 	MetricsAPIPort               int                     `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"Port for metrics API server"`
 	EnableProfile                bool                    `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"Enable Go profiling tools"`
@@ -106,8 +129,15 @@ type config struct {
 	WsAPIPort                    int                     `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port for WebSocket API server"`
 	WithPing                     bool                    `yaml:"WithPing" env:"WITH_PING" env-description:"Enable WebSocket ping messages"`
 	SSVAPIPort                   int                     `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port for SSV API server"`
+	SSVAPIAdmin                  SSVAPIAdminConfig       `yaml:"SSVAPIAdmin" env-prefix:"SSV_API_ADMIN_"`
 	LocalEventsPath              string                  `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"Path to local events file"`
 	EnableDoppelgangerProtection bool                    `yaml:"EnableDoppelgangerProtection" env:"ENABLE_DOPPELGANGER_PROTECTION" env-description:"Enable doppelganger protection for validators"`
+	DoppelgangerResumeWindow     uint64                  `yaml:"DoppelgangerResumeWindow" env:"DOPPELGANGER_RESUME_WINDOW" env-default:"0" env-description:"Number of epochs since a validator was last safe within which doppelganger protection resumes from its persisted state after a restart (0 disables)"`
+	MessageValidationTraceSize   int                     `yaml:"MessageValidationTraceSize" env:"MESSAGE_VALIDATION_TRACE_SIZE" env-default:"4096" env-description:"Number of recent messages which failed validation kept for the SSV API (0 disables)"`
+	MessageValidationCapture     string                  `yaml:"MessageValidationCapture" env:"MESSAGE_VALIDATION_CAPTURE" env-description:"Path of a file to append the received pubsub messages to, for replaying them with 'ssvnode validation replay' (empty disables)"`
+	RegistrySnapshot             string                  `yaml:"RegistrySnapshot" env:"REGISTRY_SNAPSHOT" env-description:"Path to a registry snapshot exported with 'ssvnode events export' to seed an empty database with, syncing events from the block it was taken at"`
+	RegistrySnapshotSamples      int                     `yaml:"RegistrySnapshotSamples" env:"REGISTRY_SNAPSHOT_SAMPLES" env-default:"5" env-description:"Number of operators and validators of the registry snapshot to verify against their contract events before importing it"`
+	EventsJournalDepth           uint64                  `yaml:"EventsJournalDepth" env:"EVENTS_JOURNAL_DEPTH" env-default:"512" env-description:"Number of recent blocks whose registry changes are kept for reverting them on reorg or with 'ssvnode events rewind'"`
 }
 
 var cfg config
@@ -173,6 +203,22 @@ var StartNodeCmd = &cobra.Command{
 				ssvsigner.WithRequestTimeout(cfg.SSVSigner.RequestTimeout),
 			}
 
+			if cfg.SSVSigner.Operator != "" {
+				ssvSignerOptions = append(ssvSignerOptions, ssvsigner.WithOperator(cfg.SSVSigner.Operator))
+			}
+
+			if cfg.SSVSigner.OperatorTokenFile != "" {
+				tokenBytes, err := os.ReadFile(cfg.SSVSigner.OperatorTokenFile)
+				if err != nil {
+					logger.Fatal("failed to read ssv-signer operator token file", zap.Error(err))
+				}
+				token := strings.TrimSpace(string(tokenBytes))
+				if token == "" {
+					logger.Fatal("ssv-signer operator token file is empty")
+				}
+				ssvSignerOptions = append(ssvSignerOptions, ssvsigner.WithOperatorToken(token))
+			}
+
 			if cfg.SSVSigner.KeystoreFile != "" || cfg.SSVSigner.ServerCertFile != "" {
 				tlsConfig := &ssvsignertls.Config{
 					ClientKeystoreFile:         cfg.SSVSigner.KeystoreFile,
@@ -287,20 +333,35 @@ var StartNodeCmd = &cobra.Command{
 			logger.Fatal("no execution node address provided")
 		}
 
+		finalityTag, err := executionclient.ParseFinalityTag(cfg.ExecutionClient.FinalityTag)
+		if err != nil {
+			logger.Fatal("invalid execution client finality tag", zap.Error(err))
+		}
+		if cfg.ExecutionClient.ProvisionalEvents && finalityTag == executionclient.FinalityTagNone {
+			logger.Fatal("provisional events require a finality tag")
+		}
+
 		var executionClient executionclient.Provider
 
 		if len(executionAddrList) == 1 {
-			ec, err := executionclient.New(
-				cmd.Context(),
-				executionAddrList[0],
-				ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+			opts := []executionclient.Option{
 				executionclient.WithLogger(logger),
 				executionclient.WithFollowDistance(executionclient.DefaultFollowDistance),
+				executionclient.WithFinalityTag(finalityTag),
 				executionclient.WithConnectionTimeout(cfg.ExecutionClient.ConnectionTimeout),
 				executionclient.WithReconnectionInitialInterval(executionclient.DefaultReconnectionInitialInterval),
 				executionclient.WithReconnectionMaxInterval(executionclient.DefaultReconnectionMaxInterval),
 				executionclient.WithHealthInvalidationInterval(executionclient.DefaultHealthInvalidationInterval),
 				executionclient.WithSyncDistanceTolerance(cfg.ExecutionClient.SyncDistanceTolerance),
+			}
+			if cfg.ExecutionClient.ProvisionalEvents {
+				opts = append(opts, executionclient.WithProvisionalLogs())
+			}
+			ec, err := executionclient.New(
+				cmd.Context(),
+				executionAddrList[0],
+				ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+				opts...,
 			)
 			if err != nil {
 				logger.Fatal("could not connect to execution client", zap.Error(err))
@@ -308,17 +369,24 @@ var StartNodeCmd = &cobra.Command{
 
 			executionClient = ec
 		} else {
-			ec, err := executionclient.NewMulti(
-				cmd.Context(),
-				executionAddrList,
-				ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+			opts := []executionclient.OptionMulti{
 				executionclient.WithLoggerMulti(logger),
 				executionclient.WithFollowDistanceMulti(executionclient.DefaultFollowDistance),
+				executionclient.WithFinalityTagMulti(finalityTag),
 				executionclient.WithConnectionTimeoutMulti(cfg.ExecutionClient.ConnectionTimeout),
 				executionclient.WithReconnectionInitialIntervalMulti(executionclient.DefaultReconnectionInitialInterval),
 				executionclient.WithReconnectionMaxIntervalMulti(executionclient.DefaultReconnectionMaxInterval),
 				executionclient.WithHealthInvalidationIntervalMulti(executionclient.DefaultHealthInvalidationInterval),
 				executionclient.WithSyncDistanceToleranceMulti(cfg.ExecutionClient.SyncDistanceTolerance),
+			}
+			if cfg.ExecutionClient.ProvisionalEvents {
+				opts = append(opts, executionclient.WithProvisionalLogsMulti())
+			}
+			ec, err := executionclient.NewMulti(
+				cmd.Context(),
+				executionAddrList,
+				ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+				opts...,
 			)
 			if err != nil {
 				logger.Fatal("could not connect to execution client", zap.Error(err))
@@ -361,6 +429,8 @@ var StartNodeCmd = &cobra.Command{
 		cfg.P2pNetworkConfig.OperatorPubKeyHash = format.OperatorID(operatorDataStore.GetOperatorData().PublicKey)
 		cfg.P2pNetworkConfig.OperatorDataStore = operatorDataStore
 		cfg.P2pNetworkConfig.FullNode = cfg.SSVOptions.ValidatorOptions.FullNode
+		cfg.P2pNetworkConfig.Exporter = cfg.SSVOptions.ValidatorOptions.Exporter
+		cfg.P2pNetworkConfig.RemoteSigner = usingSSVSigner
 		cfg.P2pNetworkConfig.Network = networkConfig
 
 		validatorsMap := validators.New(cmd.Context())
@@ -370,6 +440,26 @@ var StartNodeCmd = &cobra.Command{
 
 		signatureVerifier := signatureverifier.NewSignatureVerifier(nodeStorage)
 
+		validationOpts := []validation.Option{validation.WithLogger(logger)}
+		// validationTraceAPI is left nil when disabled, so that the SSV API reports it as such.
+		var validationTraceAPI handlers.ValidationTrace
+		if cfg.MessageValidationTraceSize > 0 {
+			validationTrace := validation.NewDecisionTrace(cfg.MessageValidationTraceSize)
+			validationOpts = append(validationOpts, validation.WithDecisionTrace(validationTrace))
+			validationTraceAPI = validationTrace
+		}
+		if cfg.MessageValidationCapture != "" {
+			capture, err := validation.NewCaptureWriter(logger, cfg.MessageValidationCapture)
+			if err != nil {
+				logger.Fatal("failed to open message validation capture", zap.Error(err))
+			}
+			defer func() {
+				_ = capture.Close()
+			}()
+			logger.Warn("capturing received pubsub messages, the capture file grows quickly", zap.String("path", cfg.MessageValidationCapture))
+			validationOpts = append(validationOpts, validation.WithCapture(capture))
+		}
+
 		messageValidator := validation.New(
 			networkConfig,
 			nodeStorage.ValidatorStore(),
@@ -377,7 +467,7 @@ var StartNodeCmd = &cobra.Command{
 			dutyStore,
 			signatureVerifier,
 			consensusClient.ForkEpochElectra,
-			validation.WithLogger(logger),
+			validationOpts...,
 		)
 
 		cfg.P2pNetworkConfig.MessageValidator = messageValidator
@@ -433,6 +523,13 @@ var StartNodeCmd = &cobra.Command{
 			retain := cfg.SSVOptions.ValidatorOptions.ExporterRetainSlots
 			threshold := cfg.SSVOptions.Network.Beacon.EstimatedCurrentSlot()
 			initSlotPruning(cmd.Context(), logger, storageMap, slotTickerProvider, threshold, retain)
+
+			// Serve the decided history to other exporters syncing it.
+			cfg.P2pNetworkConfig.DecidedHistory = decidedsync.NewServer(
+				storageMap,
+				nodeStorage.ValidatorStore(),
+				cfg.P2pNetworkConfig.MaxBatchResponse,
+			)
 		}
 
 		cfg.SSVOptions.ValidatorOptions.StorageMap = storageMap
@@ -440,6 +537,35 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.ProposerDelay = cfg.ProposerDelay
 		cfg.SSVOptions.ValidatorOptions.ValidatorStore = nodeStorage.ValidatorStore()
 
+		// proposerConfigAPI is nil unless a proposer config file is set, so the API reports it as disabled.
+		var proposerConfigAPI handlers.ProposerConfig
+		if cfg.ProposerConfigFile != "" {
+			proposerConfig, err := proposerconfig.Open(cfg.ProposerConfigFile)
+			if err != nil {
+				logger.Fatal("failed to open proposer config", zap.Error(err))
+			}
+			config := proposerConfig.Config()
+			for _, warning := range config.Warnings() {
+				logger.Warn("proposer config sets a committee-wide value, make sure it matches the other operators",
+					zap.String("target", warning.Target),
+					zap.String("field", warning.Field),
+					zap.String("reason", warning.Message),
+				)
+			}
+			cfg.SSVOptions.ValidatorOptions.ProposerConfig = proposerConfig
+			proposerConfigAPI = proposerConfig
+		}
+
+		var dutyHistoryStore handlers.DutyHistory
+		if retainEpochs := cfg.SSVOptions.ValidatorOptions.DutyHistoryRetainEpochs; retainEpochs > 0 {
+			store := dutyhistory.NewStore(db)
+			recorder := dutyhistory.NewRecorder(logger.Named(logging.NameDutyHistory), store)
+			retain := phase0.Slot(retainEpochs * networkConfig.SlotsPerEpoch())
+			go recorder.PruneContinuously(cmd.Context(), slotTickerProvider, retain)
+			cfg.SSVOptions.ValidatorOptions.DutyHistory = recorder
+			dutyHistoryStore = store
+		}
+
 		fixedSubnets, err := networkcommons.FromString(cfg.P2pNetworkConfig.Subnets)
 		if err != nil {
 			logger.Fatal("failed to parse fixed subnets", zap.Error(err))
@@ -463,6 +589,8 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.ValidatorSyncer = metadataSyncer
 
 		var doppelgangerHandler doppelganger.Provider
+		// doppelgangerAPI is nil unless Doppelganger protection is enabled, so the API reports it as disabled.
+		var doppelgangerAPI doppelganger.Provider
 		if cfg.EnableDoppelgangerProtection {
 			doppelgangerHandler = doppelganger.NewHandler(&doppelganger.Options{
 				Network:            networkConfig,
@@ -470,8 +598,11 @@ var StartNodeCmd = &cobra.Command{
 				ValidatorProvider:  nodeStorage.ValidatorStore().WithOperatorID(operatorDataStore.GetOperatorID),
 				SlotTickerProvider: slotTickerProvider,
 				Logger:             logger,
+				DB:                 db,
+				ResumeWindow:       phase0.Epoch(cfg.DoppelgangerResumeWindow),
 			})
-			logger.Info("Doppelganger protection enabled.")
+			doppelgangerAPI = doppelgangerHandler
+			logger.Info("Doppelganger protection enabled.", zap.Uint64("resume_window", cfg.DoppelgangerResumeWindow))
 		} else {
 			doppelgangerHandler = doppelganger.NoOpHandler{}
 			logger.Info("Doppelganger protection disabled.")
@@ -518,6 +649,8 @@ var StartNodeCmd = &cobra.Command{
 			operatorPrivKey,
 			keyManager,
 			doppelgangerHandler,
+			finalityTag,
+			cfg.ExecutionClient.ProvisionalEvents,
 		)
 		if len(cfg.LocalEventsPath) == 0 {
 			nodeProber.AddNode("event syncer", eventSyncer)
@@ -572,6 +705,21 @@ var StartNodeCmd = &cobra.Command{
 			}
 		}
 
+		if cfg.SSVOptions.ValidatorOptions.Exporter {
+			decidedSyncer := decidedsync.NewSyncer(decidedsync.SyncerOptions{
+				Logger:           logger.Named(logging.NameDecidedSync),
+				Network:          p2pNetwork.(decidedsync.Network),
+				Stores:           storageMap,
+				ValidatorStore:   nodeStorage.ValidatorStore(),
+				BeaconNetwork:    networkConfig.Beacon,
+				Domains:          consensusClient,
+				DB:               db,
+				MaxBatchResponse: cfg.P2pNetworkConfig.MaxBatchResponse,
+				Retain:           phase0.Slot(cfg.SSVOptions.ValidatorOptions.ExporterRetainSlots),
+			})
+			go decidedSyncer.Run(cmd.Context(), slotTickerProvider)
+		}
+
 		if cfg.SSVAPIPort > 0 {
 			apiServer := apiserver.New(
 				logger,
@@ -583,14 +731,22 @@ var StartNodeCmd = &cobra.Command{
 					Network:         p2pNetwork.(p2pv1.HostProvider).Host().Network(),
 					TopicIndex:      p2pNetwork.(handlers.TopicIndex),
 					NodeProber:      nodeProber,
+					ValidationTrace: validationTraceAPI,
+					Shares:          nodeStorage.Shares(),
+					SubnetsHealth:   p2pNetwork.(handlers.SubnetsHealthProvider),
 				},
 				&handlers.Validators{
-					Shares: nodeStorage.Shares(),
+					Shares:       nodeStorage.Shares(),
+					DutyHistory:  dutyHistoryStore,
+					Doppelganger: doppelgangerAPI,
 				},
 				&handlers.Exporter{
 					ParticipantStores: storageMap,
 				},
 			)
+			if cfg.SSVAPIAdmin.enabled() {
+				setupAdminAPI(logger, apiServer, db, networkConfig.NetworkName(), metadataSyncer, p2pNetwork, doppelgangerAPI, proposerConfigAPI)
+			}
 			go func() {
 				err := apiServer.Run()
 				if err != nil {
@@ -604,6 +760,58 @@ var StartNodeCmd = &cobra.Command{
 	},
 }
 
+func setupAdminAPI(
+	logger *zap.Logger,
+	apiServer *apiserver.Server,
+	db kv.DB,
+	networkName string,
+	metadataSyncer *metadata.Syncer,
+	p2pNetwork network.P2PNetwork,
+	doppelgangerAPI doppelganger.Provider,
+	proposerConfigAPI handlers.ProposerConfig,
+) {
+	var token string
+	if cfg.SSVAPIAdmin.TokenFile != "" {
+		tokenBytes, err := os.ReadFile(cfg.SSVAPIAdmin.TokenFile)
+		if err != nil {
+			logger.Fatal("failed to read SSV API admin token file", zap.Error(err))
+		}
+		token = strings.TrimSpace(string(tokenBytes))
+		if token == "" {
+			logger.Fatal("SSV API admin token file is empty")
+		}
+	}
+
+	peerAdmin, ok := p2pNetwork.(handlers.PeerAdmin)
+	if !ok {
+		logger.Fatal("p2p network doesn't support peer administration, can't setup SSV API admin endpoints",
+			zap.String("p2p_network_type", fmt.Sprintf("%T", p2pNetwork)))
+	}
+
+	err := apiServer.WithAdmin(
+		&handlers.Admin{
+			Logger:         logger.Named("admin_api"),
+			LogLevel:       logging.GlobalLevel(),
+			DB:             db,
+			MetadataSyncer: metadataSyncer,
+			Peers:          peerAdmin,
+			Doppelganger:   doppelgangerAPI,
+			ProposerConfig: proposerConfigAPI,
+			Backup:         backup.NewOnline(db, networkName),
+			BackupDir:      cfg.SSVAPIAdmin.BackupDir,
+		},
+		apiserver.AdminOptions{
+			Token:        token,
+			TLSCertFile:  cfg.SSVAPIAdmin.TLSCertFile,
+			TLSKeyFile:   cfg.SSVAPIAdmin.TLSKeyFile,
+			ClientCAFile: cfg.SSVAPIAdmin.ClientCAFile,
+		},
+	)
+	if err != nil {
+		logger.Fatal("failed to setup SSV API admin endpoints", zap.Error(err))
+	}
+}
+
 func ensureNoMissingKeys(
 	ctx context.Context,
 	logger *zap.Logger,
@@ -782,8 +990,8 @@ func setupDB(
 	logger *zap.Logger,
 	networkConfig networkconfig.NetworkConfig,
 	operatorPrivKey keys.OperatorPrivateKey,
-) (*kv.BadgerDB, error) {
-	db, err := kv.New(logger, cfg.DBOptions)
+) (kv.DB, error) {
+	db, err := kv.Open(logger, cfg.DBOptions)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open db")
 	}
@@ -791,7 +999,7 @@ func setupDB(
 		if err := db.Close(); err != nil {
 			return errors.Wrap(err, "failed to close db")
 		}
-		db, err = kv.New(logger, cfg.DBOptions)
+		db, err = kv.Open(logger, cfg.DBOptions)
 		return errors.Wrap(err, "failed to reopen db")
 	}
 
@@ -978,6 +1186,7 @@ func setupP2P(logger *zap.Logger, db basedb.Database) network.P2PNetwork {
 		logger.Fatal("failed to setup network private key", zap.Error(err))
 	}
 	cfg.P2pNetworkConfig.NetworkPrivateKey = netPrivKey
+	cfg.P2pNetworkConfig.DB = db
 
 	n, err := p2pv1.New(logger, &cfg.P2pNetworkConfig)
 	if err != nil {
@@ -1008,6 +1217,8 @@ func syncContractEvents(
 	operatorDecrypter keys.OperatorDecrypter,
 	keyManager ekm.KeyManager,
 	doppelgangerHandler eventhandler.DoppelgangerProvider,
+	finalityTag executionclient.FinalityTag,
+	provisionalEvents bool,
 ) *eventsyncer.EventSyncer {
 	eventFilterer, err := executionClient.Filterer()
 	if err != nil {
@@ -1027,18 +1238,32 @@ func syncContractEvents(
 		doppelgangerHandler,
 		eventhandler.WithFullNode(),
 		eventhandler.WithLogger(logger),
+		eventhandler.WithJournalDepth(cfg.EventsJournalDepth),
 	)
 	if err != nil {
 		logger.Fatal("failed to setup event data handler", zap.Error(err))
 	}
 
+	syncerOpts := []eventsyncer.Option{
+		eventsyncer.WithLogger(logger),
+		eventsyncer.WithFinalityTag(finalityTag),
+	}
+	if provisionalEvents {
+		syncerOpts = append(syncerOpts, eventsyncer.WithProvisionalEvents(eventHandler))
+	} else {
+		syncerOpts = append(syncerOpts, eventsyncer.WithReorgRollback(eventHandler))
+	}
 	eventSyncer := eventsyncer.New(
 		nodeStorage,
 		executionClient,
 		eventHandler,
-		eventsyncer.WithLogger(logger),
+		syncerOpts...,
 	)
 
+	if len(cfg.RegistrySnapshot) != 0 && len(cfg.LocalEventsPath) == 0 {
+		importRegistrySnapshot(ctx, logger, executionClient, networkConfig, nodeStorage, operatorDataStore, eventHandler)
+	}
+
 	fromBlock, found, err := nodeStorage.GetLastProcessedBlock(nil)
 	if err != nil {
 		logger.Fatal("syncing registry contract events failed, could not get last processed block", zap.Error(err))
@@ -1118,6 +1343,95 @@ func syncContractEvents(
 	return eventSyncer
 }
 
+// importRegistrySnapshot seeds the registry state from the configured snapshot, unless events were already synced,
+// after verifying a sample of it and its event hash chain against the contract events,
+// and restores the keys of the operator's own shares.
+func importRegistrySnapshot(
+	ctx context.Context,
+	logger *zap.Logger,
+	executionClient executionclient.Provider,
+	networkConfig networkconfig.NetworkConfig,
+	nodeStorage operatorstorage.Storage,
+	operatorDataStore operatordatastore.OperatorDataStore,
+	eventHandler *eventhandler.EventHandler,
+) {
+	logger = logger.With(zap.String("file", cfg.RegistrySnapshot))
+
+	_, found, err := nodeStorage.GetLastProcessedBlock(nil)
+	if err != nil {
+		logger.Fatal("could not get last processed block", zap.Error(err))
+	}
+	if found {
+		logger.Info("registry events were already synced, ignoring registry snapshot")
+		return
+	}
+
+	registrySnapshot, err := snapshot.ReadFile(cfg.RegistrySnapshot)
+	if err != nil {
+		logger.Fatal("failed to read registry snapshot", zap.Error(err))
+	}
+	if err := registrySnapshot.Validate(networkConfig.NetworkName()); err != nil {
+		logger.Fatal("invalid registry snapshot", zap.Error(err))
+	}
+	if err := snapshot.Verify(
+		ctx,
+		executionClient,
+		ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+		registrySnapshot,
+		networkConfig.RegistrySyncOffset.Uint64(),
+		cfg.RegistrySnapshotSamples,
+	); err != nil {
+		logger.Fatal("failed to verify registry snapshot against contract events", zap.Error(err))
+	}
+	if err := snapshot.Import(nodeStorage, registrySnapshot); err != nil {
+		logger.Fatal("failed to import registry snapshot", zap.Error(err))
+	}
+
+	// The operator's data and keys are otherwise set up while processing its events.
+	operatorData, found, err := nodeStorage.GetOperatorDataByPubKey(nil, operatorDataStore.GetOperatorData().PublicKey)
+	if err != nil {
+		logger.Fatal("could not get operator data by public key", zap.Error(err))
+	}
+	if found {
+		operatorDataStore.SetOperatorData(operatorData)
+
+		ownShares := nodeStorage.Shares().List(nil, registrystorage.ByOperatorID(operatorData.ID))
+		owners := make(map[ethcommon.Address]struct{})
+		for _, share := range ownShares {
+			owners[share.OwnerAddress] = struct{}{}
+		}
+		if len(owners) > 0 {
+			filterer, err := executionClient.Filterer()
+			if err != nil {
+				logger.Fatal("failed to set up event filterer", zap.Error(err))
+			}
+			events, err := snapshot.ValidatorAddedEvents(
+				ctx,
+				filterer,
+				networkConfig.RegistrySyncOffset.Uint64(),
+				registrySnapshot.LastProcessedBlock,
+				slices.Collect(maps.Keys(owners))...,
+			)
+			if err != nil {
+				logger.Fatal("failed to fetch own validators' events", zap.Error(err))
+			}
+			restored, err := eventHandler.RestoreShareKeys(ctx, events)
+			if err != nil {
+				logger.Fatal("failed to restore own share keys", zap.Error(err))
+			}
+			if restored != len(ownShares) {
+				logger.Fatal("could not restore the keys of all own shares", zap.Int("shares", len(ownShares)), zap.Int("restored", restored))
+			}
+		}
+	}
+
+	logger.Info("imported registry snapshot",
+		zap.Uint64("last_processed_block", registrySnapshot.LastProcessedBlock),
+		zap.Int("operators", len(registrySnapshot.Operators)),
+		zap.Int("validators", len(registrySnapshot.Shares)),
+	)
+}
+
 func startMetricsHandler(logger *zap.Logger, db basedb.Database, port int, enableProf bool, opNode *operator.Node) {
 	logger = logger.Named(logging.NameMetricsHandler)
 	// init and start HTTP handler
@@ -0,0 +1,196 @@
+package operator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/ssvsigner/ekm"
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/logging/fields"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+	"github.com/ssvlabs/ssv/utils/cliflag"
+)
+
+const (
+	genesisValidatorsRootFlag = "genesis-validators-root"
+	interchangeFileFlag       = "file"
+)
+
+// SlashingProtectionCmd groups the commands for moving slashing protection data
+// in and out of the node database using the EIP-3076 interchange format.
+// The node must be stopped while these commands run.
+var SlashingProtectionCmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "Import or export slashing protection data (EIP-3076 interchange format)",
+}
+
+var exportSlashingProtectionCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the slashing protection data of all shares in the node database",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		genesisValidatorsRoot, filePath := interchangeFlags(cmd, logger)
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		db := setupOfflineDB(cmd, logger)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(networkConfig, logger, db)
+		if err != nil {
+			logger.Fatal("failed to create node storage", zap.Error(err))
+		}
+
+		var sharePubKeys []phase0.BLSPubKey
+		for _, share := range nodeStorage.Shares().List(nil) {
+			sharePubKeys = append(sharePubKeys, phase0.BLSPubKey(share.SharePubKey))
+		}
+
+		signerStore := ekm.NewSignerStorage(db, networkConfig.Beacon, logger)
+		doc, err := ekm.ExportSlashingProtection(nil, signerStore, genesisValidatorsRoot, sharePubKeys)
+		if err != nil {
+			logger.Fatal("failed to export slashing protection data", zap.Error(err))
+		}
+
+		data, err := doc.Marshal()
+		if err != nil {
+			logger.Fatal("failed to marshal slashing protection data", zap.Error(err))
+		}
+
+		if err := os.WriteFile(filePath, data, 0600); err != nil {
+			logger.Fatal("failed to write slashing protection data", zap.Error(err))
+		}
+
+		logger.Info("exported slashing protection data",
+			zap.String("file", filePath),
+			fields.Count(len(doc.Data)),
+		)
+	},
+}
+
+var importSlashingProtectionCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports slashing protection data into the node database",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		genesisValidatorsRoot, filePath := interchangeFlags(cmd, logger)
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		// #nosec G304
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			logger.Fatal("failed to read slashing protection data", zap.Error(err))
+		}
+
+		doc, err := interchange.Parse(data)
+		if err != nil {
+			logger.Fatal("failed to parse slashing protection data", zap.Error(err))
+		}
+
+		db := setupOfflineDB(cmd, logger)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		signerStore := ekm.NewSignerStorage(db, networkConfig.Beacon, logger)
+		err = db.Update(func(txn basedb.Txn) error {
+			return ekm.ImportSlashingProtection(txn, signerStore, genesisValidatorsRoot, doc)
+		})
+		if err != nil {
+			logger.Fatal("failed to import slashing protection data", zap.Error(err))
+		}
+
+		logger.Info("imported slashing protection data",
+			zap.String("file", filePath),
+			fields.Count(len(doc.Data)),
+		)
+	},
+}
+
+func interchangeFlags(cmd *cobra.Command, logger *zap.Logger) (phase0.Root, string) {
+	rootHex, err := cmd.Flags().GetString(genesisValidatorsRootFlag)
+	if err != nil {
+		logger.Fatal("failed to get genesis validators root flag value", zap.Error(err))
+	}
+
+	rootBytes, err := hex.DecodeString(strings.TrimPrefix(rootHex, "0x"))
+	if err != nil {
+		logger.Fatal("failed to decode genesis validators root", zap.Error(err))
+	}
+	if len(rootBytes) != len(phase0.Root{}) {
+		logger.Fatal("invalid genesis validators root length", zap.Int("length", len(rootBytes)))
+	}
+
+	filePath, err := cmd.Flags().GetString(interchangeFileFlag)
+	if err != nil {
+		logger.Fatal("failed to get file flag value", zap.Error(err))
+	}
+
+	return phase0.Root(rootBytes), filePath
+}
+
+// setupOfflineDB opens the node database for a command which runs while the node is stopped.
+// Migrations aren't applied and background routines (GC, reporting) are disabled.
+func setupOfflineDB(cmd *cobra.Command, logger *zap.Logger) kv.DB {
+	return openOfflineDB(cmd, logger, false)
+}
+
+// setupReadOnlyDB opens the node database for a command which only reads it.
+func setupReadOnlyDB(cmd *cobra.Command, logger *zap.Logger) kv.DB {
+	return openOfflineDB(cmd, logger, true)
+}
+
+func openOfflineDB(cmd *cobra.Command, logger *zap.Logger, readOnly bool) kv.DB {
+	options := cfg.DBOptions
+	options.Ctx = cmd.Context()
+	options.Reporting = false
+	options.GCInterval = 0
+	options.ReadOnly = readOnly
+
+	db, err := kv.Open(logger, options)
+	if err != nil {
+		logger.Fatal("could not open db", zap.Error(err), zap.String("path", options.Path))
+	}
+
+	return db
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, SlashingProtectionCmd)
+
+	cliflag.AddPersistentStringFlag(SlashingProtectionCmd, genesisValidatorsRootFlag, "",
+		"Hex encoded genesis validators root of the beacon chain", true)
+	cliflag.AddPersistentStringFlag(SlashingProtectionCmd, interchangeFileFlag, "",
+		fmt.Sprintf("Path to the interchange file (format version %s)", interchange.FormatVersion), true)
+
+	SlashingProtectionCmd.AddCommand(exportSlashingProtectionCmd)
+	SlashingProtectionCmd.AddCommand(importSlashingProtectionCmd)
+}
@@ -0,0 +1,180 @@
+package operator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/message/signatureverifier"
+	"github.com/ssvlabs/ssv/message/validation"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/utils/cliflag"
+)
+
+const (
+	replayCaptureFlag         = "capture"
+	replayPectraForkEpochFlag = "pectra-fork-epoch"
+	replayJSONFlag            = "json"
+)
+
+// ValidationCmd groups the message validation commands.
+var ValidationCmd = &cobra.Command{
+	Use:   "validation",
+	Short: "Message validation operations",
+}
+
+type replayResultJSON struct {
+	ReceivedAt time.Time `json:"received_at"`
+	PeerID     string    `json:"peer_id"`
+	Topic      string    `json:"topic"`
+	Result     string    `json:"result"`
+	Reason     string    `json:"reason,omitempty"`
+}
+
+var replayValidationCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replays a capture of pubsub messages through the message validator and prints its verdicts",
+	Long: `Replays a capture of pubsub messages, written by a node with MessageValidationCapture set, through the message
+validator in the order they were received, as if each message was received at its captured time. Validation uses the
+shares and operators of the node database, which should be a snapshot taken around the time of the capture.
+Beacon duties aren't captured, so proposer and sync committee contribution messages may be ignored for having no duty.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		capturePath, err := cmd.Flags().GetString(replayCaptureFlag)
+		if err != nil {
+			logger.Fatal("failed to get capture flag value", zap.Error(err))
+		}
+		pectraForkEpoch, err := cmd.Flags().GetUint64(replayPectraForkEpochFlag)
+		if err != nil {
+			logger.Fatal("failed to get pectra fork epoch flag value", zap.Error(err))
+		}
+		asJSON, err := cmd.Flags().GetBool(replayJSONFlag)
+		if err != nil {
+			logger.Fatal("failed to get json flag value", zap.Error(err))
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		db := setupReadOnlyDB(cmd, logger)
+		defer func() {
+			_ = db.Close()
+		}()
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(networkConfig, logger, db)
+		if err != nil {
+			logger.Fatal("failed to create node storage", zap.Error(err))
+		}
+
+		// #nosec G304
+		f, err := os.Open(capturePath)
+		if err != nil {
+			logger.Fatal("failed to open capture file", zap.Error(err))
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		replayer := validation.NewReplayer(
+			networkConfig,
+			nodeStorage.ValidatorStore(),
+			nodeStorage,
+			dutystore.New(),
+			signatureverifier.NewSignatureVerifier(nodeStorage),
+			phase0.Epoch(pectraForkEpoch),
+		)
+
+		var printResult func(replayResultJSON)
+		var flush func() error
+		if asJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			printResult = func(r replayResultJSON) {
+				if err := encoder.Encode(r); err != nil {
+					logger.Fatal("failed to encode result", zap.Error(err))
+				}
+			}
+			flush = func() error { return nil }
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "RECEIVED AT\tPEER\tTOPIC\tRESULT\tREASON")
+			printResult = func(r replayResultJSON) {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.ReceivedAt.Format(time.RFC3339Nano), r.PeerID, r.Topic, r.Result, r.Reason)
+			}
+			flush = w.Flush
+		}
+
+		counts := make(map[string]int)
+		reader := validation.NewCaptureReader(f)
+		for {
+			rec, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				logger.Fatal("failed to read capture file", zap.Error(err))
+			}
+
+			result := replayer.Replay(cmd.Context(), rec)
+			verdict := validationResultString(result.Result)
+			counts[verdict]++
+
+			printResult(replayResultJSON{
+				ReceivedAt: rec.ReceivedAt,
+				PeerID:     rec.PeerID.String(),
+				Topic:      rec.Topic,
+				Result:     verdict,
+				Reason:     result.Reason,
+			})
+		}
+		if err := flush(); err != nil {
+			logger.Fatal("failed to print results", zap.Error(err))
+		}
+
+		logger.Info("replay completed",
+			zap.Int("accepted", counts["accept"]),
+			zap.Int("ignored", counts["ignore"]),
+			zap.Int("rejected", counts["reject"]),
+		)
+	},
+}
+
+func validationResultString(result pubsub.ValidationResult) string {
+	switch result {
+	case pubsub.ValidationAccept:
+		return "accept"
+	case pubsub.ValidationIgnore:
+		return "ignore"
+	case pubsub.ValidationReject:
+		return "reject"
+	default:
+		return fmt.Sprintf("unknown(%d)", result)
+	}
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, ValidationCmd)
+
+	cliflag.AddPersistentStringFlag(replayValidationCmd, replayCaptureFlag, "", "Path to the capture file", true)
+	cliflag.AddPersistentIntFlag(replayValidationCmd, replayPectraForkEpochFlag, 0, "Epoch of the Pectra fork, which raises the maximum message size", false)
+	replayValidationCmd.PersistentFlags().Bool(replayJSONFlag, false, "Print the results as JSON, one per line")
+
+	ValidationCmd.AddCommand(replayValidationCmd)
+}
@@ -1,8 +1,11 @@
 package doppelganger
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/operator/slotticker"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
 )
 
 //go:generate mockgen -package=doppelganger -destination=./mock.go -source=./doppelganger.go
@@ -25,6 +29,13 @@ import (
 // a validator must pass without liveness detection before being considered safe to sign.
 const initialRemainingDetectionEpochs phase0.Epoch = 2
 
+var (
+	// ErrValidatorNotFound is returned when the validator isn't tracked by Doppelganger protection.
+	ErrValidatorNotFound = errors.New("validator not found in doppelganger state")
+	// ErrDisabled is returned by NoOpHandler for operations which require Doppelganger protection.
+	ErrDisabled = errors.New("doppelganger protection is disabled")
+)
+
 type Provider interface {
 	// Start begins the Doppelganger protection monitoring, periodically checking validator liveness.
 	// Returns an error if the process fails to start or encounters a critical issue.
@@ -41,6 +52,14 @@ type Provider interface {
 	// RemoveValidatorState removes a validator from Doppelganger tracking, clearing its protection status.
 	// Useful when a validator is no longer managed (validator removed or liquidated).
 	RemoveValidatorState(validatorIndex phase0.ValidatorIndex)
+
+	// ValidatorStates returns the state of all tracked validators, ordered by validator index.
+	ValidatorStates() []ValidatorState
+
+	// OverrideValidatorState manually marks a validator as safe to sign, or restarts its detection if safe is false.
+	// A validator marked as unsafe can become safe again through liveness checks or a post-consensus quorum.
+	// Returns ErrValidatorNotFound if the validator isn't tracked.
+	OverrideValidatorState(validatorIndex phase0.ValidatorIndex, safe bool) (ValidatorState, error)
 }
 
 // ValidatorProvider represents a provider of validator information.
@@ -60,6 +79,12 @@ type Options struct {
 	ValidatorProvider  ValidatorProvider
 	SlotTickerProvider slotticker.Provider
 	Logger             *zap.Logger
+
+	// DB persists the state of safe validators, so that it can be resumed after a restart.
+	DB basedb.Database
+	// ResumeWindow is the number of epochs since a validator was last safe within which its persisted state
+	// is resumed instead of starting over. Zero disables persistence.
+	ResumeWindow phase0.Epoch
 }
 
 // handler is the main struct for the Doppelgänger protection.
@@ -73,18 +98,32 @@ type handler struct {
 	validatorProvider  ValidatorProvider
 	slotTickerProvider slotticker.Provider
 	logger             *zap.Logger
+
+	// store is nil if persistence is disabled.
+	store        *store
+	resumeWindow phase0.Epoch
+	// persisted holds the states as of the last persistence, so that only changes are written.
+	// It's nil until the states are loaded or replaced, and only accessed by the monitoring loop.
+	persisted map[phase0.ValidatorIndex]persistedState
 }
 
 // NewHandler initializes a new instance of the Doppelgänger protection.
 func NewHandler(opts *Options) *handler {
-	return &handler{
+	h := &handler{
 		network:            opts.Network,
 		beaconNode:         opts.BeaconNode,
 		validatorProvider:  opts.ValidatorProvider,
 		slotTickerProvider: opts.SlotTickerProvider,
 		logger:             opts.Logger.Named(logging.NameDoppelganger),
 		validatorsState:    make(map[phase0.ValidatorIndex]*doppelgangerState),
+		resumeWindow:       opts.ResumeWindow,
 	}
+
+	if opts.DB != nil && opts.ResumeWindow > 0 {
+		h.store = newStore(opts.DB)
+	}
+
+	return h
 }
 
 // CanSign returns true if the validator is safe to sign, otherwise false.
@@ -112,8 +151,10 @@ func (h *handler) ReportQuorum(validatorIndex phase0.ValidatorIndex) {
 		return
 	}
 
-	if !state.safe() {
-		state.observedQuorum = true
+	// The quorum is recorded even if the validator is already safe, so that it's persisted.
+	wasSafe := state.safe()
+	state.observedQuorum = true
+	if !wasSafe {
 		h.logger.Info("Validator marked as safe due to observed quorum", fields.ValidatorIndex(validatorIndex))
 	}
 }
@@ -169,12 +210,57 @@ func (h *handler) RemoveValidatorState(validatorIndex phase0.ValidatorIndex) {
 	h.logger.Debug("Removed validator from Doppelganger state", fields.ValidatorIndex(validatorIndex))
 }
 
+// ValidatorStates returns the state of all tracked validators, ordered by validator index.
+func (h *handler) ValidatorStates() []ValidatorState {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	states := make([]ValidatorState, 0, len(h.validatorsState))
+	for validatorIndex, state := range h.validatorsState {
+		states = append(states, state.snapshot(validatorIndex))
+	}
+
+	slices.SortFunc(states, func(a, b ValidatorState) int {
+		return cmp.Compare(a.ValidatorIndex, b.ValidatorIndex)
+	})
+
+	return states
+}
+
+// OverrideValidatorState manually marks the validator as safe, or restarts its detection if safe is false.
+func (h *handler) OverrideValidatorState(validatorIndex phase0.ValidatorIndex, safe bool) (ValidatorState, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	state := h.validatorsState[validatorIndex]
+	if state == nil {
+		return ValidatorState{}, ErrValidatorNotFound
+	}
+
+	previous := state.snapshot(validatorIndex)
+	if safe {
+		state.forcedSafe = true
+	} else {
+		state.forcedSafe = false
+		state.observedQuorum = false
+		state.resetRemainingEpochs()
+	}
+
+	h.logger.Warn("Validator Doppelganger state overridden",
+		fields.ValidatorIndex(validatorIndex),
+		zap.Bool("previously_safe", previous.Safe),
+		zap.Bool("safe", safe),
+	)
+
+	return state.snapshot(validatorIndex), nil
+}
+
 // Start starts the Doppelganger monitoring.
 func (h *handler) Start(ctx context.Context) error {
 	h.logger.Info("Doppelganger monitoring started")
 
 	var startEpoch, previousEpoch phase0.Epoch
-	firstRun := true
+	firstRun, resumed := true, false
 	ticker := h.slotTickerProvider()
 	slotsPerEpoch := h.network.Beacon.SlotsPerEpoch()
 
@@ -191,6 +277,10 @@ func (h *handler) Start(ctx context.Context) error {
 
 			// Update DG state with self participating validators from validator provider at the current epoch
 			validatorIndices := indicesFromShares(h.validatorProvider.SelfParticipatingValidators(currentEpoch))
+			if !resumed {
+				h.resumeStates(ctx, currentSlot, currentEpoch, validatorIndices)
+				resumed = true
+			}
 			h.updateDoppelgangerState(currentEpoch, validatorIndices)
 
 			// Perform liveness checks during the first run or at the last slot of the epoch.
@@ -225,6 +315,8 @@ func (h *handler) Start(ctx context.Context) error {
 			// This ensures metrics reflect any changes from quorum reports, liveness updates, or state resets.
 			h.recordValidatorStates(ctx)
 
+			h.persistStates(currentEpoch)
+
 			// Update the previous epoch tracker to detect potential future skips.
 			previousEpoch = currentEpoch
 		}
@@ -315,6 +407,132 @@ func (h *handler) resetDoppelgangerStates() {
 	h.logger.Info("All Doppelganger states reset to initial detection epochs")
 }
 
+// resumeStates restores the persisted state of the validators which were safe within the resume window.
+// The node didn't observe the epochs since then, so all of them are checked for liveness in those epochs,
+// and start over if they were live or the check failed. This includes validators which had observed a quorum,
+// as the node can't tell whether their liveness came from their committee or from a doppelganger.
+func (h *handler) resumeStates(ctx context.Context, slot phase0.Slot, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) {
+	if h.store == nil {
+		return
+	}
+
+	persisted, lastEpoch, found, err := h.store.load()
+	if err != nil {
+		h.logger.Error("Failed to load persisted Doppelganger states, starting over", zap.Error(err))
+		return
+	}
+	h.persisted = persisted
+
+	if !found || lastEpoch >= epoch || epoch-lastEpoch > h.resumeWindow {
+		h.logger.Info("No persisted Doppelganger states to resume",
+			fields.Epoch(epoch),
+			zap.Uint64("last_persisted_epoch", uint64(lastEpoch)),
+		)
+		return
+	}
+
+	states := make(map[phase0.ValidatorIndex]*doppelgangerState)
+	for _, validatorIndex := range validatorIndices {
+		if state, ok := persisted[validatorIndex]; ok {
+			states[validatorIndex] = &doppelgangerState{observedQuorum: state.ObservedQuorum}
+		}
+	}
+
+	// Set a deadline until the start of the next slot, with a 100ms safety margin
+	ctx, cancel := context.WithDeadline(ctx, h.network.Beacon.GetSlotStartTime(slot+1).Add(100*time.Millisecond))
+	defer cancel()
+
+	for gapEpoch := lastEpoch + 1; gapEpoch < epoch && len(states) > 0; gapEpoch++ {
+		validatorsToCheck := make([]phase0.ValidatorIndex, 0, len(states))
+		for validatorIndex := range states {
+			validatorsToCheck = append(validatorsToCheck, validatorIndex)
+		}
+		slices.Sort(validatorsToCheck)
+
+		livenessData, err := h.beaconNode.ValidatorLiveness(ctx, gapEpoch, validatorsToCheck)
+		if err != nil {
+			h.logger.Error("Failed to obtain validator liveness data for missed epoch, starting over",
+				fields.Epoch(gapEpoch),
+				zap.Error(err),
+			)
+			clear(states)
+			break
+		}
+
+		for _, response := range livenessData {
+			if response.IsLive {
+				h.logger.Warn("Doppelganger detected live validator in missed epoch, starting over",
+					fields.ValidatorIndex(response.Index),
+					fields.Epoch(gapEpoch),
+				)
+				delete(states, response.Index)
+			}
+		}
+	}
+
+	h.mu.Lock()
+	for validatorIndex, state := range states {
+		h.validatorsState[validatorIndex] = state
+	}
+	h.mu.Unlock()
+
+	h.logger.Info("Resumed persisted Doppelganger states",
+		fields.Epoch(epoch),
+		zap.Uint64("last_persisted_epoch", uint64(lastEpoch)),
+		zap.Int("persisted", len(persisted)),
+		zap.Int("resumed", len(states)),
+	)
+}
+
+// persistStates records the epoch along with the states of safe validators which changed since the last time,
+// so that a validator's state is only written when it becomes safe, unsafe or observes a quorum.
+// Validators which are only safe due to an override aren't persisted, overrides don't survive restarts.
+func (h *handler) persistStates(epoch phase0.Epoch) {
+	if h.store == nil {
+		return
+	}
+
+	h.mu.RLock()
+	states := make(map[phase0.ValidatorIndex]persistedState, len(h.validatorsState))
+	for validatorIndex, state := range h.validatorsState {
+		if state.remainingEpochs == 0 || state.observedQuorum {
+			states[validatorIndex] = persistedState{ObservedQuorum: state.observedQuorum}
+		}
+	}
+	h.mu.RUnlock()
+
+	if h.persisted == nil {
+		// The persisted states are unknown, so they're all replaced.
+		if err := h.store.replace(epoch, states); err != nil {
+			h.logger.Error("Failed to persist Doppelganger states", zap.Error(err))
+			return
+		}
+		h.persisted = states
+		return
+	}
+
+	changed := make(map[phase0.ValidatorIndex]persistedState)
+	for validatorIndex, state := range states {
+		if previous, ok := h.persisted[validatorIndex]; !ok || previous != state {
+			changed[validatorIndex] = state
+		}
+	}
+	var removed []phase0.ValidatorIndex
+	for validatorIndex := range h.persisted {
+		if _, ok := states[validatorIndex]; !ok {
+			removed = append(removed, validatorIndex)
+		}
+	}
+
+	if err := h.store.update(epoch, changed, removed); err != nil {
+		h.logger.Error("Failed to persist Doppelganger states", zap.Error(err))
+		// The outcome is unknown, so the states are replaced next time.
+		h.persisted = nil
+		return
+	}
+	h.persisted = states
+}
+
 func (h *handler) recordValidatorStates(ctx context.Context) {
 	safe, unsafe := func() (safe, unsafe uint64) {
 		h.mu.RLock()
@@ -2,6 +2,7 @@ package doppelganger
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
 )
 
 func newTestDoppelgangerHandler(t *testing.T) *handler {
@@ -176,3 +179,132 @@ func TestDecreaseRemainingEpochs_ErrorOnZero(t *testing.T) {
 	require.Error(t, err, "Expected error when attempting to decrease remaining epochs at 0")
 	require.Equal(t, phase0.Epoch(0), state.remainingEpochs, "remainingEpochs should still be 0")
 }
+
+func TestPersistAndResumeStates(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	newHandler := func() *handler {
+		ctrl := gomock.NewController(t)
+		return NewHandler(&Options{
+			Network:           networkconfig.TestNetwork,
+			BeaconNode:        NewMockBeaconNode(ctrl),
+			ValidatorProvider: NewMockValidatorProvider(ctrl),
+			Logger:            logger,
+			DB:                db,
+			ResumeWindow:      3,
+		})
+	}
+
+	// Validator 1 is safe due to liveness checks, 2 due to quorum, 3 isn't safe and 4 is only safe due to an override.
+	dg := newHandler()
+	dg.validatorsState[1] = &doppelgangerState{remainingEpochs: 0}
+	dg.validatorsState[2] = &doppelgangerState{remainingEpochs: 2}
+	dg.validatorsState[3] = &doppelgangerState{remainingEpochs: 2}
+	dg.validatorsState[4] = &doppelgangerState{remainingEpochs: 2, forcedSafe: true}
+	dg.ReportQuorum(2)
+	dg.persistStates(9)
+	dg.persistStates(10)
+
+	t.Run("not live in missed epochs", func(t *testing.T) {
+		dg := newHandler()
+		dg.beaconNode.(*MockBeaconNode).EXPECT().ValidatorLiveness(gomock.Any(), phase0.Epoch(11), []phase0.ValidatorIndex{1, 2}).Return(
+			[]*v1.ValidatorLiveness{{Index: 1, IsLive: false}, {Index: 2, IsLive: false}}, nil,
+		)
+
+		dg.resumeStates(context.Background(), 0, 12, []phase0.ValidatorIndex{1, 2, 3, 4})
+		require.True(t, dg.CanSign(1))
+		require.True(t, dg.CanSign(2))
+		require.NotContains(t, dg.validatorsState, phase0.ValidatorIndex(3))
+		require.NotContains(t, dg.validatorsState, phase0.ValidatorIndex(4))
+	})
+
+	t.Run("live in missed epochs", func(t *testing.T) {
+		dg := newHandler()
+		dg.beaconNode.(*MockBeaconNode).EXPECT().ValidatorLiveness(gomock.Any(), phase0.Epoch(11), []phase0.ValidatorIndex{1, 2}).Return(
+			[]*v1.ValidatorLiveness{{Index: 1, IsLive: false}, {Index: 2, IsLive: true}}, nil,
+		)
+		dg.beaconNode.(*MockBeaconNode).EXPECT().ValidatorLiveness(gomock.Any(), phase0.Epoch(12), []phase0.ValidatorIndex{1}).Return(
+			[]*v1.ValidatorLiveness{{Index: 1, IsLive: true}}, nil,
+		)
+
+		dg.resumeStates(context.Background(), 0, 13, []phase0.ValidatorIndex{1, 2, 3})
+		require.Empty(t, dg.validatorsState)
+	})
+
+	t.Run("liveness check fails", func(t *testing.T) {
+		dg := newHandler()
+		dg.beaconNode.(*MockBeaconNode).EXPECT().ValidatorLiveness(gomock.Any(), phase0.Epoch(11), []phase0.ValidatorIndex{1, 2}).Return(
+			nil, errors.New("epoch not available"),
+		)
+
+		dg.resumeStates(context.Background(), 0, 12, []phase0.ValidatorIndex{1, 2, 3})
+		require.Empty(t, dg.validatorsState)
+	})
+
+	t.Run("outside window", func(t *testing.T) {
+		dg := newHandler()
+
+		dg.resumeStates(context.Background(), 0, 14, []phase0.ValidatorIndex{1, 2, 3})
+		require.Empty(t, dg.validatorsState)
+	})
+
+	t.Run("only changes are written", func(t *testing.T) {
+		dg := newHandler()
+		dg.resumeStates(context.Background(), 0, 14, nil)
+		require.Len(t, dg.persisted, 2)
+
+		// Validator 1 is no longer safe, 3 became safe and 2 is unchanged.
+		dg.validatorsState[2] = &doppelgangerState{remainingEpochs: 2, observedQuorum: true}
+		dg.validatorsState[3] = &doppelgangerState{remainingEpochs: 0}
+		require.NoError(t, db.Set(storePrefix, stateKey(2), []byte(`{"observed_quorum":false}`)))
+		dg.persistStates(14)
+
+		persisted, lastEpoch, found, err := dg.store.load()
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, phase0.Epoch(14), lastEpoch)
+		require.Equal(t, map[phase0.ValidatorIndex]persistedState{
+			// Validator 2 wasn't rewritten, so the value set above is kept.
+			2: {ObservedQuorum: false},
+			3: {ObservedQuorum: false},
+		}, persisted)
+	})
+}
+
+func TestValidatorStatesAndOverride(t *testing.T) {
+	dg := newTestDoppelgangerHandler(t)
+
+	dg.validatorsState[2] = &doppelgangerState{remainingEpochs: 2}
+	dg.validatorsState[1] = &doppelgangerState{remainingEpochs: 0}
+	dg.validatorsState[3] = &doppelgangerState{remainingEpochs: 2, observedQuorum: true}
+
+	require.Equal(t, []ValidatorState{
+		{ValidatorIndex: 1, Safe: true, RemainingEpochs: 0, SafeReason: SafeReasonLiveness},
+		{ValidatorIndex: 2, Safe: false, RemainingEpochs: 2, SafeReason: SafeReasonNone},
+		{ValidatorIndex: 3, Safe: true, RemainingEpochs: 2, SafeReason: SafeReasonQuorum},
+	}, dg.ValidatorStates())
+
+	_, err := dg.OverrideValidatorState(4, true)
+	require.ErrorIs(t, err, ErrValidatorNotFound)
+
+	state, err := dg.OverrideValidatorState(2, true)
+	require.NoError(t, err)
+	require.Equal(t, ValidatorState{ValidatorIndex: 2, Safe: true, RemainingEpochs: 2, SafeReason: SafeReasonOverride}, state)
+	require.True(t, dg.CanSign(2))
+
+	// The override survives an epoch skip reset.
+	dg.resetDoppelgangerStates()
+	require.True(t, dg.CanSign(2))
+
+	state, err = dg.OverrideValidatorState(3, false)
+	require.NoError(t, err)
+	require.Equal(t, ValidatorState{ValidatorIndex: 3, RemainingEpochs: initialRemainingDetectionEpochs}, state)
+	require.False(t, dg.CanSign(3))
+
+	// Detection is re-armed, so a quorum marks the validator safe again.
+	dg.ReportQuorum(3)
+	require.True(t, dg.CanSign(3))
+}
@@ -56,6 +56,21 @@ func (mr *MockProviderMockRecorder) CanSign(validatorIndex any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanSign", reflect.TypeOf((*MockProvider)(nil).CanSign), validatorIndex)
 }
 
+// OverrideValidatorState mocks base method.
+func (m *MockProvider) OverrideValidatorState(validatorIndex phase0.ValidatorIndex, safe bool) (ValidatorState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OverrideValidatorState", validatorIndex, safe)
+	ret0, _ := ret[0].(ValidatorState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OverrideValidatorState indicates an expected call of OverrideValidatorState.
+func (mr *MockProviderMockRecorder) OverrideValidatorState(validatorIndex, safe any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OverrideValidatorState", reflect.TypeOf((*MockProvider)(nil).OverrideValidatorState), validatorIndex, safe)
+}
+
 // RemoveValidatorState mocks base method.
 func (m *MockProvider) RemoveValidatorState(validatorIndex phase0.ValidatorIndex) {
 	m.ctrl.T.Helper()
@@ -94,6 +109,20 @@ func (mr *MockProviderMockRecorder) Start(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockProvider)(nil).Start), ctx)
 }
 
+// ValidatorStates mocks base method.
+func (m *MockProvider) ValidatorStates() []ValidatorState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatorStates")
+	ret0, _ := ret[0].([]ValidatorState)
+	return ret0
+}
+
+// ValidatorStates indicates an expected call of ValidatorStates.
+func (mr *MockProviderMockRecorder) ValidatorStates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorStates", reflect.TypeOf((*MockProvider)(nil).ValidatorStates))
+}
+
 // MockValidatorProvider is a mock of ValidatorProvider interface.
 type MockValidatorProvider struct {
 	ctrl     *gomock.Controller
@@ -23,3 +23,11 @@ func (NoOpHandler) ReportQuorum(validatorIndex phase0.ValidatorIndex) {
 func (NoOpHandler) RemoveValidatorState(validatorIndex phase0.ValidatorIndex) {
 	// No operation
 }
+
+func (NoOpHandler) ValidatorStates() []ValidatorState {
+	return nil
+}
+
+func (NoOpHandler) OverrideValidatorState(validatorIndex phase0.ValidatorIndex, safe bool) (ValidatorState, error) {
+	return ValidatorState{}, ErrDisabled
+}
@@ -6,15 +6,57 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
+// SafeReason is the reason a validator is considered safe to sign.
+type SafeReason string
+
+const (
+	SafeReasonNone     SafeReason = ""
+	SafeReasonLiveness SafeReason = "liveness"
+	SafeReasonQuorum   SafeReason = "quorum"
+	SafeReasonOverride SafeReason = "override"
+)
+
+// ValidatorState is a snapshot of a validator's state in Doppelganger Protection.
+type ValidatorState struct {
+	ValidatorIndex  phase0.ValidatorIndex
+	Safe            bool
+	RemainingEpochs phase0.Epoch
+	SafeReason      SafeReason
+}
+
 // doppelgangerState tracks the validator's state in Doppelganger Protection.
 type doppelgangerState struct {
 	remainingEpochs phase0.Epoch // The number of epochs that must be not live before it's considered safe.
 	observedQuorum  bool         // Whether the validator has observed a quorum of SSV operators.
+	forcedSafe      bool         // Whether the validator was manually marked as safe.
 }
 
 // safe returns true if the validator is safe to sign.
 func (ds *doppelgangerState) safe() bool {
-	return ds.remainingEpochs == 0 || ds.observedQuorum
+	return ds.remainingEpochs == 0 || ds.observedQuorum || ds.forcedSafe
+}
+
+// safeReason returns why the validator is safe to sign, or SafeReasonNone if it isn't.
+func (ds *doppelgangerState) safeReason() SafeReason {
+	switch {
+	case ds.forcedSafe:
+		return SafeReasonOverride
+	case ds.observedQuorum:
+		return SafeReasonQuorum
+	case ds.remainingEpochs == 0:
+		return SafeReasonLiveness
+	default:
+		return SafeReasonNone
+	}
+}
+
+func (ds *doppelgangerState) snapshot(validatorIndex phase0.ValidatorIndex) ValidatorState {
+	return ValidatorState{
+		ValidatorIndex:  validatorIndex,
+		Safe:            ds.safe(),
+		RemainingEpochs: ds.remainingEpochs,
+		SafeReason:      ds.safeReason(),
+	}
 }
 
 // decreaseRemainingEpochs decreases remaining epochs.
@@ -0,0 +1,144 @@
+package doppelganger
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var (
+	storePrefix = []byte("doppelganger/")
+	// lastEpochKey is stored under its own prefix, so it isn't mistaken for a validator's state.
+	lastEpochPrefix = []byte("doppelganger_epoch/")
+	lastEpochKey    = []byte("last")
+)
+
+// persistedState is the part of a validator's Doppelganger state which survives restarts.
+// Only safe validators are persisted, they were safe as of the last persisted epoch.
+type persistedState struct {
+	// ObservedQuorum is whether the validator had observed a quorum of SSV operators.
+	ObservedQuorum bool `json:"observed_quorum"`
+}
+
+// store persists the Doppelganger state of safe validators keyed by validator index,
+// along with the last epoch the node ran with them.
+type store struct {
+	db basedb.Database
+}
+
+func newStore(db basedb.Database) *store {
+	return &store{db: db}
+}
+
+// load returns all persisted states and the last epoch they were persisted at.
+// found is false if nothing was persisted yet.
+func (s *store) load() (states map[phase0.ValidatorIndex]persistedState, lastEpoch phase0.Epoch, found bool, err error) {
+	obj, found, err := s.db.Get(lastEpochPrefix, lastEpochKey)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("get last epoch: %w", err)
+	}
+	if !found {
+		return map[phase0.ValidatorIndex]persistedState{}, 0, false, nil
+	}
+	if len(obj.Value) != 8 {
+		return nil, 0, false, fmt.Errorf("invalid last epoch length %d", len(obj.Value))
+	}
+	lastEpoch = phase0.Epoch(binary.BigEndian.Uint64(obj.Value))
+
+	states = make(map[phase0.ValidatorIndex]persistedState)
+	err = s.db.GetRange(storePrefix, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+		if len(obj.Key) != 8 {
+			return fmt.Errorf("invalid key length %d", len(obj.Key))
+		}
+
+		var state persistedState
+		if err := json.Unmarshal(obj.Value, &state); err != nil {
+			return fmt.Errorf("unmarshal state: %w", err)
+		}
+
+		states[phase0.ValidatorIndex(binary.BigEndian.Uint64(obj.Key))] = state
+		return nil
+	})
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	return states, lastEpoch, true, nil
+}
+
+// update records the epoch and applies the changes since the previous update.
+func (s *store) update(epoch phase0.Epoch, changed map[phase0.ValidatorIndex]persistedState, removed []phase0.ValidatorIndex) error {
+	return s.db.Update(func(txn basedb.Txn) error {
+		for _, validatorIndex := range removed {
+			if err := txn.Delete(storePrefix, stateKey(validatorIndex)); err != nil {
+				return fmt.Errorf("delete state: %w", err)
+			}
+		}
+
+		if err := setStates(txn, changed); err != nil {
+			return err
+		}
+
+		return setLastEpoch(txn, epoch)
+	})
+}
+
+// replace records the epoch and replaces all the persisted states with the given ones.
+func (s *store) replace(epoch phase0.Epoch, states map[phase0.ValidatorIndex]persistedState) error {
+	return s.db.Update(func(txn basedb.Txn) error {
+		var stale [][]byte
+		err := txn.GetRange(storePrefix, basedb.RangeOptions{KeysOnly: true}, func(obj basedb.Obj) error {
+			if len(obj.Key) != 8 {
+				stale = append(stale, obj.Key)
+				return nil
+			}
+			if _, ok := states[phase0.ValidatorIndex(binary.BigEndian.Uint64(obj.Key))]; !ok {
+				stale = append(stale, obj.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("get persisted states: %w", err)
+		}
+
+		for _, key := range stale {
+			if err := txn.Delete(storePrefix, key); err != nil {
+				return fmt.Errorf("delete state: %w", err)
+			}
+		}
+
+		if err := setStates(txn, states); err != nil {
+			return err
+		}
+
+		return setLastEpoch(txn, epoch)
+	})
+}
+
+func setStates(txn basedb.Txn, states map[phase0.ValidatorIndex]persistedState) error {
+	for validatorIndex, state := range states {
+		value, err := json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("marshal state: %w", err)
+		}
+		if err := txn.Set(storePrefix, stateKey(validatorIndex), value); err != nil {
+			return fmt.Errorf("set state: %w", err)
+		}
+	}
+	return nil
+}
+
+func setLastEpoch(txn basedb.Txn, epoch phase0.Epoch) error {
+	if err := txn.Set(lastEpochPrefix, lastEpochKey, binary.BigEndian.AppendUint64(nil, uint64(epoch))); err != nil {
+		return fmt.Errorf("set last epoch: %w", err)
+	}
+	return nil
+}
+
+func stateKey(validatorIndex phase0.ValidatorIndex) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(validatorIndex))
+}
@@ -71,8 +71,14 @@ type EventHandler struct {
 	keyManager          ekm.KeyManager
 	doppelgangerHandler DoppelgangerProvider
 
-	fullNode bool
-	logger   *zap.Logger
+	fullNode     bool
+	journalDepth uint64
+	logger       *zap.Logger
+
+	// journal records the registry state mutated by the block being processed.
+	journal *blockJournal
+	// provisional is true while processing the events of a block which isn't final yet.
+	provisional bool
 }
 
 func New(
@@ -95,6 +101,7 @@ func New(
 		operatorDecrypter:   operatorDecrypter,
 		keyManager:          keyManager,
 		doppelgangerHandler: doppelgangerHandler,
+		journalDepth:        DefaultJournalDepth,
 		logger:              zap.NewNop(),
 	}
 
@@ -167,8 +174,32 @@ func (eh *EventHandler) processBlockEvents(ctx context.Context, block executionc
 		return nil, ErrInferiorBlock
 	}
 
+	finalBlock := block.BlockNumber
+	if block.Provisional {
+		finalBlock = block.LastFinalBlock
+	}
+	if err := eh.finalizeKeyRemovals(ctx, txn, finalBlock); err != nil {
+		return nil, fmt.Errorf("finalize share key removals: %w", err)
+	}
+
+	eh.provisional = block.Provisional
+	defer func() { eh.provisional = false }()
+
+	eh.journal = newBlockJournal(block.BlockNumber, block.BlockHash)
+	defer func() { eh.journal = nil }()
+
+	hashChain, _, err := eh.nodeStorage.GetEventHashChain(txn)
+	if err != nil {
+		return nil, fmt.Errorf("get event hash chain: %w", err)
+	}
+	if len(block.Logs) > 0 {
+		eh.journalHashChain(hashChain)
+	}
+
 	var tasks []Task
 	for _, log := range block.Logs {
+		hashChain = hashChain.Next(log)
+
 		task, err := eh.processEvent(ctx, txn, log)
 		if err != nil {
 			return nil, err
@@ -178,6 +209,16 @@ func (eh *EventHandler) processBlockEvents(ctx context.Context, block executionc
 		}
 	}
 
+	if len(block.Logs) > 0 {
+		if err := eh.nodeStorage.SaveEventHashChain(txn, hashChain); err != nil {
+			return nil, fmt.Errorf("save event hash chain: %w", err)
+		}
+	}
+
+	if err := eh.saveJournal(txn, lastProcessedBlock.Uint64()); err != nil {
+		return nil, fmt.Errorf("save journal: %w", err)
+	}
+
 	if err := eh.nodeStorage.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(block.BlockNumber)); err != nil {
 		return nil, fmt.Errorf("set last processed block: %w", err)
 	}
@@ -780,6 +780,8 @@ func TestHandleBlockEventsStream(t *testing.T) {
 			block := <-logs
 			require.NotEmpty(t, block.Logs)
 			require.Equal(t, ethcommon.HexToHash("0xccf4370403e5fbbde0cd3f13426479dcd8a5916b05db424b7a2c04978cf8ce6e"), block.Logs[0].Topics[0])
+			block.Provisional = true
+			block.LastFinalBlock = blockNum
 
 			eventsCh := make(chan executionclient.BlockLogs)
 			go func() {
@@ -798,6 +800,12 @@ func TestHandleBlockEventsStream(t *testing.T) {
 			valShare, exists = eh.nodeStorage.Shares().Get(nil, valPubKey)
 			require.False(t, exists)
 			require.Nil(t, valShare)
+
+			// The share's key is only removed once its block is final.
+			requireKeyManagerDataToExist(t, eh, 4, validatorData1)
+			txn := eh.nodeStorage.Begin()
+			require.NoError(t, eh.finalizeKeyRemovals(ctx, txn, blockNum))
+			require.NoError(t, txn.Commit())
 			requireKeyManagerDataToNotExist(t, eh, 3, validatorData1)
 		})
 	})
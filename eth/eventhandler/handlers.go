@@ -80,6 +80,9 @@ func (eh *EventHandler) handleOperatorAdded(txn basedb.Txn, event *contract.Cont
 		return &MalformedEventError{Err: ErrOperatorPubkeyAlreadyExists}
 	}
 
+	if err := eh.journalOperator(txn, event.OperatorId); err != nil {
+		return err
+	}
 	exists, err := eh.nodeStorage.SaveOperatorData(txn, od)
 	if err != nil {
 		return fmt.Errorf("save operator data: %w", err)
@@ -121,6 +124,10 @@ func (eh *EventHandler) handleOperatorRemoved(txn basedb.Txn, event *contract.Co
 		fields.Owner(od.OwnerAddress),
 	)
 
+	if err := eh.journalOperator(txn, event.OperatorId); err != nil {
+		return err
+	}
+
 	// Permanently remove operator data to prevent further message validation.
 	if err := eh.nodeStorage.DeleteOperatorData(txn, event.OperatorId); err != nil {
 		return fmt.Errorf("could not delete operator data: %w", err)
@@ -151,6 +158,9 @@ func (eh *EventHandler) handleValidatorAdded(
 
 	// Bump nonce. This transaction would be reverted later if the handling fails,
 	// unless the failure is due to a malformed event.
+	if err := eh.journalRecipient(txn, event.Owner); err != nil {
+		return nil, err
+	}
 	if err := eh.nodeStorage.BumpNonce(txn, event.Owner); err != nil {
 		return nil, err
 	}
@@ -263,6 +273,10 @@ func (eh *EventHandler) handleShareCreation(
 		share.SetMinParticipationEpoch(eh.networkConfig.Beacon.EstimatedCurrentEpoch() + contractParticipationDelay)
 	}
 
+	if err := eh.journalShare(share.ValidatorPubKey[:], nil); err != nil {
+		return nil, err
+	}
+
 	// Save share to DB.
 	if err := eh.nodeStorage.Shares().Save(txn, share); err != nil {
 		return nil, fmt.Errorf("could not save validator share: %w", err)
@@ -361,6 +375,9 @@ func (eh *EventHandler) handleValidatorRemoved(ctx context.Context, txn basedb.T
 		return emptyPK, &MalformedEventError{Err: ErrShareBelongsToDifferentOwner}
 	}
 
+	if err := eh.journalShare(share.ValidatorPubKey[:], share); err != nil {
+		return emptyPK, err
+	}
 	if err := eh.nodeStorage.Shares().Delete(txn, share.ValidatorPubKey[:]); err != nil {
 		return emptyPK, fmt.Errorf("could not remove validator share: %w", err)
 	}
@@ -370,8 +387,12 @@ func (eh *EventHandler) handleValidatorRemoved(ctx context.Context, txn basedb.T
 		logger = logger.With(zap.String("validator_pubkey", hex.EncodeToString(share.ValidatorPubKey[:])))
 	}
 	if isOperatorShare {
-		err := eh.keyManager.RemoveShare(ctx, txn, phase0.BLSPubKey(share.SharePubKey))
-		if err != nil {
+		if eh.provisional {
+			// The key is only removed once the block is final, so that reverting the removal can restore the validator.
+			if err := eh.deferKeyRemoval(txn, event.Raw.BlockNumber, share.SharePubKey, share.ValidatorPubKey[:]); err != nil {
+				return emptyPK, err
+			}
+		} else if err := eh.keyManager.RemoveShare(ctx, txn, phase0.BLSPubKey(share.SharePubKey)); err != nil {
 			return emptyPK, fmt.Errorf("could not remove share from ekm storage: %w", err)
 		}
 
@@ -476,6 +497,10 @@ func (eh *EventHandler) handleFeeRecipientAddressUpdated(txn basedb.Txn, event *
 
 	copy(recipientData.FeeRecipient[:], event.RecipientAddress.Bytes())
 
+	if err := eh.journalRecipient(txn, event.Owner); err != nil {
+		return false, err
+	}
+
 	r, err := eh.nodeStorage.SaveRecipientData(txn, recipientData)
 	if err != nil {
 		return false, fmt.Errorf("could not save recipient data: %w", err)
@@ -556,6 +581,9 @@ func (eh *EventHandler) processClusterEvent(
 	var operatorValidatorPubKeys []string
 
 	for _, share := range shares {
+		if err := eh.journalShare(share.ValidatorPubKey[:], share); err != nil {
+			return nil, nil, err
+		}
 		share.Liquidated = toLiquidate
 		toUpdate = append(toUpdate, share)
 
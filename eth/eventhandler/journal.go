@@ -0,0 +1,497 @@
+package eventhandler
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	nodestorage "github.com/ssvlabs/ssv/operator/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// DefaultJournalDepth is the default amount of recent blocks whose registry mutations are journaled.
+const DefaultJournalDepth = 512
+
+var (
+	journalPrefix     = []byte("events_journal/")
+	journalMetaPrefix = []byte("events_journal_meta/")
+	journalHorizonKey = []byte("horizon")
+)
+
+// ErrRevertBeyondJournal is returned when reverting to a block older than the journal goes back to.
+var ErrRevertBeyondJournal = errors.New("block is older than the journal")
+
+// blockJournal holds the state of the registry entries (operators, shares including their cluster's
+// liquidation, and recipients including their nonce) a block's events mutated, as it was before the block.
+// A nil value means the entry didn't exist before the block.
+type blockJournal struct {
+	BlockNumber uint64                                                 `json:"block_number"`
+	Operators   map[spectypes.OperatorID]*registrystorage.OperatorData `json:"operators,omitempty"`
+	Shares      map[string][]byte                                      `json:"shares,omitempty"`
+	Recipients  map[ethcommon.Address]*registrystorage.RecipientData   `json:"recipients,omitempty"`
+	// HashChain is the event hash chain before the block if the block had events,
+	// being a zero value if the chain didn't exist yet.
+	HashChain *nodestorage.EventHashChain `json:"hash_chain,omitempty"`
+	// BlockHash is the hash of the block, if known, to detect whether it was reorged out.
+	BlockHash ethcommon.Hash `json:"block_hash"`
+}
+
+func newBlockJournal(blockNumber uint64, blockHash ethcommon.Hash) *blockJournal {
+	return &blockJournal{
+		BlockNumber: blockNumber,
+		BlockHash:   blockHash,
+		Operators:   make(map[spectypes.OperatorID]*registrystorage.OperatorData),
+		Shares:      make(map[string][]byte),
+		Recipients:  make(map[ethcommon.Address]*registrystorage.RecipientData),
+	}
+}
+
+func (j *blockJournal) empty() bool {
+	return len(j.Operators) == 0 && len(j.Shares) == 0 && len(j.Recipients) == 0 && j.HashChain == nil
+}
+
+func journalKey(blockNumber uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, blockNumber)
+}
+
+// journalOperator records the operator's state before it's mutated by the current block.
+func (eh *EventHandler) journalOperator(txn basedb.Txn, id spectypes.OperatorID) error {
+	if eh.journal == nil {
+		return nil
+	}
+	if _, ok := eh.journal.Operators[id]; ok {
+		return nil
+	}
+	od, found, err := eh.nodeStorage.GetOperatorData(txn, id)
+	if err != nil {
+		return fmt.Errorf("could not get operator data: %w", err)
+	}
+	if !found {
+		od = nil
+	}
+	eh.journal.Operators[id] = od
+	return nil
+}
+
+// journalShare records the share's state before it's mutated by the current block,
+// share being nil if it doesn't exist yet.
+func (eh *EventHandler) journalShare(pubKey []byte, share *ssvtypes.SSVShare) error {
+	if eh.journal == nil {
+		return nil
+	}
+	key := hex.EncodeToString(pubKey)
+	if _, ok := eh.journal.Shares[key]; ok {
+		return nil
+	}
+	if share == nil {
+		eh.journal.Shares[key] = nil
+		return nil
+	}
+	encoded, err := registrystorage.FromSSVShare(share).Encode()
+	if err != nil {
+		return fmt.Errorf("could not encode share: %w", err)
+	}
+	eh.journal.Shares[key] = encoded
+	return nil
+}
+
+// journalRecipient records the owner's recipient data before it's mutated by the current block.
+func (eh *EventHandler) journalRecipient(txn basedb.Txn, owner ethcommon.Address) error {
+	if eh.journal == nil {
+		return nil
+	}
+	if _, ok := eh.journal.Recipients[owner]; ok {
+		return nil
+	}
+	rd, found, err := eh.nodeStorage.GetRecipientData(txn, owner)
+	if err != nil {
+		return fmt.Errorf("could not get recipient data: %w", err)
+	}
+	if !found {
+		rd = nil
+	}
+	eh.journal.Recipients[owner] = rd
+	return nil
+}
+
+// journalHashChain records the event hash chain before it's extended by the current block.
+func (eh *EventHandler) journalHashChain(chain nodestorage.EventHashChain) {
+	if eh.journal == nil || eh.journal.HashChain != nil {
+		return
+	}
+	eh.journal.HashChain = &chain
+}
+
+// saveJournal persists the journal of the current block, if it mutated anything or its hash is known,
+// and prunes the journals which fell out of the journal depth.
+func (eh *EventHandler) saveJournal(txn basedb.Txn, lastProcessedBlock uint64) error {
+	horizon, found, err := journalHorizon(txn)
+	if err != nil {
+		return err
+	}
+	updateHorizon := !found
+	if !found {
+		// Blocks processed before journaling started can't be reverted.
+		horizon = lastProcessedBlock
+	}
+	if eh.journal.BlockNumber > eh.journalDepth && eh.journal.BlockNumber-eh.journalDepth > horizon {
+		horizon = eh.journal.BlockNumber - eh.journalDepth
+		updateHorizon = true
+
+		var expired [][]byte
+		err := txn.GetRange(journalPrefix, basedb.RangeOptions{End: journalKey(horizon + 1), KeysOnly: true}, func(obj basedb.Obj) error {
+			expired = append(expired, obj.Key)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not list expired journals: %w", err)
+		}
+		for _, key := range expired {
+			if err := txn.Delete(journalPrefix, key); err != nil {
+				return fmt.Errorf("could not delete expired journal: %w", err)
+			}
+		}
+	}
+	if updateHorizon {
+		if err := txn.Set(journalMetaPrefix, journalHorizonKey, journalKey(horizon)); err != nil {
+			return fmt.Errorf("could not save journal horizon: %w", err)
+		}
+	}
+
+	if eh.journal.empty() && eh.journal.BlockHash == (ethcommon.Hash{}) {
+		return nil
+	}
+	raw, err := json.Marshal(eh.journal)
+	if err != nil {
+		return fmt.Errorf("could not encode journal: %w", err)
+	}
+	if err := txn.Set(journalPrefix, journalKey(eh.journal.BlockNumber), raw); err != nil {
+		return fmt.Errorf("could not save journal: %w", err)
+	}
+	return nil
+}
+
+// journalHorizon returns the oldest block the registry state can be reverted to.
+func journalHorizon(r basedb.Reader) (uint64, bool, error) {
+	obj, found, err := r.Get(journalMetaPrefix, journalHorizonKey)
+	if err != nil {
+		return 0, false, fmt.Errorf("could not get journal horizon: %w", err)
+	}
+	if !found || len(obj.Value) != 8 {
+		return 0, false, nil
+	}
+	return binary.BigEndian.Uint64(obj.Value), true, nil
+}
+
+// RevertToBlock reverts the registry state to how it was right after the given block was processed,
+// undoing the events of all the blocks processed after it, and executes the tasks reverting their effects.
+// The block must not be older than the journal depth.
+func (eh *EventHandler) RevertToBlock(ctx context.Context, block uint64) error {
+	txn := eh.nodeStorage.Begin()
+	defer txn.Discard()
+
+	r, err := revertRegistry(txn, eh.nodeStorage, block)
+	if err != nil || r == nil {
+		return err
+	}
+
+	tasks, err := eh.revertTasks(ctx, txn, r)
+	if err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	eh.logger.Info("reverted registry events",
+		fields.FromBlock(block+1),
+		fields.ToBlock(r.lastProcessedBlock),
+		zap.Int("mutated_blocks", r.mutatedBlocks))
+
+	for _, task := range tasks {
+		if err := task.Execute(); err != nil {
+			eh.logger.Error("failed to execute revert task", fields.Type(task), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Rewind reverts the registry state to how it was right after the given block was processed,
+// like RevertToBlock, but without applying the reverted state to validators or the key manager.
+// It's meant for a stopped node, which loads its validators from the reverted state on start.
+// It returns the last processed block before the rewind.
+func Rewind(nodeStorage nodestorage.Storage, block uint64) (uint64, error) {
+	txn := nodeStorage.Begin()
+	defer txn.Discard()
+
+	r, err := revertRegistry(txn, nodeStorage, block)
+	if err != nil {
+		return 0, err
+	}
+	if r == nil {
+		return block, nil
+	}
+	if err := txn.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return r.lastProcessedBlock, nil
+}
+
+// ProcessedBlockHashes returns the hashes of the journaled blocks by block number,
+// which are the revertible blocks that had contract events or were processed provisionally.
+func (eh *EventHandler) ProcessedBlockHashes() (map[uint64]ethcommon.Hash, error) {
+	txn := eh.nodeStorage.BeginRead()
+	defer txn.Discard()
+
+	hashes := make(map[uint64]ethcommon.Hash)
+	err := txn.GetRange(journalPrefix, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+		var j blockJournal
+		if err := json.Unmarshal(obj.Value, &j); err != nil {
+			return fmt.Errorf("could not decode journal: %w", err)
+		}
+		if j.BlockHash != (ethcommon.Hash{}) {
+			hashes[j.BlockNumber] = j.BlockHash
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not load journals: %w", err)
+	}
+	return hashes, nil
+}
+
+// revertRegistry reverts the registry state within txn to how it was right after the given block was processed,
+// returning nil if no block after it was processed.
+func revertRegistry(txn basedb.Txn, nodeStorage nodestorage.Storage, block uint64) (*journalReverter, error) {
+	lastProcessedBlock, found, err := nodeStorage.GetLastProcessedBlock(txn)
+	if err != nil {
+		return nil, fmt.Errorf("get last processed block: %w", err)
+	}
+	if !found || lastProcessedBlock == nil || lastProcessedBlock.Uint64() <= block {
+		return nil, nil
+	}
+	horizon, found, err := journalHorizon(txn)
+	if err != nil {
+		return nil, err
+	}
+	if !found || block < horizon {
+		return nil, fmt.Errorf("%w: can't revert to block %d, oldest revertible block is %d", ErrRevertBeyondJournal, block, horizon)
+	}
+
+	var journals []*blockJournal
+	err = txn.GetRange(journalPrefix, basedb.RangeOptions{Start: journalKey(block + 1), Reverse: true}, func(obj basedb.Obj) error {
+		var j blockJournal
+		if err := json.Unmarshal(obj.Value, &j); err != nil {
+			return fmt.Errorf("could not decode journal: %w", err)
+		}
+		journals = append(journals, &j)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not load journals: %w", err)
+	}
+
+	// Newest journals come first, so that each entry ends up restored to its oldest recorded state.
+	r := newJournalReverter()
+	r.lastProcessedBlock = lastProcessedBlock.Uint64()
+	for _, j := range journals {
+		if err := revertJournal(txn, nodeStorage, j, r); err != nil {
+			return nil, fmt.Errorf("revert block %d: %w", j.BlockNumber, err)
+		}
+		if err := txn.Delete(journalPrefix, journalKey(j.BlockNumber)); err != nil {
+			return nil, fmt.Errorf("could not delete journal: %w", err)
+		}
+		if !j.empty() {
+			r.mutatedBlocks++
+		}
+	}
+
+	// The keys of the shares removed by the reverted blocks are kept, as their removal was deferred.
+	var deferred []basedb.Obj
+	err = txn.GetRange(deferredKeyRemovalsPrefix, basedb.RangeOptions{Start: journalKey(block + 1)}, func(obj basedb.Obj) error {
+		deferred = append(deferred, obj)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list deferred share key removals: %w", err)
+	}
+	for _, obj := range deferred {
+		r.keptKeys[hex.EncodeToString(obj.Value)] = struct{}{}
+		if err := txn.Delete(deferredKeyRemovalsPrefix, obj.Key); err != nil {
+			return nil, fmt.Errorf("could not delete deferred share key removal: %w", err)
+		}
+	}
+
+	if err := nodeStorage.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(block)); err != nil {
+		return nil, fmt.Errorf("set last processed block: %w", err)
+	}
+	return r, nil
+}
+
+// journalReverter tracks the state of the shares and recipients touched by a revert,
+// both before the revert started and after it.
+type journalReverter struct {
+	lastProcessedBlock uint64
+	mutatedBlocks      int
+
+	sharesBefore     map[string]*ssvtypes.SSVShare
+	sharesAfter      map[string]*ssvtypes.SSVShare
+	recipientsBefore map[ethcommon.Address]*registrystorage.RecipientData
+	recipientsAfter  map[ethcommon.Address]*registrystorage.RecipientData
+	// keptKeys holds the validators whose share key removal was deferred by a reverted block.
+	keptKeys map[string]struct{}
+}
+
+func newJournalReverter() *journalReverter {
+	return &journalReverter{
+		sharesBefore:     make(map[string]*ssvtypes.SSVShare),
+		sharesAfter:      make(map[string]*ssvtypes.SSVShare),
+		recipientsBefore: make(map[ethcommon.Address]*registrystorage.RecipientData),
+		recipientsAfter:  make(map[ethcommon.Address]*registrystorage.RecipientData),
+		keptKeys:         make(map[string]struct{}),
+	}
+}
+
+func revertJournal(txn basedb.Txn, nodeStorage nodestorage.Storage, j *blockJournal, r *journalReverter) error {
+	for id, od := range j.Operators {
+		if err := nodeStorage.DeleteOperatorData(txn, id); err != nil {
+			return fmt.Errorf("could not delete operator data: %w", err)
+		}
+		if od == nil {
+			continue
+		}
+		if _, err := nodeStorage.SaveOperatorData(txn, od); err != nil {
+			return fmt.Errorf("could not save operator data: %w", err)
+		}
+	}
+
+	for key, encoded := range j.Shares {
+		pubKey, err := hex.DecodeString(key)
+		if err != nil {
+			return fmt.Errorf("could not decode share public key: %w", err)
+		}
+		if _, ok := r.sharesBefore[key]; !ok {
+			current, _ := nodeStorage.Shares().Get(txn, pubKey)
+			r.sharesBefore[key] = current
+		}
+		if encoded == nil {
+			if err := nodeStorage.Shares().Delete(txn, pubKey); err != nil {
+				return fmt.Errorf("could not delete share: %w", err)
+			}
+			r.sharesAfter[key] = nil
+			continue
+		}
+		stored := &registrystorage.Share{}
+		if err := stored.Decode(encoded); err != nil {
+			return fmt.Errorf("could not decode share: %w", err)
+		}
+		share, err := registrystorage.ToSSVShare(stored)
+		if err != nil {
+			return fmt.Errorf("could not convert share: %w", err)
+		}
+		if err := nodeStorage.Shares().Save(txn, share); err != nil {
+			return fmt.Errorf("could not save share: %w", err)
+		}
+		r.sharesAfter[key] = share
+	}
+
+	if j.HashChain != nil {
+		if *j.HashChain == (nodestorage.EventHashChain{}) {
+			if err := nodeStorage.DeleteEventHashChain(txn); err != nil {
+				return fmt.Errorf("could not delete event hash chain: %w", err)
+			}
+		} else if err := nodeStorage.SaveEventHashChain(txn, *j.HashChain); err != nil {
+			return fmt.Errorf("could not save event hash chain: %w", err)
+		}
+	}
+
+	for owner, rd := range j.Recipients {
+		if _, ok := r.recipientsBefore[owner]; !ok {
+			current, found, err := nodeStorage.GetRecipientData(txn, owner)
+			if err != nil {
+				return fmt.Errorf("could not get recipient data: %w", err)
+			}
+			if !found {
+				current = nil
+			}
+			r.recipientsBefore[owner] = current
+		}
+		if err := nodeStorage.DeleteRecipientData(txn, owner); err != nil {
+			return fmt.Errorf("could not delete recipient data: %w", err)
+		}
+		r.recipientsAfter[owner] = rd
+		if rd == nil {
+			continue
+		}
+		if _, err := nodeStorage.SaveRecipientData(txn, rd); err != nil {
+			return fmt.Errorf("could not save recipient data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// revertTasks returns the tasks applying the reverted state to the running validators.
+func (eh *EventHandler) revertTasks(ctx context.Context, txn basedb.Txn, r *journalReverter) ([]Task, error) {
+	operatorID := eh.operatorDataStore.GetOperatorID()
+
+	var tasks []Task
+	for key, before := range r.sharesBefore {
+		after := r.sharesAfter[key]
+		switch {
+		case before != nil && before.BelongsToOperator(operatorID) && after == nil:
+			if err := eh.keyManager.RemoveShare(ctx, txn, phase0.BLSPubKey(before.SharePubKey)); err != nil {
+				return nil, fmt.Errorf("could not remove share from ekm storage: %w", err)
+			}
+			tasks = append(tasks, NewStopValidatorTask(eh.taskExecutor, before.ValidatorPubKey))
+
+		case after != nil && after.BelongsToOperator(operatorID) && before == nil:
+			if _, kept := r.keptKeys[key]; kept {
+				// The validator is started again once its restored share's metadata is synced.
+				eh.logger.Info("restored own validator share", fields.Validator(after.ValidatorPubKey[:]))
+				continue
+			}
+			// The share's key was removed from the key manager along with the share and only
+			// the contract event carries it, so the validator can't be started again until it's re-added.
+			eh.logger.Warn("restored own validator share whose key is no longer available",
+				fields.Validator(after.ValidatorPubKey[:]))
+
+		case after != nil && after.BelongsToOperator(operatorID) && before.Liquidated != after.Liquidated:
+			if after.Liquidated {
+				tasks = append(tasks, NewLiquidateClusterTask(eh.taskExecutor, after.OwnerAddress, after.OperatorIDs(), []*ssvtypes.SSVShare{after}))
+			} else {
+				tasks = append(tasks, NewReactivateClusterTask(eh.taskExecutor, after.OwnerAddress, after.OperatorIDs(), []*ssvtypes.SSVShare{after}))
+			}
+		}
+	}
+
+	for owner, before := range r.recipientsBefore {
+		after := r.recipientsAfter[owner]
+		recipient := owner
+		if after != nil {
+			recipient = ethcommon.Address(after.FeeRecipient)
+		}
+		if before == nil && after == nil {
+			continue
+		}
+		if before != nil && ethcommon.Address(before.FeeRecipient) == recipient {
+			continue
+		}
+		tasks = append(tasks, NewUpdateFeeRecipientTask(eh.taskExecutor, owner, recipient))
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,190 @@
+package eventhandler
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ssvlabs/ssv/eth/contract"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+func TestRevertToBlock(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	ops, err := createOperators(3, 0)
+	require.NoError(t, err)
+	eh, validatorCtrl, err := setupEventHandler(t, ctx, logger, nil, ops[0], true)
+	require.NoError(t, err)
+
+	owner := ethcommon.HexToAddress("0x1")
+	recipient := ethcommon.HexToAddress("0x2")
+
+	addOperator := func(txn basedb.Txn, op *testOperator) {
+		pubKey, err := op.privateKey.Public().Base64()
+		require.NoError(t, err)
+		require.NoError(t, eh.handleOperatorAdded(txn, &contract.ContractOperatorAdded{
+			OperatorId: op.id,
+			Owner:      owner,
+			PublicKey:  []byte(pubKey),
+		}))
+	}
+
+	processBlock(t, eh, 1, func(txn basedb.Txn) {
+		addOperator(txn, ops[1])
+	})
+	processBlock(t, eh, 2, func(txn basedb.Txn) {
+		addOperator(txn, ops[2])
+		_, err := eh.handleFeeRecipientAddressUpdated(txn, &contract.ContractFeeRecipientAddressUpdated{
+			Owner:            owner,
+			RecipientAddress: recipient,
+		})
+		require.NoError(t, err)
+	})
+	processBlock(t, eh, 3, func(txn basedb.Txn) {
+		require.NoError(t, eh.handleOperatorRemoved(txn, &contract.ContractOperatorRemoved{OperatorId: ops[1].id}))
+	})
+	processBlock(t, eh, 4, func(txn basedb.Txn) {})
+
+	// Reverting restores the removed operator, deletes the added one and resets the fee recipient.
+	validatorCtrl.EXPECT().UpdateFeeRecipient(owner, owner).Return(nil)
+	require.NoError(t, eh.RevertToBlock(ctx, 1))
+
+	_, found, err := eh.nodeStorage.GetOperatorData(nil, ops[1].id)
+	require.NoError(t, err)
+	require.True(t, found)
+	_, found, err = eh.nodeStorage.GetOperatorData(nil, ops[2].id)
+	require.NoError(t, err)
+	require.False(t, found)
+	_, found, err = eh.nodeStorage.GetRecipientData(nil, owner)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	lastProcessedBlock, found, err := eh.nodeStorage.GetLastProcessedBlock(nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 1, lastProcessedBlock.Uint64())
+
+	// Reverting to a later block is a no-op.
+	require.NoError(t, eh.RevertToBlock(ctx, 2))
+
+	require.NoError(t, eh.RevertToBlock(ctx, 0))
+	_, found, err = eh.nodeStorage.GetOperatorData(nil, ops[1].id)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestRevertToBlock_BeyondJournal(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	ops, err := createOperators(1, 0)
+	require.NoError(t, err)
+	eh, _, err := setupEventHandler(t, ctx, logger, nil, ops[0], true)
+	require.NoError(t, err)
+	WithJournalDepth(2)(eh)
+
+	for block := uint64(1); block <= 5; block++ {
+		processBlock(t, eh, block, func(txn basedb.Txn) {})
+	}
+
+	txn := eh.nodeStorage.BeginRead()
+	defer txn.Discard()
+	horizon, found, err := journalHorizon(txn)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 3, horizon)
+
+	require.ErrorIs(t, eh.RevertToBlock(ctx, 2), ErrRevertBeyondJournal)
+	require.NoError(t, eh.RevertToBlock(ctx, 3))
+}
+
+// processBlock applies the mutations of fn as processBlockEvents would for the given block.
+func processBlock(t *testing.T, eh *EventHandler, blockNumber uint64, fn func(txn basedb.Txn)) {
+	txn := eh.nodeStorage.Begin()
+	defer txn.Discard()
+
+	lastProcessedBlock, found, err := eh.nodeStorage.GetLastProcessedBlock(txn)
+	require.NoError(t, err)
+	if !found {
+		lastProcessedBlock = new(big.Int)
+	}
+
+	eh.journal = newBlockJournal(blockNumber, ethcommon.Hash{})
+	defer func() { eh.journal = nil }()
+
+	fn(txn)
+
+	require.NoError(t, eh.saveJournal(txn, lastProcessedBlock.Uint64()))
+	require.NoError(t, eh.nodeStorage.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(blockNumber)))
+	require.NoError(t, txn.Commit())
+}
+
+func TestRewind(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	ops, err := createOperators(2, 0)
+	require.NoError(t, err)
+	eh, _, err := setupEventHandler(t, ctx, logger, nil, ops[0], true)
+	require.NoError(t, err)
+
+	pubKey, err := ops[1].privateKey.Public().Base64()
+	require.NoError(t, err)
+
+	processBlock(t, eh, 1, func(txn basedb.Txn) {})
+	processBlock(t, eh, 2, func(txn basedb.Txn) {
+		require.NoError(t, eh.handleOperatorAdded(txn, &contract.ContractOperatorAdded{
+			OperatorId: ops[1].id,
+			Owner:      ethcommon.HexToAddress("0x1"),
+			PublicKey:  []byte(pubKey),
+		}))
+	})
+	processBlock(t, eh, 3, func(txn basedb.Txn) {})
+
+	rewoundFrom, err := Rewind(eh.nodeStorage, 1)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, rewoundFrom)
+
+	_, found, err := eh.nodeStorage.GetOperatorData(nil, ops[1].id)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	lastProcessedBlock, found, err := eh.nodeStorage.GetLastProcessedBlock(nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 1, lastProcessedBlock.Uint64())
+
+	// Rewinding to a later block is a no-op.
+	rewoundFrom, err = Rewind(eh.nodeStorage, 2)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, rewoundFrom)
+}
+
+func TestProcessedBlockHashes(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	ops, err := createOperators(1, 0)
+	require.NoError(t, err)
+	eh, _, err := setupEventHandler(t, ctx, logger, nil, ops[0], true)
+	require.NoError(t, err)
+
+	hash := ethcommon.HexToHash("0x1234")
+	txn := eh.nodeStorage.Begin()
+	eh.journal = newBlockJournal(1, hash)
+	require.NoError(t, eh.saveJournal(txn, 0))
+	eh.journal = nil
+	require.NoError(t, txn.Commit())
+
+	processBlock(t, eh, 2, func(txn basedb.Txn) {})
+
+	hashes, err := eh.ProcessedBlockHashes()
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]ethcommon.Hash{1: hash}, hashes)
+}
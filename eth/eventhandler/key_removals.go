@@ -0,0 +1,58 @@
+package eventhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// deferredKeyRemovalsPrefix holds the share keys to remove from the key manager once the provisional
+// block whose events removed them is final, keyed by the block number and the share public key.
+// The value is the validator public key of the share.
+var deferredKeyRemovalsPrefix = []byte("events_deferred_key_removals/")
+
+func deferredKeyRemovalKey(blockNumber uint64, sharePubKey []byte) []byte {
+	return append(binary.BigEndian.AppendUint64(nil, blockNumber), sharePubKey...)
+}
+
+// deferKeyRemoval records the share key to remove from the key manager once the given block is final,
+// since a reorg may revert the removal of the share, and its key can't be recovered once removed.
+func (eh *EventHandler) deferKeyRemoval(txn basedb.Txn, blockNumber uint64, sharePubKey, validatorPubKey []byte) error {
+	if err := txn.Set(deferredKeyRemovalsPrefix, deferredKeyRemovalKey(blockNumber, sharePubKey), validatorPubKey); err != nil {
+		return fmt.Errorf("could not defer share key removal: %w", err)
+	}
+	return nil
+}
+
+// finalizeKeyRemovals removes the share keys whose removal was deferred by blocks up to the given final block,
+// unless their share was added back since.
+func (eh *EventHandler) finalizeKeyRemovals(ctx context.Context, txn basedb.Txn, finalBlock uint64) error {
+	var final []basedb.Obj
+	err := txn.GetRange(deferredKeyRemovalsPrefix, basedb.RangeOptions{End: binary.BigEndian.AppendUint64(nil, finalBlock+1)}, func(obj basedb.Obj) error {
+		final = append(final, obj)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not list deferred share key removals: %w", err)
+	}
+
+	for _, obj := range final {
+		sharePubKey := obj.Key[8:]
+		share, exists := eh.nodeStorage.Shares().Get(txn, obj.Value)
+		readded := exists && share.BelongsToOperator(eh.operatorDataStore.GetOperatorID()) && bytes.Equal(share.SharePubKey, sharePubKey)
+		if !readded {
+			if err := eh.keyManager.RemoveShare(ctx, txn, phase0.BLSPubKey(sharePubKey)); err != nil {
+				return fmt.Errorf("could not remove share from ekm storage: %w", err)
+			}
+		}
+		if err := txn.Delete(deferredKeyRemovalsPrefix, obj.Key); err != nil {
+			return fmt.Errorf("could not delete deferred share key removal: %w", err)
+		}
+	}
+	return nil
+}
@@ -22,3 +22,11 @@ func WithFullNode() Option {
 		eh.fullNode = true
 	}
 }
+
+// WithJournalDepth sets the amount of recent blocks whose registry mutations are journaled,
+// which bounds how far back RevertToBlock can go.
+func WithJournalDepth(depth uint64) Option {
+	return func(eh *EventHandler) {
+		eh.journalDepth = depth
+	}
+}
@@ -0,0 +1,87 @@
+package eventhandler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/eth/contract"
+	"github.com/ssvlabs/ssv/logging/fields"
+)
+
+// RestoreShareKeys adds the keys of this operator's shares to the key manager from the given ValidatorAdded events,
+// for shares which were imported from a registry snapshot rather than created by processing their events.
+// The events must be in the order they were emitted. It returns the amount of shares whose keys were restored.
+func (eh *EventHandler) RestoreShareKeys(ctx context.Context, events []*contract.ContractValidatorAdded) (int, error) {
+	operatorID := eh.operatorDataStore.GetOperatorID()
+
+	// A validator may have been removed and added again, in which case its share is of its last event.
+	shareEvents := make(map[spectypes.ValidatorPK]*contract.ContractValidatorAdded)
+	for _, event := range events {
+		share, exists := eh.nodeStorage.Shares().Get(nil, event.PublicKey)
+		if !exists || !share.BelongsToOperator(operatorID) {
+			continue
+		}
+		if event.Owner != share.OwnerAddress || !slices.Equal(event.OperatorIds, share.OperatorIDs()) {
+			continue
+		}
+		shareEvents[share.ValidatorPubKey] = event
+	}
+
+	txn := eh.nodeStorage.Begin()
+	defer txn.Discard()
+
+	for pubKey, event := range shareEvents {
+		share, _ := eh.nodeStorage.Shares().Get(txn, pubKey[:])
+
+		encryptedKey, err := operatorEncryptedKey(event, operatorID, share.SharePubKey)
+		if err != nil {
+			return 0, fmt.Errorf("validator %x: %w", pubKey[:], err)
+		}
+		if err := eh.keyManager.AddShare(ctx, txn, encryptedKey, phase0.BLSPubKey(share.SharePubKey)); err != nil {
+			return 0, fmt.Errorf("could not add share encrypted key of validator %x: %w", pubKey[:], err)
+		}
+
+		share.SetMinParticipationEpoch(eh.networkConfig.Beacon.EstimatedCurrentEpoch() + contractParticipationDelay)
+		if err := eh.nodeStorage.Shares().Save(txn, share); err != nil {
+			return 0, fmt.Errorf("could not save validator share: %w", err)
+		}
+
+		eh.logger.Debug("restored share key", fields.Validator(pubKey[:]), fields.TxHash(event.Raw.TxHash))
+	}
+
+	if err := txn.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	eh.logger.Info("restored share keys of imported shares", zap.Int("shares", len(shareEvents)))
+	return len(shareEvents), nil
+}
+
+// operatorEncryptedKey returns the given operator's encrypted share key from the event,
+// checking that the event assigns the operator the given share public key.
+func operatorEncryptedKey(event *contract.ContractValidatorAdded, operatorID spectypes.OperatorID, sharePubKey []byte) ([]byte, error) {
+	operatorCount := len(event.OperatorIds)
+	signatureOffset := phase0.SignatureLength
+	pubKeysOffset := phase0.PublicKeyLength*operatorCount + signatureOffset
+	if len(event.Shares) != encryptedKeyLength*operatorCount+pubKeysOffset {
+		return nil, ErrIncorrectSharesLength
+	}
+
+	i := slices.Index(event.OperatorIds, operatorID)
+	if i < 0 {
+		return nil, fmt.Errorf("operator %d is not in the event's committee", operatorID)
+	}
+
+	sharePublicKeys := splitBytes(event.Shares[signatureOffset:pubKeysOffset], phase0.PublicKeyLength)
+	if !bytes.Equal(sharePublicKeys[i], sharePubKey) {
+		return nil, fmt.Errorf("share public key doesn't match the event")
+	}
+	encryptedKeys := splitBytes(event.Shares[pubKeysOffset:], encryptedKeyLength)
+	return encryptedKeys[i], nil
+}
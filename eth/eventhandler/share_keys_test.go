@@ -0,0 +1,101 @@
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ssvlabs/ssv/eth/contract"
+	"github.com/ssvlabs/ssv/networkconfig"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/utils"
+)
+
+func TestRestoreShareKeys(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	ops, err := createOperators(4, 0)
+	require.NoError(t, err)
+	// Restoring a share key bumps its slashing protection to the current slot, which can't be 0.
+	currentSlot := &utils.SlotValue{}
+	currentSlot.SetSlot(100)
+	network := &networkconfig.NetworkConfig{}
+	network.Beacon = utils.SetupMockBeaconNetwork(t, currentSlot)
+
+	eh, _, err := setupEventHandler(t, ctx, logger, network, ops[0], true)
+	require.NoError(t, err)
+
+	validatorData, err := createNewValidator(ops)
+	require.NoError(t, err)
+	sharesData, err := generateSharesData(validatorData, ops, testAddr, 0)
+	require.NoError(t, err)
+
+	// Save the share as an imported snapshot would, without adding its key.
+	share := &ssvtypes.SSVShare{OwnerAddress: testAddr}
+	copy(share.ValidatorPubKey[:], validatorData.masterPubKey.Serialize())
+	operatorIDs := make([]uint64, len(ops))
+	for i, op := range ops {
+		operatorIDs[i] = op.id
+		share.Committee = append(share.Committee, &spectypes.ShareMember{
+			Signer:      op.id,
+			SharePubKey: validatorData.operatorsShares[i].pub.Serialize(),
+		})
+	}
+	share.SharePubKey = share.Committee[0].SharePubKey
+	share.DomainType = networkconfig.TestNetwork.DomainType
+	require.NoError(t, eh.nodeStorage.Shares().Save(nil, share))
+
+	event := &contract.ContractValidatorAdded{
+		Owner:       testAddr,
+		OperatorIds: operatorIDs,
+		PublicKey:   validatorData.masterPubKey.Serialize(),
+		Shares:      sharesData,
+	}
+
+	t.Run("ignores events of other committees", func(t *testing.T) {
+		otherEvent := *event
+		otherEvent.OperatorIds = []uint64{1, 2, 3, 5}
+
+		restored, err := eh.RestoreShareKeys(ctx, []*contract.ContractValidatorAdded{&otherEvent})
+		require.NoError(t, err)
+		require.Zero(t, restored)
+		requireKeyManagerDataToNotExist(t, eh, 0, validatorData)
+	})
+
+	t.Run("restores own share key", func(t *testing.T) {
+		restored, err := eh.RestoreShareKeys(ctx, []*contract.ContractValidatorAdded{event})
+		require.NoError(t, err)
+		require.Equal(t, 1, restored)
+		requireKeyManagerDataToExist(t, eh, 1, validatorData)
+	})
+}
+
+func TestOperatorEncryptedKey(t *testing.T) {
+	ops, err := createOperators(4, 0)
+	require.NoError(t, err)
+	validatorData, err := createNewValidator(ops)
+	require.NoError(t, err)
+	sharesData, err := generateSharesData(validatorData, ops, testAddr, 0)
+	require.NoError(t, err)
+
+	event := &contract.ContractValidatorAdded{OperatorIds: []uint64{1, 2, 3, 4}, Shares: sharesData}
+
+	encryptedKey, err := operatorEncryptedKey(event, 2, validatorData.operatorsShares[1].pub.Serialize())
+	require.NoError(t, err)
+	decrypted, err := ops[1].privateKey.Decrypt(encryptedKey)
+	require.NoError(t, err)
+	require.Equal(t, validatorData.operatorsShares[1].sec.SerializeToHexStr(), string(decrypted))
+
+	_, err = operatorEncryptedKey(event, 2, validatorData.operatorsShares[0].pub.Serialize())
+	require.Error(t, err)
+	_, err = operatorEncryptedKey(event, 5, validatorData.operatorsShares[1].pub.Serialize())
+	require.Error(t, err)
+
+	event.Shares = sharesData[1:]
+	_, err = operatorEncryptedKey(event, 2, validatorData.operatorsShares[1].pub.Serialize())
+	require.ErrorIs(t, err, ErrIncorrectSharesLength)
+}
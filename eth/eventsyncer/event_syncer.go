@@ -6,14 +6,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
+	"slices"
 	"time"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/eth/executionclient"
 	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/observability"
 	nodestorage "github.com/ssvlabs/ssv/operator/storage"
 )
 
@@ -24,6 +28,10 @@ import (
 
 const (
 	defaultStalenessThreshold = 300 * time.Second
+	defaultMaxFinalityLag     = 128
+	// finalitySyncTolerance is how many blocks the last processed block may be behind
+	// the final block for the syncer to be considered synced, as the final block advances an epoch at once.
+	finalitySyncTolerance = 64
 )
 
 var (
@@ -41,6 +49,13 @@ type EventHandler interface {
 	HandleBlockEventsStream(ctx context.Context, logs <-chan executionclient.BlockLogs, executeTasks bool) (uint64, error)
 }
 
+// BlockReverter reverts the processed events of the blocks after the given block.
+type BlockReverter interface {
+	RevertToBlock(ctx context.Context, block uint64) error
+	// ProcessedBlockHashes returns the hashes of the revertible processed blocks by block number.
+	ProcessedBlockHashes() (map[uint64]ethcommon.Hash, error)
+}
+
 // EventSyncer syncs registry contract events from the given ExecutionClient
 // and passes them to the given EventHandler for processing.
 type EventSyncer struct {
@@ -50,6 +65,9 @@ type EventSyncer struct {
 
 	logger             *zap.Logger
 	stalenessThreshold time.Duration
+	finalityTag        executionclient.FinalityTag
+	maxFinalityLag     uint64
+	blockReverter      BlockReverter
 
 	lastProcessedBlock       uint64
 	lastProcessedBlockChange time.Time
@@ -63,6 +81,7 @@ func New(nodeStorage nodestorage.Storage, executionClient ExecutionClient, event
 
 		logger:             zap.NewNop(),
 		stalenessThreshold: defaultStalenessThreshold,
+		maxFinalityLag:     defaultMaxFinalityLag,
 	}
 
 	for _, opt := range opts {
@@ -81,6 +100,10 @@ func (es *EventSyncer) Healthy(ctx context.Context) error {
 	if !found || lastProcessedBlock == nil || lastProcessedBlock.Uint64() == 0 {
 		return fmt.Errorf("last processed block is not set")
 	}
+	if es.finalityTag != executionclient.FinalityTagNone {
+		// The final block only advances once an epoch, so staleness is judged against it instead.
+		return es.finalityHealthy(ctx, lastProcessedBlock.Uint64())
+	}
 	if es.lastProcessedBlock != lastProcessedBlock.Uint64() {
 		es.lastProcessedBlock = lastProcessedBlock.Uint64()
 		es.lastProcessedBlockChange = time.Now()
@@ -107,12 +130,75 @@ func (es *EventSyncer) blockBelowThreshold(ctx context.Context, block *big.Int)
 	return nil
 }
 
+// finalityHealthy returns an error if the final block lags too far behind the head block,
+// or if the given last processed block is behind the final block.
+func (es *EventSyncer) finalityHealthy(ctx context.Context, lastProcessedBlock uint64) error {
+	head, err := es.executionClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get head block: %w", err)
+	}
+	finalBlock, err := es.finalBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	var lag uint64
+	if head.Number.Uint64() > finalBlock {
+		lag = head.Number.Uint64() - finalBlock
+	}
+	observability.RecordUint64Value(ctx, lag, finalityLagGauge.Record)
+	if lag > es.maxFinalityLag {
+		return fmt.Errorf("%s block %d lags %d blocks behind head block, exceeding %d", es.finalityTag, finalBlock, lag, es.maxFinalityLag)
+	}
+
+	return es.caughtUp(lastProcessedBlock, finalBlock)
+}
+
+func (es *EventSyncer) caughtUp(lastProcessedBlock, finalBlock uint64) error {
+	if lastProcessedBlock+finalitySyncTolerance < finalBlock {
+		return fmt.Errorf("last processed block %d is behind %s block %d", lastProcessedBlock, es.finalityTag, finalBlock)
+	}
+	return nil
+}
+
+// finalBlock returns the number of the block of the finality tag.
+func (es *EventSyncer) finalBlock(ctx context.Context) (uint64, error) {
+	header, err := es.executionClient.HeaderByNumber(ctx, es.finalityTag.BlockNumber())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s block: %w", es.finalityTag, err)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// synced returns nil if events were synced up to the given block recently enough.
+func (es *EventSyncer) synced(ctx context.Context, block uint64) error {
+	if es.finalityTag == executionclient.FinalityTagNone {
+		return es.blockBelowThreshold(ctx, new(big.Int).SetUint64(block))
+	}
+	finalBlock, err := es.finalBlock(ctx)
+	if err != nil {
+		return err
+	}
+	return es.caughtUp(block, finalBlock)
+}
+
 // SyncHistory reads and processes historical events since the given fromBlock.
 func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastProcessedBlock uint64, err error) {
+	verifiedFromBlock, err := es.verifyProcessedBlocks(ctx, fromBlock)
+	if err != nil {
+		return 0, err
+	}
+	reverted := verifiedFromBlock < fromBlock
+	fromBlock = verifiedFromBlock
+
 	const maxTries = 3
 	var prevProcessedBlock uint64
 	for i := 0; i < maxTries; i++ {
 		fetchLogs, fetchError, err := es.executionClient.FetchHistoricalLogs(ctx, fromBlock)
+		if errors.Is(err, executionclient.ErrNothingToSync) && reverted && i == 0 {
+			// Nothing is final past the block reverted to yet, so ongoing sync should resume right after it.
+			return fromBlock - 1, nil
+		}
 		if errors.Is(err, executionclient.ErrNothingToSync) {
 			// Nothing to sync, should keep ongoing sync from the given fromBlock.
 			return 0, executionclient.ErrNothingToSync
@@ -143,7 +229,7 @@ func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastP
 		}
 		prevProcessedBlock = lastProcessedBlock
 
-		err = es.blockBelowThreshold(ctx, new(big.Int).SetUint64(lastProcessedBlock))
+		err = es.synced(ctx, lastProcessedBlock)
 		if err == nil {
 			// Successfully synced up to a fresh block.
 			es.logger.Info("finished syncing historical events",
@@ -164,7 +250,170 @@ func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastP
 func (es *EventSyncer) SyncOngoing(ctx context.Context, fromBlock uint64) error {
 	es.logger.Info("subscribing to ongoing registry events", fields.FromBlock(fromBlock))
 
-	logStream := es.executionClient.StreamLogs(ctx, fromBlock)
-	_, err := es.eventHandler.HandleBlockEventsStream(ctx, logStream, true)
-	return err
+	if es.blockReverter == nil {
+		logStream := es.executionClient.StreamLogs(ctx, fromBlock)
+		_, err := es.eventHandler.HandleBlockEventsStream(ctx, logStream, true)
+		return err
+	}
+
+	fromBlock, err := es.verifyProcessedBlocks(ctx, fromBlock)
+	if err != nil {
+		return err
+	}
+
+	for {
+		streamCtx, cancel := context.WithCancel(ctx)
+		verifiedLogs := make(chan executionclient.BlockLogs)
+		reorgs := make(chan uint64, 1)
+		logStream := es.executionClient.StreamLogs(streamCtx, fromBlock)
+		go func() {
+			es.verifyBlocks(streamCtx, logStream, verifiedLogs, reorgs)
+			close(verifiedLogs)
+			for range logStream {
+				// Drain the stream until it's closed by the canceled context.
+			}
+		}()
+
+		_, err := es.eventHandler.HandleBlockEventsStream(ctx, verifiedLogs, true)
+		cancel()
+		for range verifiedLogs {
+			// Wait for the stream to be stopped.
+		}
+		if err != nil {
+			return err
+		}
+
+		var revertTo uint64
+		select {
+		case revertTo = <-reorgs:
+		default:
+			return nil
+		}
+
+		if err := es.revert(ctx, revertTo); err != nil {
+			return err
+		}
+		fromBlock = revertTo + 1
+	}
+}
+
+// verifyProcessedBlocks reverts the processed blocks which were reorged out of the chain, such as while the node
+// was offline, and returns the block to continue syncing from, which is fromBlock unless blocks were reverted.
+func (es *EventSyncer) verifyProcessedBlocks(ctx context.Context, fromBlock uint64) (uint64, error) {
+	if es.blockReverter == nil {
+		return fromBlock, nil
+	}
+
+	hashes, err := es.blockReverter.ProcessedBlockHashes()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get processed block hashes: %w", err)
+	}
+	if len(hashes) == 0 {
+		return fromBlock, nil
+	}
+
+	highest := slices.Max(slices.Collect(maps.Keys(hashes)))
+	canonical, err := es.canonical(ctx, highest, hashes[highest])
+	if err != nil {
+		return 0, err
+	}
+	if canonical {
+		return fromBlock, nil
+	}
+
+	revertTo, err := es.findRevertBlock(ctx, highest)
+	if err != nil {
+		return 0, err
+	}
+	if err := es.revert(ctx, revertTo); err != nil {
+		return 0, err
+	}
+	return min(fromBlock, revertTo+1), nil
+}
+
+// revert reverts the processed events of the blocks after the given block, which were reorged out of the chain.
+func (es *EventSyncer) revert(ctx context.Context, revertTo uint64) error {
+	reorgsCounter.Add(ctx, 1)
+	es.logger.Warn("reorg detected, reverting processed events", zap.Uint64("revert_to_block", revertTo))
+	if err := es.blockReverter.RevertToBlock(ctx, revertTo); err != nil {
+		return fmt.Errorf("failed to revert to block %d: %w", revertTo, err)
+	}
+	return nil
+}
+
+// verifyBlocks forwards the given logs until it finds that the last forwarded block of a known hash
+// was reorged out of the chain, in which case it sends the block to revert to and stops.
+func (es *EventSyncer) verifyBlocks(
+	ctx context.Context,
+	logs <-chan executionclient.BlockLogs,
+	verifiedLogs chan<- executionclient.BlockLogs,
+	reorgs chan<- uint64,
+) {
+	// A canonical block implies its ancestors are canonical as well, so it's enough to check the last one.
+	var lastBlock uint64
+	var lastHash ethcommon.Hash
+	for blockLogs := range logs {
+		if lastHash != (ethcommon.Hash{}) {
+			canonical, err := es.canonical(ctx, lastBlock, lastHash)
+			if err != nil {
+				es.logger.Warn("could not verify processed block", fields.BlockNumber(lastBlock), zap.Error(err))
+			} else if !canonical {
+				revertTo, err := es.findRevertBlock(ctx, lastBlock)
+				if err != nil {
+					es.logger.Warn("could not find block to revert to", zap.Error(err))
+				} else {
+					reorgs <- revertTo
+					return
+				}
+			}
+		}
+
+		if blockLogs.BlockHash != (ethcommon.Hash{}) {
+			lastBlock, lastHash = blockLogs.BlockNumber, blockLogs.BlockHash
+		}
+
+		select {
+		case verifiedLogs <- blockLogs:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// findRevertBlock returns the highest processed block below the given reorged block
+// which is still in the canonical chain, or the block before the lowest revertible processed block
+// if none of them is.
+func (es *EventSyncer) findRevertBlock(ctx context.Context, reorgedBlock uint64) (uint64, error) {
+	hashes, err := es.blockReverter.ProcessedBlockHashes()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get processed block hashes: %w", err)
+	}
+
+	lowest := reorgedBlock
+	for _, number := range slices.Backward(slices.Sorted(maps.Keys(hashes))) {
+		if number >= reorgedBlock {
+			continue
+		}
+		canonical, err := es.canonical(ctx, number, hashes[number])
+		if err != nil {
+			return 0, err
+		}
+		if canonical {
+			return number, nil
+		}
+		lowest = number
+	}
+	if lowest == 0 {
+		return 0, fmt.Errorf("no block to revert to below block %d", reorgedBlock)
+	}
+	return lowest - 1, nil
+}
+
+// canonical returns whether the block of the given number and hash is in the canonical chain.
+func (es *EventSyncer) canonical(ctx context.Context, number uint64, hash ethcommon.Hash) (bool, error) {
+	header, err := es.executionClient.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return false, fmt.Errorf("failed to get header of block %d: %w", number, err)
+	}
+	return header.Hash() == hash, nil
 }
@@ -14,6 +14,7 @@ import (
 	big "math/big"
 	reflect "reflect"
 
+	common "github.com/ethereum/go-ethereum/common"
 	types "github.com/ethereum/go-ethereum/core/types"
 	executionclient "github.com/ssvlabs/ssv/eth/executionclient"
 	gomock "go.uber.org/mock/gomock"
@@ -124,3 +125,55 @@ func (mr *MockEventHandlerMockRecorder) HandleBlockEventsStream(ctx, logs, execu
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockEventsStream", reflect.TypeOf((*MockEventHandler)(nil).HandleBlockEventsStream), ctx, logs, executeTasks)
 }
+
+// MockBlockReverter is a mock of BlockReverter interface.
+type MockBlockReverter struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockReverterMockRecorder
+}
+
+// MockBlockReverterMockRecorder is the mock recorder for MockBlockReverter.
+type MockBlockReverterMockRecorder struct {
+	mock *MockBlockReverter
+}
+
+// NewMockBlockReverter creates a new mock instance.
+func NewMockBlockReverter(ctrl *gomock.Controller) *MockBlockReverter {
+	mock := &MockBlockReverter{ctrl: ctrl}
+	mock.recorder = &MockBlockReverterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockReverter) EXPECT() *MockBlockReverterMockRecorder {
+	return m.recorder
+}
+
+// ProcessedBlockHashes mocks base method.
+func (m *MockBlockReverter) ProcessedBlockHashes() (map[uint64]common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessedBlockHashes")
+	ret0, _ := ret[0].(map[uint64]common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessedBlockHashes indicates an expected call of ProcessedBlockHashes.
+func (mr *MockBlockReverterMockRecorder) ProcessedBlockHashes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessedBlockHashes", reflect.TypeOf((*MockBlockReverter)(nil).ProcessedBlockHashes))
+}
+
+// RevertToBlock mocks base method.
+func (m *MockBlockReverter) RevertToBlock(ctx context.Context, block uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertToBlock", ctx, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertToBlock indicates an expected call of RevertToBlock.
+func (mr *MockBlockReverterMockRecorder) RevertToBlock(ctx, block any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertToBlock", reflect.TypeOf((*MockBlockReverter)(nil).RevertToBlock), ctx, block)
+}
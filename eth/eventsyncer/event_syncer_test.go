@@ -257,3 +257,142 @@ func TestBlockBelowThreshold(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestFinalityHealthy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockExecutionClient(ctrl)
+	ctx := context.Background()
+
+	s := New(nil, m, nil, WithFinalityTag(executionclient.FinalityTagFinalized), WithMaxFinalityLag(100))
+	finalized := executionclient.FinalityTagFinalized.BlockNumber()
+
+	expectBlocks := func(head, final int64) {
+		m.EXPECT().HeaderByNumber(ctx, nil).Return(&ethtypes.Header{Number: big.NewInt(head)}, nil)
+		m.EXPECT().HeaderByNumber(ctx, finalized).Return(&ethtypes.Header{Number: big.NewInt(final)}, nil)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectBlocks(1064, 1000)
+		require.NoError(t, s.finalityHealthy(ctx, 1000))
+	})
+
+	t.Run("fails if finality lags", func(t *testing.T) {
+		expectBlocks(1101, 1000)
+		require.Error(t, s.finalityHealthy(ctx, 1000))
+	})
+
+	t.Run("fails if behind final block", func(t *testing.T) {
+		expectBlocks(1064, 1000)
+		require.Error(t, s.finalityHealthy(ctx, 1000-finalitySyncTolerance-1))
+	})
+}
+
+func TestFindRevertBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockExecutionClient(ctrl)
+	reverter := NewMockBlockReverter(ctrl)
+	ctx := context.Background()
+
+	s := New(nil, m, nil, WithReorgRollback(reverter))
+
+	header := func(number int64, extra string) *ethtypes.Header {
+		return &ethtypes.Header{Number: big.NewInt(number), Extra: []byte(extra)}
+	}
+	hashes := map[uint64]ethcommon.Hash{
+		10: header(10, "").Hash(),
+		12: header(12, "").Hash(),
+		14: header(14, "").Hash(),
+	}
+
+	t.Run("returns highest canonical block", func(t *testing.T) {
+		reverter.EXPECT().ProcessedBlockHashes().Return(hashes, nil)
+		m.EXPECT().HeaderByNumber(ctx, big.NewInt(12)).Return(header(12, "reorged"), nil)
+		m.EXPECT().HeaderByNumber(ctx, big.NewInt(10)).Return(header(10, ""), nil)
+
+		revertTo, err := s.findRevertBlock(ctx, 14)
+		require.NoError(t, err)
+		require.EqualValues(t, 10, revertTo)
+	})
+
+	t.Run("returns block before lowest if none is canonical", func(t *testing.T) {
+		reverter.EXPECT().ProcessedBlockHashes().Return(hashes, nil)
+		m.EXPECT().HeaderByNumber(ctx, big.NewInt(12)).Return(header(12, "reorged"), nil)
+		m.EXPECT().HeaderByNumber(ctx, big.NewInt(10)).Return(header(10, "reorged"), nil)
+
+		revertTo, err := s.findRevertBlock(ctx, 14)
+		require.NoError(t, err)
+		require.EqualValues(t, 9, revertTo)
+	})
+}
+
+func TestVerifyProcessedBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockExecutionClient(ctrl)
+	reverter := NewMockBlockReverter(ctrl)
+	ctx := context.Background()
+
+	s := New(nil, m, nil, WithReorgRollback(reverter))
+
+	header := func(number int64, extra string) *ethtypes.Header {
+		return &ethtypes.Header{Number: big.NewInt(number), Extra: []byte(extra)}
+	}
+	hashes := map[uint64]ethcommon.Hash{
+		10: header(10, "").Hash(),
+		12: header(12, "").Hash(),
+	}
+
+	t.Run("keeps canonical blocks", func(t *testing.T) {
+		reverter.EXPECT().ProcessedBlockHashes().Return(hashes, nil)
+		m.EXPECT().HeaderByNumber(ctx, big.NewInt(12)).Return(header(12, ""), nil)
+
+		fromBlock, err := s.verifyProcessedBlocks(ctx, 13)
+		require.NoError(t, err)
+		require.EqualValues(t, 13, fromBlock)
+	})
+
+	t.Run("reverts reorged blocks", func(t *testing.T) {
+		reverter.EXPECT().ProcessedBlockHashes().Return(hashes, nil).Times(2)
+		m.EXPECT().HeaderByNumber(ctx, big.NewInt(12)).Return(header(12, "reorged"), nil)
+		m.EXPECT().HeaderByNumber(ctx, big.NewInt(10)).Return(header(10, ""), nil)
+		reverter.EXPECT().RevertToBlock(ctx, uint64(10)).Return(nil)
+
+		fromBlock, err := s.verifyProcessedBlocks(ctx, 13)
+		require.NoError(t, err)
+		require.EqualValues(t, 11, fromBlock)
+	})
+}
+
+func TestVerifyBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockExecutionClient(ctrl)
+	reverter := NewMockBlockReverter(ctrl)
+	ctx := context.Background()
+
+	s := New(nil, m, nil, WithReorgRollback(reverter))
+
+	header := func(number int64, extra string) *ethtypes.Header {
+		return &ethtypes.Header{Number: big.NewInt(number), Extra: []byte(extra)}
+	}
+
+	logs := make(chan executionclient.BlockLogs, 2)
+	logs <- executionclient.BlockLogs{BlockNumber: 10, BlockHash: header(10, "").Hash()}
+	logs <- executionclient.BlockLogs{BlockNumber: 11, BlockHash: header(11, "").Hash()}
+	close(logs)
+
+	// Block 10 got reorged out after it was forwarded, before block 11 arrived.
+	m.EXPECT().HeaderByNumber(ctx, big.NewInt(10)).Return(header(10, "reorged"), nil)
+	reverter.EXPECT().ProcessedBlockHashes().Return(map[uint64]ethcommon.Hash{8: header(8, "").Hash()}, nil)
+	m.EXPECT().HeaderByNumber(ctx, big.NewInt(8)).Return(header(8, ""), nil)
+
+	verifiedLogs := make(chan executionclient.BlockLogs, 2)
+	reorgs := make(chan uint64, 1)
+	s.verifyBlocks(ctx, logs, verifiedLogs, reorgs)
+	close(verifiedLogs)
+
+	var verified []uint64
+	for blockLogs := range verifiedLogs {
+		verified = append(verified, blockLogs.BlockNumber)
+	}
+	require.Equal(t, []uint64{10}, verified)
+	require.EqualValues(t, 8, <-reorgs)
+}
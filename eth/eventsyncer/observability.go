@@ -0,0 +1,35 @@
+package eventsyncer
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+const (
+	observabilityName      = "github.com/ssvlabs/ssv/eth/eventsyncer"
+	observabilityNamespace = "ssv.event_syncer"
+)
+
+var (
+	meter = otel.Meter(observabilityName)
+
+	finalityLagGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("finality_lag"),
+			metric.WithUnit("{block}"),
+			metric.WithDescription("number of blocks the final block lags behind the head block")))
+
+	reorgsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("reorgs"),
+			metric.WithUnit("{reorg}"),
+			metric.WithDescription("total number of reorgs which reverted processed events")))
+)
+
+func metricName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
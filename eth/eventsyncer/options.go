@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/eth/executionclient"
 )
 
 // Option defines EventSyncer configuration option.
@@ -21,3 +23,35 @@ func WithStalenessThreshold(threshold time.Duration) Option {
 		es.stalenessThreshold = threshold
 	}
 }
+
+// WithFinalityTag makes the syncer consider the block of the given tag as the last final block,
+// which must match the finality tag of the execution client.
+func WithFinalityTag(tag executionclient.FinalityTag) Option {
+	return func(es *EventSyncer) {
+		es.finalityTag = tag
+	}
+}
+
+// WithMaxFinalityLag sets the amount of blocks the final block may lag behind the head block
+// before the syncer is reported unhealthy.
+func WithMaxFinalityLag(blocks uint64) Option {
+	return func(es *EventSyncer) {
+		es.maxFinalityLag = blocks
+	}
+}
+
+// WithReorgRollback makes the syncer verify that the processed blocks are still in the canonical chain,
+// using the given BlockReverter to revert the ones which were reorged out.
+func WithReorgRollback(reverter BlockReverter) Option {
+	return func(es *EventSyncer) {
+		es.blockReverter = reverter
+	}
+}
+
+// WithProvisionalEvents enables processing the events of blocks which aren't final yet,
+// using the given BlockReverter to revert them on reorg. It requires a finality tag.
+func WithProvisionalEvents(reverter BlockReverter) Option {
+	return func(es *EventSyncer) {
+		es.blockReverter = reverter
+	}
+}
@@ -11,4 +11,6 @@ type Options struct {
 	Addr                  string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-required:"true" env-description:"Execution client WebSocket URL(s). Multiple clients are supported via semicolon-separated URLs (e.g. 'ws://localhost:8546;ws://localhost:8547')"`
 	ConnectionTimeout     time.Duration `yaml:"ETH1ConnectionTimeout" env:"ETH_1_CONNECTION_TIMEOUT" env-default:"10s" env-description:"Timeout for execution client connections"`
 	SyncDistanceTolerance uint64        `yaml:"ETH1SyncDistanceTolerance" env:"ETH_1_SYNC_DISTANCE_TOLERANCE" env-default:"5" env-description:"Maximum number of blocks behind head considered in-sync"`
+	FinalityTag           string        `yaml:"ETH1FinalityTag" env:"ETH_1_FINALITY_TAG" env-description:"Block tag ('safe' or 'finalized') up to which contract events are considered final, instead of a fixed follow distance"`
+	ProvisionalEvents     bool          `yaml:"ETH1ProvisionalEvents" env:"ETH_1_PROVISIONAL_EVENTS" env-description:"Process contract events of blocks which aren't final yet, reverting them on reorg. Requires ETH1FinalityTag"`
 }
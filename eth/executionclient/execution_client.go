@@ -64,7 +64,11 @@ type ExecutionClient struct {
 	logger *zap.Logger
 	// followDistance defines an offset into the past from the head block such that the block
 	// at this offset will be considered as very likely finalized.
-	followDistance              uint64 // TODO: consider reading the finalized checkpoint from consensus layer
+	followDistance uint64
+	// finalityTag replaces followDistance with the block of the given tag, unless it's FinalityTagNone.
+	finalityTag FinalityTag
+	// provisionalLogs enables streaming the logs of blocks which aren't final yet.
+	provisionalLogs             bool
 	connectionTimeout           time.Duration
 	reconnectionInitialInterval time.Duration
 	reconnectionMaxInterval     time.Duration
@@ -135,10 +139,10 @@ func (ec *ExecutionClient) FetchHistoricalLogs(ctx context.Context, fromBlock ui
 			zap.Error(err))
 		return nil, nil, fmt.Errorf("failed to get current block: %w", err)
 	}
-	if currentBlock < ec.followDistance {
-		return nil, nil, ErrNothingToSync
+	toBlock, err := ec.lastFinalBlock(ctx, currentBlock)
+	if err != nil {
+		return nil, nil, err
 	}
-	toBlock := currentBlock - ec.followDistance
 	if toBlock < fromBlock {
 		return nil, nil, ErrNothingToSync
 	}
@@ -147,6 +151,22 @@ func (ec *ExecutionClient) FetchHistoricalLogs(ctx context.Context, fromBlock ui
 	return
 }
 
+// lastFinalBlock returns the highest block whose logs are considered final: the block of the finality tag if set,
+// otherwise the block at followDistance from the given head block.
+func (ec *ExecutionClient) lastFinalBlock(ctx context.Context, head uint64) (uint64, error) {
+	if ec.finalityTag == FinalityTagNone {
+		if head < ec.followDistance {
+			return 0, ErrNothingToSync
+		}
+		return head - ec.followDistance, nil
+	}
+	header, err := ec.HeaderByNumber(ctx, ec.finalityTag.BlockNumber())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s block: %w", ec.finalityTag, err)
+	}
+	return header.Number.Uint64(), nil
+}
+
 // Calls FilterLogs multiple times and batches results to avoid fetching an enormous number of events.
 func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, endBlock uint64) (<-chan BlockLogs, <-chan error) {
 	if startBlock > endBlock {
@@ -419,15 +439,31 @@ func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- Blo
 			return fromBlock, fmt.Errorf("subscription: %w", err)
 
 		case header := <-heads:
-			if header.Number.Uint64() < ec.followDistance {
+			finalBlock, err := ec.lastFinalBlock(ctx, header.Number.Uint64())
+			if errors.Is(err, ErrNothingToSync) {
+				continue
+			}
+			if err != nil {
+				// Try again on the next head.
+				ec.logger.Warn("could not get the last final block", zap.Error(err))
 				continue
 			}
-			toBlock := header.Number.Uint64() - ec.followDistance
+			toBlock := finalBlock
+			if ec.provisionalLogs {
+				toBlock = header.Number.Uint64()
+			}
 			if toBlock < fromBlock {
 				continue
 			}
 			logStream, fetchErrors := ec.fetchLogsInBatches(ctx, fromBlock, toBlock)
 			for block := range logStream {
+				if block.BlockNumber > finalBlock {
+					block.Provisional = true
+					block.LastFinalBlock = finalBlock
+					if block.BlockNumber == header.Number.Uint64() && block.BlockHash == (ethcommon.Hash{}) {
+						block.BlockHash = header.Hash()
+					}
+				}
 				logs <- block
 				lastBlock = block.BlockNumber
 			}
@@ -0,0 +1,42 @@
+package executionclient
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// FinalityTag is the block tag up to which contract events are considered final.
+type FinalityTag string
+
+const (
+	// FinalityTagNone considers the block at followDistance from the head block as final.
+	FinalityTagNone FinalityTag = ""
+	// FinalityTagSafe considers the "safe" block as final.
+	FinalityTagSafe FinalityTag = "safe"
+	// FinalityTagFinalized considers the "finalized" block as final.
+	FinalityTagFinalized FinalityTag = "finalized"
+)
+
+// ParseFinalityTag parses a FinalityTag, an empty string being FinalityTagNone.
+func ParseFinalityTag(s string) (FinalityTag, error) {
+	switch tag := FinalityTag(s); tag {
+	case FinalityTagNone, FinalityTagSafe, FinalityTagFinalized:
+		return tag, nil
+	default:
+		return "", fmt.Errorf("unknown finality tag %q, expected %q or %q", s, FinalityTagSafe, FinalityTagFinalized)
+	}
+}
+
+// BlockNumber returns the block number to request the tagged block with, nil for FinalityTagNone.
+func (t FinalityTag) BlockNumber() *big.Int {
+	switch t {
+	case FinalityTagSafe:
+		return big.NewInt(rpc.SafeBlockNumber.Int64())
+	case FinalityTagFinalized:
+		return big.NewInt(rpc.FinalizedBlockNumber.Int64())
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,26 @@
+package executionclient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFinalityTag(t *testing.T) {
+	for _, tag := range []FinalityTag{FinalityTagNone, FinalityTagSafe, FinalityTagFinalized} {
+		parsed, err := ParseFinalityTag(string(tag))
+		require.NoError(t, err)
+		require.Equal(t, tag, parsed)
+	}
+
+	_, err := ParseFinalityTag("latest")
+	require.Error(t, err)
+}
+
+func TestFinalityTag_BlockNumber(t *testing.T) {
+	require.Nil(t, FinalityTagNone.BlockNumber())
+	require.Equal(t, big.NewInt(int64(rpc.SafeBlockNumber)), FinalityTagSafe.BlockNumber())
+	require.Equal(t, big.NewInt(int64(rpc.FinalizedBlockNumber)), FinalityTagFinalized.BlockNumber())
+}
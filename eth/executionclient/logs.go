@@ -3,13 +3,21 @@ package executionclient
 import (
 	"sort"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // BlockLogs holds a block's number and it's logs.
 type BlockLogs struct {
 	BlockNumber uint64
-	Logs        []ethtypes.Log
+	// BlockHash is the hash of the block, it's empty for blocks without logs
+	// unless they're provisional head blocks.
+	BlockHash ethcommon.Hash
+	Logs      []ethtypes.Log
+	// Provisional is true if the block isn't final yet, so its logs may be reverted by a reorg.
+	Provisional bool
+	// LastFinalBlock is the highest final block at the time a provisional block was streamed.
+	LastFinalBlock uint64
 }
 
 // PackLogs packs logs into []BlockLogs by their block number.
@@ -28,6 +36,7 @@ func PackLogs(logs []ethtypes.Log) []BlockLogs {
 		if len(all) == 0 || all[len(all)-1].BlockNumber != log.BlockNumber {
 			all = append(all, BlockLogs{
 				BlockNumber: log.BlockNumber,
+				BlockHash:   log.BlockHash,
 			})
 		}
 
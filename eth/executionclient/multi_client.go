@@ -56,7 +56,11 @@ type MultiClient struct {
 	logger *zap.Logger
 	// followDistance defines an offset into the past from the head block such that the block
 	// at this offset will be considered as very likely finalized.
-	followDistance              uint64 // TODO: consider reading the finalized checkpoint from consensus layer
+	followDistance uint64
+	// finalityTag replaces followDistance with the block of the given tag, unless it's FinalityTagNone.
+	finalityTag FinalityTag
+	// provisionalLogs enables streaming the logs of blocks which aren't final yet.
+	provisionalLogs             bool
 	connectionTimeout           time.Duration
 	reconnectionInitialInterval time.Duration
 	reconnectionMaxInterval     time.Duration
@@ -150,17 +154,24 @@ func (mc *MultiClient) connect(ctx context.Context, clientIndex int) error {
 	// Therefore, we need to override its Fatal behavior to avoid crashing.
 	logger := mc.logger.WithOptions(zap.WithFatalHook(zapcore.WriteThenNoop), zap.WithPanicHook(zapcore.WriteThenNoop))
 
-	singleClient, err := New(
-		ctx,
-		mc.nodeAddrs[clientIndex],
-		mc.contractAddress,
+	opts := []Option{
 		WithLogger(logger),
 		WithFollowDistance(mc.followDistance),
+		WithFinalityTag(mc.finalityTag),
 		WithConnectionTimeout(mc.connectionTimeout),
 		WithReconnectionInitialInterval(mc.reconnectionInitialInterval),
 		WithReconnectionMaxInterval(mc.reconnectionMaxInterval),
 		WithHealthInvalidationInterval(mc.healthInvalidationInterval),
 		WithSyncDistanceTolerance(mc.syncDistanceTolerance),
+	}
+	if mc.provisionalLogs {
+		opts = append(opts, WithProvisionalLogs())
+	}
+	singleClient, err := New(
+		ctx,
+		mc.nodeAddrs[clientIndex],
+		mc.contractAddress,
+		opts...,
 	)
 	if err != nil {
 		recordClientInitStatus(ctx, mc.nodeAddrs[clientIndex], false)
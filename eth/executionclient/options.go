@@ -42,6 +42,38 @@ func WithFollowDistanceMulti(offset uint64) OptionMulti {
 	}
 }
 
+// WithFinalityTag sets the block tag up to which logs are considered final,
+// replacing the follow distance unless it's FinalityTagNone.
+func WithFinalityTag(tag FinalityTag) Option {
+	return func(s *ExecutionClient) {
+		s.finalityTag = tag
+	}
+}
+
+// WithFinalityTagMulti sets the block tag up to which logs are considered final,
+// replacing the follow distance unless it's FinalityTagNone.
+func WithFinalityTagMulti(tag FinalityTag) OptionMulti {
+	return func(s *MultiClient) {
+		s.finalityTag = tag
+	}
+}
+
+// WithProvisionalLogs enables streaming logs up to the head block, marking the logs
+// of blocks which aren't final yet as provisional.
+func WithProvisionalLogs() Option {
+	return func(s *ExecutionClient) {
+		s.provisionalLogs = true
+	}
+}
+
+// WithProvisionalLogsMulti enables streaming logs up to the head block, marking the logs
+// of blocks which aren't final yet as provisional.
+func WithProvisionalLogsMulti() OptionMulti {
+	return func(s *MultiClient) {
+		s.provisionalLogs = true
+	}
+}
+
 // WithConnectionTimeout sets timeout for network connection to eth1 node.
 func WithConnectionTimeout(timeout time.Duration) Option {
 	return func(s *ExecutionClient) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"slices"
 	"sync"
 	"time"
@@ -22,9 +23,18 @@ import (
 const (
 	highestInstanceKey = "highest_instance"
 	instanceKey        = "instance"
-	participantsKey    = "pt"
+	// participantsKey prefixes participants keys, which are made of the role, the slot and the validator public key.
+	// Slots used to be encoded in little-endian under "pt", see migration_8_participants_slot_order.
+	participantsKey = "ps"
+	proofsKey       = "pf"
 )
 
+// pruneBatchSize limits the number of keys removed per transaction when pruning.
+const pruneBatchSize = 1000
+
+// blsSignatureSize is the size of the aggregated signature of a proof.
+const blsSignatureSize = 96
+
 // participantStorage struct
 // instanceType is what separates different iBFT eth2 duty types (attestation, proposal and aggregation)
 type participantStorage struct {
@@ -77,77 +87,77 @@ func (i *participantStorage) PruneContinously(ctx context.Context, logger *zap.L
 
 // removes ALL entries that have given slot in their prefix
 func (i *participantStorage) removeSlotAt(slot phase0.Slot) (int, error) {
-	var keySet [][]byte
-
-	prefix := i.makePrefix(slotToByteSlice(slot))
-
-	tx := i.db.Begin()
-	defer tx.Discard()
-
-	// filter and collect keys
-	err := i.db.UsingReader(tx).GetAll(prefix, func(i int, o basedb.Obj) error {
-		keySet = append(keySet, o.Key)
-		return nil
-	})
+	slotBytes := slotToByteSlice(slot)
 
+	count, err := i.removePrefix(i.makePrefix(slotBytes))
 	if err != nil {
-		return 0, fmt.Errorf("collect keys of stale slots: %w", err)
+		return count, err
 	}
 
-	if len(keySet) == 0 {
-		return 0, nil
+	// Proofs aren't counted, as every proof has a matching participants entry.
+	if _, err := i.removePrefix(i.makeProofPrefix(slotBytes)); err != nil {
+		return count, fmt.Errorf("remove proofs: %w", err)
 	}
 
-	for _, id := range keySet {
-		if err := i.db.Using(tx).Delete(append(prefix, id...), nil); err != nil {
-			return 0, fmt.Errorf("remove slot: %w", err)
+	return count, nil
+}
+
+// removePrefix removes all entries under the given prefix.
+func (i *participantStorage) removePrefix(prefix []byte) (int, error) {
+	// Keys are collected and removed in bounded batches, so that neither the collected keys
+	// nor the transaction grow with the number of validators.
+	var total int
+	for {
+		keys := make([][]byte, 0, pruneBatchSize)
+		err := i.db.GetRange(prefix, basedb.RangeOptions{KeysOnly: true, Limit: pruneBatchSize}, func(o basedb.Obj) error {
+			keys = append(keys, o.Key)
+			return nil
+		})
+		if err != nil {
+			return total, fmt.Errorf("collect keys of stale slots: %w", err)
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		return 0, fmt.Errorf("commit old slot removal: %w", err)
-	}
+		if len(keys) == 0 {
+			return total, nil
+		}
 
-	return len(keySet), nil
-}
+		err = i.db.Update(func(txn basedb.Txn) error {
+			for _, key := range keys {
+				if err := txn.Delete(prefix, key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return total, fmt.Errorf("remove slot: %w", err)
+		}
+		total += len(keys)
 
-var dropPrefixMu sync.Mutex
+		if len(keys) < pruneBatchSize {
+			return total, nil
+		}
+	}
+}
 
 // removes ALL entries for any slots older or equal to given slot
 func (i *participantStorage) removeSlotsOlderThan(logger *zap.Logger, slot phase0.Slot) int {
 	var total int
 	for {
 		slot-- // slots are incremental
-		prefix := i.makePrefix(slotToByteSlice(slot))
-		stop := func() bool {
-			dropPrefixMu.Lock()
-			defer dropPrefixMu.Unlock()
-
-			count, err := i.db.CountPrefix(prefix)
-			if err != nil {
-				logger.Error("count prefix of stale slots", zap.String("store", i.ID()), fields.Slot(slot), zap.Error(err))
-				return true
-			}
-
-			if count == 0 {
-				logger.Debug("no more keys at slot", zap.String("store", i.ID()), fields.Slot(slot))
-				return true
-			}
-
-			if err := i.db.DropPrefix(prefix); err != nil {
-				logger.Error("drop prefix of stale slots", zap.String("store", i.ID()), fields.Slot(slot), zap.Error(err))
-				return true
-			}
-
-			logger.Debug("drop prefix", zap.String("store", i.ID()), zap.Int64("count", count), fields.Slot(slot))
-			total += int(count)
-
-			return false
-		}()
+		count, err := i.removeSlotAt(slot)
+		if err != nil {
+			logger.Error("remove stale slot", zap.String("store", i.ID()), fields.Slot(slot), zap.Error(err))
+			break
+		}
 
-		if stop {
+		if count == 0 {
+			logger.Debug("no more keys at slot", zap.String("store", i.ID()), fields.Slot(slot))
 			break
 		}
+
+		logger.Debug("removed stale slot", zap.String("store", i.ID()), fields.Count(count), fields.Slot(slot))
+		total += count
 	}
 
 	return total
@@ -198,22 +208,15 @@ func (i *participantStorage) SaveParticipants(pk spectypes.ValidatorPK, slot pha
 
 func (i *participantStorage) GetAllParticipantsInRange(from, to phase0.Slot) ([]qbftstorage.ParticipantsRangeEntry, error) {
 	var ee []qbftstorage.ParticipantsRangeEntry
-	for slot := from; slot <= to; slot++ {
-		slotBytes := slotToByteSlice(slot)
-		prefix := i.makePrefix(slotBytes)
-		err := i.db.GetAll(prefix, func(_ int, o basedb.Obj) error {
-			re := qbftstorage.ParticipantsRangeEntry{
-				Slot:    slot,
-				PubKey:  spectypes.ValidatorPK(o.Key),
-				Signers: decodeOperators(o.Value),
-			}
-			ee = append(ee, re)
-			return nil
+	err := i.getRange(from, to, func(slot phase0.Slot, pk spectypes.ValidatorPK, value []byte) {
+		ee = append(ee, qbftstorage.ParticipantsRangeEntry{
+			Slot:    slot,
+			PubKey:  pk,
+			Signers: decodeOperators(value),
 		})
-
-		if err != nil {
-			return nil, err
-		}
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return ee, nil
@@ -222,14 +225,14 @@ func (i *participantStorage) GetAllParticipantsInRange(from, to phase0.Slot) ([]
 func (i *participantStorage) GetParticipantsInRange(pk spectypes.ValidatorPK, from, to phase0.Slot) ([]qbftstorage.ParticipantsRangeEntry, error) {
 	participantsRange := make([]qbftstorage.ParticipantsRangeEntry, 0)
 
-	for slot := from; slot <= to; slot++ {
-		participants, err := i.GetParticipants(pk, slot)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get participants: %w", err)
+	err := i.getRange(from, to, func(slot phase0.Slot, entryPK spectypes.ValidatorPK, value []byte) {
+		if entryPK != pk {
+			return
 		}
 
+		participants := decodeOperators(value)
 		if len(participants) == 0 {
-			continue
+			return
 		}
 
 		participantsRange = append(participantsRange, qbftstorage.ParticipantsRangeEntry{
@@ -237,11 +240,33 @@ func (i *participantStorage) GetParticipantsInRange(pk spectypes.ValidatorPK, fr
 			PubKey:  pk,
 			Signers: participants,
 		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
 	}
 
 	return participantsRange, nil
 }
 
+// getRange iterates over all entries of the slots from..to (inclusive) in a single range read,
+// relying on slots being encoded in big-endian so that keys are ordered by slot.
+func (i *participantStorage) getRange(from, to phase0.Slot, handler func(phase0.Slot, spectypes.ValidatorPK, []byte)) error {
+	opts := basedb.RangeOptions{
+		Start: slotToByteSlice(from),
+		End:   slotToByteSlice(to + 1),
+	}
+
+	return i.db.GetRange(i.rolePrefix(), opts, func(o basedb.Obj) error {
+		if len(o.Key) != slotSize+len(spectypes.ValidatorPK{}) {
+			return fmt.Errorf("unexpected participants key length %d", len(o.Key))
+		}
+
+		slot := phase0.Slot(binary.BigEndian.Uint32(o.Key[:slotSize]))
+		handler(slot, spectypes.ValidatorPK(o.Key[slotSize:]), o.Value)
+		return nil
+	})
+}
+
 func (i *participantStorage) GetParticipants(pk spectypes.ValidatorPK, slot phase0.Slot) ([]spectypes.OperatorID, error) {
 	return i.getParticipants(nil, pk, slot)
 }
@@ -271,6 +296,63 @@ func (i *participantStorage) saveParticipants(txn basedb.ReadWriter, pk spectype
 	return nil
 }
 
+func (i *participantStorage) SaveProof(pk spectypes.ValidatorPK, slot phase0.Slot, proof *qbftstorage.ParticipantsProof) error {
+	if len(proof.Signature) != blsSignatureSize {
+		return fmt.Errorf("invalid signature length %d", len(proof.Signature))
+	}
+	if uint64(len(proof.Data)) > math.MaxUint32 {
+		return fmt.Errorf("invalid data length %d", len(proof.Data))
+	}
+
+	// The value is the root, the signature, the length-prefixed data and then the signers.
+	value := make([]byte, 0, len(proof.Root)+blsSignatureSize+4+len(proof.Data)+len(proof.Signers)*8)
+	value = append(value, proof.Root[:]...)
+	value = append(value, proof.Signature...)
+	value = binary.BigEndian.AppendUint32(value, uint32(len(proof.Data))) // #nosec G115 -- checked above
+	value = append(value, proof.Data...)
+	for _, signer := range proof.Signers {
+		value = binary.BigEndian.AppendUint64(value, signer)
+	}
+
+	if err := i.db.Set(i.makeProofPrefix(slotToByteSlice(slot)), pk[:], value); err != nil {
+		return fmt.Errorf("save proof: %w", err)
+	}
+
+	return nil
+}
+
+func (i *participantStorage) GetProof(pk spectypes.ValidatorPK, slot phase0.Slot) (*qbftstorage.ParticipantsProof, error) {
+	obj, found, err := i.db.Get(i.makeProofPrefix(slotToByteSlice(slot)), pk[:])
+	if err != nil {
+		return nil, fmt.Errorf("get proof: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	value := obj.Value
+	headerSize := len(phase0.Root{}) + blsSignatureSize + 4
+	if len(value) < headerSize {
+		return nil, fmt.Errorf("corrupted proof: wrong length %d", len(value))
+	}
+
+	proof := &qbftstorage.ParticipantsProof{}
+	copy(proof.Root[:], value)
+	value = value[len(proof.Root):]
+	proof.Signature = slices.Clone(value[:blsSignatureSize])
+	value = value[blsSignatureSize:]
+
+	dataSize := uint64(binary.BigEndian.Uint32(value))
+	value = value[4:]
+	if uint64(len(value)) < dataSize || (uint64(len(value))-dataSize)%8 != 0 {
+		return nil, fmt.Errorf("corrupted proof: wrong length %d", len(obj.Value))
+	}
+	proof.Data = slices.Clone(value[:dataSize])
+	proof.Signers = decodeOperators(value[dataSize:])
+
+	return proof, nil
+}
+
 func mergeParticipants(existingParticipants, newParticipants []spectypes.OperatorID) []spectypes.OperatorID {
 	allParticipants := slices.Concat(existingParticipants, newParticipants)
 	slices.Sort(allParticipants)
@@ -299,21 +381,41 @@ func (i *participantStorage) ID() string {
 	return bnr.String()
 }
 
-func (i *participantStorage) makePrefix(slot []byte) []byte {
-	prefix := make([]byte, 0, len(participantsKey)+1+len(slot))
+// ParticipantsPrefix returns the key prefix under which the participants of the given role are stored.
+func ParticipantsPrefix(role spectypes.BeaconRole) []byte {
+	return append([]byte(participantsKey), byte(role&0xff))
+}
+
+func (i *participantStorage) rolePrefix() []byte {
+	prefix := make([]byte, 0, len(participantsKey)+len(i.prefix))
 	prefix = append(prefix, participantsKey...)
 	prefix = append(prefix, i.prefix...)
+	return prefix
+}
+
+func (i *participantStorage) makeProofPrefix(slot []byte) []byte {
+	prefix := make([]byte, 0, len(proofsKey)+1+len(slot))
+	prefix = append(prefix, proofsKey...)
+	prefix = append(prefix, i.prefix...)
 	prefix = append(prefix, slot...)
 	return prefix
 }
 
+func (i *participantStorage) makePrefix(slot []byte) []byte {
+	return append(i.rolePrefix(), slot...)
+}
+
+// slotSize is the size of the slot encoded in participants keys.
+const slotSize = 4
+
+// slotToByteSlice encodes the slot in big-endian, so participants keys are ordered by slot.
 func slotToByteSlice(v phase0.Slot) []byte {
-	b := make([]byte, 4)
+	b := make([]byte, slotSize)
 
 	// we're casting down but we should be good for now
 	slot := uint32(uint64(v)) // #nosec G115
 
-	binary.LittleEndian.PutUint32(b, slot)
+	binary.BigEndian.PutUint32(b, slot)
 	return b
 }
 
@@ -102,6 +102,77 @@ func TestRemoveSlot(t *testing.T) {
 	})
 }
 
+func TestRemoveSlotAt_Batches(t *testing.T) {
+	db, err := kv.NewInMemory(zap.NewNop(), basedb.Options{})
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, err)
+
+	storage := New(db, spectypes.BNRoleAttester).(*participantStorage)
+
+	// More validators at a single slot than a single pruning batch holds.
+	const validators = pruneBatchSize*2 + 10
+	for i := 0; i < validators; i++ {
+		var pk spectypes.ValidatorPK
+		pk[0], pk[1] = byte(i>>8), byte(i)
+		_, err := storage.SaveParticipants(pk, 10, []spectypes.OperatorID{1, 2, 3})
+		require.NoError(t, err)
+	}
+	_, err = storage.SaveParticipants(spectypes.ValidatorPK{0xff}, 11, []spectypes.OperatorID{1, 2, 3})
+	require.NoError(t, err)
+
+	pp, err := storage.GetAllParticipantsInRange(10, 11)
+	require.NoError(t, err)
+	require.Len(t, pp, validators+1)
+
+	count, err := storage.removeSlotAt(10)
+	require.NoError(t, err)
+	require.Equal(t, validators, count)
+
+	pp, err = storage.GetAllParticipantsInRange(10, 11)
+	require.NoError(t, err)
+	require.Len(t, pp, 1)
+	require.Equal(t, phase0.Slot(11), pp[0].Slot)
+}
+
+func TestSaveGetProof(t *testing.T) {
+	db, err := kv.NewInMemory(zap.NewNop(), basedb.Options{})
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, err)
+
+	storage := New(db, spectypes.BNRoleAttester).(*participantStorage)
+	pk := spectypes.ValidatorPK{1, 2, 3}
+
+	proof, err := storage.GetProof(pk, 10)
+	require.NoError(t, err)
+	require.Nil(t, proof)
+
+	want := &qbftstorage.ParticipantsProof{
+		Signers:   []spectypes.OperatorID{1, 2, 4},
+		Root:      phase0.Root{0x1, 0x2},
+		Signature: make(spectypes.Signature, blsSignatureSize),
+		Data:      []byte{0x3, 0x4, 0x5},
+	}
+	want.Signature[0] = 0xaa
+	require.NoError(t, storage.SaveProof(pk, 10, want))
+
+	proof, err = storage.GetProof(pk, 10)
+	require.NoError(t, err)
+	require.Equal(t, want, proof)
+
+	require.Error(t, storage.SaveProof(pk, 10, &qbftstorage.ParticipantsProof{Signature: spectypes.Signature{1}}))
+
+	// Proofs are pruned along with participants.
+	_, err = storage.SaveParticipants(pk, 10, want.Signers)
+	require.NoError(t, err)
+	count, err := storage.removeSlotAt(10)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	proof, err = storage.GetProof(pk, 10)
+	require.NoError(t, err)
+	require.Nil(t, proof)
+}
+
 func TestSlotCleanupJob(t *testing.T) {
 	// to test the slot cleanup job we insert 10 unique slots with two pubkey entries
 	// per slot, then we configure the job to retain only 1 slot in the past
@@ -29,6 +29,16 @@ func parseConfigLevelEncoder(levelEncoderName string) zapcore.LevelEncoder {
 	}
 }
 
+// globalLevel is the level of the console core of the global logger.
+// It can be changed at runtime through GlobalLevel.
+var globalLevel = zap.NewAtomicLevel()
+
+// GlobalLevel returns the atomic level of the global logger, which allows changing
+// the log level at runtime. The file core (if any) isn't affected by it and always logs everything.
+func GlobalLevel() zap.AtomicLevel {
+	return globalLevel
+}
+
 func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string, fileOptions *LogFileOptions) (err error) {
 	defer func() {
 		if err == nil {
@@ -47,13 +57,11 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 
 	levelEncoder := parseConfigLevelEncoder(levelEncoderName)
 
-	lv := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl >= level
-	})
+	globalLevel.SetLevel(level)
 
 	cfg := zap.Config{
 		Encoding:    logFormat,
-		Level:       zap.NewAtomicLevelAt(level),
+		Level:       globalLevel,
 		OutputPaths: []string{"stdout"},
 		EncoderConfig: zapcore.EncoderConfig{
 			MessageKey:  "msg",
@@ -75,9 +83,9 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 
 	switch logFormat {
 	case "console":
-		usedcore = zapcore.NewCore(zapcore.NewConsoleEncoder(cfg.EncoderConfig), os.Stdout, lv)
+		usedcore = zapcore.NewCore(zapcore.NewConsoleEncoder(cfg.EncoderConfig), os.Stdout, globalLevel)
 	case "json":
-		usedcore = zapcore.NewCore(zapcore.NewJSONEncoder(cfg.EncoderConfig), os.Stdout, lv)
+		usedcore = zapcore.NewCore(zapcore.NewJSONEncoder(cfg.EncoderConfig), os.Stdout, globalLevel)
 	}
 
 	if fileOptions == nil {
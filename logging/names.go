@@ -16,6 +16,8 @@ const (
 
 	NameBadgerDBLog       = "BadgerDBLog"
 	NameBadgerDBReporting = "BadgerDBReporting"
+	NamePebbleDBLog       = "PebbleDBLog"
+	NamePebbleDBReporting = "PebbleDBReporting"
 	NameCreateThreshold   = "CreateThreshold"
 	NameDiscoveryV5Logger = "DiscoveryV5Logger"
 	NameExportKeys        = "ExportKeys"
@@ -25,4 +27,7 @@ const (
 	NameEventHandler      = "EventHandler"
 	NameDutyFetcher       = "DutyFetcher"
 	NameDoppelganger      = "Doppelganger"
+	NameDutyHistory       = "DutyHistory"
+	NameAudit             = "Audit"
+	NameDecidedSync       = "DecidedSync"
 )
@@ -0,0 +1,133 @@
+package validation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+)
+
+// captureQueueSize bounds the number of records waiting to be written, further records are dropped.
+const captureQueueSize = 4096
+
+// CaptureRecord is a pubsub message received by the message validator.
+type CaptureRecord struct {
+	ReceivedAt time.Time `json:"received_at"`
+	Topic      string    `json:"topic"`
+	PeerID     peer.ID   `json:"peer_id"`
+	// Data is the raw pubsub payload, an encoded SignedSSVMessage.
+	Data []byte `json:"data"`
+}
+
+// CaptureWriter appends the messages received by the message validator to a file, one JSON record per line.
+// Records are written in the background and dropped when the writer falls behind, so that it never blocks validation.
+type CaptureWriter struct {
+	logger  *zap.Logger
+	file    *os.File
+	records chan CaptureRecord
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	closeMu sync.RWMutex
+	closed  bool
+}
+
+// NewCaptureWriter opens the capture file at path, appending to it if it exists.
+func NewCaptureWriter(logger *zap.Logger, path string) (*CaptureWriter, error) {
+	// #nosec G304
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open capture file: %w", err)
+	}
+
+	w := &CaptureWriter{
+		logger:  logger,
+		file:    file,
+		records: make(chan CaptureRecord, captureQueueSize),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+
+	return w, nil
+}
+
+func (w *CaptureWriter) record(rec CaptureRecord) {
+	w.closeMu.RLock()
+	defer w.closeMu.RUnlock()
+
+	if w.closed {
+		return
+	}
+
+	select {
+	case w.records <- rec:
+	default:
+		if w.dropped.Add(1)%captureQueueSize == 1 {
+			w.logger.Warn("dropping captured messages, capture file can't keep up", zap.Uint64("dropped", w.dropped.Load()))
+		}
+	}
+}
+
+func (w *CaptureWriter) run() {
+	defer close(w.done)
+
+	buf := bufio.NewWriter(w.file)
+	encoder := json.NewEncoder(buf)
+	for rec := range w.records {
+		if err := encoder.Encode(rec); err != nil {
+			w.logger.Error("failed to write captured message", zap.Error(err))
+			continue
+		}
+		// Flush once the queue is drained, so that the file is complete up to the last message.
+		if len(w.records) == 0 {
+			if err := buf.Flush(); err != nil {
+				w.logger.Error("failed to flush capture file", zap.Error(err))
+			}
+		}
+	}
+
+	if err := buf.Flush(); err != nil {
+		w.logger.Error("failed to flush capture file", zap.Error(err))
+	}
+}
+
+// Close writes the pending records and closes the file.
+func (w *CaptureWriter) Close() error {
+	w.closeMu.Lock()
+	if w.closed {
+		w.closeMu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.records)
+	w.closeMu.Unlock()
+
+	<-w.done
+	return w.file.Close()
+}
+
+// CaptureReader reads the records written by a CaptureWriter.
+type CaptureReader struct {
+	decoder *json.Decoder
+}
+
+// NewCaptureReader returns a CaptureReader reading from r.
+func NewCaptureReader(r io.Reader) *CaptureReader {
+	return &CaptureReader{decoder: json.NewDecoder(bufio.NewReader(r))}
+}
+
+// Next returns the next record, or io.EOF when there are no more records.
+func (r *CaptureReader) Next() (CaptureRecord, error) {
+	var rec CaptureRecord
+	if err := r.decoder.Decode(&rec); err != nil {
+		return CaptureRecord{}, err
+	}
+	return rec, nil
+}
@@ -134,7 +134,7 @@ var (
 	ErrOperatorValidation                      = Error{text: "failed to validate operator data"}
 )
 
-func (mv *messageValidator) handleValidationError(ctx context.Context, peerID peer.ID, decodedMessage *queue.SSVMessage, err error) pubsub.ValidationResult {
+func (mv *messageValidator) handleValidationError(ctx context.Context, peerID peer.ID, messageID string, decodedMessage *queue.SSVMessage, err error) pubsub.ValidationResult {
 	loggerFields := mv.buildLoggerFields(decodedMessage)
 
 	logger := mv.logger.
@@ -144,6 +144,7 @@ func (mv *messageValidator) handleValidationError(ctx context.Context, peerID pe
 	var valErr Error
 	if !errors.As(err, &valErr) {
 		recordIgnoredMessage(ctx, loggerFields.Role, err.Error())
+		mv.traceDecision(peerID, messageID, loggerFields, err.Error(), false)
 		logger.Debug("ignoring invalid message", zap.Error(err))
 		return pubsub.ValidationIgnore
 	}
@@ -153,6 +154,7 @@ func (mv *messageValidator) handleValidationError(ctx context.Context, peerID pe
 			logger.Debug("ignoring invalid message", zap.Error(valErr))
 		}
 		recordIgnoredMessage(ctx, loggerFields.Role, valErr.Text())
+		mv.traceDecision(peerID, messageID, loggerFields, valErr.Text(), false)
 		return pubsub.ValidationIgnore
 	}
 
@@ -161,6 +163,7 @@ func (mv *messageValidator) handleValidationError(ctx context.Context, peerID pe
 	}
 
 	recordRejectedMessage(ctx, loggerFields.Role, valErr.Text())
+	mv.traceDecision(peerID, messageID, loggerFields, valErr.Text(), true)
 	return pubsub.ValidationReject
 }
 
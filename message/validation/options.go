@@ -22,3 +22,17 @@ func WithSelfAccept(selfPID peer.ID, selfAccept bool) Option {
 		mv.selfAccept = selfAccept
 	}
 }
+
+// WithDecisionTrace records the messages which fail validation in the given DecisionTrace.
+func WithDecisionTrace(trace *DecisionTrace) Option {
+	return func(mv *messageValidator) {
+		mv.decisionTrace = trace
+	}
+}
+
+// WithCapture writes the received messages to the given CaptureWriter, so that they can be replayed with a Replayer.
+func WithCapture(capture *CaptureWriter) Option {
+	return func(mv *messageValidator) {
+		mv.capture = capture
+	}
+}
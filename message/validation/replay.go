@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
+
+	"github.com/ssvlabs/ssv/message/signatureverifier"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+// ReplayResult is the verdict of the message validator on a captured message.
+type ReplayResult struct {
+	Record CaptureRecord
+	Result pubsub.ValidationResult
+	// Reason is the text of the validation error, empty if the message was accepted.
+	Reason string
+}
+
+// Replayer feeds captured messages through a message validator, simulating the clock
+// as if each message was received at its capture time.
+// Messages must be replayed in the order they were captured, since validation is stateful.
+type Replayer struct {
+	mv     *messageValidator
+	beacon *simulatedClockBeacon
+}
+
+// NewReplayer returns a Replayer validating with a new message validator.
+func NewReplayer(
+	netCfg networkconfig.NetworkConfig,
+	validatorStore validatorStore,
+	operators operators,
+	dutyStore *dutystore.Store,
+	signatureVerifier signatureverifier.SignatureVerifier,
+	pectraForkEpoch phase0.Epoch,
+	opts ...Option,
+) *Replayer {
+	simulatedBeacon := &simulatedClockBeacon{BeaconNetwork: netCfg.Beacon}
+	netCfg.Beacon = simulatedBeacon
+
+	return &Replayer{
+		mv:     New(netCfg, validatorStore, operators, dutyStore, signatureVerifier, pectraForkEpoch, opts...).(*messageValidator),
+		beacon: simulatedBeacon,
+	}
+}
+
+// Replay validates a captured message.
+func (r *Replayer) Replay(ctx context.Context, rec CaptureRecord) ReplayResult {
+	r.beacon.now = rec.ReceivedAt
+
+	topic := rec.Topic
+	pmsg := &pubsub.Message{
+		Message: &pspb.Message{
+			Data:  rec.Data,
+			Topic: &topic,
+		},
+		ReceivedFrom: rec.PeerID,
+	}
+
+	decodedMessage, err := r.mv.handlePubsubMessage(pmsg, rec.ReceivedAt)
+	if err != nil {
+		return ReplayResult{
+			Record: rec,
+			Result: r.mv.handleValidationError(ctx, rec.PeerID, "", decodedMessage, err),
+			Reason: errorReason(err),
+		}
+	}
+
+	return ReplayResult{
+		Record: rec,
+		Result: r.mv.handleValidationSuccess(ctx, decodedMessage),
+	}
+}
+
+// errorReason returns the text of a validation error, without its details.
+func errorReason(err error) string {
+	var valErr Error
+	if errors.As(err, &valErr) {
+		return valErr.Text()
+	}
+	return err.Error()
+}
+
+// simulatedClockBeacon estimates the current slot and epoch at a simulated time instead of the wall clock.
+type simulatedClockBeacon struct {
+	beacon.BeaconNetwork
+	now time.Time
+}
+
+func (b *simulatedClockBeacon) EstimatedCurrentSlot() phase0.Slot {
+	return b.EstimatedSlotAtTime(b.now.Unix())
+}
+
+func (b *simulatedClockBeacon) EstimatedCurrentEpoch() phase0.Epoch {
+	return b.EstimatedEpochAtSlot(b.EstimatedCurrentSlot())
+}
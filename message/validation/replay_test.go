@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+)
+
+func TestCaptureWriterAndReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.jsonl")
+	netCfg := networkconfig.TestNetwork
+
+	w, err := NewCaptureWriter(logging.TestLogger(t), path)
+	require.NoError(t, err)
+
+	records := []CaptureRecord{
+		{ReceivedAt: netCfg.Beacon.GetSlotStartTime(1), Topic: "ssv.v2.1", PeerID: generatePeerID(t), Data: []byte{1, 2, 3}},
+		{ReceivedAt: netCfg.Beacon.GetSlotStartTime(2), Topic: "ssv.v2.2", PeerID: generatePeerID(t), Data: []byte{4}},
+	}
+	for _, rec := range records {
+		w.record(rec)
+	}
+	require.NoError(t, w.Close())
+
+	// Records after closing are discarded.
+	w.record(records[0])
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	r := NewCaptureReader(f)
+	for _, want := range records {
+		got, err := r.Next()
+		require.NoError(t, err)
+		require.True(t, want.ReceivedAt.Equal(got.ReceivedAt))
+		require.Equal(t, want.Topic, got.Topic)
+		require.Equal(t, want.PeerID, got.PeerID)
+		require.Equal(t, want.Data, got.Data)
+	}
+	_, err = r.Next()
+	require.True(t, errors.Is(err, io.EOF))
+}
+
+func generatePeerID(t *testing.T) peer.ID {
+	sk, _, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(sk)
+	require.NoError(t, err)
+	return pid
+}
+
+func TestReplayer(t *testing.T) {
+	ctx := context.Background()
+	netCfg := networkconfig.TestNetwork
+
+	const pectraForkEpoch = phase0.Epoch(10)
+	replayer := NewReplayer(netCfg, nil, nil, dutystore.New(), nil, pectraForkEpoch)
+
+	t.Run("empty message", func(t *testing.T) {
+		result := replayer.Replay(ctx, CaptureRecord{ReceivedAt: netCfg.Beacon.EpochStartTime(1), PeerID: "a"})
+		require.Equal(t, pubsub.ValidationReject, result.Result)
+		require.Equal(t, ErrPubSubMessageHasNoData.Text(), result.Reason)
+	})
+
+	t.Run("message size is checked at the captured time", func(t *testing.T) {
+		rec := CaptureRecord{
+			PeerID: "a",
+			Data:   bytes.Repeat([]byte{1}, MaxEncodedMsgSizeBeforePectra+1),
+		}
+
+		rec.ReceivedAt = netCfg.Beacon.EpochStartTime(pectraForkEpoch - 1)
+		result := replayer.Replay(ctx, rec)
+		require.Equal(t, pubsub.ValidationIgnore, result.Result)
+		require.Equal(t, ErrPubSubDataTooBig.Text(), result.Reason)
+
+		rec.ReceivedAt = netCfg.Beacon.EpochStartTime(pectraForkEpoch)
+		result = replayer.Replay(ctx, rec)
+		require.Equal(t, pubsub.ValidationReject, result.Result)
+		require.Equal(t, ErrMalformedPubSubMessage.Text(), result.Reason)
+	})
+}
@@ -0,0 +1,212 @@
+package validation
+
+import (
+	"bytes"
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// maxTracedPeers bounds the number of peers whose reasons are counted,
+// the least recently seen peer is evicted to make room for a new one.
+const maxTracedPeers = 1000
+
+// Decision describes a message which failed validation.
+type Decision struct {
+	Time time.Time
+	// PeerID is the peer which propagated the message.
+	PeerID peer.ID
+	// MessageID is the pubsub message ID.
+	MessageID      string
+	DutyExecutorID []byte
+	Role           spectypes.RunnerRole
+	Slot           phase0.Slot
+	Round          specqbft.Round
+	// Reason is the text of the validation error.
+	Reason string
+	// Reject is set when the message was rejected, otherwise it was ignored.
+	Reject bool
+}
+
+// PeerReasons counts the reasons for which a peer's messages failed validation.
+type PeerReasons struct {
+	PeerID   peer.ID
+	Rejected map[string]uint64
+	Ignored  map[string]uint64
+	LastSeen time.Time
+}
+
+// DecisionFilter selects decisions. Empty fields match all decisions.
+type DecisionFilter struct {
+	PeerID peer.ID
+	// Validator matches the decisions of messages of the validator's non-committee duties.
+	Validator []byte
+	// CommitteeID matches the decisions of messages of the committee's duties.
+	CommitteeID *spectypes.CommitteeID
+	// Limit caps the number of returned decisions, 0 is unlimited.
+	Limit int
+}
+
+func (f DecisionFilter) match(d *Decision) bool {
+	if f.PeerID != "" && d.PeerID != f.PeerID {
+		return false
+	}
+	if f.Validator == nil && f.CommitteeID == nil {
+		return true
+	}
+	if d.Role == spectypes.RoleCommittee {
+		// The duty executor ID of committee messages is the committee ID prefixed with 16 zero bytes.
+		return f.CommitteeID != nil && len(d.DutyExecutorID) == 48 && bytes.Equal(d.DutyExecutorID[16:], f.CommitteeID[:])
+	}
+	return f.Validator != nil && bytes.Equal(d.DutyExecutorID, f.Validator)
+}
+
+// DecisionTrace keeps the most recent messages which failed validation in a ring buffer,
+// along with a per-peer breakdown of the reasons.
+type DecisionTrace struct {
+	mu        sync.Mutex
+	decisions []Decision
+	next      int
+	full      bool
+	peers     map[peer.ID]*PeerReasons
+}
+
+// NewDecisionTrace returns a DecisionTrace keeping the last size decisions.
+func NewDecisionTrace(size int) *DecisionTrace {
+	return &DecisionTrace{
+		decisions: make([]Decision, size),
+		peers:     make(map[peer.ID]*PeerReasons),
+	}
+}
+
+func (t *DecisionTrace) record(d Decision) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.decisions) > 0 {
+		t.decisions[t.next] = d
+		t.next = (t.next + 1) % len(t.decisions)
+		if t.next == 0 {
+			t.full = true
+		}
+	}
+
+	reasons, ok := t.peers[d.PeerID]
+	if !ok {
+		if len(t.peers) >= maxTracedPeers {
+			t.evictPeer()
+		}
+		reasons = &PeerReasons{
+			PeerID:   d.PeerID,
+			Rejected: make(map[string]uint64),
+			Ignored:  make(map[string]uint64),
+		}
+		t.peers[d.PeerID] = reasons
+	}
+	reasons.LastSeen = d.Time
+	if d.Reject {
+		reasons.Rejected[d.Reason]++
+	} else {
+		reasons.Ignored[d.Reason]++
+	}
+}
+
+// evictPeer removes the least recently seen peer.
+func (t *DecisionTrace) evictPeer() {
+	var oldest *PeerReasons
+	for _, reasons := range t.peers {
+		if oldest == nil || reasons.LastSeen.Before(oldest.LastSeen) {
+			oldest = reasons
+		}
+	}
+	if oldest != nil {
+		delete(t.peers, oldest.PeerID)
+	}
+}
+
+// Decisions returns the decisions matching the filter, most recent first.
+func (t *DecisionTrace) Decisions(filter DecisionFilter) []Decision {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count := t.next
+	if t.full {
+		count = len(t.decisions)
+	}
+
+	var result []Decision
+	for i := 1; i <= count; i++ {
+		if filter.Limit > 0 && len(result) >= filter.Limit {
+			break
+		}
+		d := &t.decisions[(t.next-i+len(t.decisions))%len(t.decisions)]
+		if filter.match(d) {
+			result = append(result, *d)
+		}
+	}
+	return result
+}
+
+// PeerReasons returns the reasons of the given peer, or of all peers if it's empty,
+// sorted by the number of rejected messages in descending order.
+func (t *DecisionTrace) PeerReasons(peerID peer.ID) []PeerReasons {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var result []PeerReasons
+	for id, reasons := range t.peers {
+		if peerID != "" && id != peerID {
+			continue
+		}
+		result = append(result, PeerReasons{
+			PeerID:   reasons.PeerID,
+			Rejected: maps.Clone(reasons.Rejected),
+			Ignored:  maps.Clone(reasons.Ignored),
+			LastSeen: reasons.LastSeen,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b PeerReasons) int {
+		if c := cmp.Compare(sum(b.Rejected), sum(a.Rejected)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PeerID, b.PeerID)
+	})
+	return result
+}
+
+func sum(counts map[string]uint64) uint64 {
+	var total uint64
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
+
+func (mv *messageValidator) traceDecision(peerID peer.ID, messageID string, loggerFields *LoggerFields, reason string, reject bool) {
+	if mv.decisionTrace == nil {
+		return
+	}
+
+	d := Decision{
+		Time:           time.Now(),
+		PeerID:         peerID,
+		MessageID:      messageID,
+		DutyExecutorID: loggerFields.DutyExecutorID,
+		Role:           loggerFields.Role,
+		Slot:           loggerFields.Slot,
+		Reason:         reason,
+		Reject:         reject,
+	}
+	if loggerFields.Consensus != nil {
+		d.Round = loggerFields.Consensus.Round
+	}
+	mv.decisionTrace.record(d)
+}
@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecisionTrace(t *testing.T) {
+	validatorPK := spectypes.ValidatorPK{1}
+	committeeID := spectypes.CommitteeID{2}
+	committeeExecutorID := append(make([]byte, 16), committeeID[:]...)
+
+	trace := NewDecisionTrace(3)
+	start := time.Now()
+	trace.record(Decision{Time: start, PeerID: "a", DutyExecutorID: validatorPK[:], Role: spectypes.RoleProposer, Reason: ErrNoDuty.Text()})
+	trace.record(Decision{Time: start.Add(1), PeerID: "b", DutyExecutorID: committeeExecutorID, Role: spectypes.RoleCommittee, Reason: ErrZeroRound.Text(), Reject: true})
+	trace.record(Decision{Time: start.Add(2), PeerID: "a", DutyExecutorID: validatorPK[:], Role: spectypes.RoleProposer, Reason: ErrSignerNotLeader.Text(), Reject: true})
+	trace.record(Decision{Time: start.Add(3), PeerID: "b", DutyExecutorID: committeeExecutorID, Role: spectypes.RoleCommittee, Reason: ErrZeroRound.Text(), Reject: true})
+
+	t.Run("decisions are bounded and most recent first", func(t *testing.T) {
+		decisions := trace.Decisions(DecisionFilter{})
+		require.Len(t, decisions, 3)
+		require.Equal(t, start.Add(3), decisions[0].Time)
+		require.Equal(t, start.Add(1), decisions[2].Time)
+
+		require.Len(t, trace.Decisions(DecisionFilter{Limit: 2}), 2)
+	})
+
+	t.Run("filter by peer", func(t *testing.T) {
+		decisions := trace.Decisions(DecisionFilter{PeerID: "a"})
+		require.Len(t, decisions, 1)
+		require.Equal(t, ErrSignerNotLeader.Text(), decisions[0].Reason)
+	})
+
+	t.Run("filter by validator and committee", func(t *testing.T) {
+		require.Len(t, trace.Decisions(DecisionFilter{Validator: validatorPK[:]}), 1)
+		require.Len(t, trace.Decisions(DecisionFilter{CommitteeID: &committeeID}), 2)
+		require.Len(t, trace.Decisions(DecisionFilter{Validator: validatorPK[:], CommitteeID: &committeeID}), 3)
+		require.Empty(t, trace.Decisions(DecisionFilter{Validator: []byte{3}}))
+	})
+
+	t.Run("peer reasons count all decisions", func(t *testing.T) {
+		reasons := trace.PeerReasons("")
+		require.Len(t, reasons, 2)
+		require.Equal(t, peer.ID("b"), reasons[0].PeerID)
+		require.Equal(t, map[string]uint64{ErrZeroRound.Text(): 2}, reasons[0].Rejected)
+
+		reasons = trace.PeerReasons("a")
+		require.Len(t, reasons, 1)
+		require.Equal(t, map[string]uint64{ErrSignerNotLeader.Text(): 1}, reasons[0].Rejected)
+		require.Equal(t, map[string]uint64{ErrNoDuty.Text(): 1}, reasons[0].Ignored)
+	})
+}
+
+func TestDecisionTrace_EvictsPeers(t *testing.T) {
+	trace := NewDecisionTrace(0)
+	start := time.Now()
+	for i := 0; i <= maxTracedPeers; i++ {
+		trace.record(Decision{Time: start.Add(time.Duration(i)), PeerID: peer.ID(fmt.Sprint(i)), Reason: ErrNoDuty.Text()})
+	}
+
+	require.Empty(t, trace.Decisions(DecisionFilter{}))
+	require.Len(t, trace.PeerReasons(""), maxTracedPeers)
+	require.Empty(t, trace.PeerReasons("0"))
+}
@@ -74,6 +74,9 @@ type messageValidator struct {
 
 	selfPID    peer.ID
 	selfAccept bool
+
+	decisionTrace *DecisionTrace
+	capture       *CaptureWriter
 }
 
 // New returns a new MessageValidator with the given network configuration and options.
@@ -128,9 +131,19 @@ func (mv *messageValidator) Validate(ctx context.Context, peerID peer.ID, pmsg *
 
 	recordMessage(ctx)
 
-	decodedMessage, err := mv.handlePubsubMessage(pmsg, time.Now())
+	receivedAt := time.Now()
+	if mv.capture != nil {
+		mv.capture.record(CaptureRecord{
+			ReceivedAt: receivedAt,
+			Topic:      pmsg.GetTopic(),
+			PeerID:     peerID,
+			Data:       pmsg.GetData(),
+		})
+	}
+
+	decodedMessage, err := mv.handlePubsubMessage(pmsg, receivedAt)
 	if err != nil {
-		return mv.handleValidationError(ctx, peerID, decodedMessage, err)
+		return mv.handleValidationError(ctx, peerID, pmsg.ID, decodedMessage, err)
 	}
 
 	pmsg.ValidatorData = decodedMessage
@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+const migration_8_batchSize = 1000
+
+var (
+	migration_8_oldParticipantsPrefix = []byte("pt")
+	migration_8_newParticipantsPrefix = []byte("ps")
+)
+
+// migration_8_participants_slot_order re-encodes the slot in participants keys in big-endian,
+// so that keys are ordered by slot and slot ranges can be read with a single range read.
+// Keys are made of the role (1 byte), the slot (4 bytes) and the validator public key.
+// They're moved to a new prefix, since a little-endian slot may equal the big-endian encoding of another slot.
+var migration_8_participants_slot_order = Migration{
+	Name: "migration_8_participants_slot_order",
+	Run: func(ctx context.Context, logger *zap.Logger, opt Options, key []byte, completed CompletedFunc) error {
+		var total int
+		for {
+			var batch []basedb.Obj
+			err := opt.Db.GetRange(migration_8_oldParticipantsPrefix, basedb.RangeOptions{Limit: migration_8_batchSize}, func(obj basedb.Obj) error {
+				batch = append(batch, obj)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("get participants: %w", err)
+			}
+
+			if len(batch) == 0 {
+				break
+			}
+
+			err = opt.Db.Update(func(txn basedb.Txn) error {
+				for _, obj := range batch {
+					if len(obj.Key) >= 5 {
+						newKey := slices.Clone(obj.Key)
+						binary.BigEndian.PutUint32(newKey[1:5], binary.LittleEndian.Uint32(obj.Key[1:5]))
+						if err := txn.Set(migration_8_newParticipantsPrefix, newKey, obj.Value); err != nil {
+							return fmt.Errorf("set participants: %w", err)
+						}
+					}
+
+					if err := txn.Delete(migration_8_oldParticipantsPrefix, obj.Key); err != nil {
+						return fmt.Errorf("delete participants: %w", err)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			total += len(batch)
+		}
+
+		logger.Info("re-encoded participants keys", fields.Count(total))
+
+		return completed(opt.Db)
+	},
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+func TestMigration8ParticipantsSlotOrder(t *testing.T) {
+	db, logger := setupTest(t)
+
+	pk := spectypes.ValidatorPK{1, 2, 3}
+	role := spectypes.BNRoleProposer
+	signers := []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}
+
+	// 256 and 1 swap places when encoded in little-endian.
+	slots := []phase0.Slot{1, 256, 1000}
+	for _, slot := range slots {
+		key := make([]byte, 5, 5+len(pk))
+		key[0] = byte(role)
+		binary.LittleEndian.PutUint32(key[1:], uint32(slot))
+		key = append(key, pk[:]...)
+		require.NoError(t, db.Set(migration_8_oldParticipantsPrefix, key, signers))
+	}
+
+	err := migration_8_participants_slot_order.Run(t.Context(), logger, Options{Db: db},
+		[]byte(migration_8_participants_slot_order.Name),
+		func(rw basedb.ReadWriter) error { return nil })
+	require.NoError(t, err)
+
+	count, err := db.CountPrefix(migration_8_oldParticipantsPrefix)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	store := ibftstorage.New(db, role)
+
+	entries, err := store.GetAllParticipantsInRange(1, 1000)
+	require.NoError(t, err)
+	require.Len(t, entries, len(slots))
+	for i, entry := range entries {
+		require.Equal(t, slots[i], entry.Slot)
+		require.Equal(t, pk, entry.PubKey)
+		require.Equal(t, []spectypes.OperatorID{1, 2}, entry.Signers)
+	}
+
+	entries, err = store.GetParticipantsInRange(pk, 2, 999)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, phase0.Slot(256), entries[0].Slot)
+}
@@ -30,6 +30,7 @@ var (
 		migration_5_change_share_format_from_gob_to_ssz,
 		migration_6_share_exit_epoch,
 		migration_7_derive_signer_key_with_hkdf,
+		migration_8_participants_slot_order,
 	}
 )
 
@@ -38,6 +39,56 @@ func Run(ctx context.Context, logger *zap.Logger, opt Options) (applied int, err
 	return defaultMigrations.Run(ctx, logger.Named("Migrations"), opt)
 }
 
+// Version returns the number of default migrations known to this binary.
+// A database with a higher applied version was migrated by a newer binary.
+func Version() int {
+	return len(defaultMigrations)
+}
+
+// AppliedVersion returns the number of default migrations applied to the database, in order.
+func AppliedVersion(r basedb.Reader) (int, error) {
+	return defaultMigrations.appliedVersion(r)
+}
+
+// Status is whether a migration was applied to the database.
+type Status struct {
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+}
+
+// Statuses returns the status of each default migration, in order.
+func Statuses(r basedb.Reader) ([]Status, error) {
+	return defaultMigrations.statuses(r)
+}
+
+func (m Migrations) statuses(r basedb.Reader) ([]Status, error) {
+	statuses := make([]Status, 0, len(m))
+	for _, migration := range m {
+		obj, _, err := r.Get(migrationsPrefix, []byte(migration.Name))
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, Status{
+			Name:    migration.Name,
+			Applied: bytes.Equal(obj.Value, migrationCompleted),
+		})
+	}
+	return statuses, nil
+}
+
+func (m Migrations) appliedVersion(r basedb.Reader) (int, error) {
+	statuses, err := m.statuses(r)
+	if err != nil {
+		return 0, err
+	}
+	for i, status := range statuses {
+		if !status.Applied {
+			return i, nil
+		}
+	}
+	return len(statuses), nil
+}
+
 // CompletedFunc is a function that marks a migration as completed.
 type CompletedFunc func(rw basedb.ReadWriter) error
 
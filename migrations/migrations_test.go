@@ -107,6 +107,43 @@ func Test_NextMigrationNotExecutedOnFailure(t *testing.T) {
 	require.False(t, found)
 }
 
+func Test_AppliedVersion(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.TestLogger(t)
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+
+	migrations := Migrations{
+		fakeMigration("first", nil),
+		fakeMigration("second", nil),
+		fakeMigration("third", nil),
+	}
+
+	version, err := migrations.appliedVersion(opt.Db)
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+
+	_, err = migrations[:2].Run(ctx, logger, opt)
+	require.NoError(t, err)
+	version, err = migrations.appliedVersion(opt.Db)
+	require.NoError(t, err)
+	require.Equal(t, 2, version)
+
+	statuses, err := migrations.statuses(opt.Db)
+	require.NoError(t, err)
+	require.Equal(t, []Status{
+		{Name: "first", Applied: true},
+		{Name: "second", Applied: true},
+		{Name: "third", Applied: false},
+	}, statuses)
+
+	_, err = migrations.Run(ctx, logger, opt)
+	require.NoError(t, err)
+	version, err = migrations.appliedVersion(opt.Db)
+	require.NoError(t, err)
+	require.Equal(t, 3, version)
+}
+
 func fakeMigration(name string, returnErr error) Migration {
 	return Migration{
 		Name: name,
@@ -32,6 +32,15 @@ func CheckAddress(addr string) error {
 	return nil
 }
 
+// BuildQUICMultiAddress creates a QUIC multiaddr listening on the given UDP port
+func BuildQUICMultiAddress(ipAddr string, port uint) (ma.Multiaddr, error) {
+	udpAddr, err := BuildMultiAddress(ipAddr, "udp", port, "")
+	if err != nil {
+		return nil, err
+	}
+	return udpAddr.Encapsulate(ma.StringCast("/quic-v1")), nil
+}
+
 // BuildMultiAddress creates a multiaddr from the given params
 func BuildMultiAddress(ipAddr, protocol string, port uint, id peer.ID) (ma.Multiaddr, error) {
 	parsedIP := net.ParseIP(ipAddr)
@@ -0,0 +1,68 @@
+package decidedsync
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+const (
+	// ProtocolID is the protocol.ID of the decided history sync protocol.
+	ProtocolID = "/ssv/sync/decided/0.0.1"
+
+	// maxRequestSlots bounds the slot range of a single request, so that serving it stays cheap.
+	// Longer ranges are split into multiple requests.
+	maxRequestSlots = 64
+)
+
+// Request asks for the decided participants of a role, of either a validator or all validators of a committee,
+// in an inclusive slot range.
+type Request struct {
+	Role        spectypes.BeaconRole   `json:"role"`
+	PubKey      *spectypes.ValidatorPK `json:"pubkey,omitempty"`
+	CommitteeID *spectypes.CommitteeID `json:"committee_id,omitempty"`
+	From        phase0.Slot            `json:"from"`
+	To          phase0.Slot            `json:"to"`
+
+	// After resumes a truncated response: entries at From of validators up to and including After are skipped.
+	After *spectypes.ValidatorPK `json:"after,omitempty"`
+}
+
+func (r *Request) validate() error {
+	if (r.PubKey == nil) == (r.CommitteeID == nil) {
+		return errors.New("exactly one of pubkey and committee_id must be set")
+	}
+	if r.From > r.To {
+		return fmt.Errorf("from slot %d is after to slot %d", r.From, r.To)
+	}
+	if r.To-r.From >= maxRequestSlots {
+		return fmt.Errorf("slot range exceeds %d slots", maxRequestSlots)
+	}
+	return nil
+}
+
+// Entry holds the participants of a decided duty along with their proof:
+// Signature is the aggregation of the post-consensus partial signatures of Signers over Root,
+// which is the signing root of Data, the SSZ encoding of the signed beacon object of the role.
+type Entry struct {
+	Slot      phase0.Slot            `json:"slot"`
+	PubKey    spectypes.ValidatorPK  `json:"pubkey"`
+	Signers   []spectypes.OperatorID `json:"signers"`
+	Root      phase0.Root            `json:"root"`
+	Signature spectypes.Signature    `json:"signature"`
+	Data      []byte                 `json:"data"`
+}
+
+// Response holds the entries matching a Request, ordered by slot and validator public key.
+type Response struct {
+	Entries []Entry `json:"entries"`
+
+	// Truncated is set when the response was capped by the server's batch size,
+	// the rest is requested again starting after the last entry.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Error is set when the request couldn't be served.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,126 @@
+package decidedsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/ibft/storage"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+// Server serves decided history requests from the participant stores.
+type Server struct {
+	stores           *storage.ParticipantStores
+	validatorStore   registrystorage.BaseValidatorStore
+	maxBatchResponse uint64
+}
+
+// NewServer returns a Server responding with at most maxBatchResponse entries per request.
+func NewServer(
+	stores *storage.ParticipantStores,
+	validatorStore registrystorage.BaseValidatorStore,
+	maxBatchResponse uint64,
+) *Server {
+	return &Server{
+		stores:           stores,
+		validatorStore:   validatorStore,
+		maxBatchResponse: maxBatchResponse,
+	}
+}
+
+// Handle serves an encoded Request and returns the encoded Response.
+func (s *Server) Handle(data []byte) []byte {
+	resp, err := s.serve(data)
+	if err != nil {
+		resp = &Response{Error: err.Error()}
+	}
+
+	encoded, err := json.Marshal(resp)
+	if err != nil {
+		// Unreachable, the response only holds JSON-encodable types.
+		return []byte(fmt.Sprintf(`{"error":%q}`, err.Error()))
+	}
+	return encoded
+}
+
+func (s *Server) serve(data []byte) (*Response, error) {
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+	if err := req.validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	store := s.stores.Get(req.Role)
+	if store == nil || !verifiableRole(req.Role) {
+		return nil, fmt.Errorf("unsupported role %s", req.Role)
+	}
+
+	pubKeys, err := s.pubKeys(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Response{Entries: []Entry{}}
+	for slot := req.From; ; slot++ {
+		for _, pk := range pubKeys {
+			if slot == req.From && req.After != nil && bytes.Compare(pk[:], req.After[:]) <= 0 {
+				continue
+			}
+
+			proof, err := store.GetProof(pk, slot)
+			if err != nil {
+				return nil, fmt.Errorf("get proof: %w", err)
+			}
+			if proof == nil {
+				continue
+			}
+
+			if uint64(len(resp.Entries)) >= s.maxBatchResponse {
+				resp.Truncated = true
+				return resp, nil
+			}
+
+			resp.Entries = append(resp.Entries, Entry{
+				Slot:      slot,
+				PubKey:    pk,
+				Signers:   proof.Signers,
+				Root:      proof.Root,
+				Signature: proof.Signature,
+				Data:      proof.Data,
+			})
+		}
+
+		// Checked here rather than in the loop condition, so that slot can't overflow.
+		if slot == req.To {
+			return resp, nil
+		}
+	}
+}
+
+// pubKeys returns the public keys of the requested validators, sorted.
+func (s *Server) pubKeys(req Request) ([]spectypes.ValidatorPK, error) {
+	if req.PubKey != nil {
+		return []spectypes.ValidatorPK{*req.PubKey}, nil
+	}
+
+	committee, ok := s.validatorStore.Committee(*req.CommitteeID)
+	if !ok {
+		return nil, fmt.Errorf("unknown committee")
+	}
+
+	pubKeys := make([]spectypes.ValidatorPK, 0, len(committee.Validators))
+	for _, share := range committee.Validators {
+		pubKeys = append(pubKeys, share.ValidatorPubKey)
+	}
+	slices.SortFunc(pubKeys, func(a, b spectypes.ValidatorPK) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	return pubKeys, nil
+}
@@ -0,0 +1,368 @@
+package decidedsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/operator/slotticker"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+const (
+	// peersPollInterval is how often the Syncer checks for peers serving decided history while there are none.
+	peersPollInterval = 6 * time.Second
+	// gapsQueueSize bounds the number of slot ranges waiting to be backfilled.
+	gapsQueueSize = 16
+)
+
+var (
+	checkpointPrefix = []byte("decided_sync/")
+	checkpointKey    = []byte("checkpoint")
+)
+
+// ErrNoPeers is returned when no connected peer serves decided history.
+var ErrNoPeers = errors.New("no peers serving decided history")
+
+var errUnknownValidator = errors.New("unknown validator")
+
+// Network sends the decided history requests of the Syncer.
+type Network interface {
+	// DecidedHistoryPeers returns the connected peers which serve decided history.
+	DecidedHistoryPeers() []peer.ID
+	// RequestDecidedHistory sends an encoded Request to the peer and returns its encoded Response.
+	RequestDecidedHistory(peerID peer.ID, request []byte) ([]byte, error)
+}
+
+// SyncerOptions contains the configuration options for the Syncer.
+type SyncerOptions struct {
+	Logger         *zap.Logger
+	Network        Network
+	Stores         *storage.ParticipantStores
+	ValidatorStore registrystorage.BaseValidatorStore
+	BeaconNetwork  beacon.BeaconNetwork
+	// Domains provides the signature domains needed to verify the signed data of entries.
+	Domains DomainProvider
+
+	// DB persists the last slot the node ran at, so that the slots missed while it was down are backfilled.
+	DB basedb.Database
+	// MaxBatchResponse is the maximum number of entries accepted in a single response.
+	MaxBatchResponse uint64
+	// Retain is the number of slots kept by the participant stores, older slots aren't backfilled.
+	Retain phase0.Slot
+}
+
+// Syncer fetches decided participants from peers, verifies them and backfills the participant stores.
+type Syncer struct {
+	logger           *zap.Logger
+	network          Network
+	stores           *storage.ParticipantStores
+	validatorStore   registrystorage.BaseValidatorStore
+	beaconNetwork    beacon.BeaconNetwork
+	domains          DomainProvider
+	db               basedb.Database
+	maxBatchResponse uint64
+	retain           phase0.Slot
+}
+
+// NewSyncer returns a new Syncer.
+func NewSyncer(opts SyncerOptions) *Syncer {
+	return &Syncer{
+		logger:           opts.Logger,
+		network:          opts.Network,
+		stores:           opts.Stores,
+		validatorStore:   opts.ValidatorStore,
+		beaconNetwork:    opts.BeaconNetwork,
+		domains:          opts.Domains,
+		db:               opts.DB,
+		maxBatchResponse: opts.MaxBatchResponse,
+		retain:           opts.Retain,
+	}
+}
+
+type slotRange struct {
+	from, to phase0.Slot
+}
+
+// Run records the slot the node is running at on every tick, and backfills the slots it missed
+// while it was down or stalled.
+func (s *Syncer) Run(ctx context.Context, slotTickerProvider slotticker.Provider) {
+	gaps := make(chan slotRange, gapsQueueSize)
+	go s.backfillGaps(ctx, gaps)
+
+	checkpoint, found, err := s.loadCheckpoint()
+	if err != nil {
+		s.logger.Error("failed to load decided sync checkpoint", zap.Error(err))
+	}
+
+	ticker := slotTickerProvider()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Next():
+			slot := ticker.Slot()
+
+			if found && slot > checkpoint+1 {
+				gap := slotRange{from: checkpoint + 1, to: slot - 1}
+				if slot > s.retain && gap.from < slot-s.retain {
+					gap.from = slot - s.retain
+				}
+
+				select {
+				case gaps <- gap:
+				default:
+					s.logger.Warn("dropping decided history gap, too many gaps are pending",
+						zap.Uint64("from", uint64(gap.from)),
+						zap.Uint64("to", uint64(gap.to)),
+					)
+				}
+			}
+
+			checkpoint, found = slot, true
+			if err := s.saveCheckpoint(slot); err != nil {
+				s.logger.Error("failed to save decided sync checkpoint", fields.Slot(slot), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Syncer) backfillGaps(ctx context.Context, gaps <-chan slotRange) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case gap := <-gaps:
+			if err := s.waitForPeers(ctx); err != nil {
+				return
+			}
+
+			logger := s.logger.With(zap.Uint64("from", uint64(gap.from)), zap.Uint64("to", uint64(gap.to)))
+			logger.Info("backfilling decided history")
+
+			start := time.Now()
+			stored, err := s.Backfill(ctx, gap.from, gap.to)
+			if err != nil {
+				logger.Warn("failed to backfill decided history", zap.Int("stored", stored), zap.Error(err))
+				continue
+			}
+			logger.Info("backfilled decided history", zap.Int("stored", stored), fields.Took(time.Since(start)))
+		}
+	}
+}
+
+func (s *Syncer) waitForPeers(ctx context.Context) error {
+	for len(s.network.DecidedHistoryPeers()) == 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(peersPollInterval):
+		}
+	}
+	return nil
+}
+
+// Backfill syncs the decided history of all verifiable roles of all committees in the inclusive slot range.
+// Returns the number of stored entries.
+func (s *Syncer) Backfill(ctx context.Context, from, to phase0.Slot) (int, error) {
+	committees := s.validatorStore.Committees()
+
+	var total int
+	err := s.stores.Each(func(role spectypes.BeaconRole, _ qbftstorage.ParticipantStore) error {
+		if !verifiableRole(role) {
+			return nil
+		}
+		for _, committee := range committees {
+			committeeID := committee.ID
+			stored, err := s.Sync(ctx, Request{Role: role, CommitteeID: &committeeID, From: from, To: to})
+			total += stored
+			if err != nil {
+				if ctx.Err() != nil || errors.Is(err, ErrNoPeers) {
+					return err
+				}
+				s.logger.Debug("failed to sync decided history of committee",
+					fields.CommitteeID(committeeID),
+					zap.String("role", role.String()),
+					zap.Error(err),
+				)
+			}
+		}
+		return nil
+	})
+
+	return total, err
+}
+
+// Sync fetches the decided participants matching the request from peers, verifies and stores them.
+// The slot range may be of any length, it's split into multiple requests.
+// Returns the number of stored entries.
+func (s *Syncer) Sync(ctx context.Context, req Request) (int, error) {
+	if s.stores.Get(req.Role) == nil || !verifiableRole(req.Role) {
+		return 0, fmt.Errorf("unsupported role %s", req.Role)
+	}
+	if req.From > req.To {
+		return 0, fmt.Errorf("from slot %d is after to slot %d", req.From, req.To)
+	}
+
+	var total int
+	for from := req.From; ; from += maxRequestSlots {
+		chunk := req
+		chunk.From = from
+		if req.To-from >= maxRequestSlots {
+			chunk.To = from + maxRequestSlots - 1
+		}
+		if err := chunk.validate(); err != nil {
+			return total, fmt.Errorf("invalid request: %w", err)
+		}
+
+		stored, err := s.syncChunk(ctx, chunk)
+		total += stored
+		if err != nil {
+			return total, err
+		}
+
+		if chunk.To == req.To {
+			return total, nil
+		}
+	}
+}
+
+// syncChunk syncs a request peers serve, trying one peer after the other until one succeeds.
+func (s *Syncer) syncChunk(ctx context.Context, req Request) (int, error) {
+	peers := s.network.DecidedHistoryPeers()
+	if len(peers) == 0 {
+		return 0, ErrNoPeers
+	}
+	rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+
+	var total int
+	var errs error
+	for _, peerID := range peers {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		stored, err := s.syncChunkFrom(peerID, req)
+		total += stored
+		if err == nil {
+			return total, nil
+		}
+
+		s.logger.Debug("failed to sync decided history from peer", fields.PeerID(peerID), zap.Error(err))
+		errs = errors.Join(errs, fmt.Errorf("peer %s: %w", peerID, err))
+	}
+
+	return total, errs
+}
+
+func (s *Syncer) syncChunkFrom(peerID peer.ID, req Request) (int, error) {
+	store := s.stores.Get(req.Role)
+	domains := newDomainMemo(s.beaconNetwork, s.domains)
+
+	var stored int
+	for {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return stored, fmt.Errorf("encode request: %w", err)
+		}
+
+		respData, err := s.network.RequestDecidedHistory(peerID, data)
+		if err != nil {
+			return stored, fmt.Errorf("request: %w", err)
+		}
+
+		var resp Response
+		if err := json.Unmarshal(respData, &resp); err != nil {
+			return stored, fmt.Errorf("decode response: %w", err)
+		}
+		if resp.Error != "" {
+			return stored, fmt.Errorf("peer responded with error: %s", resp.Error)
+		}
+		if uint64(len(resp.Entries)) > s.maxBatchResponse {
+			return stored, fmt.Errorf("response has %d entries, more than %d", len(resp.Entries), s.maxBatchResponse)
+		}
+
+		// Entries must be strictly ordered after the cursor, so that a peer can't keep the Syncer paging forever.
+		cursorSlot, cursorPK := req.From, req.After
+		for _, entry := range resp.Entries {
+			if cursorPK != nil && !entryAfter(entry, cursorSlot, *cursorPK) {
+				return stored, fmt.Errorf("entries aren't ordered")
+			}
+			cursorSlot, cursorPK = entry.Slot, &entry.PubKey
+
+			if err := verifyEntry(s.validatorStore, domains, req, entry); err != nil {
+				if errors.Is(err, errUnknownValidator) {
+					// The peer may know validators this node doesn't know yet.
+					continue
+				}
+				return stored, fmt.Errorf("invalid entry of validator %x at slot %d: %w", entry.PubKey[:], entry.Slot, err)
+			}
+
+			updated, err := store.SaveParticipants(entry.PubKey, entry.Slot, entry.Signers)
+			if err != nil {
+				return stored, fmt.Errorf("save participants: %w", err)
+			}
+			if !updated {
+				continue
+			}
+
+			err = store.SaveProof(entry.PubKey, entry.Slot, &qbftstorage.ParticipantsProof{
+				Signers:   entry.Signers,
+				Root:      entry.Root,
+				Signature: entry.Signature,
+				Data:      entry.Data,
+			})
+			if err != nil {
+				return stored, fmt.Errorf("save participants proof: %w", err)
+			}
+			stored++
+		}
+
+		if !resp.Truncated || len(resp.Entries) == 0 {
+			return stored, nil
+		}
+
+		last := resp.Entries[len(resp.Entries)-1]
+		req.From, req.After = last.Slot, &last.PubKey
+	}
+}
+
+// entryAfter returns whether the entry is ordered after the given slot and validator.
+func entryAfter(entry Entry, slot phase0.Slot, pubKey spectypes.ValidatorPK) bool {
+	if entry.Slot != slot {
+		return entry.Slot > slot
+	}
+	return bytes.Compare(entry.PubKey[:], pubKey[:]) > 0
+}
+
+func (s *Syncer) loadCheckpoint() (phase0.Slot, bool, error) {
+	obj, found, err := s.db.Get(checkpointPrefix, checkpointKey)
+	if err != nil || !found {
+		return 0, false, err
+	}
+	if len(obj.Value) != 8 {
+		return 0, false, fmt.Errorf("invalid checkpoint length %d", len(obj.Value))
+	}
+	return phase0.Slot(binary.BigEndian.Uint64(obj.Value)), true, nil
+}
+
+func (s *Syncer) saveCheckpoint(slot phase0.Slot) error {
+	return s.db.Set(checkpointPrefix, checkpointKey, binary.BigEndian.AppendUint64(nil, uint64(slot)))
+}
@@ -0,0 +1,401 @@
+package decidedsync
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/slotticker"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/registry/storage/mocks"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+const testRole = spectypes.BNRoleAttester
+
+type testNetwork struct {
+	servers  map[peer.ID]*Server
+	requests int
+	// tamper optionally modifies responses.
+	tamper func(*Response)
+}
+
+func (n *testNetwork) DecidedHistoryPeers() []peer.ID {
+	return slices.Collect(maps.Keys(n.servers))
+}
+
+func (n *testNetwork) RequestDecidedHistory(peerID peer.ID, request []byte) ([]byte, error) {
+	n.requests++
+	response := n.servers[peerID].Handle(request)
+	if n.tamper == nil {
+		return response, nil
+	}
+
+	var resp Response
+	if err := json.Unmarshal(response, &resp); err != nil {
+		return nil, err
+	}
+	n.tamper(&resp)
+	return json.Marshal(resp)
+}
+
+type testValidator struct {
+	share     *ssvtypes.SSVShare
+	shareKeys map[spectypes.OperatorID]*bls.SecretKey
+}
+
+func newTestValidator(t *testing.T, pk spectypes.ValidatorPK) *testValidator {
+	require.NoError(t, bls.Init(bls.BLS12_381))
+
+	v := &testValidator{
+		share:     &ssvtypes.SSVShare{Share: spectypes.Share{ValidatorPubKey: pk}},
+		shareKeys: make(map[spectypes.OperatorID]*bls.SecretKey),
+	}
+	for signer := spectypes.OperatorID(1); signer <= 4; signer++ {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		v.shareKeys[signer] = sk
+		v.share.Committee = append(v.share.Committee, &spectypes.ShareMember{
+			Signer:      signer,
+			SharePubKey: sk.GetPublicKey().Serialize(),
+		})
+	}
+	return v
+}
+
+// sign returns the aggregated signature of the signers over the root.
+func (v *testValidator) sign(root phase0.Root, signers ...spectypes.OperatorID) spectypes.Signature {
+	sigs := make([]bls.Sign, len(signers))
+	for i, signer := range signers {
+		sigs[i] = *v.shareKeys[signer].SignByte(root[:])
+	}
+	var aggregated bls.Sign
+	aggregated.Aggregate(sigs)
+	return aggregated.Serialize()
+}
+
+// proof returns the proof of the signers attesting at the slot.
+func (v *testValidator) proof(t *testing.T, slot phase0.Slot, signers ...spectypes.OperatorID) *qbftstorage.ParticipantsProof {
+	data := &phase0.AttestationData{
+		Slot:            slot,
+		BeaconBlockRoot: phase0.Root{byte(slot)},
+		Source:          &phase0.Checkpoint{},
+		Target:          &phase0.Checkpoint{},
+	}
+	encoded, err := data.MarshalSSZ()
+	require.NoError(t, err)
+
+	domain, err := testDomains{}.DomainData(0, spectypes.DomainAttester)
+	require.NoError(t, err)
+	root, err := spectypes.ComputeETHSigningRoot(data, domain)
+	require.NoError(t, err)
+
+	return &qbftstorage.ParticipantsProof{
+		Signers:   signers,
+		Root:      root,
+		Signature: v.sign(root, signers...),
+		Data:      encoded,
+	}
+}
+
+// testDomains returns a distinct domain per domain type.
+type testDomains struct{}
+
+func (testDomains) DomainData(_ phase0.Epoch, domainType phase0.DomainType) (phase0.Domain, error) {
+	var domain phase0.Domain
+	copy(domain[:], domainType[:])
+	return domain, nil
+}
+
+func newTestStores(t *testing.T) *storage.ParticipantStores {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return storage.NewStoresFromRoles(db, testRole)
+}
+
+func newTestValidatorStore(t *testing.T, validators ...*testValidator) registrystorage.BaseValidatorStore {
+	validatorStore := mocks.NewMockBaseValidatorStore(gomock.NewController(t))
+
+	committee := &registrystorage.Committee{ID: validators[0].share.CommitteeID()}
+	for _, v := range validators {
+		committee.Validators = append(committee.Validators, v.share)
+	}
+
+	validatorStore.EXPECT().Validator(gomock.Any()).DoAndReturn(func(pubKey []byte) (*ssvtypes.SSVShare, bool) {
+		for _, v := range validators {
+			if spectypes.ValidatorPK(pubKey) == v.share.ValidatorPubKey {
+				return v.share, true
+			}
+		}
+		return nil, false
+	}).AnyTimes()
+	validatorStore.EXPECT().Committee(committee.ID).Return(committee, true).AnyTimes()
+	validatorStore.EXPECT().Committee(gomock.Any()).Return(nil, false).AnyTimes()
+	validatorStore.EXPECT().Committees().Return([]*registrystorage.Committee{committee}).AnyTimes()
+
+	return validatorStore
+}
+
+// setup returns a syncer with an empty store, requesting from a server which has decided slots 10 to 12
+// of two validators in the same committee.
+func setup(t *testing.T, maxBatchResponse uint64) (*Syncer, *testNetwork, []*testValidator, *storage.ParticipantStores) {
+	validators := []*testValidator{
+		newTestValidator(t, spectypes.ValidatorPK{1}),
+		newTestValidator(t, spectypes.ValidatorPK{2}),
+	}
+	validatorStore := newTestValidatorStore(t, validators...)
+
+	serverStores := newTestStores(t)
+	for slot := phase0.Slot(10); slot <= 12; slot++ {
+		for _, v := range validators {
+			proof := v.proof(t, slot, 1, 2, 3)
+			_, err := serverStores.Get(testRole).SaveParticipants(v.share.ValidatorPubKey, slot, proof.Signers)
+			require.NoError(t, err)
+			require.NoError(t, serverStores.Get(testRole).SaveProof(v.share.ValidatorPubKey, slot, proof))
+		}
+	}
+
+	network := &testNetwork{servers: map[peer.ID]*Server{
+		"server": NewServer(serverStores, validatorStore, maxBatchResponse),
+	}}
+
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	stores := newTestStores(t)
+	syncer := NewSyncer(SyncerOptions{
+		Logger:           logging.TestLogger(t),
+		Network:          network,
+		Stores:           stores,
+		ValidatorStore:   validatorStore,
+		BeaconNetwork:    networkconfig.TestNetwork.Beacon,
+		Domains:          testDomains{},
+		DB:               db,
+		MaxBatchResponse: maxBatchResponse,
+		Retain:           100,
+	})
+
+	return syncer, network, validators, stores
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("validator", func(t *testing.T) {
+		syncer, network, validators, stores := setup(t, 25)
+
+		pk := validators[0].share.ValidatorPubKey
+		stored, err := syncer.Sync(ctx, Request{Role: testRole, PubKey: &pk, From: 0, To: 200})
+		require.NoError(t, err)
+		require.Equal(t, 3, stored)
+		// The range is split into requests of at most maxRequestSlots slots.
+		require.Equal(t, 4, network.requests)
+
+		participants, err := stores.Get(testRole).GetParticipantsInRange(pk, 0, 200)
+		require.NoError(t, err)
+		require.Len(t, participants, 3)
+
+		proof, err := stores.Get(testRole).GetProof(pk, 11)
+		require.NoError(t, err)
+		require.Equal(t, validators[0].proof(t, 11, 1, 2, 3), proof)
+
+		participants, err = stores.Get(testRole).GetParticipantsInRange(validators[1].share.ValidatorPubKey, 0, 200)
+		require.NoError(t, err)
+		require.Empty(t, participants)
+	})
+
+	t.Run("committee with truncated responses", func(t *testing.T) {
+		syncer, network, validators, stores := setup(t, 4)
+
+		committeeID := validators[0].share.CommitteeID()
+		stored, err := syncer.Sync(ctx, Request{Role: testRole, CommitteeID: &committeeID, From: 10, To: 12})
+		require.NoError(t, err)
+		require.Equal(t, 6, stored)
+		require.Equal(t, 2, network.requests)
+
+		entries, err := stores.Get(testRole).GetAllParticipantsInRange(10, 12)
+		require.NoError(t, err)
+		require.Len(t, entries, 6)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		syncer, network, validators, stores := setup(t, 25)
+		network.tamper = func(resp *Response) {
+			for i := range resp.Entries {
+				resp.Entries[i].Signature = validators[0].sign(phase0.Root{0xff}, 1, 2, 3)
+			}
+		}
+
+		pk := validators[0].share.ValidatorPubKey
+		_, err := syncer.Sync(ctx, Request{Role: testRole, PubKey: &pk, From: 10, To: 12})
+		require.ErrorContains(t, err, "invalid aggregated signature")
+
+		participants, err := stores.Get(testRole).GetParticipantsInRange(pk, 10, 12)
+		require.NoError(t, err)
+		require.Empty(t, participants)
+	})
+
+	t.Run("root of other data", func(t *testing.T) {
+		syncer, network, validators, _ := setup(t, 25)
+		network.tamper = func(resp *Response) {
+			for i := range resp.Entries {
+				resp.Entries[i].Root = phase0.Root{0xff}
+				resp.Entries[i].Signature = validators[0].sign(resp.Entries[i].Root, 1, 2, 3)
+			}
+		}
+
+		pk := validators[0].share.ValidatorPubKey
+		_, err := syncer.Sync(ctx, Request{Role: testRole, PubKey: &pk, From: 10, To: 12})
+		require.ErrorContains(t, err, "root isn't the signing root of the signed data")
+	})
+
+	t.Run("data of another slot", func(t *testing.T) {
+		syncer, network, validators, _ := setup(t, 25)
+		network.tamper = func(resp *Response) {
+			for i := range resp.Entries {
+				proof := validators[0].proof(t, resp.Entries[i].Slot+1, 1, 2, 3)
+				resp.Entries[i].Root, resp.Entries[i].Signature, resp.Entries[i].Data = proof.Root, proof.Signature, proof.Data
+			}
+		}
+
+		pk := validators[0].share.ValidatorPubKey
+		_, err := syncer.Sync(ctx, Request{Role: testRole, PubKey: &pk, From: 10, To: 12})
+		require.ErrorContains(t, err, "attestation data is of slot")
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		syncer, network, validators, _ := setup(t, 25)
+		network.tamper = func(resp *Response) {
+			for i := range resp.Entries {
+				resp.Entries[i].Signers = []spectypes.OperatorID{1, 2}
+				resp.Entries[i].Signature = validators[0].sign(resp.Entries[i].Root, 1, 2)
+			}
+		}
+
+		pk := validators[0].share.ValidatorPubKey
+		_, err := syncer.Sync(ctx, Request{Role: testRole, PubKey: &pk, From: 10, To: 12})
+		require.ErrorContains(t, err, "aren't a quorum")
+	})
+
+	t.Run("no peers", func(t *testing.T) {
+		syncer, network, validators, _ := setup(t, 25)
+		network.servers = nil
+
+		pk := validators[0].share.ValidatorPubKey
+		_, err := syncer.Sync(ctx, Request{Role: testRole, PubKey: &pk, From: 10, To: 12})
+		require.ErrorIs(t, err, ErrNoPeers)
+	})
+}
+
+func TestVerifyEntry_Role(t *testing.T) {
+	v := newTestValidator(t, spectypes.ValidatorPK{1})
+	validatorStore := newTestValidatorStore(t, v)
+	domains := newDomainMemo(networkconfig.TestNetwork.Beacon, testDomains{})
+
+	proof := v.proof(t, 10, 1, 2, 3)
+	entry := Entry{
+		Slot:      10,
+		PubKey:    v.share.ValidatorPubKey,
+		Signers:   proof.Signers,
+		Root:      proof.Root,
+		Signature: proof.Signature,
+		Data:      proof.Data,
+	}
+
+	req := Request{Role: spectypes.BNRoleAttester, PubKey: &entry.PubKey, From: 10, To: 10}
+	require.NoError(t, verifyEntry(validatorStore, domains, req, entry))
+
+	// The attestation data isn't a sync committee block root.
+	req.Role = spectypes.BNRoleSyncCommittee
+	require.ErrorContains(t, verifyEntry(validatorStore, domains, req, entry), "invalid block root length")
+
+	// The signed data of other roles isn't kept.
+	req.Role = spectypes.BNRoleProposer
+	require.ErrorContains(t, verifyEntry(validatorStore, domains, req, entry), "unsupported role")
+}
+
+func TestServer_InvalidRequests(t *testing.T) {
+	_, network, validators, _ := setup(t, 25)
+	server := network.servers["server"]
+	pk := validators[0].share.ValidatorPubKey
+	committeeID := validators[0].share.CommitteeID()
+
+	for name, req := range map[string]Request{
+		"no target":      {Role: testRole, From: 10, To: 12},
+		"both targets":   {Role: testRole, PubKey: &pk, CommitteeID: &committeeID, From: 10, To: 12},
+		"reversed range": {Role: testRole, PubKey: &pk, From: 12, To: 10},
+		"long range":     {Role: testRole, PubKey: &pk, From: 0, To: maxRequestSlots},
+		"unknown role":   {Role: spectypes.BNRoleProposer, PubKey: &pk, From: 10, To: 12},
+		"unknown committee": {
+			Role: testRole, CommitteeID: &spectypes.CommitteeID{0xff}, From: 10, To: 12,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			var resp Response
+			require.NoError(t, json.Unmarshal(server.Handle(data), &resp))
+			require.NotEmpty(t, resp.Error)
+			require.Empty(t, resp.Entries)
+		})
+	}
+}
+
+type testSlotTicker struct {
+	ticks chan time.Time
+	slot  phase0.Slot
+}
+
+func (t *testSlotTicker) Next() <-chan time.Time {
+	return t.ticks
+}
+
+func (t *testSlotTicker) Slot() phase0.Slot {
+	return t.slot
+}
+
+func TestSyncer_RunBackfillsMissedSlots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	syncer, _, validators, stores := setup(t, 25)
+
+	// The node last ran at slot 9.
+	require.NoError(t, syncer.saveCheckpoint(9))
+
+	ticker := &testSlotTicker{ticks: make(chan time.Time), slot: 20}
+	go syncer.Run(ctx, func() slotticker.SlotTicker { return ticker })
+	ticker.ticks <- time.Now()
+
+	require.Eventually(t, func() bool {
+		entries, err := stores.Get(testRole).GetAllParticipantsInRange(10, 19)
+		require.NoError(t, err)
+		return len(entries) == 3*len(validators)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		checkpoint, found, err := syncer.loadCheckpoint()
+		require.NoError(t, err)
+		return found && checkpoint == 20
+	}, 5*time.Second, 10*time.Millisecond)
+}
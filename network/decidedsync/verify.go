@@ -0,0 +1,165 @@
+package decidedsync
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+// DomainProvider returns the signature domain of the given type at the given epoch.
+type DomainProvider interface {
+	DomainData(epoch phase0.Epoch, domain phase0.DomainType) (phase0.Domain, error)
+}
+
+type domainKey struct {
+	epoch      phase0.Epoch
+	domainType phase0.DomainType
+}
+
+// domainMemo memoizes the domains fetched while verifying the entries of a request.
+type domainMemo struct {
+	beaconNetwork beacon.BeaconNetwork
+	provider      DomainProvider
+	domains       map[domainKey]phase0.Domain
+}
+
+func newDomainMemo(beaconNetwork beacon.BeaconNetwork, provider DomainProvider) *domainMemo {
+	return &domainMemo{
+		beaconNetwork: beaconNetwork,
+		provider:      provider,
+		domains:       make(map[domainKey]phase0.Domain),
+	}
+}
+
+// get returns the domain of the given type at the epoch of the slot.
+func (m *domainMemo) get(slot phase0.Slot, domainType phase0.DomainType) (phase0.Domain, error) {
+	key := domainKey{epoch: m.beaconNetwork.EstimatedEpochAtSlot(slot), domainType: domainType}
+	if domain, ok := m.domains[key]; ok {
+		return domain, nil
+	}
+
+	domain, err := m.provider.DomainData(key.epoch, domainType)
+	if err != nil {
+		return phase0.Domain{}, fmt.Errorf("get domain: %w", err)
+	}
+	m.domains[key] = domain
+	return domain, nil
+}
+
+// verifiableRole returns whether entries of the role carry signed data which can be verified,
+// which is the case of committee roles only.
+func verifiableRole(role spectypes.BeaconRole) bool {
+	return role == spectypes.BNRoleAttester || role == spectypes.BNRoleSyncCommittee
+}
+
+// signingRoot decodes the signed data of the entry, checks that it's of the requested role and the entry's slot,
+// and returns its signing root.
+func signingRoot(domains *domainMemo, role spectypes.BeaconRole, entry Entry) (phase0.Root, error) {
+	switch role {
+	case spectypes.BNRoleAttester:
+		data := &phase0.AttestationData{}
+		if err := data.UnmarshalSSZ(entry.Data); err != nil {
+			return phase0.Root{}, fmt.Errorf("decode attestation data: %w", err)
+		}
+		if data.Slot != entry.Slot {
+			return phase0.Root{}, fmt.Errorf("attestation data is of slot %d", data.Slot)
+		}
+
+		domain, err := domains.get(entry.Slot, spectypes.DomainAttester)
+		if err != nil {
+			return phase0.Root{}, err
+		}
+		return spectypes.ComputeETHSigningRoot(data, domain)
+
+	case spectypes.BNRoleSyncCommittee:
+		// The block root doesn't hold the slot, which is bound to its epoch by the domain.
+		if len(entry.Data) != len(phase0.Root{}) {
+			return phase0.Root{}, fmt.Errorf("invalid block root length %d", len(entry.Data))
+		}
+
+		domain, err := domains.get(entry.Slot, spectypes.DomainSyncCommittee)
+		if err != nil {
+			return phase0.Root{}, err
+		}
+		return spectypes.ComputeETHSigningRoot(spectypes.SSZBytes(entry.Data), domain)
+
+	default:
+		return phase0.Root{}, fmt.Errorf("unsupported role %s", role)
+	}
+}
+
+// verifyEntry checks that the entry answers the request, that its root is the signing root of its data
+// of the requested role and slot, and that its signers are a quorum of the validator's committee
+// whose aggregated signature over the root is valid.
+func verifyEntry(validatorStore registrystorage.BaseValidatorStore, domains *domainMemo, req Request, entry Entry) error {
+	if entry.Slot < req.From || entry.Slot > req.To {
+		return fmt.Errorf("slot %d is out of the requested range", entry.Slot)
+	}
+
+	if req.PubKey != nil && entry.PubKey != *req.PubKey {
+		return fmt.Errorf("unrequested validator")
+	}
+
+	share, ok := validatorStore.Validator(entry.PubKey[:])
+	if !ok {
+		return errUnknownValidator
+	}
+
+	if req.CommitteeID != nil && share.CommitteeID() != *req.CommitteeID {
+		return fmt.Errorf("validator isn't in the requested committee")
+	}
+
+	root, err := signingRoot(domains, req.Role, entry)
+	if err != nil {
+		return fmt.Errorf("invalid signed data: %w", err)
+	}
+	if root != entry.Root {
+		return fmt.Errorf("root isn't the signing root of the signed data")
+	}
+
+	if uint64(len(entry.Signers)) < share.Quorum() {
+		return fmt.Errorf("%d signers aren't a quorum", len(entry.Signers))
+	}
+
+	pubKeys := make([]bls.PublicKey, 0, len(entry.Signers))
+	for i, signer := range entry.Signers {
+		if i > 0 && signer <= entry.Signers[i-1] {
+			return fmt.Errorf("signers aren't sorted and unique")
+		}
+
+		var sharePubKey []byte
+		for _, member := range share.Committee {
+			if member.Signer == signer {
+				sharePubKey = member.SharePubKey
+				break
+			}
+		}
+		if sharePubKey == nil {
+			return fmt.Errorf("signer %d isn't in the validator's committee", signer)
+		}
+
+		pk, err := ssvtypes.DeserializeBLSPublicKey(sharePubKey)
+		if err != nil {
+			return fmt.Errorf("deserialize share public key of signer %d: %w", signer, err)
+		}
+		pubKeys = append(pubKeys, pk)
+	}
+
+	var sig bls.Sign
+	if err := sig.Deserialize(entry.Signature); err != nil {
+		return fmt.Errorf("deserialize signature: %w", err)
+	}
+	// The root is copied, as cgo doesn't allow passing a pointer into a Go struct that holds other pointers.
+	root := entry.Root
+	if !sig.FastAggregateVerify(pubKeys, root[:]) {
+		return fmt.Errorf("invalid aggregated signature")
+	}
+
+	return nil
+}
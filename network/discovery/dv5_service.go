@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/discover"
@@ -68,6 +69,11 @@ type DiscV5Service struct {
 	// are removed from this map after some time passes)
 	trimmedRecently *ttl.Map[peer.ID, struct{}]
 
+	// peerStore persists the accepted peers, nil if disabled.
+	peerStore *PeerStore
+	// storedNodes are the previously discovered nodes which are proposed once Bootstrap starts.
+	storedNodes []*enode.Node
+
 	conn       *net.UDPConn
 	sharedConn *SharedUDPConn
 
@@ -89,6 +95,15 @@ func newDiscV5Service(pctx context.Context, logger *zap.Logger, opts *Options) (
 		publishLock:         make(chan struct{}, 1),
 		discoveredPeersPool: opts.DiscoveredPeersPool,
 		trimmedRecently:     opts.TrimmedRecently,
+		peerStore:           opts.PeerStore,
+	}
+	for _, storedPeer := range opts.StoredPeers {
+		node, err := storedPeer.Node()
+		if err != nil {
+			logger.Debug("could not parse stored peer", zap.String("enr", storedPeer.ENR), zap.Error(err))
+			continue
+		}
+		dvs.storedNodes = append(dvs.storedNodes, node)
 	}
 
 	logger.Debug(
@@ -159,35 +174,71 @@ func (dvs *DiscV5Service) Bootstrap(logger *zap.Logger, handler HandleNewPeer) e
 	const logFrequency = 10
 	var skippedPeers uint64 = 0
 
-	dvs.discover(
-		dvs.ctx,
-		func(e PeerEvent) {
-			logger := logger.With(
-				fields.ENR(e.Node),
-				fields.PeerID(e.AddrInfo.ID),
-			)
-			err := dvs.checkPeer(dvs.ctx, logger, e)
-			if err != nil {
-				if skippedPeers%logFrequency == 0 {
-					logger.Debug("skipped discovered peer", zap.Error(err))
-				}
-				skippedPeers++
-				return
+	handle := func(e PeerEvent) {
+		logger := logger.With(
+			fields.ENR(e.Node),
+			fields.PeerID(e.AddrInfo.ID),
+		)
+		err := dvs.checkPeer(dvs.ctx, logger, e)
+		if err != nil {
+			if skippedPeers%logFrequency == 0 {
+				logger.Debug("skipped discovered peer", zap.Error(err))
+			}
+			skippedPeers++
+			return
+		}
+		if dvs.peerStore != nil {
+			if err := dvs.peerStore.Seen(e.Node); err != nil {
+				logger.Debug("could not store discovered peer", zap.Error(err))
 			}
-			handler(e)
-		},
-		defaultDiscoveryInterval,
+		}
+		handler(e)
+	}
+	filters := []NodeFilter{
 		dvs.ssvNodeFilter(logger),
 		dvs.sharedSubnetsFilter(1),
 		dvs.alreadyDiscoveredFilter(logger),
 		dvs.badNodeFilter(logger),
 		dvs.alreadyConnectedFilter(),
 		dvs.recentlyTrimmedFilter(),
-	)
+	}
+
+	// Propose the previously discovered peers first, so we don't have to wait for
+	// the random walk to find peers for our subnets.
+	dvs.proposeStoredNodes(logger, handle, filters...)
+
+	dvs.discover(dvs.ctx, handle, defaultDiscoveryInterval, filters...)
 
 	return nil
 }
 
+// proposeStoredNodes passes the stored nodes which pass the given filters to the handler.
+func (dvs *DiscV5Service) proposeStoredNodes(logger *zap.Logger, handler HandleNewPeer, filters ...NodeFilter) {
+	proposed := 0
+nodes:
+	for _, node := range dvs.storedNodes {
+		for _, f := range filters {
+			if !f(node) {
+				continue nodes
+			}
+		}
+		ai, err := ToPeer(node)
+		if err != nil {
+			continue
+		}
+		handler(PeerEvent{
+			AddrInfo: *ai,
+			Node:     node,
+		})
+		proposed++
+	}
+	if len(dvs.storedNodes) > 0 {
+		logger.Debug("proposed stored peers",
+			zap.Int("stored", len(dvs.storedNodes)),
+			zap.Int("proposed", proposed))
+	}
+}
+
 var zeroSubnets, _ = commons.FromString(commons.ZeroSubnets)
 
 func (dvs *DiscV5Service) checkPeer(ctx context.Context, logger *zap.Logger, e PeerEvent) error {
@@ -270,6 +321,8 @@ func (dvs *DiscV5Service) initDiscV5Listener(logger *zap.Logger, discOpts *Optio
 	if err != nil {
 		return err
 	}
+	// Seed the routing table with the stored nodes as well.
+	dv5PostForkCfg.Bootnodes = append(dv5PostForkCfg.Bootnodes, dvs.storedNodes...)
 
 	dv5PostForkListener, err := discover.ListenV5(udpConn, localNode, *dv5PostForkCfg)
 	if err != nil {
@@ -289,6 +342,8 @@ func (dvs *DiscV5Service) initDiscV5Listener(logger *zap.Logger, discOpts *Optio
 	if err != nil {
 		return err
 	}
+	bootnodes := dv5PreForkCfg.Bootnodes
+	dv5PreForkCfg.Bootnodes = append(slices.Clip(bootnodes), dvs.storedNodes...)
 
 	dv5PreForkListener, err := discover.ListenV5(sharedConn, localNode, *dv5PreForkCfg)
 	if err != nil {
@@ -303,7 +358,7 @@ func (dvs *DiscV5Service) initDiscV5Listener(logger *zap.Logger, discOpts *Optio
 	)
 
 	dvs.dv5Listener = NewForkingDV5Listener(logger, dv5PreForkListener, dv5PostForkListener, 5*time.Second)
-	dvs.bootnodes = dv5PreForkCfg.Bootnodes // Just take bootnodes from one of the config since they're equal
+	dvs.bootnodes = bootnodes // Just take bootnodes from one of the config since they're equal
 
 	return nil
 }
@@ -450,7 +505,7 @@ func (dvs *DiscV5Service) PublishENR(logger *zap.Logger) {
 
 func (dvs *DiscV5Service) createLocalNode(logger *zap.Logger, discOpts *Options, ipAddr net.IP) (*enode.LocalNode, error) {
 	opts := discOpts.DiscV5Opts
-	localNode, err := createLocalNode(opts.NetworkKey, opts.StoragePath, ipAddr, opts.Port, opts.TCPPort)
+	localNode, err := createLocalNode(opts.NetworkKey, opts.StoragePath, ipAddr, opts.Port, opts.TCPPort, opts.QUICPort)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create local node")
 	}
@@ -14,8 +14,8 @@ import (
 	"github.com/ssvlabs/ssv/network/commons"
 )
 
-// createLocalNode create a new enode.LocalNode instance
-func createLocalNode(privKey *ecdsa.PrivateKey, storagePath string, ipAddr net.IP, udpPort, tcpPort uint16) (*enode.LocalNode, error) {
+// createLocalNode create a new enode.LocalNode instance, the QUIC entry is only set if quicPort isn't zero
+func createLocalNode(privKey *ecdsa.PrivateKey, storagePath string, ipAddr net.IP, udpPort, tcpPort, quicPort uint16) (*enode.LocalNode, error) {
 	db, err := enode.OpenDB(storagePath)
 	if err != nil {
 		return nil, fmt.Errorf("could not open node's peer database: %w", err)
@@ -26,6 +26,9 @@ func createLocalNode(privKey *ecdsa.PrivateKey, storagePath string, ipAddr net.I
 	localNode.Set(enr.IP(ipAddr))
 	localNode.Set(enr.UDP(udpPort))
 	localNode.Set(enr.TCP(tcpPort))
+	if quicPort != 0 {
+		localNode.Set(enr.WithEntry(quic, quicPort))
+	}
 	localNode.SetFallbackIP(ipAddr)
 	localNode.SetFallbackUDP(int(udpPort))
 	localNode.Set(enr.WithEntry("ssv", true))
@@ -67,7 +70,7 @@ func addAddresses(localNode *enode.LocalNode, hostAddr, hostDNS string) error {
 	return nil
 }
 
-// ToPeer creates peer info from the given node
+// ToPeer creates peer info from the given node, with its QUIC address if it has one
 func ToPeer(node *enode.Node) (*peer.AddrInfo, error) {
 	m, err := ToMultiAddr(node)
 	if err != nil {
@@ -77,6 +80,14 @@ func ToPeer(node *enode.Node) (*peer.AddrInfo, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not create peer info: %w", err)
 	}
+
+	quicAddr, err := toQUICMultiAddr(node)
+	if err != nil {
+		return nil, fmt.Errorf("could not create quic multiaddr: %w", err)
+	}
+	if quicAddr != nil {
+		pi.Addrs = append(pi.Addrs, quicAddr)
+	}
 	return pi, nil
 }
 
@@ -113,6 +124,22 @@ func ToMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
 	return ma.NewMultiaddr(s)
 }
 
+// toQUICMultiAddr returns the node's QUIC multiaddr, without the peer ID, or nil if the node doesn't advertise QUIC.
+func toQUICMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
+	var port uint16
+	if err := node.Record().Load(enr.WithEntry(quic, &port)); err != nil {
+		if enr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	ip := node.IP()
+	if ip == nil {
+		return nil, fmt.Errorf("missing ip address")
+	}
+	return commons.BuildQUICMultiAddress(ip.String(), uint(port))
+}
+
 // ParseENR takes a list of ENR strings and returns
 // the corresponding enode.Node objects.
 // it also accepts custom schemes, defaults to enode.ValidSchemes (v4)
@@ -2,6 +2,7 @@ package discovery
 
 import (
 	crand "crypto/rand"
+	"net"
 	"strings"
 	"testing"
 
@@ -30,6 +31,21 @@ func Test_ToPeer(t *testing.T) {
 	require.Equal(t, 1, len(ai.Addrs))
 }
 
+func Test_ToPeerWithQUIC(t *testing.T) {
+	sk, _, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	pk, err := commons.ECDSAPrivFromInterface(sk)
+	require.NoError(t, err)
+	node, err := createLocalNode(pk, "", net.ParseIP("127.0.0.1"), 12000, 13000, 14000)
+	require.NoError(t, err)
+
+	ai, err := ToPeer(node.Node())
+	require.NoError(t, err)
+	require.Len(t, ai.Addrs, 2)
+	require.Equal(t, "/ip4/127.0.0.1/tcp/13000", ai.Addrs[0].String())
+	require.Equal(t, "/ip4/127.0.0.1/udp/14000/quic-v1", ai.Addrs[1].String())
+}
+
 func Test_ParseENR(t *testing.T) {
 	nodes, err := ParseENR(nil, true,
 		"enr:-Km4QH9oua5xsG_0IN3oxiv5PBb10QXMkMvDeg2IrSSDlRxtONu9hShTmAZm2LjjADQOxGzBxd8VzXYFukmJULzcwrkBh2"+
@@ -48,7 +64,7 @@ func localNodeMock(t *testing.T) *enode.LocalNode {
 	require.NoError(t, err)
 	ip, err := commons.IPAddr()
 	require.NoError(t, err)
-	node, err := createLocalNode(pk, "", ip, 12000, 13000)
+	node, err := createLocalNode(pk, "", ip, 12000, 13000, 0)
 	require.NoError(t, err)
 	return node
 }
@@ -29,6 +29,8 @@ type DiscV5Options struct {
 	Port uint16
 	// TCPPort is the TCP port exposed in the ENR
 	TCPPort uint16
+	// QUICPort is the UDP port of the QUIC transport exposed in the ENR, zero if QUIC is disabled
+	QUICPort uint16
 	// NetworkKey is the private key used to create the peer.ID if the node
 	NetworkKey *ecdsa.PrivateKey
 	// Bootnodes is a list of bootstrapper nodes
@@ -0,0 +1,289 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/records"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var peerStorePrefix = []byte("discovery_peers/")
+
+// maxFailedAttempts is the amount of connection attempts after which a stored peer
+// we never managed to connect to is forgotten.
+const maxFailedAttempts = 5
+
+// StoredPeer is a previously discovered peer, persisted so it can be reused on startup.
+type StoredPeer struct {
+	ENR       string          `json:"enr"`
+	Subnets   commons.Subnets `json:"subnets"`
+	LastSeen  time.Time       `json:"last_seen"`
+	Attempts  uint32          `json:"attempts"`
+	Successes uint32          `json:"successes"`
+}
+
+// SuccessRate returns the share of connection attempts to this peer that succeeded,
+// peers we haven't tried yet are considered as good as peers we always connected to.
+func (p StoredPeer) SuccessRate() float64 {
+	if p.Attempts == 0 {
+		return 1
+	}
+	return min(1, float64(p.Successes)/float64(p.Attempts))
+}
+
+// Node parses the peer's ENR.
+func (p StoredPeer) Node() (*enode.Node, error) {
+	return enode.Parse(enode.ValidSchemes, p.ENR)
+}
+
+func (p StoredPeer) expired(now time.Time, maxAge time.Duration) bool {
+	return now.Sub(p.LastSeen) > maxAge || (p.Successes == 0 && p.Attempts >= maxFailedAttempts)
+}
+
+// seenPeer is a sighting of a peer which wasn't flushed yet.
+type seenPeer struct {
+	enr      string
+	subnets  commons.Subnets
+	lastSeen time.Time
+}
+
+func (p seenPeer) applyTo(stored *StoredPeer) {
+	stored.ENR = p.enr
+	stored.Subnets = p.subnets
+	stored.LastSeen = p.lastSeen
+}
+
+// PeerStore persists the peers found by discovery along with their connection history,
+// so that a restarted node can connect to known peers right away instead of waiting for discovery.
+// Peers which weren't seen for longer than maxAge are aged out.
+//
+// Discovery sees the same peers over and over, so sightings are kept in memory and written in batches by Flush.
+type PeerStore struct {
+	db     basedb.Database
+	maxAge time.Duration
+
+	mu   sync.Mutex
+	seen map[peer.ID]seenPeer
+}
+
+// NewPeerStore returns a PeerStore persisting the peers in db.
+func NewPeerStore(db basedb.Database, maxAge time.Duration) *PeerStore {
+	return &PeerStore{
+		db:     db,
+		maxAge: maxAge,
+		seen:   make(map[peer.ID]seenPeer),
+	}
+}
+
+// Seen records the given node as recently seen, it's saved with the next Flush keeping its connection history.
+func (s *PeerStore) Seen(node *enode.Node) error {
+	peerID, err := PeerID(node)
+	if err != nil {
+		return fmt.Errorf("get peer id: %w", err)
+	}
+	subnets, err := records.GetSubnetsEntry(node.Record())
+	if err != nil {
+		return fmt.Errorf("get subnets: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seen[peerID] = seenPeer{
+		enr:      node.String(),
+		subnets:  subnets,
+		lastSeen: time.Now(),
+	}
+	return nil
+}
+
+// Flush saves the peers seen since the last flush in a single transaction.
+func (s *PeerStore) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.flush()
+}
+
+func (s *PeerStore) flush() error {
+	if len(s.seen) == 0 {
+		return nil
+	}
+
+	err := s.db.Update(func(txn basedb.Txn) error {
+		for peerID, seen := range s.seen {
+			stored, _, err := s.get(txn, peerID)
+			if err != nil {
+				return err
+			}
+			seen.applyTo(&stored)
+			if err := s.save(txn, peerID, stored); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	clear(s.seen)
+	return nil
+}
+
+// RecordAttempt counts a connection attempt to the given peer, unknown peers are ignored.
+func (s *PeerStore) RecordAttempt(peerID peer.ID) error {
+	return s.update(peerID, func(p *StoredPeer) {
+		p.Attempts++
+	})
+}
+
+// RecordSuccess counts a successful connection to the given peer, unknown peers are ignored.
+func (s *PeerStore) RecordSuccess(peerID peer.ID) error {
+	return s.update(peerID, func(p *StoredPeer) {
+		p.Successes++
+		p.LastSeen = time.Now()
+	})
+}
+
+// Load returns up to limit stored peers which haven't aged out, the most reliable and
+// recently seen first. A non-positive limit returns all of them.
+func (s *PeerStore) Load(limit int) ([]StoredPeer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.flush(); err != nil {
+		return nil, err
+	}
+	storedPeers, _, err := s.all()
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(storedPeers) > limit {
+		storedPeers = storedPeers[:limit]
+	}
+	return storedPeers, nil
+}
+
+// Prune deletes the peers which aged out, as well as the least reliable peers beyond maxPeers,
+// returning the amount of deleted peers.
+func (s *PeerStore) Prune(maxPeers int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.flush(); err != nil {
+		return 0, err
+	}
+	storedPeers, expired, err := s.all()
+	if err != nil {
+		return 0, err
+	}
+	var keys [][]byte
+	for _, peerID := range expired {
+		keys = append(keys, []byte(peerID))
+	}
+	if maxPeers > 0 && len(storedPeers) > maxPeers {
+		for _, p := range storedPeers[maxPeers:] {
+			node, err := p.Node()
+			if err != nil {
+				continue
+			}
+			peerID, err := PeerID(node)
+			if err != nil {
+				continue
+			}
+			keys = append(keys, []byte(peerID))
+		}
+	}
+	for _, key := range keys {
+		if err := s.db.Delete(peerStorePrefix, key); err != nil {
+			return 0, fmt.Errorf("delete peer: %w", err)
+		}
+	}
+	return len(keys), nil
+}
+
+// all returns the stored peers sorted by success rate and then last seen time,
+// along with the IDs of the peers which aged out.
+func (s *PeerStore) all() ([]StoredPeer, []peer.ID, error) {
+	now := time.Now()
+	var storedPeers []StoredPeer
+	var expired []peer.ID
+	err := s.db.GetAll(peerStorePrefix, func(_ int, obj basedb.Obj) error {
+		var stored StoredPeer
+		if err := json.Unmarshal(obj.Value, &stored); err != nil {
+			return fmt.Errorf("decode peer %s: %w", peer.ID(obj.Key), err)
+		}
+		if stored.expired(now, s.maxAge) {
+			expired = append(expired, peer.ID(obj.Key))
+			return nil
+		}
+		storedPeers = append(storedPeers, stored)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("load peers: %w", err)
+	}
+	sort.SliceStable(storedPeers, func(i, j int) bool {
+		if a, b := storedPeers[i].SuccessRate(), storedPeers[j].SuccessRate(); a != b {
+			return a > b
+		}
+		return storedPeers[i].LastSeen.After(storedPeers[j].LastSeen)
+	})
+	return storedPeers, expired, nil
+}
+
+func (s *PeerStore) update(peerID peer.ID, fn func(p *StoredPeer)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, found, err := s.get(nil, peerID)
+	if err != nil {
+		return err
+	}
+	// Peers seen since the last flush are known too.
+	if seen, ok := s.seen[peerID]; ok {
+		seen.applyTo(&stored)
+		found = true
+		delete(s.seen, peerID)
+	}
+	if !found {
+		return nil
+	}
+	fn(&stored)
+	return s.save(nil, peerID, stored)
+}
+
+func (s *PeerStore) get(r basedb.Reader, peerID peer.ID) (StoredPeer, bool, error) {
+	var stored StoredPeer
+	obj, found, err := s.db.UsingReader(r).Get(peerStorePrefix, []byte(peerID))
+	if err != nil {
+		return stored, false, fmt.Errorf("get peer: %w", err)
+	}
+	if !found {
+		return stored, false, nil
+	}
+	if err := json.Unmarshal(obj.Value, &stored); err != nil {
+		return stored, false, fmt.Errorf("decode peer: %w", err)
+	}
+	return stored, true, nil
+}
+
+func (s *PeerStore) save(rw basedb.ReadWriter, peerID peer.ID, stored StoredPeer) error {
+	value, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("encode peer: %w", err)
+	}
+	if err := s.db.Using(rw).Set(peerStorePrefix, []byte(peerID), value); err != nil {
+		return fmt.Errorf("save peer: %w", err)
+	}
+	return nil
+}
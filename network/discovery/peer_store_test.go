@@ -0,0 +1,121 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/records"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestPeerStore(t *testing.T) {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewPeerStore(db, time.Hour)
+	allSubnets, err := commons.FromString(commons.AllSubnets)
+	require.NoError(t, err)
+
+	reliable := localNodeMock(t)
+	require.NoError(t, records.SetSubnetsEntry(reliable, allSubnets))
+	unreliable := localNodeMock(t)
+	require.NoError(t, records.SetSubnetsEntry(unreliable, allSubnets))
+	noSubnets := localNodeMock(t)
+
+	require.NoError(t, store.Seen(unreliable.Node()))
+	require.NoError(t, store.Seen(reliable.Node()))
+	require.Error(t, store.Seen(noSubnets.Node()))
+
+	// Seen peers are only written by Flush.
+	count, err := db.CountPrefix(peerStorePrefix)
+	require.NoError(t, err)
+	require.Zero(t, count)
+	require.NoError(t, store.Flush())
+	count, err = db.CountPrefix(peerStorePrefix)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+
+	reliableID, err := PeerID(reliable.Node())
+	require.NoError(t, err)
+	unreliableID, err := PeerID(unreliable.Node())
+	require.NoError(t, err)
+	noSubnetsID, err := PeerID(noSubnets.Node())
+	require.NoError(t, err)
+
+	// Unknown peers are ignored.
+	require.NoError(t, store.RecordAttempt(noSubnetsID))
+
+	require.NoError(t, store.RecordAttempt(reliableID))
+	require.NoError(t, store.RecordSuccess(reliableID))
+	require.NoError(t, store.RecordAttempt(unreliableID))
+	require.NoError(t, store.RecordAttempt(unreliableID))
+	require.NoError(t, store.RecordSuccess(unreliableID))
+
+	storedPeers, err := store.Load(0)
+	require.NoError(t, err)
+	require.Len(t, storedPeers, 2)
+	require.Equal(t, reliable.Node().String(), storedPeers[0].ENR)
+	require.Equal(t, float64(1), storedPeers[0].SuccessRate())
+	require.Equal(t, unreliable.Node().String(), storedPeers[1].ENR)
+	require.Equal(t, 0.5, storedPeers[1].SuccessRate())
+	require.Equal(t, allSubnets, storedPeers[1].Subnets)
+
+	node, err := storedPeers[0].Node()
+	require.NoError(t, err)
+	require.Equal(t, reliable.Node().ID(), node.ID())
+
+	// Seeing a peer again keeps its connection history.
+	require.NoError(t, store.Seen(unreliable.Node()))
+	storedPeers, err = store.Load(1)
+	require.NoError(t, err)
+	require.Len(t, storedPeers, 1)
+	require.Equal(t, reliable.Node().String(), storedPeers[0].ENR)
+	storedPeers, err = store.Load(0)
+	require.NoError(t, err)
+	require.Equal(t, 0.5, storedPeers[1].SuccessRate())
+
+	// Attempts to peers seen since the last flush are counted.
+	newPeer := localNodeMock(t)
+	require.NoError(t, records.SetSubnetsEntry(newPeer, allSubnets))
+	require.NoError(t, store.Seen(newPeer.Node()))
+	newPeerID, err := PeerID(newPeer.Node())
+	require.NoError(t, err)
+	require.NoError(t, store.RecordAttempt(newPeerID))
+	storedPeers, err = store.Load(0)
+	require.NoError(t, err)
+	require.Len(t, storedPeers, 3)
+	require.Equal(t, newPeer.Node().String(), storedPeers[2].ENR)
+	require.Equal(t, uint32(1), storedPeers[2].Attempts)
+
+	// Prune keeps only the best peers.
+	pruned, err := store.Prune(1)
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+	storedPeers, err = store.Load(0)
+	require.NoError(t, err)
+	require.Len(t, storedPeers, 1)
+
+	// Peers age out.
+	store.maxAge = 0
+	storedPeers, err = store.Load(0)
+	require.NoError(t, err)
+	require.Empty(t, storedPeers)
+	pruned, err = store.Prune(0)
+	require.NoError(t, err)
+	require.Equal(t, 1, pruned)
+}
+
+func TestStoredPeer_Expired(t *testing.T) {
+	now := time.Now()
+
+	require.False(t, StoredPeer{LastSeen: now}.expired(now, time.Hour))
+	require.True(t, StoredPeer{LastSeen: now.Add(-2 * time.Hour)}.expired(now, time.Hour))
+	require.True(t, StoredPeer{LastSeen: now, Attempts: maxFailedAttempts}.expired(now, time.Hour))
+	require.False(t, StoredPeer{LastSeen: now, Attempts: maxFailedAttempts, Successes: 1}.expired(now, time.Hour))
+}
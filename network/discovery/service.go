@@ -19,7 +19,8 @@ import (
 const (
 	// udp4 = "udp4"
 	// udp6 = "udp6"
-	tcp = "tcp"
+	tcp  = "tcp"
+	quic = "quic"
 )
 
 // CheckPeerLimit enables listener to check peers limit
@@ -45,6 +46,10 @@ type Options struct {
 	NetworkConfig       networkconfig.NetworkConfig
 	DiscoveredPeersPool *ttl.Map[peer.ID, DiscoveredPeer]
 	TrimmedRecently     *ttl.Map[peer.ID, struct{}]
+	// PeerStore persists the discovered peers, nil if they shouldn't be persisted.
+	PeerStore *PeerStore
+	// StoredPeers are previously discovered peers to seed discovery with on startup.
+	StoredPeers []StoredPeer
 }
 
 // Service is the interface for discovery
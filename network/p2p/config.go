@@ -11,6 +11,7 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	libp2ptcp "github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
@@ -19,10 +20,12 @@ import (
 	"github.com/ssvlabs/ssv/message/validation"
 	"github.com/ssvlabs/ssv/network"
 	"github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/decidedsync"
 	"github.com/ssvlabs/ssv/networkconfig"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
 	"github.com/ssvlabs/ssv/operator/storage"
 	"github.com/ssvlabs/ssv/ssvsigner/keys"
+	"github.com/ssvlabs/ssv/storage/basedb"
 	uc "github.com/ssvlabs/ssv/utils/commons"
 )
 
@@ -40,6 +43,7 @@ type Config struct {
 
 	TCPPort     uint16 `yaml:"TcpPort" env:"TCP_PORT" env-default:"13001" env-description:"TCP port for P2P transport"`
 	UDPPort     uint16 `yaml:"UdpPort" env:"UDP_PORT" env-default:"12001" env-description:"UDP port for discovery"`
+	QUICPort    uint16 `yaml:"QuicPort" env:"QUIC_PORT" env-description:"UDP port for QUIC P2P transport, in addition to TCP (disabled if not set, must differ from UdpPort)"`
 	HostAddress string `yaml:"HostAddress" env:"HOST_ADDRESS" env-description:"External IP address for discovery (can be overridden by HostDNS)"`
 	HostDNS     string `yaml:"HostDNS" env:"HOST_DNS" env-description:"External DNS name for discovery (overrides HostAddress if both are specified)"`
 
@@ -73,6 +77,8 @@ type Config struct {
 	UserAgent string
 	// NodeStorage is used to get operator metadata.
 	NodeStorage storage.Storage
+	// DB persists the peer ban list and Gossip scores across restarts, they are kept in memory only if it's nil.
+	DB basedb.Database
 	// Network defines a network configuration.
 	Network networkconfig.NetworkConfig
 	// MessageValidator validates incoming messages.
@@ -83,10 +89,17 @@ type Config struct {
 	PubsubValidationQueueSize int           `yaml:"PubsubValidationQueueSize" env:"PUBSUB_VAL_Q_SIZE" env-description:"Size of the pubsub validation queue"`
 	PubsubValidateThrottle    int           `yaml:"PubsubValidateThrottle" env:"PUBSUB_VAL_THROTTLE" env-description:"Number of goroutines for pubsub message validation"`
 
+	// DecidedHistory serves the decided history of the node to syncing peers, it's optional.
+	DecidedHistory *decidedsync.Server
+
 	// FullNode determines whether the network should sync decided history from peers.
 	// If false, SyncDecidedByRange becomes a no-op.
 	FullNode bool
 
+	// Exporter and RemoteSigner are advertised to peers in the handshake capabilities.
+	Exporter     bool
+	RemoteSigner bool
+
 	DisableIPRateLimit bool `yaml:"DisableIPRateLimit" env:"DISABLE_IP_RATE_LIMIT" default:"false" env-description:"Disable IP-based rate limiting"`
 
 	GetValidatorStats network.GetValidatorStats
@@ -115,6 +128,13 @@ func (c *Config) Libp2pOptions(logger *zap.Logger) ([]libp2p.Option, error) {
 		libp2p.Transport(libp2ptcp.NewTCPTransport),
 		libp2p.UserAgent(c.UserAgent),
 	}
+	if c.QUICPort != 0 {
+		if c.QUICPort == c.UDPPort {
+			return nil, errors.New("QUIC port must differ from the discovery UDP port")
+		}
+		// QUIC brings its own security and multiplexing, so it isn't affected by the options below.
+		opts = append(opts, libp2p.Transport(libp2pquic.NewTransport))
+	}
 
 	opts, err = c.configureAddrs(logger, opts)
 	if err != nil {
@@ -136,6 +156,13 @@ func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]lib
 		return opts, errors.Wrap(err, "could not build multi address for zero address")
 	}
 	addrs = append(addrs, maZero)
+	if c.QUICPort != 0 {
+		maQUICZero, err := commons.BuildQUICMultiAddress("0.0.0.0", uint(c.QUICPort))
+		if err != nil {
+			return opts, errors.Wrap(err, "could not build quic multi address for zero address")
+		}
+		addrs = append(addrs, maQUICZero)
+	}
 	ipAddr, err := commons.IPAddr()
 	if err != nil {
 		return opts, errors.Wrap(err, "could not get ip addr")
@@ -161,6 +188,14 @@ func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]lib
 			} else {
 				addrs = append(addrs, external)
 			}
+			if c.QUICPort != 0 {
+				externalQUIC, err := ma.NewMultiaddr(fmt.Sprintf("/dns4/%s/udp/%d/quic-v1", c.HostDNS, c.QUICPort))
+				if err != nil {
+					logger.Error("unable to create external quic multiaddress", zap.Error(err))
+				} else {
+					addrs = append(addrs, externalQUIC)
+				}
+			}
 			return addrs
 		}))
 	} else if c.HostAddress != "" {
@@ -172,6 +207,14 @@ func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]lib
 			} else {
 				addrs = append(addrs, external)
 			}
+			if c.QUICPort != 0 {
+				externalQUIC, err := commons.BuildQUICMultiAddress(c.HostAddress, uint(c.QUICPort))
+				if err != nil {
+					logger.Error("unable to create external quic multiaddress", zap.Error(err))
+				} else {
+					addrs = append(addrs, externalQUIC)
+				}
+			}
 			return addrs
 		}))
 	}
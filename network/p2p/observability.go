@@ -44,6 +44,17 @@ var (
 			metric.WithUnit("{peer}"),
 			metric.WithDescription("number of connected peers per topic")))
 
+	subnetHealthGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("subnets.health"),
+			metric.WithDescription("health of each subnet we need for our committees: 0 is dead, 1 is weak and 2 is healthy, -1 once it's no longer needed")))
+
+	subnetMeshPeersGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("subnets.mesh_peers"),
+			metric.WithUnit("{peer}"),
+			metric.WithDescription("number of gossipsub mesh peers per subnet we need for our committees")))
+
 	peerIdentityGauge = observability.NewMetric(
 		meter.Int64Gauge(
 			metricName("peers.per_version"),
@@ -129,6 +140,30 @@ func recordPeerCountPerTopic(ctx context.Context, logger *zap.Logger, ctrl topic
 	}
 }
 
+func recordSubnetsHealth(ctx context.Context, n *p2pNetwork) func() {
+	reported := make(map[uint64]struct{})
+	return func() {
+		current := make(map[uint64]struct{})
+		for _, h := range n.SubnetsHealth() {
+			attrs := metric.WithAttributes(attribute.Int64("ssv.p2p.subnet", int64(h.Subnet))) // #nosec G115 -- subnets has a constant max len of 128
+			subnetHealthGauge.Record(ctx, h.Status.metricValue(), attrs)
+			subnetMeshPeersGauge.Record(ctx, int64(h.MeshPeers), attrs)
+			current[h.Subnet] = struct{}{}
+		}
+
+		// Gauges keep their last value, so the subnets we no longer need are reported as such.
+		for subnet := range reported {
+			if _, ok := current[subnet]; ok {
+				continue
+			}
+			attrs := metric.WithAttributes(attribute.Int64("ssv.p2p.subnet", int64(subnet))) // #nosec G115 -- subnets has a constant max len of 128
+			subnetHealthGauge.Record(ctx, subnetNotNeededMetricValue, attrs)
+			subnetMeshPeersGauge.Record(ctx, 0, attrs)
+		}
+		reported = current
+	}
+}
+
 func recordPeerIdentities(ctx context.Context, host host.Host, index peers.Index) func() {
 	return func() {
 		peersByVersion := make(map[string]int64)
@@ -18,6 +18,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	libp2pdiscbackoff "github.com/libp2p/go-libp2p/p2p/discovery/backoff"
 	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/logging"
@@ -57,8 +58,22 @@ const (
 	peersReportingInterval          = 60 * time.Second
 	peerIdentitiesReportingInterval = 5 * time.Minute
 	topicsReportingInterval         = 60 * time.Second
+	gossipScoresSavingInterval      = 5 * time.Minute
+	// restoredGossipScoresTTL is how long the Gossip scores persisted before a restart are trusted for.
+	restoredGossipScoresTTL = time.Hour
+	// storedPeersMaxAge is how long a discovered peer is kept in the peer store after it was last seen.
+	storedPeersMaxAge          = 3 * 24 * time.Hour
+	storedPeersFlushInterval   = time.Minute
+	storedPeersPruningInterval = time.Hour
+	// maxStoredPeers limits the size of the peer store, storedPeersLimit limits how many of
+	// the stored peers are used to seed discovery on startup.
+	maxStoredPeers   = 1000
+	storedPeersLimit = 200
 )
 
+// ErrPeerNotConnected is returned when trying to disconnect from a peer we aren't connected to.
+var ErrPeerNotConnected = errors.New("peer is not connected")
+
 // PeersIndexProvider holds peers index instance
 type PeersIndexProvider interface {
 	PeersIndex() peers.Index
@@ -114,6 +129,15 @@ type p2pNetwork struct {
 	// shortly after we've trimmed these (we still might consider connecting to these once they
 	// are removed from this map after some time passes)
 	trimmedRecently *ttl.Map[peer.ID, struct{}]
+	// banList keeps track of peers and IP ranges banned by the node operator, we refuse any
+	// connection with these until they are unbanned or the ban expires
+	banList *peers.BanList
+	// gossipScoresStore persists the Gossip scores, nil if there's no database
+	gossipScoresStore *peers.GossipScoresStore
+	// peerStore persists the discovered peers, nil if there's no database
+	peerStore *discovery.PeerStore
+	// hasStoredPeers is true if discovery was seeded with peers from the peer store
+	hasStoredPeers bool
 }
 
 // New creates a new p2p network
@@ -146,6 +170,15 @@ func New(
 	if err := n.parseTrustedPeers(); err != nil {
 		return nil, err
 	}
+	banList, err := peers.NewBanList(logger, cfg.DB)
+	if err != nil {
+		return nil, fmt.Errorf("could not load ban list: %w", err)
+	}
+	n.banList = banList
+	if cfg.DB != nil {
+		n.gossipScoresStore = peers.NewGossipScoresStore(cfg.DB)
+		n.peerStore = discovery.NewPeerStore(cfg.DB, storedPeersMaxAge)
+	}
 	return n, nil
 }
 
@@ -205,6 +238,117 @@ func (n *p2pNetwork) PeersByTopic() map[string][]peer.ID {
 	return peerz
 }
 
+// DisconnectPeer closes all connections to the given peer. The peer is free to reconnect.
+func (n *p2pNetwork) DisconnectPeer(id peer.ID) error {
+	if n.host.Network().Connectedness(id) != p2pnet.Connected {
+		return ErrPeerNotConnected
+	}
+	return n.host.Network().ClosePeer(id)
+}
+
+// BanPeer disconnects from the given peer and refuses any further connection with it
+// until UnbanPeer is called.
+func (n *p2pNetwork) BanPeer(id peer.ID) error {
+	return n.AddBan(peers.Ban{PeerID: id})
+}
+
+// UnbanPeer lifts the ban of the given peer, returns false if the peer wasn't banned.
+func (n *p2pNetwork) UnbanPeer(id peer.ID) bool {
+	removed, err := n.RemoveBan(peers.Ban{PeerID: id})
+	if err != nil {
+		n.logger.Error("could not unban peer", fields.PeerID(id), zap.Error(err))
+		return false
+	}
+	return removed
+}
+
+// BannedPeers returns the peers that are currently banned.
+func (n *p2pNetwork) BannedPeers() []peer.ID {
+	var banned []peer.ID
+	for _, ban := range n.banList.List() {
+		if ban.PeerID != "" {
+			banned = append(banned, ban.PeerID)
+		}
+	}
+	return banned
+}
+
+// AddBan persists the given ban and disconnects from the peers it applies to.
+func (n *p2pNetwork) AddBan(ban peers.Ban) error {
+	if err := n.banList.Add(ban); err != nil {
+		return err
+	}
+	n.logger.Info("added ban",
+		fields.PeerID(ban.PeerID),
+		zap.String("cidr", ban.CIDR),
+		zap.String("reason", ban.Reason),
+		zap.Time("expires_at", ban.ExpiresAt),
+	)
+
+	if ban.PeerID != "" {
+		if n.host.Network().Connectedness(ban.PeerID) != p2pnet.Connected {
+			return nil
+		}
+		return n.host.Network().ClosePeer(ban.PeerID)
+	}
+
+	for _, conn := range n.host.Network().Conns() {
+		ip, err := manet.ToIP(conn.RemoteMultiaddr())
+		if err != nil || !n.banList.IsIPBanned(ip) {
+			continue
+		}
+		if err := conn.Close(); err != nil {
+			n.logger.Debug("could not close connection of banned IP", fields.PeerID(conn.RemotePeer()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// RemoveBan lifts the ban of the peer or IP range of the given ban, returns false if it wasn't banned.
+func (n *p2pNetwork) RemoveBan(ban peers.Ban) (bool, error) {
+	removed, err := n.banList.Remove(ban)
+	if err != nil || !removed {
+		return removed, err
+	}
+	n.logger.Info("removed ban", fields.PeerID(ban.PeerID), zap.String("cidr", ban.CIDR))
+	return true, nil
+}
+
+// Bans returns the bans that haven't expired.
+func (n *p2pNetwork) Bans() []peers.Ban {
+	return n.banList.List()
+}
+
+// saveGossipScores returns a function persisting the current Gossip scores.
+func (n *p2pNetwork) saveGossipScores(logger *zap.Logger) func() {
+	return func() {
+		if err := n.gossipScoresStore.Save(n.idx.Scores()); err != nil {
+			logger.Warn("could not save gossip scores", zap.Error(err))
+		}
+	}
+}
+
+// flushStoredPeers returns a function saving the recently discovered peers to the peer store.
+func (n *p2pNetwork) flushStoredPeers(logger *zap.Logger) func() {
+	return func() {
+		if err := n.peerStore.Flush(); err != nil {
+			logger.Warn("could not flush stored peers", zap.Error(err))
+		}
+	}
+}
+
+// pruneStoredPeers returns a function deleting the aged out peers from the peer store.
+func (n *p2pNetwork) pruneStoredPeers(logger *zap.Logger) func() {
+	return func() {
+		pruned, err := n.peerStore.Prune(maxStoredPeers)
+		if err != nil {
+			logger.Warn("could not prune stored peers", zap.Error(err))
+			return
+		}
+		logger.Debug("pruned stored peers", zap.Int("count", pruned))
+	}
+}
+
 // Close implements io.Closer
 func (n *p2pNetwork) Close() error {
 	atomic.SwapInt32(&n.state, stateClosing)
@@ -216,6 +360,12 @@ func (n *p2pNetwork) Close() error {
 	if err := n.disc.Close(); err != nil {
 		n.logger.Warn("could not close discovery", zap.Error(err))
 	}
+	if n.gossipScoresStore != nil && n.isIdxSet.Load() {
+		n.saveGossipScores(n.logger)()
+	}
+	if n.peerStore != nil {
+		n.flushStoredPeers(n.logger)()
+	}
 	if err := n.idx.Close(); err != nil {
 		n.logger.Warn("could not close index", zap.Error(err))
 	}
@@ -284,6 +434,17 @@ func (n *p2pNetwork) Start(logger *zap.Logger) error {
 
 	async.Interval(n.ctx, topicsReportingInterval, recordPeerCountPerTopic(n.ctx, logger, n.topicsCtrl, 2))
 
+	async.Interval(n.ctx, topicsReportingInterval, recordSubnetsHealth(n.ctx, n))
+
+	if n.gossipScoresStore != nil {
+		async.Interval(n.ctx, gossipScoresSavingInterval, n.saveGossipScores(logger))
+	}
+
+	if n.peerStore != nil {
+		async.Interval(n.ctx, storedPeersFlushInterval, n.flushStoredPeers(logger))
+		async.Interval(n.ctx, storedPeersPruningInterval, n.pruneStoredPeers(logger))
+	}
+
 	if err := n.subscribeToFixedSubnets(logger); err != nil {
 		return err
 	}
@@ -424,38 +585,26 @@ func (n *p2pNetwork) PeerProtection(immunityQuota int, protectEveryOutbound bool
 		if immunityQuotaInbound == 0 && immunityQuotaOutbound == 0 {
 			break // can't protect any more peers since we reached our quotas
 		}
-		pConns := n.host.Network().ConnsToPeer(p)
-		// we shouldn't have more than 1 connection per peer, but if we do we'd want
-		// a warning about it logged, and we'd want to handle it to the best of our ability
-		if len(pConns) > 1 {
-			n.logger.Error(
-				"PeerProtection: encountered peer we have multiple open connections with (expected 1 at most)",
-				zap.String("peer_id", p.String()),
-				zap.Int("connections_count", len(pConns)),
-			)
-		}
-		for _, pConn := range pConns {
-			connDir := pConn.Stat().Direction
-			if connDir == p2pnet.DirUnknown {
+		// A peer connected over multiple transports is protected by the direction of its first connection.
+		switch peers.PeerDirection(n.host.Network(), p) {
+		case p2pnet.DirInbound:
+			if immunityQuotaInbound > 0 {
+				protectedPeers[p] = struct{}{}
+				immunityQuotaInbound--
+			}
+		case p2pnet.DirOutbound:
+			if protectEveryOutbound {
+				protectedPeers[p] = struct{}{}
+			} else if immunityQuotaOutbound > 0 {
+				immunityQuotaOutbound--
+				protectedPeers[p] = struct{}{}
+			}
+		case p2pnet.DirUnknown:
+			if len(n.host.Network().ConnsToPeer(p)) > 0 {
 				n.logger.Error(
 					"PeerProtection: encountered peer connection with direction Unknown",
 					zap.String("peer_id", p.String()),
 				)
-				continue
-			}
-			if connDir == p2pnet.DirInbound {
-				if immunityQuotaInbound > 0 {
-					protectedPeers[p] = struct{}{}
-					immunityQuotaInbound--
-				}
-			}
-			if connDir == p2pnet.DirOutbound {
-				if protectEveryOutbound {
-					protectedPeers[p] = struct{}{}
-				} else if immunityQuotaOutbound > 0 {
-					immunityQuotaOutbound--
-					protectedPeers[p] = struct{}{}
-				}
 			}
 		}
 	}
@@ -0,0 +1,46 @@
+package p2pv1
+
+import (
+	p2pnet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/network/decidedsync"
+)
+
+// DecidedHistoryPeers returns the connected peers which serve decided history.
+func (n *p2pNetwork) DecidedHistoryPeers() []peer.ID {
+	var peers []peer.ID
+	for _, peerID := range n.host.Network().Peers() {
+		protocols, err := n.host.Peerstore().SupportsProtocols(peerID, decidedsync.ProtocolID)
+		if err != nil || len(protocols) == 0 {
+			continue
+		}
+		peers = append(peers, peerID)
+	}
+	return peers
+}
+
+// RequestDecidedHistory sends an encoded decided history request to the peer and returns its encoded response.
+// Dialing, writing and reading are each bounded by RequestTimeout.
+func (n *p2pNetwork) RequestDecidedHistory(peerID peer.ID, request []byte) ([]byte, error) {
+	return n.streamCtrl.Request(n.logger, peerID, decidedsync.ProtocolID, request)
+}
+
+func (n *p2pNetwork) handleDecidedHistory(logger *zap.Logger) p2pnet.StreamHandler {
+	return func(stream p2pnet.Stream) {
+		logger := logger.With(fields.PeerID(stream.Conn().RemotePeer()))
+
+		request, respond, done, err := n.streamCtrl.HandleStream(logger, stream)
+		defer done()
+		if err != nil {
+			logger.Debug("could not handle decided history stream", zap.Error(err))
+			return
+		}
+
+		if err := respond(n.cfg.DecidedHistory.Handle(request)); err != nil {
+			logger.Debug("could not respond to decided history request", zap.Error(err))
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	p2pnet "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/oleiade/lane/v2"
 	"go.uber.org/zap"
@@ -83,6 +84,20 @@ func (n *p2pNetwork) startDiscovery(logger *zap.Logger) error {
 		}
 	}()
 
+	// Keep track of the successful connections to stored peers.
+	if n.peerStore != nil {
+		n.host.Network().Notify(&p2pnet.NotifyBundle{
+			ConnectedF: func(_ p2pnet.Network, conn p2pnet.Conn) {
+				if conn.Stat().Direction != p2pnet.DirOutbound {
+					return
+				}
+				if err := n.peerStore.RecordSuccess(conn.RemotePeer()); err != nil {
+					logger.Debug("could not record connection to stored peer", fields.PeerID(conn.RemotePeer()), zap.Error(err))
+				}
+			},
+		})
+	}
+
 	// Spawn a goroutine to repeatedly select & connect to the best peers.
 	// Try to connect only half as many peers as we have outbound slots available because this
 	// leaves some vacant slots for the next iteration - on the next iteration better peers
@@ -95,9 +110,10 @@ func (n *p2pNetwork) startDiscovery(logger *zap.Logger) error {
 	// - repeat those steps from above N times (depending on how many connection slots we have available),
 	//   also taking into account "peersToConnect" set of peers on each consecutive iteration
 	async.Interval(n.ctx, 15*time.Second, func() {
-		// Collect enough peers first to increase the quality of peer selection.
+		// Collect enough peers first to increase the quality of peer selection,
+		// unless discovery was seeded with the peers stored before a restart.
 		const minDiscoveryTime = 1 * time.Minute
-		if time.Since(startTime) < minDiscoveryTime {
+		if time.Since(startTime) < minDiscoveryTime && !n.hasStoredPeers {
 			return
 		}
 
@@ -213,6 +229,11 @@ func (n *p2pNetwork) startDiscovery(logger *zap.Logger) error {
 				Tries:    p.Tries + 1,
 				LastTry:  time.Now(),
 			})
+			if n.peerStore != nil {
+				if err := n.peerStore.RecordAttempt(p.ID); err != nil {
+					n.logger.Debug("could not record connection attempt to stored peer", fields.PeerID(p.ID), zap.Error(err))
+				}
+			}
 			connector <- p.AddrInfo
 		}
 		n.logger.Info(
@@ -25,6 +25,7 @@ import (
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/logging/fields"
 	p2pcommons "github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/decidedsync"
 	"github.com/ssvlabs/ssv/network/discovery"
 	"github.com/ssvlabs/ssv/network/peers"
 	"github.com/ssvlabs/ssv/network/peers/connections"
@@ -112,8 +113,11 @@ func (n *p2pNetwork) initCfg() error {
 	return nil
 }
 
-// IsBadPeer returns whether a peer is bad
+// IsBadPeer returns whether a peer is bad, banned peers are always considered bad
 func (n *p2pNetwork) IsBadPeer(logger *zap.Logger, peerID peer.ID) bool {
+	if n.banList.IsPeerBanned(peerID) {
+		return true
+	}
 	if !n.isIdxSet.Load() {
 		return false
 	}
@@ -138,7 +142,9 @@ func (n *p2pNetwork) SetupHost(logger *zap.Logger) error {
 		n.cfg.DisableIPRateLimit,
 		n.connectionsAtLimit,
 		n.IsBadPeer,
+		n.banList.IsIPBanned,
 		n.atInboundLimit,
+		n.isPeerConnected,
 		n.trimmedRecently,
 	)
 	opts = append(opts, libp2p.ResourceManager(rmgr), libp2p.ConnectionGater(n.connGater))
@@ -197,14 +203,23 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 	domain := "0x" + hex.EncodeToString(d[:])
 	self := records.NewNodeInfo(domain)
 	self.Metadata = &records.NodeMetadata{
-		NodeVersion: commons.GetNodeVersion(),
-		Subnets:     p2pcommons.Subnets(n.fixedSubnets).String(),
+		NodeVersion:  commons.GetNodeVersion(),
+		Subnets:      p2pcommons.Subnets(n.fixedSubnets).String(),
+		Capabilities: n.capabilities(),
 	}
 	getPrivKey := func() crypto.PrivKey {
 		return libPrivKey
 	}
 
 	n.idx = peers.NewPeersIndex(logger, n.host.Network(), self, n.getMaxPeers, getPrivKey, p2pcommons.SubnetsCount, 10*time.Minute, peers.NewGossipScoreIndex())
+	if n.gossipScoresStore != nil {
+		scores, err := n.gossipScoresStore.Load(restoredGossipScoresTTL)
+		if err != nil {
+			return errors.Wrap(err, "could not load gossip scores")
+		}
+		n.idx.RestoreScores(scores, restoredGossipScoresTTL)
+		logger.Debug("restored gossip scores", zap.Int("peers", len(scores)))
+	}
 	n.isIdxSet.Store(true)
 
 	logger.Debug("peers index is ready")
@@ -226,6 +241,7 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 		newDomainString := "0x" + hex.EncodeToString(newDomain[:])
 		return []connections.HandshakeFilter{
 			connections.NetworkIDFilter(newDomainString),
+			connections.ForkFilter(n.cfg.Network.ForkName()),
 			connections.BadPeerFilter(logger, n.idx),
 		}
 	}
@@ -245,6 +261,11 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 	n.host.SetStreamHandler(peers.NodeInfoProtocol, handshaker.Handler(logger))
 	logger.Debug("handshaker is ready")
 
+	if n.cfg.DecidedHistory != nil {
+		n.host.SetStreamHandler(decidedsync.ProtocolID, n.handleDecidedHistory(logger))
+		logger.Debug("decided history handler is ready")
+	}
+
 	n.connHandler = connections.NewConnHandler(n.ctx, handshaker, n.ActiveSubnets, n.idx, n.idx, n.idx, n.discoveredPeersPool)
 	n.host.Network().Notify(n.connHandler.Handle(logger))
 	logger.Debug("connection handler is ready")
@@ -252,6 +273,27 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 	return nil
 }
 
+// capabilities returns the capabilities advertised in the node info.
+func (n *p2pNetwork) capabilities() *records.Capabilities {
+	c := &records.Capabilities{
+		Version:      records.CapabilitiesVersion,
+		Mode:         records.NodeModeOperator,
+		Forks:        []string{n.cfg.Network.ForkName()},
+		Transports:   []string{records.TransportTCP},
+		RemoteSigner: n.cfg.RemoteSigner,
+	}
+	if n.cfg.QUICPort != 0 {
+		c.Transports = append(c.Transports, records.TransportQUIC)
+	}
+	if n.cfg.Exporter {
+		c.Mode = records.NodeModeExporter
+	}
+	if n.cfg.DecidedHistory != nil {
+		c.Protocols = append(c.Protocols, decidedsync.ProtocolID)
+	}
+	return c
+}
+
 func (n *p2pNetwork) ActiveSubnets() p2pcommons.Subnets {
 	return n.activeSubnets
 }
@@ -272,6 +314,7 @@ func (n *p2pNetwork) setupDiscovery(logger *zap.Logger) error {
 			BindIP:        net.IPv4zero.String(),
 			Port:          n.cfg.UDPPort,
 			TCPPort:       n.cfg.TCPPort,
+			QUICPort:      n.cfg.QUICPort,
 			NetworkKey:    n.cfg.NetworkPrivateKey,
 			Bootnodes:     n.cfg.TransformBootnodes(),
 			EnableLogging: n.cfg.DiscoveryTrace,
@@ -297,6 +340,16 @@ func (n *p2pNetwork) setupDiscovery(logger *zap.Logger) error {
 		DiscoveredPeersPool: n.discoveredPeersPool,
 		TrimmedRecently:     n.trimmedRecently,
 	}
+	if discV5Opts != nil && n.peerStore != nil {
+		storedPeers, err := n.peerStore.Load(storedPeersLimit)
+		if err != nil {
+			return errors.Wrap(err, "could not load stored peers")
+		}
+		logger.Info("discovery: seeding with stored peers", zap.Int("count", len(storedPeers)))
+		discOpts.PeerStore = n.peerStore
+		discOpts.StoredPeers = storedPeers
+		n.hasStoredPeers = len(storedPeers) > 0
+	}
 	disc, err := discovery.NewService(n.ctx, logger, discOpts)
 	if err != nil {
 		return err
@@ -358,6 +411,13 @@ func (n *p2pNetwork) connectionsAtLimit() bool {
 	return n.idx.AtLimit(network.DirOutbound)
 }
 
+func (n *p2pNetwork) isPeerConnected(id peer.ID) bool {
+	if !n.isIdxSet.Load() {
+		return false
+	}
+	return n.idx.Connectedness(id) == network.Connected
+}
+
 func (n *p2pNetwork) atInboundLimit() bool {
 	in, _ := n.connectionStats()
 	inboundLimit := n.inboundLimit()
@@ -382,16 +442,15 @@ func (n *p2pNetwork) connectionStats() (inbound, outbound int) {
 	return connectionStats(n.host)
 }
 
+// connectionStats counts the connected peers by the direction of their first connection,
+// so that peers connected over multiple transports are only counted once.
 func connectionStats(host host.Host) (inbound, outbound int) {
-	for _, cn := range host.Network().Conns() {
-		dir := cn.Stat().Direction
-		if dir == network.DirUnknown {
-			continue
-		}
-		if dir == network.DirOutbound {
-			outbound++
-		} else {
+	for _, id := range host.Network().Peers() {
+		switch peers.PeerDirection(host.Network(), id) {
+		case network.DirInbound:
 			inbound++
+		case network.DirOutbound:
+			outbound++
 		}
 	}
 	return inbound, outbound
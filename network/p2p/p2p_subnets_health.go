@@ -0,0 +1,129 @@
+package p2pv1
+
+import (
+	"sort"
+	"strconv"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/network/commons"
+)
+
+// minHealthySubnetPeers is the least amount of connected peers, as well as mesh peers,
+// a subnet needs to be considered healthy.
+const minHealthySubnetPeers = 3
+
+// SubnetStatus classifies how well a subnet is covered by our peers.
+type SubnetStatus string
+
+const (
+	// SubnetDead means we have no peers in the subnet, so we can't send or receive its messages.
+	SubnetDead SubnetStatus = "dead"
+	// SubnetWeak means we have too few peers, or mesh peers, in the subnet to reliably relay its messages.
+	SubnetWeak SubnetStatus = "weak"
+	// SubnetHealthy means we have enough peers in the subnet.
+	SubnetHealthy SubnetStatus = "healthy"
+)
+
+// subnetNotNeededMetricValue is reported for subnets we no longer need, so that their last status doesn't linger.
+const subnetNotNeededMetricValue = -1
+
+// metricValue returns the value reported for the status, a lower value meaning a less healthy subnet.
+func (s SubnetStatus) metricValue() int64 {
+	switch s {
+	case SubnetDead:
+		return 0
+	case SubnetWeak:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ScoreDistribution summarizes the Gossip scores of a group of peers.
+type ScoreDistribution struct {
+	Min    float64
+	Median float64
+	Max    float64
+}
+
+// SubnetHealth reports how well one of our subnets is covered by our peers.
+type SubnetHealth struct {
+	Subnet    uint64
+	Peers     int
+	MeshPeers int
+	Scores    ScoreDistribution
+	Status    SubnetStatus
+}
+
+// SubnetsHealth returns the health of each subnet we need for our committees, ordered by subnet.
+// The fixed subnets an exporter or a node subscribed to all subnets listens to aren't needed for any committee,
+// so they aren't reported.
+func (n *p2pNetwork) SubnetsHealth() []SubnetHealth {
+	var health []SubnetHealth
+	for subnet, active := range n.committeeSubnets() {
+		if active == 0 {
+			continue
+		}
+		topic := strconv.Itoa(subnet)
+		peers, err := n.topicsCtrl.Peers(topic)
+		if err != nil {
+			n.logger.Debug("could not get topic peers", fields.Topic(topic), zap.Error(err))
+		}
+
+		scores := make([]float64, 0, len(peers))
+		for _, p := range peers {
+			score, ok := n.idx.GetGossipScore(p)
+			if ok {
+				scores = append(scores, score)
+			}
+		}
+
+		h := SubnetHealth{
+			Subnet:    uint64(subnet), // #nosec G115 -- subnets has a constant max len of 128
+			Peers:     len(peers),
+			MeshPeers: len(n.topicsCtrl.MeshPeers(topic)),
+			Scores:    scoreDistribution(scores),
+		}
+		h.Status = subnetStatus(h.Peers, h.MeshPeers)
+		health = append(health, h)
+	}
+	return health
+}
+
+// committeeSubnets returns the subnets of our active committees.
+func (n *p2pNetwork) committeeSubnets() []byte {
+	subnets := make([]byte, commons.SubnetsCount)
+	n.activeCommittees.Range(func(cid string, status validatorStatus) bool {
+		if status != validatorStatusInactive {
+			subnets[commons.CommitteeSubnet(spectypes.CommitteeID([]byte(cid)))] = 1
+		}
+		return true
+	})
+	return subnets
+}
+
+func subnetStatus(peers, meshPeers int) SubnetStatus {
+	switch {
+	case peers == 0:
+		return SubnetDead
+	case peers < minHealthySubnetPeers || meshPeers < minHealthySubnetPeers:
+		return SubnetWeak
+	default:
+		return SubnetHealthy
+	}
+}
+
+func scoreDistribution(scores []float64) ScoreDistribution {
+	if len(scores) == 0 {
+		return ScoreDistribution{}
+	}
+	sort.Float64s(scores)
+	return ScoreDistribution{
+		Min:    scores[0],
+		Median: scores[len(scores)/2],
+		Max:    scores[len(scores)-1],
+	}
+}
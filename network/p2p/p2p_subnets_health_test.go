@@ -0,0 +1,19 @@
+package p2pv1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubnetStatus(t *testing.T) {
+	require.Equal(t, SubnetDead, subnetStatus(0, 0))
+	require.Equal(t, SubnetWeak, subnetStatus(2, 2))
+	require.Equal(t, SubnetWeak, subnetStatus(10, 1))
+	require.Equal(t, SubnetHealthy, subnetStatus(10, minHealthySubnetPeers))
+}
+
+func TestScoreDistribution(t *testing.T) {
+	require.Equal(t, ScoreDistribution{}, scoreDistribution(nil))
+	require.Equal(t, ScoreDistribution{Min: -5, Median: 1, Max: 20}, scoreDistribution([]float64{20, 1, -5}))
+}
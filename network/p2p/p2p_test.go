@@ -11,6 +11,9 @@ import (
 	"time"
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -20,6 +23,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/network"
+	"github.com/ssvlabs/ssv/network/records"
 	"github.com/ssvlabs/ssv/networkconfig"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
 )
@@ -33,6 +37,161 @@ func TestGetMaxPeers(t *testing.T) {
 	require.Equal(t, 8, n.getMaxPeers("100"))
 }
 
+func TestP2pNetwork_BanPeer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ln, _, err := createNetworkAndSubscribe(t, ctx, LocalNetOptions{
+		Nodes:        3,
+		MinConnected: 1,
+		UseDiscv5:    false,
+	})
+	require.NoError(t, err)
+
+	defer func() {
+		for _, node := range ln.Nodes {
+			require.NoError(t, node.(*p2pNetwork).Close())
+		}
+	}()
+
+	node := ln.Nodes[0].(*p2pNetwork)
+	require.Eventually(t, func() bool {
+		return len(node.host.Network().Peers()) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+	target := node.host.Network().Peers()[0]
+
+	require.NoError(t, node.BanPeer(target))
+	require.True(t, node.IsBadPeer(zap.NewNop(), target))
+	require.Equal(t, []peer.ID{target}, node.BannedPeers())
+	require.Eventually(t, func() bool {
+		return node.host.Network().Connectedness(target) != libp2pnetwork.Connected
+	}, 5*time.Second, 100*time.Millisecond)
+	require.ErrorIs(t, node.DisconnectPeer(target), ErrPeerNotConnected)
+
+	require.True(t, node.UnbanPeer(target))
+	require.False(t, node.UnbanPeer(target))
+	require.Empty(t, node.BannedPeers())
+}
+
+func TestP2pNetwork_QUIC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ln, _, err := createNetworkAndSubscribe(t, ctx, LocalNetOptions{
+		Nodes:        3,
+		MinConnected: 1,
+		UseDiscv5:    false,
+		QUIC:         true,
+	})
+	require.NoError(t, err)
+
+	defer func() {
+		for _, node := range ln.Nodes {
+			require.NoError(t, node.(*p2pNetwork).Close())
+		}
+	}()
+
+	node := ln.Nodes[0].(*p2pNetwork)
+	other := ln.Nodes[1].(*p2pNetwork)
+	require.NotEmpty(t, quicAddrs(other.host.Addrs()))
+	require.True(t, node.idx.Self().Metadata.Capabilities.SupportsTransport(records.TransportQUIC))
+
+	// Dial over QUIC even if the nodes are already connected over TCP.
+	dialCtx := libp2pnetwork.WithForceDirectDial(ctx, "quic test")
+	require.NoError(t, node.host.Connect(dialCtx, peer.AddrInfo{ID: other.host.ID(), Addrs: quicAddrs(other.host.Addrs())}))
+
+	var remoteAddrs []ma.Multiaddr
+	for _, conn := range node.host.Network().ConnsToPeer(other.host.ID()) {
+		remoteAddrs = append(remoteAddrs, conn.RemoteMultiaddr())
+	}
+	require.NotEmpty(t, quicAddrs(remoteAddrs))
+
+	// Peers connected over multiple transports are counted once.
+	inbound, outbound := node.connectionStats()
+	require.Equal(t, len(node.host.Network().Peers()), inbound+outbound)
+}
+
+func TestP2pNetwork_QUICBroadcast(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shares := []*ssvtypes.SSVShare{
+		{
+			Share:      *spectestingutils.TestingShare(spectestingutils.Testing4SharesSet(), spectestingutils.TestingValidatorIndex),
+			Status:     eth2apiv1.ValidatorStateActiveOngoing,
+			Liquidated: false,
+		},
+	}
+
+	ln, routers, err := createNetworkAndSubscribe(t, ctx, LocalNetOptions{
+		Nodes:        2,
+		MinConnected: 1,
+		UseDiscv5:    false,
+		Shares:       shares,
+		QUIC:         true,
+	})
+	require.NoError(t, err)
+
+	defer func() {
+		for _, node := range ln.Nodes {
+			require.NoError(t, node.(*p2pNetwork).Close())
+		}
+	}()
+
+	node := ln.Nodes[0].(*p2pNetwork)
+	other := ln.Nodes[1].(*p2pNetwork)
+
+	// Connect over QUIC and drop any other connection, so that messages can only go through QUIC.
+	dialCtx := libp2pnetwork.WithForceDirectDial(ctx, "quic test")
+	require.NoError(t, node.host.Connect(dialCtx, peer.AddrInfo{ID: other.host.ID(), Addrs: quicAddrs(other.host.Addrs())}))
+	for _, conn := range node.host.Network().ConnsToPeer(other.host.ID()) {
+		if len(quicAddrs([]ma.Multiaddr{conn.RemoteMultiaddr()})) == 0 {
+			require.NoError(t, conn.Close())
+		}
+	}
+	onlyQUIC := func() bool {
+		conns := node.host.Network().ConnsToPeer(other.host.ID())
+		for _, conn := range conns {
+			if len(quicAddrs([]ma.Multiaddr{conn.RemoteMultiaddr()})) == 0 {
+				return false
+			}
+		}
+		return len(conns) > 0
+	}
+	require.Eventually(t, onlyQUIC, 5*time.Second, 100*time.Millisecond)
+
+	// Let pubsub reopen its streams over the remaining connection.
+	time.Sleep(3 * time.Second)
+
+	// Nodes also route their own messages, so each message is counted by both routers.
+	routed := func(n uint64) func() bool {
+		return func() bool {
+			return atomic.LoadUint64(&routers[0].count) >= n && atomic.LoadUint64(&routers[1].count) >= n
+		}
+	}
+
+	msgCommittee := generateCommitteeMsg(spectestingutils.Testing4SharesSet(), 1)
+	require.NoError(t, node.Broadcast(msgCommittee.SSVMessage.GetID(), msgCommittee))
+	require.Eventually(t, routed(1), 5*time.Second, 100*time.Millisecond)
+
+	msgProposer := generateValidatorMsg(spectestingutils.Testing4SharesSet(), 4, spectypes.RoleProposer)
+	require.NoError(t, other.Broadcast(msgProposer.SSVMessage.GetID(), msgProposer))
+	require.Eventually(t, routed(2), 5*time.Second, 100*time.Millisecond)
+
+	require.True(t, onlyQUIC())
+}
+
+// quicAddrs returns the QUIC addresses among the given ones.
+func quicAddrs(addrs []ma.Multiaddr) []ma.Multiaddr {
+	var quic []ma.Multiaddr
+	for _, addr := range addrs {
+		if _, err := addr.ValueForProtocol(ma.P_QUIC_V1); err == nil {
+			quic = append(quic, addr)
+		}
+	}
+	return quic
+}
+
 func TestP2pNetwork_SubscribeBroadcast(t *testing.T) {
 	n := 4
 	ctx, cancel := context.WithCancel(context.Background())
@@ -177,6 +177,9 @@ func (ln *LocalNet) NewTestP2pNetwork(ctx context.Context, nodeIndex uint64, key
 
 	cfg := NewNetConfig(keys, format.OperatorID([]byte(operatorPubkey)), ln.Bootnode, testing.RandomTCPPort(12001, 12999), ln.udpRand.Next(13001, 13999), options.Nodes)
 	cfg.Ctx = ctx
+	if options.QUIC {
+		cfg.QUICPort = ln.udpRand.Next(14001, 14999)
+	}
 	cfg.Subnets = "00000000000000000100000400000400" // calculated for topics 64, 90, 114; PAY ATTENTION for future test scenarios which use more than one eth-validator we need to make this field dynamically changing
 	cfg.NodeStorage = nodeStorage
 	cfg.MessageValidator = validation.New(
@@ -246,6 +249,8 @@ type LocalNetOptions struct {
 	PeerScoreInspector                              func(selfPeer peer.ID, peerMap map[peer.ID]*pubsub.PeerScoreSnapshot)
 	PeerScoreInspectorInterval                      time.Duration
 	Shares                                          []*ssvtypes.SSVShare
+	// QUIC enables the QUIC transport on every node, in addition to TCP.
+	QUIC bool
 }
 
 // NewLocalNet creates a new mdns network
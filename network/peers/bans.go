@@ -0,0 +1,249 @@
+package peers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var bansPrefix = []byte("p2p_bans/")
+
+// Ban refuses connections with either a peer or the peers of an IP range, until it expires.
+type Ban struct {
+	PeerID peer.ID `json:"peer_id,omitempty"`
+	// CIDR is an IP range, a single IP is normalized to a range containing only it.
+	CIDR      string    `json:"cidr,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// ExpiresAt is when the ban is lifted, the ban is permanent if it's zero.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Expired returns whether the ban was lifted at the given time.
+func (b Ban) Expired(now time.Time) bool {
+	return !b.ExpiresAt.IsZero() && !now.Before(b.ExpiresAt)
+}
+
+// Normalize returns the ban with its IP range in canonical form,
+// or an error if it doesn't target exactly one of a peer and a valid IP range.
+func (b Ban) Normalize() (Ban, error) {
+	b, _, err := b.normalize()
+	return b, err
+}
+
+// normalize validates the target of the ban and returns it with its CIDR in canonical form.
+func (b Ban) normalize() (Ban, *net.IPNet, error) {
+	if (b.PeerID == "") == (b.CIDR == "") {
+		return Ban{}, nil, errors.New("exactly one of peer ID and CIDR must be set")
+	}
+	if b.PeerID != "" {
+		if _, err := peer.IDFromBytes([]byte(b.PeerID)); err != nil {
+			return Ban{}, nil, fmt.Errorf("invalid peer ID: %w", err)
+		}
+		return b, nil, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(b.CIDR)
+	if err != nil {
+		ip := net.ParseIP(b.CIDR)
+		if ip == nil {
+			return Ban{}, nil, fmt.Errorf("invalid CIDR or IP %q", b.CIDR)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		ipNet = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+	}
+	b.CIDR = ipNet.String()
+	return b, ipNet, nil
+}
+
+// key identifies the target of a normalized ban.
+func (b Ban) key() string {
+	if b.PeerID != "" {
+		return "peer/" + b.PeerID.String()
+	}
+	return "cidr/" + b.CIDR
+}
+
+type ipBan struct {
+	Ban
+	ipNet *net.IPNet
+}
+
+// BanList keeps the bans in memory for the connection gater, and persists them so that they outlive restarts.
+type BanList struct {
+	db basedb.Database
+
+	mu    sync.RWMutex
+	peers map[peer.ID]Ban
+	ips   map[string]ipBan
+}
+
+// NewBanList returns a BanList with the bans persisted in db, it only keeps bans in memory if db is nil.
+// Expired and corrupt bans are deleted from db.
+func NewBanList(logger *zap.Logger, db basedb.Database) (*BanList, error) {
+	b := &BanList{
+		db:    db,
+		peers: make(map[peer.ID]Ban),
+		ips:   make(map[string]ipBan),
+	}
+	if db == nil {
+		return b, nil
+	}
+
+	var stale [][]byte
+	now := time.Now()
+	err := db.GetAll(bansPrefix, func(_ int, obj basedb.Obj) error {
+		var ban Ban
+		if err := json.Unmarshal(obj.Value, &ban); err != nil {
+			logger.Warn("deleting corrupt ban", zap.ByteString("key", obj.Key), zap.Error(err))
+			stale = append(stale, obj.Key)
+			return nil
+		}
+		if ban.Expired(now) {
+			stale = append(stale, obj.Key)
+			return nil
+		}
+		if err := b.set(ban); err != nil {
+			logger.Warn("deleting corrupt ban", zap.ByteString("key", obj.Key), zap.Error(err))
+			stale = append(stale, obj.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load bans: %w", err)
+	}
+
+	for _, key := range stale {
+		if err := db.Delete(bansPrefix, key); err != nil {
+			return nil, fmt.Errorf("delete stale ban: %w", err)
+		}
+	}
+	return b, nil
+}
+
+func (b *BanList) set(ban Ban) error {
+	ban, ipNet, err := ban.normalize()
+	if err != nil {
+		return err
+	}
+	if ban.PeerID != "" {
+		b.peers[ban.PeerID] = ban
+	} else {
+		b.ips[ban.CIDR] = ipBan{Ban: ban, ipNet: ipNet}
+	}
+	return nil
+}
+
+// Add adds or replaces the ban of its peer or IP range.
+func (b *BanList) Add(ban Ban) error {
+	ban, _, err := ban.normalize()
+	if err != nil {
+		return err
+	}
+	if ban.CreatedAt.IsZero() {
+		ban.CreatedAt = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.db != nil {
+		value, err := json.Marshal(ban)
+		if err != nil {
+			return fmt.Errorf("encode ban: %w", err)
+		}
+		if err := b.db.Set(bansPrefix, []byte(ban.key()), value); err != nil {
+			return fmt.Errorf("save ban: %w", err)
+		}
+	}
+	return b.set(ban)
+}
+
+// Remove lifts the ban of the peer or IP range of the given ban, returns false if it wasn't banned.
+func (b *BanList) Remove(target Ban) (bool, error) {
+	target, _, err := target.normalize()
+	if err != nil {
+		return false, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if target.PeerID != "" {
+		if _, ok := b.peers[target.PeerID]; !ok {
+			return false, nil
+		}
+	} else if _, ok := b.ips[target.CIDR]; !ok {
+		return false, nil
+	}
+
+	if b.db != nil {
+		if err := b.db.Delete(bansPrefix, []byte(target.key())); err != nil {
+			return false, fmt.Errorf("delete ban: %w", err)
+		}
+	}
+	if target.PeerID != "" {
+		delete(b.peers, target.PeerID)
+	} else {
+		delete(b.ips, target.CIDR)
+	}
+	return true, nil
+}
+
+// List returns the bans which haven't expired, oldest first.
+func (b *BanList) List() []Ban {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	now := time.Now()
+	bans := make([]Ban, 0, len(b.peers)+len(b.ips))
+	for _, ban := range b.peers {
+		if !ban.Expired(now) {
+			bans = append(bans, ban)
+		}
+	}
+	for _, ban := range b.ips {
+		if !ban.Expired(now) {
+			bans = append(bans, ban.Ban)
+		}
+	}
+	slices.SortFunc(bans, func(a, b Ban) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return bans
+}
+
+// IsPeerBanned returns whether the peer is banned.
+func (b *BanList) IsPeerBanned(id peer.ID) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ban, ok := b.peers[id]
+	return ok && !ban.Expired(time.Now())
+}
+
+// IsIPBanned returns whether the IP is within a banned range.
+func (b *BanList) IsIPBanned(ip net.IP) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	now := time.Now()
+	for _, ban := range b.ips {
+		if ban.ipNet.Contains(ip) && !ban.Expired(now) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package peers
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestBanList(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	pids, err := createPeerIDs(3)
+	require.NoError(t, err)
+	banned, other, expired := pids[0], pids[1], pids[2]
+
+	bans, err := NewBanList(logger, db)
+	require.NoError(t, err)
+
+	require.Error(t, bans.Add(Ban{}))
+	require.Error(t, bans.Add(Ban{PeerID: banned, CIDR: "10.0.0.0/8"}))
+	require.Error(t, bans.Add(Ban{CIDR: "not an ip"}))
+	require.Error(t, bans.Add(Ban{PeerID: "not a peer id"}))
+
+	require.NoError(t, bans.Add(Ban{PeerID: banned, Reason: "spam"}))
+	require.NoError(t, bans.Add(Ban{CIDR: "10.0.0.0/8"}))
+	require.NoError(t, bans.Add(Ban{CIDR: "192.168.1.1"}))
+	require.NoError(t, bans.Add(Ban{PeerID: expired, ExpiresAt: time.Now().Add(-time.Second)}))
+
+	require.True(t, bans.IsPeerBanned(banned))
+	require.False(t, bans.IsPeerBanned(other))
+	require.False(t, bans.IsPeerBanned(expired))
+	require.True(t, bans.IsIPBanned(net.ParseIP("10.1.2.3")))
+	require.True(t, bans.IsIPBanned(net.ParseIP("192.168.1.1")))
+	require.False(t, bans.IsIPBanned(net.ParseIP("192.168.1.2")))
+	require.Len(t, bans.List(), 3)
+
+	// Bans are restored from the database, without the expired and corrupt ones.
+	require.NoError(t, db.Set(bansPrefix, []byte("corrupt"), []byte("{")))
+	require.NoError(t, db.Set(bansPrefix, []byte("invalid"), []byte(`{"cidr":"not an ip"}`)))
+	restored, err := NewBanList(logger, db)
+	require.NoError(t, err)
+	require.True(t, restored.IsPeerBanned(banned))
+	require.True(t, restored.IsIPBanned(net.ParseIP("192.168.1.1")))
+	list := restored.List()
+	require.Len(t, list, 3)
+	require.Equal(t, "spam", list[0].Reason)
+	require.Equal(t, "192.168.1.1/32", list[2].CIDR)
+
+	removed, err := restored.Remove(Ban{CIDR: "192.168.1.1/32"})
+	require.NoError(t, err)
+	require.True(t, removed)
+	removed, err = restored.Remove(Ban{PeerID: other})
+	require.NoError(t, err)
+	require.False(t, removed)
+
+	restored, err = NewBanList(logger, db)
+	require.NoError(t, err)
+	require.False(t, restored.IsIPBanned(net.ParseIP("192.168.1.1")))
+	require.Len(t, restored.List(), 2)
+
+	_, found, err := db.Get(bansPrefix, []byte("corrupt"))
+	require.NoError(t, err)
+	require.False(t, found)
+	_, found, err = db.Get(bansPrefix, []byte("invalid"))
+	require.NoError(t, err)
+	require.False(t, found)
+}
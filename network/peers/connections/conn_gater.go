@@ -1,6 +1,7 @@
 package connections
 
 import (
+	"net"
 	"runtime"
 	"time"
 
@@ -26,6 +27,8 @@ const (
 
 type IsBadPeerF func(logger *zap.Logger, peerID peer.ID) bool
 type AtInboundLimitF func() bool
+type IsBannedIPF func(ip net.IP) bool
+type IsConnectedF func(peerID peer.ID) bool
 
 // connGater implements ConnectionGater interface:
 // https://github.com/libp2p/go-libp2p/core/blob/master/connmgr/gater.go
@@ -35,7 +38,9 @@ type connGater struct {
 	atMaxPeersLimit func() bool
 	ipLimiter       *leakybucket.Collector
 	isBadPeer       IsBadPeerF
+	isBannedIP      IsBannedIPF
 	atInboundLimit  AtInboundLimitF
+	isConnected     IsConnectedF
 	trimmedRecently *ttl.Map[peer.ID, struct{}]
 }
 
@@ -45,7 +50,9 @@ func NewConnectionGater(
 	disable bool,
 	atLimit func() bool,
 	isBadPeer IsBadPeerF,
+	isBannedIP IsBannedIPF,
 	atInboundLimit AtInboundLimitF,
+	isConnected IsConnectedF,
 	trimmedRecently *ttl.Map[peer.ID, struct{}],
 ) connmgr.ConnectionGater {
 	return &connGater{
@@ -54,7 +61,9 @@ func NewConnectionGater(
 		atMaxPeersLimit: atLimit,
 		ipLimiter:       leakybucket.NewCollector(ipLimitRate, ipLimitBurst, ipLimitPeriod, true),
 		isBadPeer:       isBadPeer,
+		isBannedIP:      isBannedIP,
 		atInboundLimit:  atInboundLimit,
+		isConnected:     isConnected,
 		trimmedRecently: trimmedRecently,
 	}
 }
@@ -63,6 +72,10 @@ func NewConnectionGater(
 // to the addresses of that peer being available/resolved. Blocking connections
 // at this stage is typical for blacklisting scenarios
 func (n *connGater) InterceptPeerDial(id peer.ID) bool {
+	if n.isBadPeer(n.logger, id) {
+		n.logger.Debug("preventing outbound dial due to bad peer", fields.PeerID(id))
+		return false
+	}
 	return true
 }
 
@@ -74,6 +87,10 @@ func (n *connGater) InterceptAddrDial(id peer.ID, multiaddr ma.Multiaddr) bool {
 		n.logger.Debug("preventing outbound connection due to bad peer", fields.PeerID(id))
 		return false
 	}
+	if n.isBannedAddr(multiaddr) {
+		n.logger.Debug("preventing outbound connection due to banned IP", fields.PeerID(id), zap.String("addr", multiaddr.String()))
+		return false
+	}
 	return true
 }
 
@@ -82,22 +99,28 @@ func (n *connGater) InterceptAddrDial(id peer.ID, multiaddr ma.Multiaddr) bool {
 // accept already secure and/or multiplexed connections (e.g. possibly QUIC)
 // MUST call this method regardless, for correctness/consistency.
 func (n *connGater) InterceptAccept(multiaddrs libp2pnetwork.ConnMultiaddrs) bool {
+	remoteAddr := multiaddrs.RemoteMultiaddr()
+	// Bans are enforced even when the gater is disabled, since they are set explicitly.
+	if n.isBannedAddr(remoteAddr) {
+		n.logger.Debug("connection rejected due to banned IP", zap.String("remote_addr", remoteAddr.String()))
+		return false
+	}
+
 	if n.disable {
 		return true
 	}
-	if n.atInboundLimit() {
-		return false
-	}
 
-	remoteAddr := multiaddrs.RemoteMultiaddr()
-	if !n.validateDial(remoteAddr) {
+	// The peer isn't known yet, so the limits are enforced in InterceptSecured, where a peer which is already
+	// connected over another transport can be told apart. IPs which ran out of dials are rejected right away though,
+	// to spare the security handshake.
+	if !n.hasDialsLeft(remoteAddr) {
 		// Yield this goroutine to allow others to run in-between connection attempts.
 		runtime.Gosched()
 
 		n.logger.Debug("connection rejected due to IP rate limit", zap.String("remote_addr", remoteAddr.String()))
 		return false
 	}
-	return !n.atMaxPeersLimit()
+	return true
 }
 
 // InterceptSecured is called for both inbound and outbound connections,
@@ -115,7 +138,25 @@ func (n *connGater) InterceptSecured(direction libp2pnetwork.Direction, id peer.
 		n.logger.Debug("rejecting inbound connection due to bad peer", fields.PeerID(id))
 		return false
 	}
-	return true
+
+	if direction != libp2pnetwork.DirInbound || n.disable {
+		return true
+	}
+	// A peer connecting over another transport is already accounted for.
+	if n.isConnected(id) {
+		return true
+	}
+	if n.atInboundLimit() {
+		return false
+	}
+	if !n.validateDial(multiaddrs.RemoteMultiaddr()) {
+		// Yield this goroutine to allow others to run in-between connection attempts.
+		runtime.Gosched()
+
+		n.logger.Debug("connection rejected due to IP rate limit", fields.PeerID(id), zap.String("remote_addr", multiaddrs.RemoteMultiaddr().String()))
+		return false
+	}
+	return !n.atMaxPeersLimit()
 }
 
 // InterceptUpgraded is called for inbound and outbound connections, after
@@ -125,6 +166,23 @@ func (n *connGater) InterceptUpgraded(conn libp2pnetwork.Conn) (bool, control.Di
 	return true, 0
 }
 
+func (n *connGater) isBannedAddr(addr ma.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	return n.isBannedIP(ip)
+}
+
+// hasDialsLeft returns whether the IP of addr hasn't reached its rate limit, without counting a dial.
+func (n *connGater) hasDialsLeft(addr ma.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	return n.ipLimiter.Remaining(ip.String()) > 0
+}
+
 func (n *connGater) validateDial(addr ma.Multiaddr) bool {
 	ip, err := manet.ToIP(addr)
 	if err != nil {
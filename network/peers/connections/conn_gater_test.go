@@ -0,0 +1,107 @@
+package connections
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/utils/ttl"
+)
+
+type testConnMultiaddrs struct {
+	local, remote ma.Multiaddr
+}
+
+func (c testConnMultiaddrs) LocalMultiaddr() ma.Multiaddr  { return c.local }
+func (c testConnMultiaddrs) RemoteMultiaddr() ma.Multiaddr { return c.remote }
+
+func TestConnGater_Bans(t *testing.T) {
+	bans, err := peers.NewBanList(logging.TestLogger(t), nil)
+	require.NoError(t, err)
+
+	sk, _, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	bannedPeer, err := peer.IDFromPrivateKey(sk)
+	require.NoError(t, err)
+	require.NoError(t, bans.Add(peers.Ban{PeerID: bannedPeer}))
+	require.NoError(t, bans.Add(peers.Ban{CIDR: "10.0.0.0/8"}))
+
+	for _, disable := range []bool{false, true} {
+		gater := NewConnectionGater(
+			logging.TestLogger(t),
+			disable,
+			func() bool { return false },
+			func(_ *zap.Logger, id peer.ID) bool { return bans.IsPeerBanned(id) },
+			bans.IsIPBanned,
+			func() bool { return false },
+			func(peer.ID) bool { return false },
+			ttl.New[peer.ID, struct{}](time.Minute, time.Minute),
+		)
+
+		require.False(t, gater.InterceptPeerDial(bannedPeer))
+		require.True(t, gater.InterceptPeerDial("other"))
+
+		bannedAddr := ma.StringCast("/ip4/10.1.2.3/tcp/13001")
+		allowedAddr := ma.StringCast("/ip4/192.168.1.1/tcp/13001")
+		require.False(t, gater.InterceptAddrDial("other", bannedAddr))
+		require.True(t, gater.InterceptAddrDial("other", allowedAddr))
+
+		local := ma.StringCast("/ip4/127.0.0.1/tcp/13001")
+		require.False(t, gater.InterceptAccept(testConnMultiaddrs{local: local, remote: bannedAddr}))
+		require.True(t, gater.InterceptAccept(testConnMultiaddrs{local: local, remote: allowedAddr}))
+	}
+
+	require.True(t, bans.IsIPBanned(net.ParseIP("10.255.0.1")))
+}
+
+func TestConnGater_MultipleTransports(t *testing.T) {
+	bans, err := peers.NewBanList(logging.TestLogger(t), nil)
+	require.NoError(t, err)
+
+	connectedPeer := peer.ID("connected")
+	atLimit := false
+	gater := NewConnectionGater(
+		logging.TestLogger(t),
+		false,
+		func() bool { return atLimit },
+		func(*zap.Logger, peer.ID) bool { return false },
+		bans.IsIPBanned,
+		func() bool { return atLimit },
+		func(id peer.ID) bool { return id == connectedPeer },
+		ttl.New[peer.ID, struct{}](time.Minute, time.Minute),
+	)
+
+	local := ma.StringCast("/ip4/127.0.0.1/udp/13002/quic-v1")
+	remote := testConnMultiaddrs{local: local, remote: ma.StringCast("/ip4/192.168.1.1/udp/13002/quic-v1")}
+
+	// Connections of a peer which is already connected over another transport don't use up the IP's dials.
+	for i := 0; i < 2*ipLimitBurst; i++ {
+		require.True(t, gater.InterceptAccept(remote))
+		require.True(t, gater.InterceptSecured(libp2pnetwork.DirInbound, connectedPeer, remote))
+	}
+
+	// Nor are they rejected due to the peers limits, unlike connections of other peers.
+	atLimit = true
+	require.True(t, gater.InterceptSecured(libp2pnetwork.DirInbound, connectedPeer, remote))
+	require.False(t, gater.InterceptSecured(libp2pnetwork.DirInbound, "other", remote))
+
+	// Other peers use up the IP's dials.
+	atLimit = false
+	for i := 0; i < ipLimitBurst; i++ {
+		require.True(t, gater.InterceptAccept(remote))
+		require.True(t, gater.InterceptSecured(libp2pnetwork.DirInbound, peer.ID(fmt.Sprintf("peer%d", i)), remote))
+	}
+	require.False(t, gater.InterceptAccept(remote))
+	require.False(t, gater.InterceptSecured(libp2pnetwork.DirInbound, "other", remote))
+}
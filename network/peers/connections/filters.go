@@ -30,4 +30,19 @@ func BadPeerFilter(logger *zap.Logger, n peers.Index) HandshakeFilter {
 	}
 }
 
+// ForkFilter avoids connecting to nodes whose capabilities don't include the given fork,
+// nodes which don't advertise capabilities are accepted
+func ForkFilter(fork string) HandshakeFilter {
+	return func(sender peer.ID, ni *records.NodeInfo) error {
+		metadata := ni.GetNodeInfo().Metadata
+		if metadata == nil || metadata.Capabilities == nil {
+			return nil
+		}
+		if !metadata.Capabilities.SupportsFork(fork) {
+			return errors.Errorf("unsupported fork (want %s, got %v)", fork, metadata.Capabilities.Forks)
+		}
+		return nil
+	}
+}
+
 // TODO: filter based on domaintype
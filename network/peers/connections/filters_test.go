@@ -21,3 +21,23 @@ func TestNetworkIDFilter(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestForkFilter(t *testing.T) {
+	f := ForkFilter("alan")
+
+	err := f("", &records.NodeInfo{})
+	require.NoError(t, err)
+
+	err = f("", &records.NodeInfo{Metadata: &records.NodeMetadata{}})
+	require.NoError(t, err)
+
+	err = f("", &records.NodeInfo{Metadata: &records.NodeMetadata{
+		Capabilities: &records.Capabilities{Version: records.CapabilitiesVersion, Forks: []string{"alan", "next"}},
+	}})
+	require.NoError(t, err)
+
+	err = f("", &records.NodeInfo{Metadata: &records.NodeMetadata{
+		Capabilities: &records.Capabilities{Version: records.CapabilitiesVersion, Forks: []string{"next"}},
+	}})
+	require.Error(t, err)
+}
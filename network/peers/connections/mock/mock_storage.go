@@ -46,6 +46,18 @@ func (m NodeStorage) GetLastProcessedBlock(txn basedb.Reader) (*big.Int, bool, e
 	panic("unexpected GetLastProcessedBlock call")
 }
 
+func (m NodeStorage) SaveEventHashChain(txn basedb.ReadWriter, chain storage.EventHashChain) error {
+	panic("unexpected SaveEventHashChain call")
+}
+
+func (m NodeStorage) GetEventHashChain(txn basedb.Reader) (storage.EventHashChain, bool, error) {
+	panic("unexpected GetEventHashChain call")
+}
+
+func (m NodeStorage) DeleteEventHashChain(txn basedb.ReadWriter) error {
+	panic("unexpected DeleteEventHashChain call")
+}
+
 func (m NodeStorage) DropRegistryData() error {
 	panic("unexpected DropRegistryData call")
 }
@@ -100,6 +112,10 @@ func (m NodeStorage) DeleteRecipientData(txn basedb.ReadWriter, owner common.Add
 	panic("unexpected DeleteRecipientData call")
 }
 
+func (m NodeStorage) ListRecipients(txn basedb.Reader) ([]registrystorage.RecipientData, error) {
+	panic("unexpected ListRecipients call")
+}
+
 func (m NodeStorage) GetRecipientsPrefix() []byte {
 	panic("unexpected GetRecipientsPrefix call")
 }
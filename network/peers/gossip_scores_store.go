@@ -0,0 +1,82 @@
+package peers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var gossipScoresPrefix = []byte("p2p_scores/")
+
+// GossipScore is the Gossip score of a peer along with the time it was computed at.
+type GossipScore struct {
+	Score     float64   `json:"score"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GossipScoresStore persists the Gossip scores of peers, so that misbehaving peers
+// are still recognized after a restart.
+type GossipScoresStore struct {
+	db basedb.Database
+}
+
+// NewGossipScoresStore returns a GossipScoresStore persisting the scores in db.
+func NewGossipScoresStore(db basedb.Database) *GossipScoresStore {
+	return &GossipScoresStore{db: db}
+}
+
+// Save atomically replaces the persisted scores with the given scores.
+func (s *GossipScoresStore) Save(scores map[peer.ID]GossipScore) error {
+	return s.db.Update(func(txn basedb.Txn) error {
+		var stale [][]byte
+		err := txn.GetRange(gossipScoresPrefix, basedb.RangeOptions{KeysOnly: true}, func(obj basedb.Obj) error {
+			if _, ok := scores[peer.ID(obj.Key)]; !ok {
+				stale = append(stale, obj.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("list scores: %w", err)
+		}
+		for _, key := range stale {
+			if err := txn.Delete(gossipScoresPrefix, key); err != nil {
+				return fmt.Errorf("delete score: %w", err)
+			}
+		}
+
+		for peerID, score := range scores {
+			value, err := json.Marshal(score)
+			if err != nil {
+				return fmt.Errorf("encode score: %w", err)
+			}
+			if err := txn.Set(gossipScoresPrefix, []byte(peerID), value); err != nil {
+				return fmt.Errorf("save score: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// Load returns the persisted scores which were updated within maxAge.
+func (s *GossipScoresStore) Load(maxAge time.Duration) (map[peer.ID]GossipScore, error) {
+	scores := make(map[peer.ID]GossipScore)
+	err := s.db.GetAll(gossipScoresPrefix, func(_ int, obj basedb.Obj) error {
+		var stored GossipScore
+		if err := json.Unmarshal(obj.Value, &stored); err != nil {
+			return fmt.Errorf("decode score of %s: %w", peer.ID(obj.Key), err)
+		}
+		if time.Since(stored.UpdatedAt) > maxAge {
+			return nil
+		}
+		scores[peer.ID(obj.Key)] = stored
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load scores: %w", err)
+	}
+	return scores, nil
+}
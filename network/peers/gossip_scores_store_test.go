@@ -0,0 +1,57 @@
+package peers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestGossipScoresStore(t *testing.T) {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	store := NewGossipScoresStore(db)
+	now := time.Now()
+	require.NoError(t, store.Save(map[peer.ID]GossipScore{
+		"a": {Score: -20000, UpdatedAt: now.Add(-2 * time.Hour)},
+		"b": {Score: 10, UpdatedAt: now.Add(-2 * time.Hour)},
+	}))
+
+	scores, err := store.Load(time.Hour)
+	require.NoError(t, err)
+	require.Empty(t, scores)
+
+	// Saving replaces all the persisted scores.
+	recent := GossipScore{Score: -20000, UpdatedAt: now.Add(-30 * time.Minute)}
+	require.NoError(t, store.Save(map[peer.ID]GossipScore{"a": recent}))
+	scores, err = store.Load(3 * time.Hour)
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	require.Equal(t, recent.Score, scores["a"].Score)
+	require.True(t, recent.UpdatedAt.Equal(scores["a"].UpdatedAt))
+
+	index := NewGossipScoreIndex()
+	index.RestoreScores(scores, time.Hour)
+	bad, _ := index.HasBadGossipScore("a")
+	require.True(t, bad)
+
+	// Restored scores are saved again with the time they were computed at, so they still expire.
+	require.Equal(t, scores, index.Scores())
+	index.RestoreScores(map[peer.ID]GossipScore{"b": {Score: 10, UpdatedAt: now.Add(-2 * time.Hour)}}, time.Hour)
+	require.NotContains(t, index.Scores(), peer.ID("b"))
+
+	// Live scores take over the restored ones.
+	index.SetScores(map[peer.ID]float64{"a": 1})
+	require.Equal(t, 1.0, index.Scores()["a"].Score)
+	index.SetScores(map[peer.ID]float64{})
+	_, exists := index.GetGossipScore("a")
+	require.False(t, exists)
+	require.Empty(t, index.Scores())
+}
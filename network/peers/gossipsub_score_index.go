@@ -2,6 +2,7 @@ package peers
 
 import (
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 
@@ -11,17 +12,27 @@ import (
 // Implements GossipScoreIndex
 type gossipScoreIndex struct {
 	score map[peer.ID]float64
-	mutex sync.RWMutex
+	// updatedAt is the time score was set at.
+	updatedAt time.Time
+	// restored holds the scores persisted before a restart, until gossipsub scores the peer again or they expire.
+	restored map[peer.ID]restoredScore
+	mutex    sync.RWMutex
 
 	graylistThreshold float64
 }
 
+type restoredScore struct {
+	GossipScore
+	expiresAt time.Time
+}
+
 func NewGossipScoreIndex() *gossipScoreIndex {
 
 	graylistThreshold := params.PeerScoreThresholds().GraylistThreshold
 
 	return &gossipScoreIndex{
 		score:             make(map[peer.ID]float64),
+		restored:          make(map[peer.ID]restoredScore),
 		graylistThreshold: graylistThreshold,
 	}
 }
@@ -33,6 +44,9 @@ func (g *gossipScoreIndex) GetGossipScore(peerID peer.ID) (float64, bool) {
 	if score, exists := g.score[peerID]; exists {
 		return score, true
 	}
+	if restored, exists := g.restored[peerID]; exists && time.Now().Before(restored.expiresAt) {
+		return restored.Score, true
+	}
 	return 0.0, false
 }
 
@@ -41,9 +55,43 @@ func (g *gossipScoreIndex) SetScores(peerScores map[peer.ID]float64) {
 	defer g.mutex.Unlock()
 
 	g.clear()
+	g.updatedAt = time.Now()
 	// Copy the map
 	for peerID, score := range peerScores {
 		g.score[peerID] = score
+		delete(g.restored, peerID)
+	}
+}
+
+func (g *gossipScoreIndex) Scores() map[peer.ID]GossipScore {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	now := time.Now()
+	scores := make(map[peer.ID]GossipScore, len(g.score)+len(g.restored))
+	for peerID, restored := range g.restored {
+		if !now.Before(restored.expiresAt) {
+			delete(g.restored, peerID)
+			continue
+		}
+		// Restored scores keep the time they were computed at, so that they still expire after being saved again.
+		scores[peerID] = restored.GossipScore
+	}
+	for peerID, score := range g.score {
+		scores[peerID] = GossipScore{Score: score, UpdatedAt: g.updatedAt}
+	}
+	return scores
+}
+
+func (g *gossipScoreIndex) RestoreScores(peerScores map[peer.ID]GossipScore, ttl time.Duration) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for peerID, score := range peerScores {
+		if _, exists := g.score[peerID]; exists {
+			continue
+		}
+		g.restored[peerID] = restoredScore{GossipScore: score, expiresAt: score.UpdatedAt.Add(ttl)}
 	}
 }
 
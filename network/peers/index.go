@@ -2,6 +2,7 @@ package peers
 
 import (
 	"io"
+	"time"
 
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -120,6 +121,11 @@ type GossipScoreIndex interface {
 	GetGossipScore(peerID peer.ID) (float64, bool)
 	// HasBadGossipScore returns true if the peer has a bad Gossip score
 	HasBadGossipScore(peerID peer.ID) (bool, float64)
+	// Scores returns a copy of the current Gossip scores, including the restored ones which haven't expired
+	Scores() map[peer.ID]GossipScore
+	// RestoreScores sets scores persisted before a restart, which are used until the peers are scored again
+	// or ttl passes since they were computed
+	RestoreScores(scores map[peer.ID]GossipScore, ttl time.Duration)
 }
 
 // Index is a facade interface of this package
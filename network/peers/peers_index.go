@@ -96,12 +96,23 @@ func (pi *peersIndex) CanConnect(id peer.ID) error {
 	return nil
 }
 
+// AtLimit counts peers rather than connections, so that peers connected over multiple transports are only counted once.
 func (pi *peersIndex) AtLimit(dir libp2pnetwork.Direction) bool {
 	maxPeers := pi.maxPeers("")
 	peers := pi.network.Peers()
 	return len(peers) > maxPeers
 }
 
+// PeerDirection returns the direction of the first connection to the peer, which is the one a peer connected over
+// multiple transports is accounted by, or DirUnknown if it isn't connected.
+func PeerDirection(network libp2pnetwork.Network, id peer.ID) libp2pnetwork.Direction {
+	conns := network.ConnsToPeer(id)
+	if len(conns) == 0 {
+		return libp2pnetwork.DirUnknown
+	}
+	return conns[0].Stat().Direction
+}
+
 func (pi *peersIndex) UpdateSelfRecord(update func(self *records.NodeInfo) *records.NodeInfo) {
 	pi.selfLock.Lock()
 	defer pi.selfLock.Unlock()
@@ -199,3 +210,11 @@ func (pi *peersIndex) GetGossipScore(peerID peer.ID) (float64, bool) {
 func (pi *peersIndex) HasBadGossipScore(peerID peer.ID) (bool, float64) {
 	return pi.gossipScoreIndex.HasBadGossipScore(peerID)
 }
+
+func (pi *peersIndex) Scores() map[peer.ID]GossipScore {
+	return pi.gossipScoreIndex.Scores()
+}
+
+func (pi *peersIndex) RestoreScores(scores map[peer.ID]GossipScore, ttl time.Duration) {
+	pi.gossipScoreIndex.RestoreScores(scores, ttl)
+}
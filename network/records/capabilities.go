@@ -0,0 +1,65 @@
+package records
+
+import "slices"
+
+// CapabilitiesVersion is the version of the capability set advertised by this node.
+// It's bumped whenever the meaning of an existing field changes, new fields don't require a bump
+// since nodes ignore the fields they don't know.
+const CapabilitiesVersion = 1
+
+// NodeMode is the mode a node runs in.
+type NodeMode string
+
+const (
+	// NodeModeOperator is a node running the duties of an operator.
+	NodeModeOperator NodeMode = "operator"
+	// NodeModeExporter is a node exporting the decided messages of the network.
+	NodeModeExporter NodeMode = "exporter"
+)
+
+const (
+	// TransportTCP is the name of the TCP transport in Capabilities.Transports.
+	TransportTCP = "tcp"
+	// TransportQUIC is the name of the QUIC transport in Capabilities.Transports.
+	TransportQUIC = "quic-v1"
+)
+
+// Capabilities describes what a node supports, so that peers can adjust to mixed versions and configurations.
+type Capabilities struct {
+	// Version is the CapabilitiesVersion of the node.
+	Version int `json:"version"`
+	// Mode is the mode the node runs in.
+	Mode NodeMode `json:"mode"`
+	// Forks are the names of the SSV protocol forks the node supports.
+	Forks []string `json:"forks,omitempty"`
+	// Transports are the libp2p transports the node listens on.
+	Transports []string `json:"transports,omitempty"`
+	// RemoteSigner is whether the node signs with ssv-signer.
+	RemoteSigner bool `json:"remote_signer,omitempty"`
+	// Protocols are the optional stream protocols the node serves.
+	Protocols []string `json:"protocols,omitempty"`
+}
+
+// SupportsFork returns whether the node supports the given fork.
+func (c *Capabilities) SupportsFork(fork string) bool {
+	return slices.Contains(c.Forks, fork)
+}
+
+// SupportsProtocol returns whether the node serves the given stream protocol.
+func (c *Capabilities) SupportsProtocol(protocol string) bool {
+	return slices.Contains(c.Protocols, protocol)
+}
+
+// SupportsTransport returns whether the node listens on the given transport.
+func (c *Capabilities) SupportsTransport(transport string) bool {
+	return slices.Contains(c.Transports, transport)
+}
+
+// Clone returns a deep copy of the capabilities.
+func (c *Capabilities) Clone() *Capabilities {
+	cpy := *c
+	cpy.Forks = slices.Clone(c.Forks)
+	cpy.Transports = slices.Clone(c.Transports)
+	cpy.Protocols = slices.Clone(c.Protocols)
+	return &cpy
+}
@@ -19,6 +19,8 @@ type NodeMetadata struct {
 	ConsensusNode string
 	// Subnets represents the subnets that our node is subscribed to
 	Subnets string
+	// Capabilities describes what the node supports, it's nil for nodes predating it
+	Capabilities *Capabilities `json:",omitempty"`
 }
 
 // Encode encodes the metadata into bytes
@@ -43,5 +45,8 @@ func (nm *NodeMetadata) Decode(data []byte) error {
 
 func (nm *NodeMetadata) Clone() *NodeMetadata {
 	cpy := *nm
+	if nm.Capabilities != nil {
+		cpy.Capabilities = nm.Capabilities.Clone()
+	}
 	return &cpy
 }
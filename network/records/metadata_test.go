@@ -85,3 +85,41 @@ func TestNodeMetadata_Decode(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeMetadata_Capabilities(t *testing.T) {
+	nodeMeta := NodeMetadata{
+		NodeVersion: "1.0.0",
+		Subnets:     strings.Repeat("0", 32),
+		Capabilities: &Capabilities{
+			Version:      CapabilitiesVersion,
+			Mode:         NodeModeExporter,
+			Forks:        []string{"alan"},
+			RemoteSigner: true,
+			Protocols:    []string{"/ssv/sync/decided/0.0.1"},
+		},
+	}
+
+	encoded, err := nodeMeta.Encode()
+	require.NoError(t, err)
+
+	var decoded NodeMetadata
+	require.NoError(t, decoded.Decode(encoded))
+	require.Equal(t, nodeMeta, decoded)
+	require.True(t, decoded.Capabilities.SupportsFork("alan"))
+	require.False(t, decoded.Capabilities.SupportsFork("unknown"))
+	require.True(t, decoded.Capabilities.SupportsProtocol("/ssv/sync/decided/0.0.1"))
+
+	// Clones don't share the capabilities.
+	cloned := nodeMeta.Clone()
+	cloned.Capabilities.Forks[0] = "other"
+	require.Equal(t, "alan", nodeMeta.Capabilities.Forks[0])
+
+	// Metadata of nodes predating capabilities is still decoded, and capabilities are omitted when unset.
+	var legacy NodeMetadata
+	require.NoError(t, legacy.Decode([]byte(`{"NodeVersion":"1.0.0","ExecutionNode":"","ConsensusNode":"","Subnets":"12341234123412341234123412341234"}`)))
+	require.Nil(t, legacy.Capabilities)
+
+	encoded, err = legacy.Encode()
+	require.NoError(t, err)
+	require.NotContains(t, string(encoded), "Capabilities")
+}
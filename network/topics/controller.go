@@ -30,6 +30,8 @@ type Controller interface {
 	// Peers returns a list of peers we are connected to in the given topic, if topicName
 	// param is an empty string it returns a list of all peers we are connected to.
 	Peers(topicName string) ([]peer.ID, error)
+	// MeshPeers returns a list of the peers in our gossipsub mesh of the given topic.
+	MeshPeers(topicName string) []peer.ID
 	// Topics lists all topics this node is subscribed to
 	Topics() []string
 	// Broadcast publishes the message on the given topic
@@ -57,6 +59,7 @@ type topicsCtrl struct {
 	msgValidator       messageValidator
 	msgHandler         PubsubMessageHandler
 	subFilter          SubFilter
+	meshTracker        *MeshTracker
 
 	container *topicsContainer
 }
@@ -70,6 +73,7 @@ func NewTopicsController(
 	subFilter SubFilter,
 	pubSub *pubsub.PubSub,
 	scoreParams func(string) *pubsub.TopicScoreParams,
+	meshTracker *MeshTracker,
 ) Controller {
 	ctrl := &topicsCtrl{
 		ctx:                ctx,
@@ -79,7 +83,8 @@ func NewTopicsController(
 		msgValidator:       msgValidator,
 		msgHandler:         msgHandler,
 
-		subFilter: subFilter,
+		subFilter:   subFilter,
+		meshTracker: meshTracker,
 	}
 
 	ctrl.container = newTopicsContainer(pubSub, ctrl.onNewTopic(logger))
@@ -156,6 +161,11 @@ func (ctrl *topicsCtrl) Peers(name string) ([]peer.ID, error) {
 	return topic.ListPeers(), nil
 }
 
+// MeshPeers returns a list of the peers in our gossipsub mesh of the given topic
+func (ctrl *topicsCtrl) MeshPeers(name string) []peer.ID {
+	return ctrl.meshTracker.MeshPeers(commons.GetTopicFullName(name))
+}
+
 // Topics lists all topics this node is subscribed to
 func (ctrl *topicsCtrl) Topics() []string {
 	topics := ctrl.ps.GetTopics()
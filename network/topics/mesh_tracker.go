@@ -0,0 +1,78 @@
+package topics
+
+import (
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// MeshTracker keeps track of the peers in our gossipsub mesh of each topic,
+// which pubsub doesn't expose otherwise. It implements pubsub.RawTracer.
+type MeshTracker struct {
+	mu   sync.RWMutex
+	mesh map[string]map[peer.ID]struct{}
+}
+
+// NewMeshTracker creates a new MeshTracker
+func NewMeshTracker() *MeshTracker {
+	return &MeshTracker{mesh: make(map[string]map[peer.ID]struct{})}
+}
+
+// MeshPeers returns the peers in our mesh of the given topic.
+func (mt *MeshTracker) MeshPeers(topic string) []peer.ID {
+	mt.mu.RLock()
+	defer mt.mu.RUnlock()
+
+	peers := make([]peer.ID, 0, len(mt.mesh[topic]))
+	for p := range mt.mesh[topic] {
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+func (mt *MeshTracker) Graft(p peer.ID, topic string) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	if mt.mesh[topic] == nil {
+		mt.mesh[topic] = make(map[peer.ID]struct{})
+	}
+	mt.mesh[topic][p] = struct{}{}
+}
+
+func (mt *MeshTracker) Prune(p peer.ID, topic string) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	delete(mt.mesh[topic], p)
+}
+
+func (mt *MeshTracker) RemovePeer(p peer.ID) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	for _, peers := range mt.mesh {
+		delete(peers, p)
+	}
+}
+
+func (mt *MeshTracker) Leave(topic string) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	delete(mt.mesh, topic)
+}
+
+func (mt *MeshTracker) AddPeer(peer.ID, protocol.ID)          {}
+func (mt *MeshTracker) Join(string)                           {}
+func (mt *MeshTracker) ValidateMessage(*pubsub.Message)       {}
+func (mt *MeshTracker) DeliverMessage(*pubsub.Message)        {}
+func (mt *MeshTracker) RejectMessage(*pubsub.Message, string) {}
+func (mt *MeshTracker) DuplicateMessage(*pubsub.Message)      {}
+func (mt *MeshTracker) ThrottlePeer(peer.ID)                  {}
+func (mt *MeshTracker) RecvRPC(*pubsub.RPC)                   {}
+func (mt *MeshTracker) SendRPC(*pubsub.RPC, peer.ID)          {}
+func (mt *MeshTracker) DropRPC(*pubsub.RPC, peer.ID)          {}
+func (mt *MeshTracker) UndeliverableMessage(*pubsub.Message)  {}
@@ -0,0 +1,30 @@
+package topics
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeshTracker(t *testing.T) {
+	mt := NewMeshTracker()
+	p1, p2 := peer.ID("p1"), peer.ID("p2")
+
+	mt.Graft(p1, "a")
+	mt.Graft(p2, "a")
+	mt.Graft(p1, "b")
+	require.ElementsMatch(t, []peer.ID{p1, p2}, mt.MeshPeers("a"))
+	require.ElementsMatch(t, []peer.ID{p1}, mt.MeshPeers("b"))
+
+	mt.Prune(p2, "a")
+	require.ElementsMatch(t, []peer.ID{p1}, mt.MeshPeers("a"))
+
+	mt.RemovePeer(p1)
+	require.Empty(t, mt.MeshPeers("a"))
+	require.Empty(t, mt.MeshPeers("b"))
+
+	mt.Graft(p2, "b")
+	mt.Leave("b")
+	require.Empty(t, mt.MeshPeers("b"))
+}
@@ -197,12 +197,15 @@ func NewPubSub(ctx context.Context, logger *zap.Logger, cfg *PubSubConfig, commi
 		psOpts = append(psOpts, pubsub.WithEventTracer(newTracer(logger)))
 	}
 
+	meshTracker := NewMeshTracker()
+	psOpts = append(psOpts, pubsub.WithRawTracer(meshTracker))
+
 	ps, err := pubsub.NewGossipSub(ctx, cfg.Host, psOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	ctrl := NewTopicsController(ctx, logger, cfg.MsgHandler, cfg.MsgValidator, sf, ps, topicScoreFactory)
+	ctrl := NewTopicsController(ctx, logger, cfg.MsgHandler, cfg.MsgValidator, sf, ps, topicScoreFactory, meshTracker)
 
 	return ps, ctrl, nil
 }
@@ -46,6 +46,11 @@ func (n NetworkConfig) NetworkName() string {
 	return fmt.Sprintf("%s:%s", n.Name, forkName)
 }
 
+// ForkName returns the name of the SSV protocol fork the network runs.
+func (n NetworkConfig) ForkName() string {
+	return forkName
+}
+
 // ForkVersion returns the fork version of the network.
 func (n NetworkConfig) ForkVersion() [4]byte {
 	return n.Beacon.ForkVersion()
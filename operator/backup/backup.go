@@ -0,0 +1,223 @@
+// Package backup writes and restores node database backups. A backup is a header describing
+// the database it was taken from, followed by a full backup stream of its storage engine.
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ssvlabs/ssv/migrations"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+// FormatVersion is the version of the backup format written by this binary.
+const FormatVersion = 1
+
+// maxHeaderSize bounds the header length read from a backup, to fail fast on garbage input.
+const maxHeaderSize = 1 << 20
+
+var magic = []byte("SSVDBBAK")
+
+// Header describes the database a backup was taken from.
+type Header struct {
+	FormatVersion    int                         `json:"format_version"`
+	NetworkName      string                      `json:"network_name"`
+	ConfigLock       *operatorstorage.ConfigLock `json:"config_lock,omitempty"`
+	MigrationVersion int                         `json:"migration_version"`
+	CreatedAt        time.Time                   `json:"created_at"`
+	// Engine is the storage engine of the backed up database, empty for backups taken before it was recorded (Badger).
+	Engine basedb.Engine `json:"engine,omitempty"`
+}
+
+// engine returns the storage engine of the backed up database.
+func (h Header) engine() basedb.Engine {
+	if h.Engine == "" {
+		return basedb.EngineBadger
+	}
+	return h.Engine
+}
+
+// Validate returns an error if the backup can't be restored by this binary into a node of the given network.
+func (h Header) Validate(networkName string) error {
+	if h.FormatVersion != FormatVersion {
+		return fmt.Errorf("unsupported backup format version %d (expected %d)", h.FormatVersion, FormatVersion)
+	}
+	if h.NetworkName != networkName {
+		return fmt.Errorf("network mismatch: backup is of network %s but the node is configured for network %s", h.NetworkName, networkName)
+	}
+	if h.ConfigLock != nil && h.ConfigLock.NetworkName != networkName {
+		return fmt.Errorf("network mismatch: backup config lock is of network %s but the node is configured for network %s", h.ConfigLock.NetworkName, networkName)
+	}
+	if h.MigrationVersion > migrations.Version() {
+		return fmt.Errorf("backup was migrated to version %d by a newer binary, this binary only supports up to version %d", h.MigrationVersion, migrations.Version())
+	}
+	return nil
+}
+
+// Write writes a backup of db to w. The database may be in use while the backup is taken.
+func Write(db kv.DB, w io.Writer, networkName string) (Header, error) {
+	header := Header{
+		FormatVersion: FormatVersion,
+		NetworkName:   networkName,
+		CreatedAt:     time.Now().UTC(),
+		Engine:        db.Engine(),
+	}
+
+	configLock, found, err := operatorstorage.ReadConfig(db)
+	if err != nil {
+		return Header{}, fmt.Errorf("read config lock: %w", err)
+	}
+	if found {
+		header.ConfigLock = configLock
+	}
+
+	header.MigrationVersion, err = migrations.AppliedVersion(db)
+	if err != nil {
+		return Header{}, fmt.Errorf("read migration version: %w", err)
+	}
+
+	if err := writeHeader(w, header); err != nil {
+		return Header{}, err
+	}
+	if _, err := db.Backup(w); err != nil {
+		return Header{}, err
+	}
+
+	return header, nil
+}
+
+// WriteFile writes a backup of db to a new file at path. The file only appears at path once it's complete.
+func WriteFile(db kv.DB, path, networkName string) (Header, error) {
+	tmpPath := path + ".tmp"
+	// #nosec G304
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return Header{}, fmt.Errorf("create backup file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+	}()
+
+	bw := bufio.NewWriter(f)
+	header, err := Write(db, bw, networkName)
+	if err != nil {
+		return Header{}, err
+	}
+	if err := bw.Flush(); err != nil {
+		return Header{}, fmt.Errorf("flush backup file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return Header{}, fmt.Errorf("sync backup file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return Header{}, fmt.Errorf("close backup file: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return Header{}, fmt.Errorf("backup file %s already exists", path)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return Header{}, fmt.Errorf("rename backup file: %w", err)
+	}
+
+	return header, nil
+}
+
+// Restore validates the header of the backup in r and loads it into db, which must be empty.
+func Restore(db kv.DB, r io.Reader, networkName string) (Header, error) {
+	br := bufio.NewReader(r)
+
+	header, err := ReadHeader(br)
+	if err != nil {
+		return Header{}, err
+	}
+	if err := header.Validate(networkName); err != nil {
+		return Header{}, err
+	}
+	if header.engine() != db.Engine() {
+		return Header{}, fmt.Errorf("engine mismatch: backup is of a %s database but the node database is %s, restore it into a %s database and use `db convert`",
+			header.engine(), db.Engine(), header.engine())
+	}
+
+	count, err := db.CountPrefix(nil)
+	if err != nil {
+		return Header{}, fmt.Errorf("count existing keys: %w", err)
+	}
+	if count > 0 {
+		return Header{}, fmt.Errorf("database is not empty (%d keys), refusing to restore over it", count)
+	}
+
+	if err := db.Restore(br); err != nil {
+		return Header{}, err
+	}
+
+	return header, nil
+}
+
+// ReadHeader reads the header from the beginning of a backup, leaving r positioned at the database stream.
+func ReadHeader(r io.Reader) (Header, error) {
+	prefix := make([]byte, len(magic)+4)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return Header{}, fmt.Errorf("read header: %w", err)
+	}
+	if !bytes.Equal(prefix[:len(magic)], magic) {
+		return Header{}, fmt.Errorf("not an SSV database backup")
+	}
+
+	size := binary.BigEndian.Uint32(prefix[len(magic):])
+	if size > maxHeaderSize {
+		return Header{}, fmt.Errorf("header too large (%d bytes)", size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return Header{}, fmt.Errorf("read header: %w", err)
+	}
+
+	var header Header
+	if err := json.Unmarshal(data, &header); err != nil {
+		return Header{}, fmt.Errorf("unmarshal header: %w", err)
+	}
+	return header, nil
+}
+
+func writeHeader(w io.Writer, header Header) error {
+	data, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("marshal header: %w", err)
+	}
+
+	prefix := binary.BigEndian.AppendUint32(append([]byte{}, magic...), uint32(len(data))) // #nosec G115 -- header is small
+	if _, err := w.Write(prefix); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	return nil
+}
+
+// Online takes backups of the database of a running node.
+type Online struct {
+	db          kv.DB
+	networkName string
+}
+
+// NewOnline creates an Online for the given database and network.
+func NewOnline(db kv.DB, networkName string) *Online {
+	return &Online{db: db, networkName: networkName}
+}
+
+// WriteFile writes a backup of the database to a new file at path.
+func (o *Online) WriteFile(path string) error {
+	_, err := WriteFile(o.db, path, o.networkName)
+	return err
+}
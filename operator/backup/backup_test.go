@@ -0,0 +1,110 @@
+package backup
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/migrations"
+	"github.com/ssvlabs/ssv/networkconfig"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func newTestDB(t *testing.T) kv.DB {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestWriteRestore(t *testing.T) {
+	networkName := networkconfig.TestNetwork.NetworkName()
+
+	source := newTestDB(t)
+	nodeStorage, err := operatorstorage.NewNodeStorage(networkconfig.TestNetwork, logging.TestLogger(t), source)
+	require.NoError(t, err)
+	configLock := &operatorstorage.ConfigLock{NetworkName: networkName, UsingSSVSigner: true}
+	require.NoError(t, nodeStorage.SaveConfig(nil, configLock))
+	require.NoError(t, source.Set([]byte("test/"), []byte("key"), []byte("value")))
+
+	var buf bytes.Buffer
+	written, err := Write(source, &buf, networkName)
+	require.NoError(t, err)
+	require.Equal(t, FormatVersion, written.FormatVersion)
+	require.Equal(t, configLock, written.ConfigLock)
+	require.Equal(t, basedb.EngineBadger, written.Engine)
+
+	header, err := ReadHeader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, written.NetworkName, header.NetworkName)
+	require.Equal(t, written.MigrationVersion, header.MigrationVersion)
+
+	t.Run("wrong network", func(t *testing.T) {
+		_, err := Restore(newTestDB(t), bytes.NewReader(buf.Bytes()), "other")
+		require.ErrorContains(t, err, "network mismatch")
+	})
+
+	t.Run("non-empty database", func(t *testing.T) {
+		target := newTestDB(t)
+		require.NoError(t, target.Set([]byte("test/"), []byte("existing"), []byte("value")))
+		_, err := Restore(target, bytes.NewReader(buf.Bytes()), networkName)
+		require.ErrorContains(t, err, "not empty")
+	})
+
+	t.Run("wrong engine", func(t *testing.T) {
+		target, err := kv.NewPebbleInMemory(logging.TestLogger(t), basedb.Options{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = target.Close() })
+
+		_, err = Restore(target, bytes.NewReader(buf.Bytes()), networkName)
+		require.ErrorContains(t, err, "engine mismatch")
+	})
+
+	t.Run("not a backup", func(t *testing.T) {
+		_, err := Restore(newTestDB(t), bytes.NewReader([]byte("garbage data here")), networkName)
+		require.ErrorContains(t, err, "not an SSV database backup")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		target := newTestDB(t)
+		_, err := Restore(target, bytes.NewReader(buf.Bytes()), networkName)
+		require.NoError(t, err)
+
+		obj, found, err := target.Get([]byte("test/"), []byte("key"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("value"), obj.Value)
+
+		restoredLock, found, err := operatorstorage.ReadConfig(target)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, configLock, restoredLock)
+	})
+}
+
+func TestHeader_Validate(t *testing.T) {
+	valid := Header{
+		FormatVersion:    FormatVersion,
+		NetworkName:      "holesky",
+		ConfigLock:       &operatorstorage.ConfigLock{NetworkName: "holesky"},
+		MigrationVersion: migrations.Version(),
+	}
+	require.NoError(t, valid.Validate("holesky"))
+	require.Error(t, valid.Validate("mainnet"))
+
+	newer := valid
+	newer.MigrationVersion++
+	require.ErrorContains(t, newer.Validate("holesky"), "newer binary")
+
+	format := valid
+	format.FormatVersion++
+	require.ErrorContains(t, format.Validate("holesky"), "format version")
+
+	lock := valid
+	lock.ConfigLock = &operatorstorage.ConfigLock{NetworkName: "mainnet"}
+	require.ErrorContains(t, lock.Validate("holesky"), "config lock")
+}
@@ -0,0 +1,132 @@
+// Package dutyhistory keeps a bounded log of duty outcomes per validator,
+// recording how far each duty got through the runner flow and why it failed, if it did.
+package dutyhistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// Stage is the furthest point a duty reached in the runner flow.
+type Stage uint8
+
+const (
+	// StageScheduled means the duty scheduler dispatched the duty.
+	StageScheduled Stage = iota
+	// StageStarted means the runner started the duty.
+	StageStarted
+	// StagePreConsensusQuorum means the runner collected a quorum of pre-consensus partial signatures.
+	// Committee duties have no pre-consensus phase and skip this stage.
+	StagePreConsensusQuorum
+	// StageDecided means the QBFT instance of the duty decided.
+	StageDecided
+	// StageSubmitted means the signed result was submitted to the beacon node.
+	StageSubmitted
+)
+
+var stageNames = map[Stage]string{
+	StageScheduled:          "scheduled",
+	StageStarted:            "started",
+	StagePreConsensusQuorum: "pre_consensus_quorum",
+	StageDecided:            "decided",
+	StageSubmitted:          "submitted",
+}
+
+func (s Stage) String() string {
+	if name, ok := stageNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", s)
+}
+
+func (s Stage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *Stage) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for stage, stageName := range stageNames {
+		if stageName == name {
+			*s = stage
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown stage %q", name)
+}
+
+// ErrorClass tells in which part of the runner flow a duty failed.
+type ErrorClass string
+
+const (
+	ErrorClassNone ErrorClass = ""
+	// ErrorClassSchedule means the duty couldn't be handed over to the validator (e.g. validator not found, queue full).
+	ErrorClassSchedule ErrorClass = "schedule"
+	// ErrorClassStart means the runner failed to start the duty (e.g. beacon node request failed).
+	ErrorClassStart ErrorClass = "start"
+	// ErrorClassPreConsensus means processing a pre-consensus message failed.
+	ErrorClassPreConsensus ErrorClass = "pre_consensus"
+	// ErrorClassConsensus means processing a consensus message failed.
+	ErrorClassConsensus ErrorClass = "consensus"
+	// ErrorClassPostConsensus means processing a post-consensus message (including the submission) failed.
+	ErrorClassPostConsensus ErrorClass = "post_consensus"
+	// ErrorClassTimeout means handling a QBFT round timeout failed.
+	ErrorClassTimeout ErrorClass = "timeout"
+)
+
+// Entry is the outcome of a single duty of a validator.
+type Entry struct {
+	Role   spectypes.BeaconRole `json:"role"`
+	Slot   phase0.Slot          `json:"slot"`
+	Stage  Stage                `json:"stage"`
+	Rounds uint64               `json:"rounds"`
+
+	ErrorClass ErrorClass `json:"error_class,omitempty"`
+	Error      string     `json:"error,omitempty"`
+
+	PreConsensusTime  time.Duration `json:"pre_consensus_time"`
+	ConsensusTime     time.Duration `json:"consensus_time"`
+	PostConsensusTime time.Duration `json:"post_consensus_time"`
+	DutyTime          time.Duration `json:"duty_time"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Failed returns whether the duty hit an error without being submitted.
+func (e Entry) Failed() bool {
+	return e.ErrorClass != ErrorClassNone && e.Stage < StageSubmitted
+}
+
+// merge applies the progress in next on top of e. The stage is never lowered and an error is
+// only cleared once the duty is submitted, so late messages can't hide an earlier failure.
+func (e Entry) merge(next Entry) Entry {
+	merged := e
+	merged.Stage = max(e.Stage, next.Stage)
+	merged.Rounds = max(e.Rounds, next.Rounds)
+	if next.ErrorClass != ErrorClassNone && merged.Stage < StageSubmitted {
+		merged.ErrorClass = next.ErrorClass
+		merged.Error = next.Error
+	}
+	if merged.Stage == StageSubmitted && e.Stage < StageSubmitted {
+		merged.ErrorClass = ErrorClassNone
+		merged.Error = ""
+	}
+	merged.PreConsensusTime = max(e.PreConsensusTime, next.PreConsensusTime)
+	merged.ConsensusTime = max(e.ConsensusTime, next.ConsensusTime)
+	merged.PostConsensusTime = max(e.PostConsensusTime, next.PostConsensusTime)
+	merged.DutyTime = max(e.DutyTime, next.DutyTime)
+	return merged
+}
+
+// sameAs returns whether the persisted fields of both entries are equal, ignoring UpdatedAt.
+func (e Entry) sameAs(other Entry) bool {
+	e.UpdatedAt = time.Time{}
+	other.UpdatedAt = time.Time{}
+	return e == other
+}
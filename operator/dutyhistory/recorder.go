@@ -0,0 +1,157 @@
+package dutyhistory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/operator/slotticker"
+)
+
+// pruneEverySlots is how often stale entries are removed.
+const pruneEverySlots = 32
+
+type dutyKey struct {
+	pubKey spectypes.ValidatorPK
+	slot   phase0.Slot
+	role   spectypes.BeaconRole
+}
+
+// trackedEntry is the latest progress of a duty along with the state of its persistence.
+type trackedEntry struct {
+	entry Entry
+	// saving is set while an entry of the duty is being written, and pending when it changed meanwhile.
+	saving  bool
+	pending bool
+}
+
+// Recorder tracks the progress of recent duties in memory and persists an entry
+// only when its progress changes, so repeated reports of the same stage are cheap.
+// Entries are written outside of the lock, one write per duty at a time, so the latest
+// progress of a duty is always the last one persisted.
+// A nil Recorder is valid and records nothing.
+type Recorder struct {
+	logger *zap.Logger
+	store  *Store
+
+	mu     sync.Mutex
+	recent map[dutyKey]*trackedEntry
+}
+
+// NewRecorder creates a new Recorder persisting to the given store.
+func NewRecorder(logger *zap.Logger, store *Store) *Recorder {
+	return &Recorder{
+		logger: logger,
+		store:  store,
+		recent: make(map[dutyKey]*trackedEntry),
+	}
+}
+
+// Store returns the store the recorder persists to.
+func (r *Recorder) Store() *Store {
+	return r.store
+}
+
+// Record merges progress into the entry of the duty identified by the validator, slot and role.
+func (r *Recorder) Record(pubKey spectypes.ValidatorPK, progress Entry) {
+	if r == nil {
+		return
+	}
+
+	key := dutyKey{pubKey: pubKey, slot: progress.Slot, role: progress.Role}
+
+	r.mu.Lock()
+	next := progress
+	tracked, ok := r.recent[key]
+	if ok {
+		next = tracked.entry.merge(progress)
+		if next.sameAs(tracked.entry) {
+			r.mu.Unlock()
+			return
+		}
+	} else {
+		tracked = &trackedEntry{}
+		r.recent[key] = tracked
+	}
+	next.UpdatedAt = time.Now()
+	tracked.entry = next
+
+	// The ongoing write picks up the change once it's done.
+	if tracked.saving {
+		tracked.pending = true
+		r.mu.Unlock()
+		return
+	}
+	tracked.saving = true
+	r.mu.Unlock()
+
+	r.save(pubKey, tracked, next)
+}
+
+// save writes the entry, then keeps writing the latest one for as long as it changes meanwhile.
+func (r *Recorder) save(pubKey spectypes.ValidatorPK, tracked *trackedEntry, entry Entry) {
+	for {
+		if err := r.store.Save(pubKey, entry); err != nil {
+			r.logger.Warn("failed to save duty history entry",
+				fields.PubKey(pubKey[:]),
+				fields.Slot(entry.Slot),
+				fields.BeaconRole(entry.Role),
+				zap.Error(err),
+			)
+		}
+
+		r.mu.Lock()
+		if !tracked.pending {
+			tracked.saving = false
+			r.mu.Unlock()
+			return
+		}
+		tracked.pending = false
+		entry = tracked.entry
+		r.mu.Unlock()
+	}
+}
+
+// PruneContinuously removes entries older than retain slots every pruneEverySlots, until ctx is done.
+func (r *Recorder) PruneContinuously(ctx context.Context, slotTickerProvider slotticker.Provider, retain phase0.Slot) {
+	ticker := slotTickerProvider()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Next():
+			slot := ticker.Slot()
+			if slot%pruneEverySlots != 0 || slot <= retain {
+				continue
+			}
+			r.prune(slot - retain)
+		}
+	}
+}
+
+func (r *Recorder) prune(threshold phase0.Slot) {
+	r.mu.Lock()
+	for key := range r.recent {
+		if key.slot < threshold {
+			delete(r.recent, key)
+		}
+	}
+	r.mu.Unlock()
+
+	start := time.Now()
+	count, err := r.store.Prune(threshold)
+	if err != nil {
+		r.logger.Error("failed to prune duty history", fields.Slot(threshold), zap.Error(err))
+		return
+	}
+	r.logger.Debug("pruned duty history",
+		fields.Slot(threshold),
+		fields.Count(count),
+		fields.Took(time.Since(start)),
+	)
+}
@@ -0,0 +1,112 @@
+package dutyhistory
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+func TestRecorder_Record(t *testing.T) {
+	store := newTestStore(t)
+	recorder := NewRecorder(logging.TestLogger(t), store)
+	pk := spectypes.ValidatorPK{1}
+
+	get := func() Entry {
+		entries, err := store.Get(pk, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		return entries[0]
+	}
+
+	recorder.Record(pk, Entry{Role: spectypes.BNRoleProposer, Slot: 10, Stage: StageScheduled})
+	require.Equal(t, StageScheduled, get().Stage)
+
+	recorder.Record(pk, Entry{Role: spectypes.BNRoleProposer, Slot: 10, Stage: StagePreConsensusQuorum, Rounds: 1, PreConsensusTime: time.Second})
+	entry := get()
+	require.Equal(t, StagePreConsensusQuorum, entry.Stage)
+	require.Equal(t, time.Second, entry.PreConsensusTime)
+	updatedAt := entry.UpdatedAt
+
+	// Unchanged progress isn't persisted again.
+	recorder.Record(pk, Entry{Role: spectypes.BNRoleProposer, Slot: 10, Stage: StageStarted, Rounds: 1})
+	require.Equal(t, updatedAt, get().UpdatedAt)
+
+	// Errors are kept until the duty is submitted and the stage is never lowered.
+	recorder.Record(pk, Entry{Role: spectypes.BNRoleProposer, Slot: 10, Stage: StageStarted, Rounds: 2, ErrorClass: ErrorClassConsensus, Error: "bad proposal"})
+	entry = get()
+	require.Equal(t, StagePreConsensusQuorum, entry.Stage)
+	require.Equal(t, uint64(2), entry.Rounds)
+	require.Equal(t, ErrorClassConsensus, entry.ErrorClass)
+	require.True(t, entry.Failed())
+
+	recorder.Record(pk, Entry{Role: spectypes.BNRoleProposer, Slot: 10, Stage: StageSubmitted, Rounds: 2})
+	entry = get()
+	require.Equal(t, StageSubmitted, entry.Stage)
+	require.Equal(t, ErrorClassNone, entry.ErrorClass)
+	require.Empty(t, entry.Error)
+	require.False(t, entry.Failed())
+
+	// Errors after submission don't mark the duty as failed.
+	recorder.Record(pk, Entry{Role: spectypes.BNRoleProposer, Slot: 10, ErrorClass: ErrorClassPostConsensus, Error: "late"})
+	require.False(t, get().Failed())
+}
+
+func TestRecorder_RecordConcurrently(t *testing.T) {
+	store := newTestStore(t)
+	recorder := NewRecorder(logging.TestLogger(t), store)
+	pk := spectypes.ValidatorPK{1}
+
+	var wg sync.WaitGroup
+	for rounds := uint64(1); rounds <= 50; rounds++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recorder.Record(pk, Entry{Role: spectypes.BNRoleProposer, Slot: 10, Stage: StageStarted, Rounds: rounds})
+		}()
+	}
+	wg.Wait()
+
+	// The last write always carries the latest progress, whatever order the writes ran in.
+	entries, err := store.Get(pk, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, uint64(50), entries[0].Rounds)
+}
+
+func TestRecorder_Prune(t *testing.T) {
+	store := newTestStore(t)
+	recorder := NewRecorder(logging.TestLogger(t), store)
+	pk := spectypes.ValidatorPK{1}
+
+	recorder.Record(pk, Entry{Role: spectypes.BNRoleAttester, Slot: 1, Stage: StageSubmitted})
+	recorder.Record(pk, Entry{Role: spectypes.BNRoleAttester, Slot: 50, Stage: StageSubmitted})
+	recorder.prune(10)
+
+	entries, err := store.Get(pk, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Len(t, recorder.recent, 1)
+
+	// A nil recorder records nothing.
+	var nilRecorder *Recorder
+	nilRecorder.Record(pk, Entry{Slot: 1})
+}
+
+func TestStage_JSON(t *testing.T) {
+	for stage := StageScheduled; stage <= StageSubmitted; stage++ {
+		data, err := stage.MarshalJSON()
+		require.NoError(t, err)
+
+		var decoded Stage
+		require.NoError(t, decoded.UnmarshalJSON(data))
+		require.Equal(t, stage, decoded)
+	}
+
+	var decoded Stage
+	require.Error(t, decoded.UnmarshalJSON([]byte(`"bogus"`)))
+}
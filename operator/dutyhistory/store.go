@@ -0,0 +1,112 @@
+package dutyhistory
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var storePrefix = []byte("duty_history/")
+
+// pruneBatchSize limits the number of deletions per transaction to stay within the transaction size limit.
+const pruneBatchSize = 1000
+
+// Store persists duty outcomes keyed by validator public key, slot and role.
+type Store struct {
+	db basedb.Database
+}
+
+// NewStore creates a new duty history store.
+func NewStore(db basedb.Database) *Store {
+	return &Store{db: db}
+}
+
+// Save creates or overwrites the entry of the given validator for the entry's slot and role.
+func (s *Store) Save(pubKey spectypes.ValidatorPK, entry Entry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal entry: %w", err)
+	}
+	return s.db.Set(storePrefix, entryKey(pubKey, entry.Slot, entry.Role), value)
+}
+
+// Get returns the entries of the given validator within the inclusive slot range, ordered by slot.
+func (s *Store) Get(pubKey spectypes.ValidatorPK, from, to phase0.Slot) ([]Entry, error) {
+	prefix := append(append([]byte{}, storePrefix...), pubKey[:]...)
+
+	opts := basedb.RangeOptions{
+		Start: binary.BigEndian.AppendUint64(nil, uint64(from)),
+	}
+	if to < math.MaxUint64 {
+		opts.End = binary.BigEndian.AppendUint64(nil, uint64(to)+1)
+	}
+
+	entries := make([]Entry, 0)
+	err := s.db.GetRange(prefix, opts, func(obj basedb.Obj) error {
+		var entry Entry
+		if err := json.Unmarshal(obj.Value, &entry); err != nil {
+			return fmt.Errorf("unmarshal entry: %w", err)
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Prune removes all entries with a slot lower than threshold and returns how many were removed.
+func (s *Store) Prune(threshold phase0.Slot) (int, error) {
+	var stale [][]byte
+	err := s.db.GetRange(storePrefix, basedb.RangeOptions{KeysOnly: true}, func(obj basedb.Obj) error {
+		if len(obj.Key) < len(spectypes.ValidatorPK{})+8 {
+			return nil
+		}
+		slot := phase0.Slot(binary.BigEndian.Uint64(obj.Key[len(spectypes.ValidatorPK{}):]))
+		if slot < threshold {
+			stale = append(stale, obj.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("collect stale entries: %w", err)
+	}
+
+	if len(stale) == 0 {
+		return 0, nil
+	}
+
+	for batch := range slices.Chunk(stale, pruneBatchSize) {
+		err = s.db.Update(func(txn basedb.Txn) error {
+			for _, key := range batch {
+				if err := txn.Delete(storePrefix, key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("delete stale entries: %w", err)
+		}
+	}
+
+	return len(stale), nil
+}
+
+// entryKey is laid out as pubkey|slot|role, so that a validator's entries are sorted by slot.
+func entryKey(pubKey spectypes.ValidatorPK, slot phase0.Slot, role spectypes.BeaconRole) []byte {
+	key := make([]byte, 0, len(pubKey)+8+8)
+	key = append(key, pubKey[:]...)
+	key = binary.BigEndian.AppendUint64(key, uint64(slot))
+	key = binary.BigEndian.AppendUint64(key, uint64(role))
+	return key
+}
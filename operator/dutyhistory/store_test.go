@@ -0,0 +1,90 @@
+package dutyhistory
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func newTestStore(t *testing.T) *Store {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return NewStore(db)
+}
+
+func TestStore_SaveGet(t *testing.T) {
+	store := newTestStore(t)
+
+	pk1 := spectypes.ValidatorPK{1}
+	pk2 := spectypes.ValidatorPK{2}
+
+	for _, slot := range []phase0.Slot{5, 300, 10} {
+		require.NoError(t, store.Save(pk1, Entry{Role: spectypes.BNRoleAttester, Slot: slot, Stage: StageSubmitted}))
+	}
+	require.NoError(t, store.Save(pk1, Entry{Role: spectypes.BNRoleAggregator, Slot: 10, Stage: StageDecided, Rounds: 2}))
+	require.NoError(t, store.Save(pk2, Entry{Role: spectypes.BNRoleProposer, Slot: 10, Stage: StageStarted}))
+
+	entries, err := store.Get(pk1, 0, 1000)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	for i, slot := range []phase0.Slot{5, 10, 10, 300} {
+		require.Equal(t, slot, entries[i].Slot)
+	}
+
+	entries, err = store.Get(pk1, 10, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, spectypes.BNRoleAttester, entries[0].Role)
+	require.Equal(t, spectypes.BNRoleAggregator, entries[1].Role)
+	require.Equal(t, StageDecided, entries[1].Stage)
+	require.Equal(t, uint64(2), entries[1].Rounds)
+
+	entries, err = store.Get(pk2, 0, 1000)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, spectypes.BNRoleProposer, entries[0].Role)
+
+	entries, err = store.Get(spectypes.ValidatorPK{3}, 0, 1000)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// Overwriting keeps a single entry per slot and role.
+	require.NoError(t, store.Save(pk2, Entry{Role: spectypes.BNRoleProposer, Slot: 10, Stage: StageSubmitted}))
+	entries, err = store.Get(pk2, 0, 1000)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, StageSubmitted, entries[0].Stage)
+}
+
+func TestStore_Prune(t *testing.T) {
+	store := newTestStore(t)
+
+	pk1 := spectypes.ValidatorPK{1}
+	pk2 := spectypes.ValidatorPK{2}
+	for slot := phase0.Slot(0); slot < 100; slot++ {
+		require.NoError(t, store.Save(pk1, Entry{Role: spectypes.BNRoleAttester, Slot: slot}))
+		require.NoError(t, store.Save(pk2, Entry{Role: spectypes.BNRoleSyncCommittee, Slot: slot}))
+	}
+
+	count, err := store.Prune(60)
+	require.NoError(t, err)
+	require.Equal(t, 120, count)
+
+	for _, pk := range []spectypes.ValidatorPK{pk1, pk2} {
+		entries, err := store.Get(pk, 0, 1000)
+		require.NoError(t, err)
+		require.Len(t, entries, 40)
+		require.Equal(t, phase0.Slot(60), entries[0].Slot)
+	}
+
+	count, err = store.Prune(60)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
@@ -0,0 +1,196 @@
+// Package proposerconfig holds per-validator and per-owner overrides of the node-wide proposer settings
+// (graffiti, gas limit and builder policy), which are read from a file and can be replaced at runtime.
+package proposerconfig
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// MaxGraffitiLength is the size of the graffiti field of a beacon block.
+const MaxGraffitiLength = 32
+
+// BuilderPolicy selects where the execution payload of proposed blocks comes from.
+type BuilderPolicy string
+
+const (
+	// BuilderPolicyDefault leaves the choice to the beacon node.
+	BuilderPolicyDefault BuilderPolicy = ""
+	// BuilderPolicyMaxProfit proposes whichever of the builder and local payloads pays more.
+	BuilderPolicyMaxProfit BuilderPolicy = "max-profit"
+	// BuilderPolicyBuilderOnly always prefers the builder payload, the beacon node only falls back
+	// to the local payload if there is no builder payload.
+	BuilderPolicyBuilderOnly BuilderPolicy = "builder-only"
+	// BuilderPolicyLocalOnly never proposes builder payloads.
+	BuilderPolicyLocalOnly BuilderPolicy = "local-only"
+)
+
+// BuilderBoostFactor returns the builder boost factor of the beacon API block production request
+// which implements the policy, or false if the beacon node should use its own default.
+func (p BuilderPolicy) BuilderBoostFactor() (uint64, bool) {
+	switch p {
+	case BuilderPolicyMaxProfit:
+		return 100, true
+	case BuilderPolicyBuilderOnly:
+		return math.MaxUint64, true
+	case BuilderPolicyLocalOnly:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+func (p BuilderPolicy) validate() error {
+	switch p {
+	case BuilderPolicyDefault, BuilderPolicyMaxProfit, BuilderPolicyBuilderOnly, BuilderPolicyLocalOnly:
+		return nil
+	default:
+		return fmt.Errorf("unknown builder policy %q, must be one of %q, %q or %q",
+			p, BuilderPolicyMaxProfit, BuilderPolicyBuilderOnly, BuilderPolicyLocalOnly)
+	}
+}
+
+// Settings are proposer settings of a validator. Zero values are unset and fall back to less specific settings.
+type Settings struct {
+	Graffiti      string        `yaml:"graffiti,omitempty" json:"graffiti,omitempty"`
+	GasLimit      uint64        `yaml:"gas_limit,omitempty" json:"gas_limit,omitempty"`
+	BuilderPolicy BuilderPolicy `yaml:"builder_policy,omitempty" json:"builder_policy,omitempty"`
+}
+
+// Merge returns s with the values set in override replacing its own.
+func (s Settings) Merge(override Settings) Settings {
+	if override.Graffiti != "" {
+		s.Graffiti = override.Graffiti
+	}
+	if override.GasLimit != 0 {
+		s.GasLimit = override.GasLimit
+	}
+	if override.BuilderPolicy != BuilderPolicyDefault {
+		s.BuilderPolicy = override.BuilderPolicy
+	}
+	return s
+}
+
+func (s Settings) validate() error {
+	if len(s.Graffiti) > MaxGraffitiLength {
+		return fmt.Errorf("graffiti is %d bytes long, must be at most %d", len(s.Graffiti), MaxGraffitiLength)
+	}
+	return s.BuilderPolicy.validate()
+}
+
+// Config is the proposer config file. Validator settings take precedence over the settings of their owner,
+// which take precedence over the default settings, and the node-wide settings apply where none are set.
+type Config struct {
+	Default Settings `yaml:"default,omitempty" json:"default"`
+	// Owners are keyed by hex owner address.
+	Owners map[string]Settings `yaml:"owners,omitempty" json:"owners,omitempty"`
+	// Validators are keyed by hex validator public key.
+	Validators map[string]Settings `yaml:"validators,omitempty" json:"validators,omitempty"`
+}
+
+// Validate returns an error if the config has malformed keys or values.
+func (c *Config) Validate() error {
+	_, _, err := c.index()
+	return err
+}
+
+// index validates the config and returns its settings by parsed validator public key and owner address.
+func (c *Config) index() (map[spectypes.ValidatorPK]Settings, map[common.Address]Settings, error) {
+	if err := c.Default.validate(); err != nil {
+		return nil, nil, fmt.Errorf("default: %w", err)
+	}
+
+	owners := make(map[common.Address]Settings, len(c.Owners))
+	for key, settings := range c.Owners {
+		if !common.IsHexAddress(key) {
+			return nil, nil, fmt.Errorf("owner %s: invalid address", key)
+		}
+		owner := common.HexToAddress(key)
+		if _, exists := owners[owner]; exists {
+			return nil, nil, fmt.Errorf("owner %s: duplicate entry", key)
+		}
+		if err := settings.validate(); err != nil {
+			return nil, nil, fmt.Errorf("owner %s: %w", key, err)
+		}
+		owners[owner] = settings
+	}
+
+	validators := make(map[spectypes.ValidatorPK]Settings, len(c.Validators))
+	for key, settings := range c.Validators {
+		pubKey, err := parseValidatorPK(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("validator %s: %w", key, err)
+		}
+		if _, exists := validators[pubKey]; exists {
+			return nil, nil, fmt.Errorf("validator %s: duplicate entry", key)
+		}
+		if err := settings.validate(); err != nil {
+			return nil, nil, fmt.Errorf("validator %s: %w", key, err)
+		}
+		validators[pubKey] = settings
+	}
+
+	return validators, owners, nil
+}
+
+func parseValidatorPK(key string) (spectypes.ValidatorPK, error) {
+	if !strings.HasPrefix(key, "0x") {
+		key = "0x" + key
+	}
+	b, err := hexutil.Decode(key)
+	if err != nil {
+		return spectypes.ValidatorPK{}, fmt.Errorf("invalid public key: %w", err)
+	}
+	var pubKey spectypes.ValidatorPK
+	if len(b) != len(pubKey) {
+		return spectypes.ValidatorPK{}, fmt.Errorf("invalid public key length: %d", len(b))
+	}
+	copy(pubKey[:], b)
+	return pubKey, nil
+}
+
+// Warning flags a value which must match across the committee of the validators it applies to.
+// The node can't see the config of the other operators, so it's up to the operators to coordinate these values.
+type Warning struct {
+	// Target is "default", "owner <address>" or "validator <public key>".
+	Target  string `json:"target"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Warnings flags the committee-wide values set in the config.
+func (c *Config) Warnings() []Warning {
+	var warnings []Warning
+	flag := func(target string, settings Settings) {
+		if settings.GasLimit != 0 {
+			warnings = append(warnings, Warning{
+				Target:  target,
+				Field:   "gas_limit",
+				Message: "must match across the committee, otherwise validator registrations don't reach quorum and builder blocks can't be proposed",
+			})
+		}
+		if settings.BuilderPolicy != BuilderPolicyDefault {
+			warnings = append(warnings, Warning{
+				Target:  target,
+				Field:   "builder_policy",
+				Message: "should match across the committee, otherwise the proposed block depends on which operator leads the round",
+			})
+		}
+	}
+
+	flag("default", c.Default)
+	for _, key := range slices.Sorted(maps.Keys(c.Owners)) {
+		flag("owner "+key, c.Owners[key])
+	}
+	for _, key := range slices.Sorted(maps.Keys(c.Validators)) {
+		flag("validator "+key, c.Validators[key])
+	}
+	return warnings
+}
@@ -0,0 +1,115 @@
+package proposerconfig
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Store holds the proposer config read from a file, and persists the replacements made to it at runtime.
+type Store struct {
+	path string
+
+	mu         sync.RWMutex
+	config     Config
+	validators map[spectypes.ValidatorPK]Settings
+	owners     map[common.Address]Settings
+}
+
+// Open reads the proposer config from the file at path. A missing file is an empty config,
+// which is written to the file once it's first updated.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+
+	var config Config
+	data, err := os.ReadFile(filepath.Clean(path))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("read proposer config file: %w", err)
+	default:
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("decode proposer config file: %w", err)
+		}
+	}
+
+	validators, owners, err := config.index()
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposer config file: %w", err)
+	}
+	s.set(config, validators, owners)
+	return s, nil
+}
+
+// Config returns a copy of the current config.
+func (s *Store) Config() Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return Config{
+		Default:    s.config.Default,
+		Owners:     maps.Clone(s.config.Owners),
+		Validators: maps.Clone(s.config.Validators),
+	}
+}
+
+// Update validates the config, writes it to the file and makes it the current config.
+func (s *Store) Update(config Config) error {
+	validators, owners, err := config.index()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := writeFile(s.path, config); err != nil {
+		return err
+	}
+	s.set(config, validators, owners)
+	return nil
+}
+
+// set must be called with mu held.
+func (s *Store) set(config Config, validators map[spectypes.ValidatorPK]Settings, owners map[common.Address]Settings) {
+	s.config = Config{
+		Default:    config.Default,
+		Owners:     maps.Clone(config.Owners),
+		Validators: maps.Clone(config.Validators),
+	}
+	s.validators = validators
+	s.owners = owners
+}
+
+// Settings returns the settings which apply to the validator with the given public key and owner.
+func (s *Store) Settings(pubKey spectypes.ValidatorPK, owner common.Address) Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.config.Default.Merge(s.owners[owner]).Merge(s.validators[pubKey])
+}
+
+// writeFile replaces the file at path with the config. The file is replaced only once the new one is complete.
+func writeFile(path string, config Config) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("encode proposer config: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("write proposer config file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename proposer config file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package proposerconfig
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	pubKey := "0x" + strings.Repeat("ab", 48)
+	owner := "0x0000000000000000000000000000000000000001"
+
+	tests := []struct {
+		name   string
+		config Config
+		errStr string
+	}{
+		{name: "empty", config: Config{}},
+		{
+			name: "valid",
+			config: Config{
+				Default:    Settings{Graffiti: "ssv", BuilderPolicy: BuilderPolicyMaxProfit},
+				Owners:     map[string]Settings{owner: {GasLimit: 36_000_000}},
+				Validators: map[string]Settings{pubKey: {BuilderPolicy: BuilderPolicyLocalOnly}},
+			},
+		},
+		{
+			name:   "graffiti too long",
+			config: Config{Default: Settings{Graffiti: strings.Repeat("a", 33)}},
+			errStr: "graffiti is 33 bytes long",
+		},
+		{
+			name:   "unknown builder policy",
+			config: Config{Owners: map[string]Settings{owner: {BuilderPolicy: "mev"}}},
+			errStr: "unknown builder policy",
+		},
+		{
+			name:   "invalid owner",
+			config: Config{Owners: map[string]Settings{"0x1234": {}}},
+			errStr: "invalid address",
+		},
+		{
+			name:   "invalid public key",
+			config: Config{Validators: map[string]Settings{"0x1234": {}}},
+			errStr: "invalid public key length",
+		},
+		{
+			name: "duplicate public key",
+			config: Config{Validators: map[string]Settings{
+				pubKey:                           {},
+				strings.TrimPrefix(pubKey, "0x"): {},
+			}},
+			errStr: "duplicate entry",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.errStr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.errStr)
+			}
+		})
+	}
+}
+
+func TestConfig_Warnings(t *testing.T) {
+	config := Config{
+		Default: Settings{Graffiti: "ssv"},
+		Owners: map[string]Settings{
+			"0x0000000000000000000000000000000000000001": {GasLimit: 36_000_000, BuilderPolicy: BuilderPolicyBuilderOnly},
+		},
+	}
+
+	warnings := config.Warnings()
+	require.Len(t, warnings, 2)
+	require.Equal(t, "owner 0x0000000000000000000000000000000000000001", warnings[0].Target)
+	require.Equal(t, "gas_limit", warnings[0].Field)
+	require.Equal(t, "builder_policy", warnings[1].Field)
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proposer-config.yaml")
+
+	var pubKey, otherPubKey spectypes.ValidatorPK
+	pubKey[0], otherPubKey[0] = 1, 2
+	owner := common.HexToAddress("0x1")
+
+	store, err := Open(path)
+	require.NoError(t, err)
+	require.Equal(t, Settings{}, store.Settings(pubKey, owner))
+
+	config := Config{
+		Default: Settings{Graffiti: "default", BuilderPolicy: BuilderPolicyMaxProfit},
+		Owners: map[string]Settings{
+			owner.Hex(): {Graffiti: "owner", GasLimit: 30_000_000},
+		},
+		Validators: map[string]Settings{
+			"0x" + common.Bytes2Hex(pubKey[:]): {GasLimit: 36_000_000, BuilderPolicy: BuilderPolicyLocalOnly},
+		},
+	}
+	require.NoError(t, store.Update(config))
+
+	require.Equal(t, Settings{Graffiti: "owner", GasLimit: 36_000_000, BuilderPolicy: BuilderPolicyLocalOnly}, store.Settings(pubKey, owner))
+	require.Equal(t, Settings{Graffiti: "owner", GasLimit: 30_000_000, BuilderPolicy: BuilderPolicyMaxProfit}, store.Settings(otherPubKey, owner))
+	require.Equal(t, Settings{Graffiti: "default", BuilderPolicy: BuilderPolicyMaxProfit}, store.Settings(otherPubKey, common.Address{}))
+
+	// Invalid configs are rejected and keep the current one.
+	require.Error(t, store.Update(Config{Default: Settings{BuilderPolicy: "mev"}}))
+	require.Equal(t, config, store.Config())
+
+	// The config is persisted.
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	require.Equal(t, config, reopened.Config())
+	require.Equal(t, store.Settings(pubKey, owner), reopened.Settings(pubKey, owner))
+}
+
+func TestBuilderPolicy_BuilderBoostFactor(t *testing.T) {
+	_, ok := BuilderPolicyDefault.BuilderBoostFactor()
+	require.False(t, ok)
+
+	factor, ok := BuilderPolicyLocalOnly.BuilderBoostFactor()
+	require.True(t, ok)
+	require.Zero(t, factor)
+
+	factor, ok = BuilderPolicyMaxProfit.BuilderBoostFactor()
+	require.True(t, ok)
+	require.EqualValues(t, 100, factor)
+}
@@ -0,0 +1,250 @@
+// Package snapshot exports and imports the registry state synced from the contract events, so that a new node
+// can continue syncing from the block a snapshot was taken at instead of replaying all the events since the
+// registry sync offset.
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"slices"
+	"time"
+
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+// FormatVersion is the version of the snapshot format written by this binary.
+const FormatVersion = 1
+
+// Snapshot is the registry state as of a processed block.
+type Snapshot struct {
+	FormatVersion      int       `json:"format_version"`
+	NetworkName        string    `json:"network_name"`
+	CreatedAt          time.Time `json:"created_at"`
+	LastProcessedBlock uint64    `json:"last_processed_block"`
+	// EventHashChain is the hash chain of the events processed up to LastProcessedBlock, if the node kept one.
+	EventHashChain *operatorstorage.EventHashChain `json:"event_hash_chain,omitempty"`
+	Operators      []registrystorage.OperatorData  `json:"operators"`
+	// Shares are SSZ-encoded registry storage shares.
+	Shares     [][]byte                        `json:"shares"`
+	Recipients []registrystorage.RecipientData `json:"recipients"`
+}
+
+// Validate returns an error if the snapshot can't be imported by this binary into a node of the given network.
+func (s *Snapshot) Validate(networkName string) error {
+	if s.FormatVersion != FormatVersion {
+		return fmt.Errorf("unsupported snapshot format version %d (expected %d)", s.FormatVersion, FormatVersion)
+	}
+	if s.NetworkName != networkName {
+		return fmt.Errorf("network mismatch: snapshot is of network %s but the node is configured for network %s", s.NetworkName, networkName)
+	}
+	if s.LastProcessedBlock == 0 {
+		return fmt.Errorf("snapshot has no last processed block")
+	}
+	return nil
+}
+
+// DecodeShares returns the decoded shares of the snapshot.
+func (s *Snapshot) DecodeShares() ([]*ssvtypes.SSVShare, error) {
+	shares := make([]*ssvtypes.SSVShare, 0, len(s.Shares))
+	for _, encoded := range s.Shares {
+		stored := &registrystorage.Share{}
+		if err := stored.Decode(encoded); err != nil {
+			return nil, fmt.Errorf("decode share: %w", err)
+		}
+		share, err := registrystorage.ToSSVShare(stored)
+		if err != nil {
+			return nil, fmt.Errorf("convert share: %w", err)
+		}
+		shares = append(shares, share)
+	}
+	return shares, nil
+}
+
+// Export returns a snapshot of the registry state in nodeStorage, which must have synced events.
+func Export(nodeStorage operatorstorage.Storage, networkName string) (*Snapshot, error) {
+	txn := nodeStorage.BeginRead()
+	defer txn.Discard()
+
+	lastProcessedBlock, found, err := nodeStorage.GetLastProcessedBlock(txn)
+	if err != nil {
+		return nil, fmt.Errorf("get last processed block: %w", err)
+	}
+	if !found || lastProcessedBlock == nil || lastProcessedBlock.Uint64() == 0 {
+		return nil, fmt.Errorf("no events were synced yet")
+	}
+
+	snapshot := &Snapshot{
+		FormatVersion:      FormatVersion,
+		NetworkName:        networkName,
+		CreatedAt:          time.Now().UTC(),
+		LastProcessedBlock: lastProcessedBlock.Uint64(),
+	}
+
+	chain, found, err := nodeStorage.GetEventHashChain(txn)
+	if err != nil {
+		return nil, fmt.Errorf("get event hash chain: %w", err)
+	}
+	if found {
+		snapshot.EventHashChain = &chain
+	}
+
+	snapshot.Operators, err = nodeStorage.ListOperators(txn, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list operators: %w", err)
+	}
+
+	shares := nodeStorage.Shares().List(txn)
+	slices.SortFunc(shares, func(a, b *ssvtypes.SSVShare) int {
+		return bytes.Compare(a.ValidatorPubKey[:], b.ValidatorPubKey[:])
+	})
+	for _, share := range shares {
+		encoded, err := registrystorage.FromSSVShare(share).Encode()
+		if err != nil {
+			return nil, fmt.Errorf("encode share: %w", err)
+		}
+		snapshot.Shares = append(snapshot.Shares, encoded)
+	}
+
+	snapshot.Recipients, err = nodeStorage.ListRecipients(txn)
+	if err != nil {
+		return nil, fmt.Errorf("list recipients: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// Import seeds the registry state in nodeStorage, which must not have synced any events, from the snapshot.
+func Import(nodeStorage operatorstorage.Storage, snapshot *Snapshot) error {
+	shares, err := snapshot.DecodeShares()
+	if err != nil {
+		return err
+	}
+
+	txn := nodeStorage.Begin()
+	defer txn.Discard()
+
+	_, found, err := nodeStorage.GetLastProcessedBlock(txn)
+	if err != nil {
+		return fmt.Errorf("get last processed block: %w", err)
+	}
+	operators, err := nodeStorage.ListOperators(txn, 0, 0)
+	if err != nil {
+		return fmt.Errorf("list operators: %w", err)
+	}
+	if found || len(operators) > 0 || len(nodeStorage.Shares().List(txn)) > 0 {
+		return fmt.Errorf("registry is not empty, refusing to import over it")
+	}
+
+	for i := range snapshot.Operators {
+		if _, err := nodeStorage.SaveOperatorData(txn, &snapshot.Operators[i]); err != nil {
+			return fmt.Errorf("save operator data: %w", err)
+		}
+	}
+	if err := nodeStorage.Shares().Save(txn, shares...); err != nil {
+		return fmt.Errorf("save shares: %w", err)
+	}
+	for i := range snapshot.Recipients {
+		if _, err := nodeStorage.SaveRecipientData(txn, &snapshot.Recipients[i]); err != nil {
+			return fmt.Errorf("save recipient data: %w", err)
+		}
+	}
+	if snapshot.EventHashChain != nil {
+		if err := nodeStorage.SaveEventHashChain(txn, *snapshot.EventHashChain); err != nil {
+			return fmt.Errorf("save event hash chain: %w", err)
+		}
+	}
+	if err := nodeStorage.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(snapshot.LastProcessedBlock)); err != nil {
+		return fmt.Errorf("save last processed block: %w", err)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Write writes the snapshot to w as gzipped JSON.
+func Write(w io.Writer, snapshot *Snapshot) error {
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(snapshot); err != nil {
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("compress snapshot: %w", err)
+	}
+	return nil
+}
+
+// Read reads a snapshot written by Write from r.
+func Read(r io.Reader) (*Snapshot, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a registry snapshot: %w", err)
+	}
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(zr).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// WriteFile writes the snapshot to a new file at path. The file only appears at path once it's complete.
+func WriteFile(path string, snapshot *Snapshot) error {
+	tmpPath := path + ".tmp"
+	// #nosec G304
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("create snapshot file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+	}()
+
+	bw := bufio.NewWriter(f)
+	if err := Write(bw, snapshot); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("flush snapshot file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("sync snapshot file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close snapshot file: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("snapshot file %s already exists", path)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename snapshot file: %w", err)
+	}
+	return nil
+}
+
+// ReadFile reads a snapshot from the file at path.
+func ReadFile(path string) (*Snapshot, error) {
+	// #nosec G304
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return Read(bufio.NewReader(f))
+}
@@ -0,0 +1,122 @@
+package snapshot
+
+import (
+	"bytes"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func newTestStorage(t *testing.T) operatorstorage.Storage {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	nodeStorage, err := operatorstorage.NewNodeStorage(networkconfig.TestNetwork, logging.TestLogger(t), db)
+	require.NoError(t, err)
+	return nodeStorage
+}
+
+func testShare(pubKeyByte byte, owner common.Address) *ssvtypes.SSVShare {
+	var pubKey spectypes.ValidatorPK
+	copy(pubKey[:], bytes.Repeat([]byte{pubKeyByte}, len(pubKey)))
+
+	committee := make([]*spectypes.ShareMember, 4)
+	for i := range committee {
+		committee[i] = &spectypes.ShareMember{
+			Signer:      spectypes.OperatorID(i + 1),
+			SharePubKey: bytes.Repeat([]byte{pubKeyByte + byte(i) + 1}, len(pubKey)),
+		}
+	}
+	return &ssvtypes.SSVShare{
+		Share: spectypes.Share{
+			ValidatorPubKey: pubKey,
+			SharePubKey:     committee[0].SharePubKey,
+			Committee:       committee,
+			DomainType:      networkconfig.TestNetwork.DomainType,
+			Graffiti:        bytes.Repeat([]byte{0x01}, 32),
+		},
+		OwnerAddress: owner,
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	networkName := networkconfig.TestNetwork.NetworkName()
+	owner := common.HexToAddress("0x1")
+
+	source := newTestStorage(t)
+	require.NoError(t, source.SaveLastProcessedBlock(nil, big.NewInt(100)))
+	require.NoError(t, source.SaveEventHashChain(nil, operatorstorage.EventHashChain{StartBlock: 10, Hash: common.HexToHash("0x1234")}))
+	for id := spectypes.OperatorID(1); id <= 4; id++ {
+		_, err := source.SaveOperatorData(nil, &registrystorage.OperatorData{
+			ID:           id,
+			PublicKey:    []byte{byte(id)},
+			OwnerAddress: owner,
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, source.Shares().Save(nil, testShare(0x10, owner), testShare(0x20, owner)))
+	_, err := source.SaveRecipientData(nil, &registrystorage.RecipientData{Owner: owner})
+	require.NoError(t, err)
+
+	t.Run("export requires synced events", func(t *testing.T) {
+		_, err := Export(newTestStorage(t), networkName)
+		require.Error(t, err)
+	})
+
+	exported, err := Export(source, networkName)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, exported.LastProcessedBlock)
+	require.Len(t, exported.Operators, 4)
+	require.Len(t, exported.Shares, 2)
+	require.Len(t, exported.Recipients, 1)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json.gz")
+	require.NoError(t, WriteFile(path, exported))
+	require.Error(t, WriteFile(path, exported), "existing file must not be overwritten")
+
+	snapshot, err := ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, snapshot.Validate(networkName))
+	require.ErrorContains(t, snapshot.Validate("other"), "network mismatch")
+
+	target := newTestStorage(t)
+	require.NoError(t, Import(target, snapshot))
+
+	lastProcessedBlock, found, err := target.GetLastProcessedBlock(nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 100, lastProcessedBlock.Uint64())
+
+	chain, found, err := target.GetEventHashChain(nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, operatorstorage.EventHashChain{StartBlock: 10, Hash: common.HexToHash("0x1234")}, chain)
+
+	operators, err := target.ListOperators(nil, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, operators, 4)
+
+	share, found := target.Shares().Get(nil, bytes.Repeat([]byte{0x20}, 48))
+	require.True(t, found)
+	require.Equal(t, owner, share.OwnerAddress)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3, 4}, share.OperatorIDs())
+
+	_, found, err = target.GetRecipientData(nil, owner)
+	require.NoError(t, err)
+	require.True(t, found)
+
+	require.ErrorContains(t, Import(target, snapshot), "not empty")
+}
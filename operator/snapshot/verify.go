@@ -0,0 +1,178 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ssvlabs/ssv/eth/contract"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+// ExecutionClient reads the registry contract events to verify snapshots against.
+type ExecutionClient interface {
+	HeaderByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Header, error)
+	Filterer() (*contract.ContractFilterer, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error)
+}
+
+// filterWindow is the amount of blocks a single contract event filter spans,
+// as execution nodes limit the block range of log queries.
+const filterWindow = 10_000
+
+// Verify checks the snapshot against the chain by looking up the contract events which registered
+// up to the given amount of randomly sampled operators and shares, searching the blocks since fromBlock.
+// The event hash chain of the snapshot, if any, is recomputed from the events of the registry contract.
+func Verify(ctx context.Context, client ExecutionClient, contractAddress ethcommon.Address, snapshot *Snapshot, fromBlock uint64, samples int) error {
+	if _, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(snapshot.LastProcessedBlock)); err != nil {
+		return fmt.Errorf("failed to get last processed block %d: %w", snapshot.LastProcessedBlock, err)
+	}
+
+	if snapshot.EventHashChain != nil {
+		if err := verifyEventHashChain(ctx, client, contractAddress, *snapshot.EventHashChain, snapshot.LastProcessedBlock); err != nil {
+			return err
+		}
+	}
+
+	filterer, err := client.Filterer()
+	if err != nil {
+		return fmt.Errorf("failed to get event filterer: %w", err)
+	}
+
+	operators := make(map[uint64]*registrystorage.OperatorData)
+	for _, i := range sample(len(snapshot.Operators), samples) {
+		operators[snapshot.Operators[i].ID] = &snapshot.Operators[i]
+	}
+	if err := verifyOperators(ctx, filterer, fromBlock, snapshot.LastProcessedBlock, operators); err != nil {
+		return err
+	}
+
+	shares, err := snapshot.DecodeShares()
+	if err != nil {
+		return err
+	}
+	var sampledShares []*ssvtypes.SSVShare
+	owners := make(map[ethcommon.Address]struct{})
+	for _, i := range sample(len(shares), samples) {
+		sampledShares = append(sampledShares, shares[i])
+		owners[shares[i].OwnerAddress] = struct{}{}
+	}
+	if len(sampledShares) == 0 {
+		return nil
+	}
+	events, err := ValidatorAddedEvents(ctx, filterer, fromBlock, snapshot.LastProcessedBlock, slices.Collect(maps.Keys(owners))...)
+	if err != nil {
+		return err
+	}
+	for _, share := range sampledShares {
+		found := slices.ContainsFunc(events, func(event *contract.ContractValidatorAdded) bool {
+			return event.Owner == share.OwnerAddress &&
+				bytes.Equal(event.PublicKey, share.ValidatorPubKey[:]) &&
+				slices.Equal(event.OperatorIds, share.OperatorIDs())
+		})
+		if !found {
+			return fmt.Errorf("share of validator %x is not registered on chain", share.ValidatorPubKey[:])
+		}
+	}
+
+	return nil
+}
+
+// verifyEventHashChain recomputes the event hash chain from the contract events between its start block and toBlock,
+// returning an error if it doesn't match the given one.
+func verifyEventHashChain(ctx context.Context, client ExecutionClient, contractAddress ethcommon.Address, chain operatorstorage.EventHashChain, toBlock uint64) error {
+	var computed operatorstorage.EventHashChain
+	for start := chain.StartBlock; chain.StartBlock != 0 && start <= toBlock; start += filterWindow {
+		end := min(start+filterWindow-1, toBlock)
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			Addresses: []ethcommon.Address{contractAddress},
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to filter contract events: %w", err)
+		}
+		for _, log := range logs {
+			if !log.Removed {
+				computed = computed.Next(log)
+			}
+		}
+	}
+
+	if computed != chain {
+		return fmt.Errorf("event hash chain mismatch: snapshot has %s since block %d, contract events hash to %s since block %d",
+			chain.Hash, chain.StartBlock, computed.Hash, computed.StartBlock)
+	}
+	return nil
+}
+
+// verifyOperators looks up the OperatorAdded events of the given operators between fromBlock and toBlock,
+// returning an error if any of them isn't registered on chain with its owner and public key.
+func verifyOperators(ctx context.Context, filterer *contract.ContractFilterer, fromBlock, toBlock uint64, operators map[uint64]*registrystorage.OperatorData) error {
+	for start := fromBlock; start <= toBlock && len(operators) > 0; start += filterWindow {
+		end := min(start+filterWindow-1, toBlock)
+		opts := &bind.FilterOpts{Start: start, End: &end, Context: ctx}
+
+		it, err := filterer.FilterOperatorAdded(opts, slices.Collect(maps.Keys(operators)), nil)
+		if err != nil {
+			return fmt.Errorf("failed to filter OperatorAdded events: %w", err)
+		}
+		for it.Next() {
+			od, ok := operators[it.Event.OperatorId]
+			if ok && it.Event.Owner == od.OwnerAddress && bytes.Equal(it.Event.PublicKey, od.PublicKey) {
+				delete(operators, od.ID)
+			}
+		}
+		err = it.Error()
+		_ = it.Close()
+		if err != nil {
+			return fmt.Errorf("failed to iterate OperatorAdded events: %w", err)
+		}
+	}
+
+	if len(operators) > 0 {
+		return fmt.Errorf("operators %v are not registered on chain", slices.Sorted(maps.Keys(operators)))
+	}
+	return nil
+}
+
+// ValidatorAddedEvents returns the ValidatorAdded events of the given owners between fromBlock and toBlock,
+// in the order they were emitted.
+func ValidatorAddedEvents(ctx context.Context, filterer *contract.ContractFilterer, fromBlock, toBlock uint64, owners ...ethcommon.Address) ([]*contract.ContractValidatorAdded, error) {
+	var events []*contract.ContractValidatorAdded
+	for start := fromBlock; start <= toBlock; start += filterWindow {
+		end := min(start+filterWindow-1, toBlock)
+		opts := &bind.FilterOpts{Start: start, End: &end, Context: ctx}
+
+		it, err := filterer.FilterValidatorAdded(opts, owners)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter ValidatorAdded events: %w", err)
+		}
+		for it.Next() {
+			events = append(events, it.Event)
+		}
+		err = it.Error()
+		_ = it.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate ValidatorAdded events: %w", err)
+		}
+	}
+	return events, nil
+}
+
+// sample returns up to n random indexes of a slice of the given length.
+func sample(length, n int) []int {
+	indexes := rand.Perm(length) // #nosec G404 -- sampling doesn't need to be unpredictable
+	return indexes[:min(n, length)]
+}
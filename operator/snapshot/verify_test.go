@@ -0,0 +1,68 @@
+package snapshot
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/eth/contract"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+)
+
+// fakeLogsClient serves the logs of the registry contract within the queried block range.
+type fakeLogsClient struct {
+	logs []ethtypes.Log
+}
+
+func (c *fakeLogsClient) HeaderByNumber(context.Context, *big.Int) (*ethtypes.Header, error) {
+	return &ethtypes.Header{}, nil
+}
+
+func (c *fakeLogsClient) Filterer() (*contract.ContractFilterer, error) {
+	return nil, nil
+}
+
+func (c *fakeLogsClient) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	var logs []ethtypes.Log
+	for _, log := range c.logs {
+		if log.BlockNumber >= q.FromBlock.Uint64() && log.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func TestVerifyEventHashChain(t *testing.T) {
+	ctx := context.Background()
+	contractAddress := common.HexToAddress("0x1")
+
+	logs := []ethtypes.Log{
+		{BlockNumber: 10, TxHash: common.HexToHash("0x10"), Index: 0},
+		{BlockNumber: 10, TxHash: common.HexToHash("0x10"), Index: 1},
+		{BlockNumber: 25_000, TxHash: common.HexToHash("0x25"), Index: 3},
+	}
+	client := &fakeLogsClient{logs: logs}
+	chain := operatorstorage.EventHashChain{}.Next(logs[0]).Next(logs[1]).Next(logs[2])
+
+	require.NoError(t, verifyEventHashChain(ctx, client, contractAddress, chain, 30_000))
+
+	// Events after the last processed block aren't hashed.
+	require.Error(t, verifyEventHashChain(ctx, client, contractAddress, chain, 20_000))
+
+	tampered := chain
+	tampered.Hash = common.HexToHash("0x1234")
+	require.ErrorContains(t, verifyEventHashChain(ctx, client, contractAddress, tampered, 30_000), "mismatch")
+
+	// A chain is only recomputed from its start block.
+	partial := operatorstorage.EventHashChain{}.Next(logs[2])
+	require.NoError(t, verifyEventHashChain(ctx, client, contractAddress, partial, 30_000))
+	partial.StartBlock = 10
+	require.Error(t, verifyEventHashChain(ctx, client, contractAddress, partial, 30_000))
+
+	require.NoError(t, verifyEventHashChain(ctx, &fakeLogsClient{}, contractAddress, operatorstorage.EventHashChain{}, 30_000))
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EventHashChain is a running hash over the processed registry contract events. Nodes which started
+// hashing at the same block have processed the same events if their hashes at the same block are equal.
+type EventHashChain struct {
+	// StartBlock is the block of the first hashed event.
+	StartBlock uint64      `json:"start_block"`
+	Hash       common.Hash `json:"hash"`
+}
+
+// Next returns the chain extended with the given event.
+func (c EventHashChain) Next(event ethtypes.Log) EventHashChain {
+	if c.StartBlock == 0 {
+		c.StartBlock = event.BlockNumber
+	}
+	c.Hash = crypto.Keccak256Hash(
+		c.Hash[:],
+		binary.BigEndian.AppendUint64(nil, event.BlockNumber),
+		event.TxHash[:],
+		binary.BigEndian.AppendUint64(nil, uint64(event.Index)),
+	)
+	return c
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHashChain_Next(t *testing.T) {
+	event1 := ethtypes.Log{BlockNumber: 10, TxHash: common.HexToHash("0x1"), Index: 0}
+	event2 := ethtypes.Log{BlockNumber: 12, TxHash: common.HexToHash("0x2"), Index: 3}
+
+	chain := EventHashChain{}.Next(event1).Next(event2)
+	require.EqualValues(t, 10, chain.StartBlock)
+	require.Equal(t, chain, EventHashChain{}.Next(event1).Next(event2))
+
+	// The order of the events matters.
+	require.NotEqual(t, chain.Hash, EventHashChain{}.Next(event2).Next(event1).Hash)
+}
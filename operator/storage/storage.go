@@ -22,6 +22,7 @@ var (
 	OperatorStoragePrefix = []byte("operator/")
 	lastProcessedBlockKey = []byte("syncOffset") // TODO: temporarily left as syncOffset for compatibility, consider renaming and adding a migration for that
 	configKey             = []byte("config")
+	eventHashChainKey     = []byte("events_hash_chain")
 	hashedPrivkeyDBKey    = "hashed-private-key"
 	pubkeyDBKey           = "public-key"
 )
@@ -36,6 +37,10 @@ type Storage interface {
 	SaveLastProcessedBlock(rw basedb.ReadWriter, offset *big.Int) error
 	GetLastProcessedBlock(r basedb.Reader) (*big.Int, bool, error)
 
+	SaveEventHashChain(rw basedb.ReadWriter, chain EventHashChain) error
+	GetEventHashChain(r basedb.Reader) (EventHashChain, bool, error)
+	DeleteEventHashChain(rw basedb.ReadWriter) error
+
 	GetConfig(rw basedb.ReadWriter) (*ConfigLock, bool, error)
 	SaveConfig(rw basedb.ReadWriter, config *ConfigLock) error
 	DeleteConfig(rw basedb.ReadWriter) error
@@ -151,6 +156,10 @@ func (s *storage) BumpNonce(rw basedb.ReadWriter, owner common.Address) error {
 	return s.recipientStore.BumpNonce(rw, owner)
 }
 
+func (s *storage) ListRecipients(r basedb.Reader) ([]registrystorage.RecipientData, error) {
+	return s.recipientStore.ListRecipients(r)
+}
+
 func (s *storage) GetRecipientsPrefix() []byte {
 	return s.recipientStore.GetRecipientsPrefix()
 }
@@ -160,6 +169,10 @@ func (s *storage) DropRegistryData() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to drop last processed block")
 	}
+	err = s.DeleteEventHashChain(nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to drop event hash chain")
+	}
 	err = s.DropShares()
 	if err != nil {
 		return errors.Wrap(err, "failed to drop operators")
@@ -211,6 +224,37 @@ func (s *storage) GetLastProcessedBlock(r basedb.Reader) (*big.Int, bool, error)
 	return offset, found, nil
 }
 
+// SaveEventHashChain saves the hash chain of the processed events.
+func (s *storage) SaveEventHashChain(rw basedb.ReadWriter, chain EventHashChain) error {
+	b, err := json.Marshal(chain)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return s.db.Using(rw).Set(OperatorStoragePrefix, eventHashChainKey, b)
+}
+
+// GetEventHashChain returns the hash chain of the processed events.
+func (s *storage) GetEventHashChain(r basedb.Reader) (EventHashChain, bool, error) {
+	obj, found, err := s.db.UsingReader(r).Get(OperatorStoragePrefix, eventHashChainKey)
+	if err != nil {
+		return EventHashChain{}, false, fmt.Errorf("db: %w", err)
+	}
+	if !found {
+		return EventHashChain{}, false, nil
+	}
+
+	var chain EventHashChain
+	if err := json.Unmarshal(obj.Value, &chain); err != nil {
+		return EventHashChain{}, false, fmt.Errorf("unmarshal: %w", err)
+	}
+	return chain, true, nil
+}
+
+// DeleteEventHashChain deletes the hash chain of the processed events.
+func (s *storage) DeleteEventHashChain(rw basedb.ReadWriter) error {
+	return s.db.Using(rw).Delete(OperatorStoragePrefix, eventHashChainKey)
+}
+
 // GetPrivateKeyHash return sha256 hashed private key
 func (s *storage) GetPrivateKeyHash() ([]byte, bool, error) {
 	obj, found, err := s.db.Get(OperatorStoragePrefix, []byte(hashedPrivkeyDBKey))
@@ -256,7 +300,13 @@ func (s *storage) SavePublicKey(publicKey string) error {
 }
 
 func (s *storage) GetConfig(rw basedb.ReadWriter) (*ConfigLock, bool, error) {
-	obj, found, err := s.db.Using(rw).Get(OperatorStoragePrefix, configKey)
+	return ReadConfig(s.db.Using(rw))
+}
+
+// ReadConfig reads the config lock directly from the given reader,
+// for callers which don't have (or don't want to load) a node storage.
+func ReadConfig(r basedb.Reader) (*ConfigLock, bool, error) {
+	obj, found, err := r.Get(OperatorStoragePrefix, configKey)
 	if err != nil {
 		return nil, false, fmt.Errorf("db: %w", err)
 	}
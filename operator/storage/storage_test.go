@@ -272,6 +272,36 @@ func Test_LastProcessedBlock(t *testing.T) {
 	require.Equal(t, *big.NewInt(123), *blockNum)
 }
 
+func Test_EventHashChain(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	defer func() {
+		_ = db.Close()
+	}()
+
+	require.NoError(t, err)
+
+	operatorStorage, err := NewNodeStorage(network, logger, db)
+	require.NoError(t, err)
+
+	_, found, err := operatorStorage.GetEventHashChain(nil)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	chain := EventHashChain{StartBlock: 10, Hash: common.HexToHash("0x1234")}
+	require.NoError(t, operatorStorage.SaveEventHashChain(nil, chain))
+
+	stored, found, err := operatorStorage.GetEventHashChain(nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, chain, stored)
+
+	require.NoError(t, operatorStorage.DeleteEventHashChain(nil))
+	_, found, err = operatorStorage.GetEventHashChain(nil)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
 func Test_OperatorData(t *testing.T) {
 	logger := logging.TestLogger(t)
 	db, err := kv.NewInMemory(logger, basedb.Options{})
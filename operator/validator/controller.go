@@ -30,6 +30,8 @@ import (
 	"github.com/ssvlabs/ssv/networkconfig"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
 	"github.com/ssvlabs/ssv/operator/duties"
+	"github.com/ssvlabs/ssv/operator/dutyhistory"
+	"github.com/ssvlabs/ssv/operator/proposerconfig"
 	nodestorage "github.com/ssvlabs/ssv/operator/storage"
 	"github.com/ssvlabs/ssv/operator/validator/metadata"
 	"github.com/ssvlabs/ssv/operator/validators"
@@ -89,6 +91,9 @@ type ControllerOptions struct {
 	ValidatorSyncer            *metadata.Syncer
 	Graffiti                   []byte
 	ProposerDelay              time.Duration
+	DutyHistory                *dutyhistory.Recorder
+	ProposerConfig             *proposerconfig.Store
+	DutyHistoryRetainEpochs    uint64 `yaml:"DutyHistoryRetainEpochs" env:"DUTY_HISTORY_RETAIN_EPOCHS" env-default:"225" env-description:"Number of epochs of per-validator duty history to retain (0 to disable duty history)"`
 
 	// worker flags
 	WorkersCount    int    `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"256" env-description:"Number of message processing workers"`
@@ -184,8 +189,8 @@ type controller struct {
 	committeesObservers      *ttlcache.Cache[spectypes.MessageID, *committeeObserver]
 	committeesObserversMutex sync.Mutex
 
-	attesterRoots   *ttlcache.Cache[phase0.Root, struct{}]
-	syncCommRoots   *ttlcache.Cache[phase0.Root, struct{}]
+	attesterRoots   *ttlcache.Cache[phase0.Root, *phase0.AttestationData]
+	syncCommRoots   *ttlcache.Cache[phase0.Root, phase0.Root]
 	beaconVoteRoots *ttlcache.Cache[validator.BeaconVoteCacheKey, struct{}]
 
 	domainCache *validator.DomainCache
@@ -224,6 +229,10 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		options.Graffiti,
 		options.ProposerDelay,
 	)
+	validatorCommonOpts.DutyHistory = options.DutyHistory
+	if options.ProposerConfig != nil {
+		validatorCommonOpts.ProposerSettings = proposerSettingsProvider{store: options.ProposerConfig}
+	}
 
 	beaconNetwork := options.NetworkConfig.Beacon
 	cacheTTL := beaconNetwork.SlotDurationSec() * time.Duration(beaconNetwork.SlotsPerEpoch()*2) // #nosec G115
@@ -258,10 +267,10 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 			ttlcache.WithTTL[spectypes.MessageID, *committeeObserver](cacheTTL),
 		),
 		attesterRoots: ttlcache.New(
-			ttlcache.WithTTL[phase0.Root, struct{}](cacheTTL),
+			ttlcache.WithTTL[phase0.Root, *phase0.AttestationData](cacheTTL),
 		),
 		syncCommRoots: ttlcache.New(
-			ttlcache.WithTTL[phase0.Root, struct{}](cacheTTL),
+			ttlcache.WithTTL[phase0.Root, phase0.Root](cacheTTL),
 		),
 		domainCache: validator.NewDomainCache(options.Beacon, cacheTTL),
 		beaconVoteRoots: ttlcache.New(
@@ -604,6 +613,8 @@ func (c *controller) ExecuteDuty(ctx context.Context, logger *zap.Logger, duty *
 	pk := make([]byte, 48)
 	copy(pk, duty.PubKey[:])
 
+	c.recordScheduledDuty(spectypes.ValidatorPK(pk), duty, nil)
+
 	if v, ok := c.GetValidator(spectypes.ValidatorPK(pk)); ok {
 		ssvMsg, err := CreateDutyExecuteMsg(duty, pk, c.networkConfig.DomainType)
 		if err != nil {
@@ -617,14 +628,20 @@ func (c *controller) ExecuteDuty(ctx context.Context, logger *zap.Logger, duty *
 		}
 		if pushed := v.Queues[duty.RunnerRole()].Q.TryPush(dec); !pushed {
 			logger.Warn("dropping ExecuteDuty message because the queue is full")
+			c.recordScheduledDuty(spectypes.ValidatorPK(pk), duty, errors.New("validator queue is full"))
 		}
 		// logger.Debug("📬 queue: pushed message", fields.MessageID(dec.MsgID), fields.MessageType(dec.MsgType))
 	} else {
 		logger.Warn("could not find validator")
+		c.recordScheduledDuty(spectypes.ValidatorPK(pk), duty, errors.New("validator not found"))
 	}
 }
 
 func (c *controller) ExecuteCommitteeDuty(ctx context.Context, logger *zap.Logger, committeeID spectypes.CommitteeID, duty *spectypes.CommitteeDuty) {
+	for _, validatorDuty := range duty.ValidatorDuties {
+		c.recordScheduledDuty(spectypes.ValidatorPK(validatorDuty.PubKey), validatorDuty, nil)
+	}
+
 	if cm, ok := c.validatorsMap.GetCommittee(committeeID); ok {
 		ssvMsg, err := CreateCommitteeDutyExecuteMsg(duty, committeeID, c.networkConfig.DomainType)
 		if err != nil {
@@ -641,9 +658,27 @@ func (c *controller) ExecuteCommitteeDuty(ctx context.Context, logger *zap.Logge
 		}
 	} else {
 		logger.Warn("could not find committee", fields.CommitteeID(committeeID))
+		for _, validatorDuty := range duty.ValidatorDuties {
+			c.recordScheduledDuty(spectypes.ValidatorPK(validatorDuty.PubKey), validatorDuty, errors.New("committee not found"))
+		}
 	}
 }
 
+// recordScheduledDuty records in the duty history that the duty scheduler dispatched the duty,
+// along with the error if it couldn't be handed over to the validator.
+func (c *controller) recordScheduledDuty(pubKey spectypes.ValidatorPK, duty *spectypes.ValidatorDuty, err error) {
+	entry := dutyhistory.Entry{
+		Role:  duty.Type,
+		Slot:  duty.Slot,
+		Stage: dutyhistory.StageScheduled,
+	}
+	if err != nil {
+		entry.ErrorClass = dutyhistory.ErrorClassSchedule
+		entry.Error = err.Error()
+	}
+	c.validatorCommonOpts.DutyHistory.Record(pubKey, entry)
+}
+
 // CreateDutyExecuteMsg returns ssvMsg with event type of execute duty
 func CreateDutyExecuteMsg(duty *spectypes.ValidatorDuty, pubKey []byte, domain spectypes.DomainType) (*spectypes.SSVMessage, error) {
 	executeDutyData := ssvtypes.ExecuteDutyData{Duty: duty}
@@ -797,6 +832,7 @@ func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validator.Validator
 			nil,
 			c.dutyGuard,
 		)
+		vc.DutyHistory = c.validatorCommonOpts.DutyHistory
 		vc.AddShare(&share.Share)
 		c.validatorsMap.PutCommittee(operator.CommitteeID, vc)
 
@@ -1096,6 +1132,26 @@ func SetupCommitteeRunners(
 	}
 }
 
+// proposerSettingsProvider adapts the proposer config to the proposer settings of the runners.
+type proposerSettingsProvider struct {
+	store *proposerconfig.Store
+}
+
+func (p proposerSettingsProvider) ProposerSettings(pubKey spectypes.ValidatorPK, owner common.Address) runner.ProposerSettings {
+	settings := p.store.Settings(pubKey, owner)
+
+	proposerSettings := runner.ProposerSettings{
+		GasLimit: settings.GasLimit,
+	}
+	if settings.Graffiti != "" {
+		proposerSettings.Graffiti = []byte(settings.Graffiti)
+	}
+	if builderBoostFactor, ok := settings.BuilderPolicy.BuilderBoostFactor(); ok {
+		proposerSettings.BuilderBoostFactor = &builderBoostFactor
+	}
+	return proposerSettings
+}
+
 // SetupRunners initializes duty runners for the given validator
 func SetupRunners(
 	ctx context.Context,
@@ -1133,6 +1189,13 @@ func SetupRunners(
 		return qbftCtrl
 	}
 
+	var proposerSettings runner.ProposerSettingsFunc
+	if options.ProposerSettings != nil {
+		proposerSettings = func() runner.ProposerSettings {
+			return options.ProposerSettings.ProposerSettings(share.ValidatorPubKey, share.OwnerAddress)
+		}
+	}
+
 	shareMap := make(map[phase0.ValidatorIndex]*spectypes.Share) // TODO: fill the map
 	shareMap[share.ValidatorIndex] = &share.Share
 
@@ -1144,7 +1207,7 @@ func SetupRunners(
 		case spectypes.RoleProposer:
 			proposedValueCheck := ssv.ProposerValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetBeaconNetwork(), share.ValidatorPubKey, share.ValidatorIndex, phase0.BLSPubKey(share.SharePubKey))
 			qbftCtrl := buildController(spectypes.RoleProposer, proposedValueCheck)
-			runners[role], err = runner.NewProposerRunner(logger, domainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, options.DoppelgangerHandler, proposedValueCheck, 0, options.Graffiti, options.ProposerDelay)
+			runners[role], err = runner.NewProposerRunner(logger, domainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, options.DoppelgangerHandler, proposedValueCheck, 0, options.Graffiti, proposerSettings, options.ProposerDelay)
 		case spectypes.RoleAggregator:
 			aggregatorValueCheckF := ssv.AggregatorValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetBeaconNetwork(), share.ValidatorPubKey, share.ValidatorIndex)
 			qbftCtrl := buildController(spectypes.RoleAggregator, aggregatorValueCheckF)
@@ -1154,7 +1217,7 @@ func SetupRunners(
 			qbftCtrl := buildController(spectypes.RoleSyncCommitteeContribution, syncCommitteeContributionValueCheckF)
 			runners[role], err = runner.NewSyncCommitteeAggregatorRunner(domainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, syncCommitteeContributionValueCheckF, 0)
 		case spectypes.RoleValidatorRegistration:
-			runners[role], err = runner.NewValidatorRegistrationRunner(domainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, options.Beacon, options.Network, options.Signer, options.OperatorSigner, options.GasLimit, options.NetworkConfig.GasLimit36Epoch)
+			runners[role], err = runner.NewValidatorRegistrationRunner(domainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, options.Beacon, options.Network, options.Signer, options.OperatorSigner, options.GasLimit, options.NetworkConfig.GasLimit36Epoch, proposerSettings)
 		case spectypes.RoleVoluntaryExit:
 			runners[role], err = runner.NewVoluntaryExitRunner(domainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, options.Beacon, options.Network, options.Signer, options.OperatorSigner)
 		}
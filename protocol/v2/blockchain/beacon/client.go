@@ -4,8 +4,10 @@ import (
 	"context"
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
 
 	specssv "github.com/ssvlabs/ssv-spec/ssv"
 )
@@ -38,6 +40,9 @@ type beaconValidator interface {
 type proposer interface {
 	// SubmitProposalPreparation with fee recipients
 	SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error
+	// GetBeaconBlockWithBuilderBoostFactor returns a beacon block like GetBeaconBlock, asking the beacon node
+	// to weigh the value of builder payloads by the given percentage against the value of local payloads.
+	GetBeaconBlockWithBuilderBoostFactor(slot phase0.Slot, graffiti, randao []byte, builderBoostFactor uint64) (ssz.Marshaler, spec.DataVersion, error)
 }
 
 // TODO need to handle differently (by spec)
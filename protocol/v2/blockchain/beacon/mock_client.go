@@ -218,6 +218,22 @@ func (m *Mockproposer) EXPECT() *MockproposerMockRecorder {
 	return m.recorder
 }
 
+// GetBeaconBlockWithBuilderBoostFactor mocks base method.
+func (m *Mockproposer) GetBeaconBlockWithBuilderBoostFactor(slot phase0.Slot, graffiti, randao []byte, builderBoostFactor uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeaconBlockWithBuilderBoostFactor", slot, graffiti, randao, builderBoostFactor)
+	ret0, _ := ret[0].(ssz.Marshaler)
+	ret1, _ := ret[1].(spec.DataVersion)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBeaconBlockWithBuilderBoostFactor indicates an expected call of GetBeaconBlockWithBuilderBoostFactor.
+func (mr *MockproposerMockRecorder) GetBeaconBlockWithBuilderBoostFactor(slot, graffiti, randao, builderBoostFactor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeaconBlockWithBuilderBoostFactor", reflect.TypeOf((*Mockproposer)(nil).GetBeaconBlockWithBuilderBoostFactor), slot, graffiti, randao, builderBoostFactor)
+}
+
 // SubmitProposalPreparation mocks base method.
 func (m *Mockproposer) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
 	m.ctrl.T.Helper()
@@ -384,6 +400,22 @@ func (mr *MockBeaconNodeMockRecorder) GetBeaconBlock(slot, graffiti, randao any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeaconBlock", reflect.TypeOf((*MockBeaconNode)(nil).GetBeaconBlock), slot, graffiti, randao)
 }
 
+// GetBeaconBlockWithBuilderBoostFactor mocks base method.
+func (m *MockBeaconNode) GetBeaconBlockWithBuilderBoostFactor(slot phase0.Slot, graffiti, randao []byte, builderBoostFactor uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeaconBlockWithBuilderBoostFactor", slot, graffiti, randao, builderBoostFactor)
+	ret0, _ := ret[0].(ssz.Marshaler)
+	ret1, _ := ret[1].(spec.DataVersion)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBeaconBlockWithBuilderBoostFactor indicates an expected call of GetBeaconBlockWithBuilderBoostFactor.
+func (mr *MockBeaconNodeMockRecorder) GetBeaconBlockWithBuilderBoostFactor(slot, graffiti, randao, builderBoostFactor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeaconBlockWithBuilderBoostFactor", reflect.TypeOf((*MockBeaconNode)(nil).GetBeaconBlockWithBuilderBoostFactor), slot, graffiti, randao, builderBoostFactor)
+}
+
 // GetBeaconNetwork mocks base method.
 func (m *MockBeaconNode) GetBeaconNetwork() types.BeaconNetwork {
 	m.ctrl.T.Helper()
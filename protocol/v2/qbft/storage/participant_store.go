@@ -35,6 +35,16 @@ func (si *StoredInstance) Decode(data []byte) error {
 	return json.Unmarshal(data, &si)
 }
 
+// ParticipantsProof proves the participation of operators in a decided duty:
+// Signature is the aggregation of the post-consensus partial signatures of Signers over Root,
+// which is the signing root of Data, the SSZ encoding of the signed beacon object.
+type ParticipantsProof struct {
+	Signers   []spectypes.OperatorID
+	Root      phase0.Root
+	Signature spectypes.Signature
+	Data      []byte
+}
+
 type ParticipantsRangeEntry struct {
 	Slot    phase0.Slot
 	PubKey  spectypes.ValidatorPK
@@ -58,6 +68,12 @@ type ParticipantStore interface {
 	// GetParticipants returns participants in quorum for the given slot.
 	GetParticipants(pk spectypes.ValidatorPK, slot phase0.Slot) ([]spectypes.OperatorID, error)
 
+	// SaveProof stores the proof of the participants in quorum, replacing any previous one.
+	SaveProof(pk spectypes.ValidatorPK, slot phase0.Slot, proof *ParticipantsProof) error
+
+	// GetProof returns the proof of the participants in quorum for the given slot, or nil if there's none.
+	GetProof(pk spectypes.ValidatorPK, slot phase0.Slot) (*ParticipantsProof, error)
+
 	// InitialSlotGC performs an initial cleanup (blocking) of slots bellow the retained threshold
 	Prune(ctx context.Context, logger *zap.Logger, below phase0.Slot)
 
@@ -355,6 +355,11 @@ func (r *AggregatorRunner) GetBaseRunner() *BaseRunner {
 	return r.BaseRunner
 }
 
+// Measurements implements MeasurementsProvider
+func (r *AggregatorRunner) Measurements() Measurements {
+	return r.measurements.snapshot()
+}
+
 func (r *AggregatorRunner) GetNetwork() specqbft.Network {
 	return r.network
 }
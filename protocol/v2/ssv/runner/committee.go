@@ -178,6 +178,11 @@ func (cr *CommitteeRunner) GetBaseRunner() *BaseRunner {
 	return cr.BaseRunner
 }
 
+// Measurements implements MeasurementsProvider
+func (cr *CommitteeRunner) Measurements() Measurements {
+	return cr.measurements.snapshot()
+}
+
 func (cr *CommitteeRunner) GetBeaconNode() beacon.BeaconNode {
 	return cr.beacon
 }
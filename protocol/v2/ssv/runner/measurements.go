@@ -16,6 +16,19 @@ type measurementsStore struct {
 	dutyDuration          time.Duration
 }
 
+// Measurements holds the durations measured for the current duty of a runner.
+type Measurements struct {
+	PreConsensus  time.Duration
+	Consensus     time.Duration
+	PostConsensus time.Duration
+	Duty          time.Duration
+}
+
+// MeasurementsProvider is implemented by runners which measure the flow of their duties.
+type MeasurementsProvider interface {
+	Measurements() Measurements
+}
+
 func NewMeasurementsStore() measurementsStore {
 	return measurementsStore{}
 }
@@ -33,6 +46,15 @@ func (cm *measurementsStore) DutyDurationTime() time.Duration {
 	return cm.dutyDuration
 }
 
+func (cm *measurementsStore) snapshot() Measurements {
+	return Measurements{
+		PreConsensus:  cm.preConsensusDuration,
+		Consensus:     cm.consensusDuration,
+		PostConsensus: cm.postConsensusDuration,
+		Duty:          cm.dutyDuration,
+	}
+}
+
 func (cm *measurementsStore) TotalConsensusTime() time.Duration {
 	return cm.preConsensusDuration + cm.consensusDuration + cm.postConsensusDuration
 }
@@ -40,6 +40,8 @@ type ProposerRunner struct {
 	valCheck            specqbft.ProposedValueCheckF
 	measurements        measurementsStore
 	graffiti            []byte
+	// proposerSettings returns the per-validator overrides of graffiti and builder policy, it may be nil.
+	proposerSettings ProposerSettingsFunc
 
 	// proposerDelay allows Operator to configure a delay to wait out before requesting Ethereum
 	// block to propose if this Operator is proposer-duty Leader. This allows Operator to extract
@@ -61,6 +63,7 @@ func NewProposerRunner(
 	valCheck specqbft.ProposedValueCheckF,
 	highestDecidedSlot phase0.Slot,
 	graffiti []byte,
+	proposerSettings ProposerSettingsFunc,
 	proposerDelay time.Duration,
 ) (Runner, error) {
 	if len(share) != 1 {
@@ -85,6 +88,7 @@ func NewProposerRunner(
 		valCheck:            valCheck,
 		measurements:        NewMeasurementsStore(),
 		graffiti:            graffiti,
+		proposerSettings:    proposerSettings,
 
 		proposerDelay: proposerDelay,
 	}, nil
@@ -148,7 +152,7 @@ func (r *ProposerRunner) ProcessPreConsensus(ctx context.Context, logger *zap.Lo
 	// we are always fetching Ethereum block here just in case we need to propose it).
 	start := time.Now()
 	duty = r.GetState().StartingDuty.(*spectypes.ValidatorDuty)
-	obj, ver, err := r.GetBeaconNode().GetBeaconBlock(duty.Slot, r.graffiti, fullSig)
+	obj, ver, err := r.getBeaconBlock(duty.Slot, fullSig)
 	if err != nil {
 		logger.Error("❌ failed to get beacon block",
 			fields.PreConsensusTime(r.measurements.PreConsensusTime()),
@@ -185,6 +189,23 @@ func (r *ProposerRunner) ProcessPreConsensus(ctx context.Context, logger *zap.Lo
 	return nil
 }
 
+// getBeaconBlock fetches the block to propose, applying the proposer settings of the validator.
+func (r *ProposerRunner) getBeaconBlock(slot phase0.Slot, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	graffiti := r.graffiti
+	if r.proposerSettings == nil {
+		return r.GetBeaconNode().GetBeaconBlock(slot, graffiti, randao)
+	}
+
+	settings := r.proposerSettings()
+	if len(settings.Graffiti) != 0 {
+		graffiti = settings.Graffiti
+	}
+	if settings.BuilderBoostFactor != nil {
+		return r.GetBeaconNode().GetBeaconBlockWithBuilderBoostFactor(slot, graffiti, randao, *settings.BuilderBoostFactor)
+	}
+	return r.GetBeaconNode().GetBeaconBlock(slot, graffiti, randao)
+}
+
 func (r *ProposerRunner) ProcessConsensus(ctx context.Context, logger *zap.Logger, signedMsg *spectypes.SignedSSVMessage) error {
 	decided, decidedValue, err := r.BaseRunner.baseConsensusMsgProcessing(ctx, logger, r, signedMsg, &spectypes.ValidatorConsensusData{})
 	if err != nil {
@@ -488,6 +509,11 @@ func (r *ProposerRunner) GetBaseRunner() *BaseRunner {
 	return r.BaseRunner
 }
 
+// Measurements implements MeasurementsProvider
+func (r *ProposerRunner) Measurements() Measurements {
+	return r.measurements.snapshot()
+}
+
 func (r *ProposerRunner) GetNetwork() specqbft.Network {
 	return r.network
 }
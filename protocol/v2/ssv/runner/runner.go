@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
@@ -56,6 +57,23 @@ type DoppelgangerProvider interface {
 	ReportQuorum(validatorIndex phase0.ValidatorIndex)
 }
 
+// ProposerSettings are per-validator overrides of the node-wide proposer settings. Zero values are unset.
+type ProposerSettings struct {
+	Graffiti []byte
+	GasLimit uint64
+	// BuilderBoostFactor is sent with block production requests if set, otherwise the beacon node uses its default.
+	BuilderBoostFactor *uint64
+}
+
+// ProposerSettingsProvider returns the current proposer settings of validators.
+type ProposerSettingsProvider interface {
+	ProposerSettings(pubKey spectypes.ValidatorPK, owner common.Address) ProposerSettings
+}
+
+// ProposerSettingsFunc returns the current proposer settings of the runner's validator,
+// which override the node-wide ones where set.
+type ProposerSettingsFunc func() ProposerSettings
+
 var _ Runner = new(CommitteeRunner)
 
 type BaseRunner struct {
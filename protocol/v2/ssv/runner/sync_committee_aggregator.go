@@ -461,6 +461,11 @@ func (r *SyncCommitteeAggregatorRunner) GetBaseRunner() *BaseRunner {
 	return r.BaseRunner
 }
 
+// Measurements implements MeasurementsProvider
+func (r *SyncCommitteeAggregatorRunner) Measurements() Measurements {
+	return r.measurements.snapshot()
+}
+
 func (r *SyncCommitteeAggregatorRunner) GetNetwork() specqbft.Network {
 	return r.network
 }
@@ -42,6 +42,8 @@ type ValidatorRegistrationRunner struct {
 	// has access to SSV-config from where it can just reference GasLimit36Epoch - so, eventually, we'll want to get
 	// rid of this field here and replace it with SSV-config usage instead.
 	gasLimit36Epoch phase0.Epoch
+	// proposerSettings returns the per-validator override of gasLimit, it may be nil.
+	proposerSettings ProposerSettingsFunc
 }
 
 func NewValidatorRegistrationRunner(
@@ -54,6 +56,7 @@ func NewValidatorRegistrationRunner(
 	operatorSigner ssvtypes.OperatorSigner,
 	gasLimit uint64,
 	gasLimit36Epoch phase0.Epoch,
+	proposerSettings ProposerSettingsFunc,
 ) (Runner, error) {
 	if len(share) != 1 {
 		return nil, errors.New("must have one share")
@@ -67,12 +70,13 @@ func NewValidatorRegistrationRunner(
 			Share:          share,
 		},
 
-		beacon:          beacon,
-		network:         network,
-		signer:          signer,
-		operatorSigner:  operatorSigner,
-		gasLimit:        gasLimit,
-		gasLimit36Epoch: gasLimit36Epoch,
+		beacon:           beacon,
+		network:          network,
+		signer:           signer,
+		operatorSigner:   operatorSigner,
+		gasLimit:         gasLimit,
+		gasLimit36Epoch:  gasLimit36Epoch,
+		proposerSettings: proposerSettings,
 	}, nil
 }
 
@@ -240,6 +244,11 @@ func (r *ValidatorRegistrationRunner) calculateValidatorRegistration(slot phase0
 	// Set the default GasLimit value if it hasn't been specified already, use 36 or 30 depending
 	// on the current epoch as compared to when this transition is supposed to happen.
 	gasLimit := r.gasLimit
+	if r.proposerSettings != nil {
+		if settings := r.proposerSettings(); settings.GasLimit != 0 {
+			gasLimit = settings.GasLimit
+		}
+	}
 	if gasLimit == 0 {
 		defaultGasLimit := DefaultGasLimit
 		if r.BaseRunner.BeaconNetwork.EstimatedCurrentEpoch() < r.gasLimit36Epoch {
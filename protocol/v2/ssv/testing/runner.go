@@ -161,6 +161,7 @@ var ConstructBaseRunner = func(
 			valCheck,
 			TestingHighestDecidedSlot,
 			[]byte("graffiti"),
+			nil,
 			0,
 		)
 	case spectypes.RoleSyncCommitteeContribution:
@@ -187,6 +188,7 @@ var ConstructBaseRunner = func(
 			opSigner,
 			runner.DefaultGasLimitOld,
 			0,
+			nil,
 		)
 	case spectypes.RoleVoluntaryExit:
 		r, err = runner.NewVoluntaryExitRunner(
@@ -419,6 +421,7 @@ var ConstructBaseRunnerWithShareMap = func(
 			valCheck,
 			TestingHighestDecidedSlot,
 			[]byte("graffiti"),
+			nil,
 			0,
 		)
 	case spectypes.RoleSyncCommitteeContribution:
@@ -445,6 +448,7 @@ var ConstructBaseRunnerWithShareMap = func(
 			opSigner,
 			runner.DefaultGasLimitOld,
 			0,
+			nil,
 		)
 	case spectypes.RoleVoluntaryExit:
 		r, err = runner.NewVoluntaryExitRunner(
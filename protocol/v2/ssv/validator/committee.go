@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/operator/dutyhistory"
 	"github.com/ssvlabs/ssv/protocol/v2/message"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
@@ -44,6 +45,8 @@ type Committee struct {
 
 	dutyGuard      *CommitteeDutyGuard
 	CreateRunnerFn CommitteeRunnerFunc
+
+	DutyHistory *dutyhistory.Recorder
 }
 
 // NewCommittee creates a new cluster
@@ -93,14 +96,17 @@ func (c *Committee) RemoveShare(validatorIndex phase0.ValidatorIndex) {
 func (c *Committee) StartDuty(ctx context.Context, logger *zap.Logger, duty *spectypes.CommitteeDuty) error {
 	r, runnableDuty, err := c.prepareDutyAndRunner(logger, duty)
 	if err != nil {
+		c.recordDutyFailure(duty, dutyhistory.ErrorClassStart, err)
 		return err
 	}
 
 	logger.Info("ℹ️ starting duty processing")
 	err = r.StartNewDuty(ctx, logger, runnableDuty, c.CommitteeMember.GetQuorum())
 	if err != nil {
+		c.recordDutyFailure(runnableDuty, dutyhistory.ErrorClassStart, err)
 		return errors.Wrap(err, "runner failed to start duty")
 	}
+	c.recordDuty(r, dutyhistory.ErrorClassStart, nil)
 	return nil
 }
 
@@ -225,7 +231,9 @@ func (c *Committee) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 		if !exists {
 			return fmt.Errorf("no runner found for message's slot")
 		}
-		return r.ProcessConsensus(ctx, logger, msg.SignedSSVMessage)
+		err := r.ProcessConsensus(ctx, logger, msg.SignedSSVMessage)
+		c.recordDuty(r, dutyhistory.ErrorClassConsensus, err)
+		return err
 	case spectypes.SSVPartialSignatureMsgType:
 		pSigMessages := &spectypes.PartialSignatureMessages{}
 		if err := pSigMessages.Decode(msg.SignedSSVMessage.SSVMessage.GetData()); err != nil {
@@ -248,7 +256,9 @@ func (c *Committee) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 			if !exists {
 				return fmt.Errorf("no runner found for message's slot")
 			}
-			return r.ProcessPostConsensus(ctx, logger, pSigMessages)
+			err := r.ProcessPostConsensus(ctx, logger, pSigMessages)
+			c.recordDuty(r, dutyhistory.ErrorClassPostConsensus, err)
+			return err
 		}
 	case message.SSVEventMsgType:
 		return c.handleEventMessage(ctx, logger, msg)
@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/operator/dutyhistory"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
+)
+
+// runnerProgress derives how far the runner got with its current duty from the runner state.
+func runnerProgress(r runner.Runner) dutyhistory.Entry {
+	progress := dutyhistory.Entry{Stage: dutyhistory.StageStarted}
+
+	state := r.GetBaseRunner().State
+	if instance := state.RunningInstance; instance != nil {
+		// Runners with a pre-consensus phase only start consensus once they have a pre-consensus quorum.
+		if r.GetBaseRunner().RunnerRoleType != spectypes.RoleCommittee {
+			progress.Stage = dutyhistory.StagePreConsensusQuorum
+		}
+		progress.Rounds = uint64(instance.State.Round)
+		if decided, _ := instance.IsDecided(); decided {
+			progress.Stage = dutyhistory.StageDecided
+		}
+	}
+	if state.Finished {
+		progress.Stage = dutyhistory.StageSubmitted
+	}
+
+	if mp, ok := r.(runner.MeasurementsProvider); ok {
+		m := mp.Measurements()
+		progress.PreConsensusTime = m.PreConsensus
+		progress.ConsensusTime = m.Consensus
+		progress.PostConsensusTime = m.PostConsensus
+		progress.DutyTime = m.Duty
+	}
+
+	return progress
+}
+
+func withError(progress dutyhistory.Entry, errClass dutyhistory.ErrorClass, err error) dutyhistory.Entry {
+	if err != nil {
+		progress.ErrorClass = errClass
+		progress.Error = err.Error()
+	}
+	return progress
+}
+
+// recordDuty records the progress of the runner's current duty, if it's the duty of the given slot.
+func (v *Validator) recordDuty(r runner.Runner, slot phase0.Slot, errClass dutyhistory.ErrorClass, err error) {
+	if v.DutyHistory == nil {
+		return
+	}
+
+	state := r.GetBaseRunner().State
+	if state == nil {
+		return
+	}
+	duty, ok := state.StartingDuty.(*spectypes.ValidatorDuty)
+	if !ok || duty.Slot != slot {
+		return
+	}
+
+	progress := withError(runnerProgress(r), errClass, err)
+	progress.Role = duty.Type
+	progress.Slot = duty.Slot
+	v.DutyHistory.Record(v.Share.ValidatorPubKey, progress)
+}
+
+// recordDutyFailure records a duty which failed before its runner took it over.
+func (v *Validator) recordDutyFailure(duty *spectypes.ValidatorDuty, errClass dutyhistory.ErrorClass, err error) {
+	if v.DutyHistory == nil {
+		return
+	}
+
+	progress := withError(dutyhistory.Entry{Role: duty.Type, Slot: duty.Slot}, errClass, err)
+	v.DutyHistory.Record(v.Share.ValidatorPubKey, progress)
+}
+
+// recordDuty records the progress of the runner's current duty for each validator taking part in it.
+func (c *Committee) recordDuty(r *runner.CommitteeRunner, errClass dutyhistory.ErrorClass, err error) {
+	if c.DutyHistory == nil {
+		return
+	}
+
+	state := r.GetBaseRunner().State
+	if state == nil {
+		return
+	}
+	duty, ok := state.StartingDuty.(*spectypes.CommitteeDuty)
+	if !ok {
+		return
+	}
+
+	progress := withError(runnerProgress(r), errClass, err)
+	for _, validatorDuty := range duty.ValidatorDuties {
+		progress.Role = validatorDuty.Type
+		progress.Slot = validatorDuty.Slot
+		c.DutyHistory.Record(spectypes.ValidatorPK(validatorDuty.PubKey), progress)
+	}
+}
+
+// recordDutyFailure records a committee duty which failed before its runner took it over.
+func (c *Committee) recordDutyFailure(duty *spectypes.CommitteeDuty, errClass dutyhistory.ErrorClass, err error) {
+	if c.DutyHistory == nil {
+		return
+	}
+
+	for _, validatorDuty := range duty.ValidatorDuties {
+		progress := withError(dutyhistory.Entry{Role: validatorDuty.Type, Slot: validatorDuty.Slot}, errClass, err)
+		c.DutyHistory.Record(spectypes.ValidatorPK(validatorDuty.PubKey), progress)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/operator/dutyhistory"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
@@ -19,7 +21,11 @@ func (v *Validator) handleEventMessage(ctx context.Context, logger *zap.Logger,
 	}
 	switch eventMsg.Type {
 	case types.Timeout:
-		if err := dutyRunner.GetBaseRunner().QBFTController.OnTimeout(ctx, logger, *eventMsg); err != nil {
+		err := dutyRunner.GetBaseRunner().QBFTController.OnTimeout(ctx, logger, *eventMsg)
+		if timeoutData, dataErr := eventMsg.GetTimeoutData(); dataErr == nil {
+			v.recordDuty(dutyRunner, phase0.Slot(timeoutData.Height), dutyhistory.ErrorClassTimeout, err)
+		}
+		if err != nil {
 			return fmt.Errorf("timeout event: %w", err)
 		}
 		return nil
@@ -53,7 +59,9 @@ func (c *Committee) handleEventMessage(ctx context.Context, logger *zap.Logger,
 			return nil
 		}
 
-		if err := dutyRunner.GetBaseRunner().QBFTController.OnTimeout(ctx, logger, *eventMsg); err != nil {
+		err = dutyRunner.GetBaseRunner().QBFTController.OnTimeout(ctx, logger, *eventMsg)
+		c.recordDuty(dutyRunner, dutyhistory.ErrorClassTimeout, err)
+		if err != nil {
 			return fmt.Errorf("timeout event: %w", err)
 		}
 		return nil
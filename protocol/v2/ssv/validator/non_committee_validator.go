@@ -36,9 +36,10 @@ type CommitteeObserver struct {
 	networkConfig     networkconfig.NetworkConfig
 	ValidatorStore    registrystorage.ValidatorStore
 	newDecidedHandler qbftcontroller.NewDecidedHandler
-	attesterRoots     *ttlcache.Cache[phase0.Root, struct{}]
-	syncCommRoots     *ttlcache.Cache[phase0.Root, struct{}]
-	domainCache       *DomainCache
+	// attesterRoots and syncCommRoots map signing roots to the data they were computed from.
+	attesterRoots *ttlcache.Cache[phase0.Root, *phase0.AttestationData]
+	syncCommRoots *ttlcache.Cache[phase0.Root, phase0.Root]
+	domainCache   *DomainCache
 
 	// cache to identify and skip duplicate computations of attester/sync committee roots
 	beaconVoteRoots *ttlcache.Cache[BeaconVoteCacheKey, struct{}]
@@ -63,8 +64,8 @@ type CommitteeObserverOptions struct {
 	OperatorSigner    ssvtypes.OperatorSigner
 	NewDecidedHandler qbftcontroller.NewDecidedHandler
 	ValidatorStore    registrystorage.ValidatorStore
-	AttesterRoots     *ttlcache.Cache[phase0.Root, struct{}]
-	SyncCommRoots     *ttlcache.Cache[phase0.Root, struct{}]
+	AttesterRoots     *ttlcache.Cache[phase0.Root, *phase0.AttestationData]
+	SyncCommRoots     *ttlcache.Cache[phase0.Root, phase0.Root]
 	BeaconVoteRoots   *ttlcache.Cache[BeaconVoteCacheKey, struct{}]
 	DomainCache       *DomainCache
 }
@@ -164,6 +165,19 @@ func (ncv *CommitteeObserver) ProcessMessage(msg *queue.SSVMessage) error {
 				continue
 			}
 
+			proof, err := ncv.participantsProof(slot, beaconRole, key, quorum)
+			if err != nil {
+				// Participants are still saved, they just can't be served to syncing peers.
+				logger.Debug("❗ could not build participants proof",
+					zap.String("role", beaconRole.String()),
+					zap.Uint64("validator_index", uint64(key.ValidatorIndex)),
+					fields.BlockRoot(key.Root),
+					zap.Error(err),
+				)
+			} else if err := roleStorage.SaveProof(validator.ValidatorPubKey, slot, proof); err != nil {
+				return fmt.Errorf("save participants proof: %w", err)
+			}
+
 			logger.Info("✅ saved participants",
 				zap.String("role", beaconRole.String()),
 				zap.Uint64("validator_index", uint64(key.ValidatorIndex)),
@@ -190,6 +204,70 @@ func (ncv *CommitteeObserver) ProcessMessage(msg *queue.SSVMessage) error {
 	return nil
 }
 
+// participantsProof aggregates the post-consensus partial signatures of the quorum along with the data they signed,
+// so that syncing peers can verify the participants.
+func (ncv *CommitteeObserver) participantsProof(
+	slot phase0.Slot,
+	role spectypes.BeaconRole,
+	key validatorIndexAndRoot,
+	quorum []spectypes.OperatorID,
+) (*qbftstorage.ParticipantsProof, error) {
+	data, err := ncv.signedData(role, key.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	container, ok := ncv.postConsensusContainer[slot][key.ValidatorIndex]
+	if !ok {
+		return nil, fmt.Errorf("no signatures container")
+	}
+
+	signatures := container.GetSignatures(key.ValidatorIndex, key.Root)
+
+	sigs := make([]bls.Sign, len(quorum))
+	for i, signer := range quorum {
+		signature, ok := signatures[signer]
+		if !ok {
+			return nil, fmt.Errorf("missing signature of operator %d", signer)
+		}
+		if err := sigs[i].Deserialize(signature); err != nil {
+			return nil, fmt.Errorf("deserialize signature of operator %d: %w", signer, err)
+		}
+	}
+
+	var aggregated bls.Sign
+	aggregated.Aggregate(sigs)
+
+	return &qbftstorage.ParticipantsProof{
+		Signers:   quorum,
+		Root:      key.Root,
+		Signature: aggregated.Serialize(),
+		Data:      data,
+	}, nil
+}
+
+// signedData returns the SSZ encoding of the beacon object of the role whose signing root is root.
+// Only the data of committee roles is known to the observer.
+func (ncv *CommitteeObserver) signedData(role spectypes.BeaconRole, root phase0.Root) ([]byte, error) {
+	switch role {
+	case spectypes.BNRoleAttester:
+		item := ncv.attesterRoots.Get(root)
+		if item == nil {
+			return nil, fmt.Errorf("unknown attestation data")
+		}
+		return item.Value().MarshalSSZ()
+	case spectypes.BNRoleSyncCommittee:
+		item := ncv.syncCommRoots.Get(root)
+		if item == nil {
+			return nil, fmt.Errorf("unknown sync committee block root")
+		}
+		blockRoot := item.Value()
+		return blockRoot[:], nil
+	default:
+		return nil, fmt.Errorf("signed data of role %s isn't known", role)
+	}
+}
+
 func (ncv *CommitteeObserver) getBeaconRoles(msg *queue.SSVMessage, root phase0.Root) []spectypes.BeaconRole {
 	switch msg.MsgID.GetRoleType() {
 	case spectypes.RoleCommittee:
@@ -377,7 +455,7 @@ func (ncv *CommitteeObserver) saveAttesterRoots(epoch phase0.Epoch, beaconVote *
 			return err
 		}
 
-		ncv.attesterRoots.Set(attesterRoot, struct{}{}, ttlcache.DefaultTTL)
+		ncv.attesterRoots.Set(attesterRoot, attestationData, ttlcache.DefaultTTL)
 	}
 
 	return nil
@@ -395,7 +473,7 @@ func (ncv *CommitteeObserver) saveSyncCommRoots(epoch phase0.Epoch, beaconVote *
 		return err
 	}
 
-	ncv.syncCommRoots.Set(syncCommitteeRoot, struct{}{}, ttlcache.DefaultTTL)
+	ncv.syncCommRoots.Set(syncCommitteeRoot, beaconVote.BlockRoot, ttlcache.DefaultTTL)
 
 	return nil
 }
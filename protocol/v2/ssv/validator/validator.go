@@ -16,6 +16,7 @@ import (
 	"github.com/ssvlabs/ssv/logging/fields"
 	"github.com/ssvlabs/ssv/message/validation"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/dutyhistory"
 	"github.com/ssvlabs/ssv/protocol/v2/message"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
@@ -48,6 +49,8 @@ type Validator struct {
 	state uint32
 
 	messageValidator validation.MessageValidator
+
+	DutyHistory *dutyhistory.Recorder
 }
 
 // NewValidator creates a new instance of Validator.
@@ -67,6 +70,7 @@ func NewValidator(pctx context.Context, cancel func(), options *Options) *Valida
 		state:            uint32(NotStarted),
 		dutyIDs:          hashmap.New[spectypes.RunnerRole, string](), // TODO: use beaconrole here?
 		messageValidator: options.MessageValidator,
+		DutyHistory:      options.DutyHistory,
 	}
 
 	for _, dutyRunner := range options.DutyRunners {
@@ -114,7 +118,12 @@ func (v *Validator) StartDuty(ctx context.Context, logger *zap.Logger, duty spec
 
 	logger.Info("ℹ️ starting duty processing")
 
-	return dutyRunner.StartNewDuty(ctx, logger, vDuty, v.Operator.GetQuorum())
+	if err := dutyRunner.StartNewDuty(ctx, logger, vDuty, v.Operator.GetQuorum()); err != nil {
+		v.recordDutyFailure(vDuty, dutyhistory.ErrorClassStart, err)
+		return err
+	}
+	v.recordDuty(dutyRunner, vDuty.Slot, dutyhistory.ErrorClassStart, nil)
+	return nil
 }
 
 // ProcessMessage processes Network Message of all types
@@ -158,7 +167,9 @@ func (v *Validator) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 			With(fields.Slot(phase0.Slot(qbftMsg.Height))).
 			With(fields.Height(qbftMsg.Height))
 
-		return dutyRunner.ProcessConsensus(ctx, logger, msg.SignedSSVMessage)
+		err := dutyRunner.ProcessConsensus(ctx, logger, msg.SignedSSVMessage)
+		v.recordDuty(dutyRunner, phase0.Slot(qbftMsg.Height), dutyhistory.ErrorClassConsensus, err)
+		return err
 	case spectypes.SSVPartialSignatureMsgType:
 		signedMsg, ok := msg.Body.(*spectypes.PartialSignatureMessages)
 		if !ok {
@@ -177,10 +188,14 @@ func (v *Validator) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 		}
 
 		if signedMsg.Type == spectypes.PostConsensusPartialSig {
-			return dutyRunner.ProcessPostConsensus(ctx, logger, signedMsg)
+			err := dutyRunner.ProcessPostConsensus(ctx, logger, signedMsg)
+			v.recordDuty(dutyRunner, signedMsg.Slot, dutyhistory.ErrorClassPostConsensus, err)
+			return err
 		}
 
-		return dutyRunner.ProcessPreConsensus(ctx, logger, signedMsg)
+		err := dutyRunner.ProcessPreConsensus(ctx, logger, signedMsg)
+		v.recordDuty(dutyRunner, signedMsg.Slot, dutyhistory.ErrorClassPreConsensus, err)
+		return err
 	case message.SSVEventMsgType:
 		return v.handleEventMessage(ctx, logger, msg, dutyRunner)
 	default:
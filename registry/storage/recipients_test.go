@@ -57,6 +57,36 @@ func TestStorage_DropRecipients(t *testing.T) {
 	require.False(t, found)
 }
 
+func TestStorage_ListRecipients(t *testing.T) {
+	logger := logging.TestLogger(t)
+	storageCollection, done := newRecipientStorageForTest(logger)
+	require.NotNil(t, storageCollection)
+	defer done()
+
+	recipients, err := storageCollection.ListRecipients(nil)
+	require.NoError(t, err)
+	require.Empty(t, recipients)
+
+	owners := []common.Address{
+		common.BytesToAddress([]byte("0x1")),
+		common.BytesToAddress([]byte("0x2")),
+	}
+	for _, owner := range owners {
+		rd := &storage.RecipientData{Owner: owner}
+		copy(rd.FeeRecipient[:], owner.Bytes())
+		_, err := storageCollection.SaveRecipientData(nil, rd)
+		require.NoError(t, err)
+	}
+
+	recipients, err = storageCollection.ListRecipients(nil)
+	require.NoError(t, err)
+	require.Len(t, recipients, len(owners))
+	for _, rd := range recipients {
+		require.Contains(t, owners, rd.Owner)
+		require.Equal(t, rd.Owner.Bytes(), rd.FeeRecipient[:])
+	}
+}
+
 func TestStorage_GetRecipientsPrefix(t *testing.T) {
 	logger := logging.TestLogger(t)
 	storageCollection, done := newRecipientStorageForTest(logger)
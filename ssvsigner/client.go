@@ -16,6 +16,7 @@ import (
 
 	"github.com/ssvlabs/ssv/logging/fields"
 
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
 	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
 )
 
@@ -25,6 +26,8 @@ type Client struct {
 	logger     *zap.Logger
 	baseURL    string
 	httpClient *http.Client
+	operator   string
+	token      string
 }
 
 // ClientOption is used to handle client options.
@@ -56,6 +59,21 @@ func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
 	}
 }
 
+// WithOperator selects the operator the client acts on behalf of, by its ID or base64 public key,
+// when the remote signer hosts several operators. Otherwise, the remote signer's primary operator is used.
+func WithOperator(operator string) ClientOption {
+	return func(client *Client) {
+		client.operator = operator
+	}
+}
+
+// WithOperatorToken sets the token the remote signer requires from requests on behalf of the client's operator.
+func WithOperatorToken(token string) ClientOption {
+	return func(client *Client) {
+		client.token = token
+	}
+}
+
 func NewClient(baseURL string, opts ...ClientOption) *Client {
 	baseURL = strings.TrimRight(baseURL, "/")
 
@@ -82,9 +100,7 @@ func (c *Client) ListValidators(ctx context.Context) (listResp []phase0.BLSPubKe
 		recordClientRequest(ctx, opListValidators, err, duration)
 		c.logger.Debug("requested to list keys in remote signer", zap.Duration("duration", duration), zap.Error(err))
 	}()
-	err = requests.
-		URL(c.baseURL).
-		Client(c.httpClient).
+	err = c.request().
 		Path(pathValidators).
 		ToJSON(&listResp).
 		Fetch(ctx)
@@ -92,7 +108,17 @@ func (c *Client) ListValidators(ctx context.Context) (listResp []phase0.BLSPubKe
 	return listResp, err
 }
 
-func (c *Client) AddValidators(ctx context.Context, shares ...ShareKeys) (statuses []web3signer.Status, err error) {
+func (c *Client) AddValidators(ctx context.Context, shares ...ShareKeys) ([]web3signer.Status, error) {
+	return c.AddValidatorsWithSlashingProtection(ctx, "", shares...)
+}
+
+// AddValidatorsWithSlashingProtection adds shares to the remote signer along with their signing history
+// in the EIP-3076 interchange format, so the remote slashing protection database is seeded with it.
+func (c *Client) AddValidatorsWithSlashingProtection(
+	ctx context.Context,
+	slashingProtection string,
+	shares ...ShareKeys,
+) (statuses []web3signer.Status, err error) {
 	start := time.Now()
 	defer func() {
 		duration := time.Since(start)
@@ -113,14 +139,13 @@ func (c *Client) AddValidators(ctx context.Context, shares ...ShareKeys) (status
 	}
 
 	req := AddValidatorRequest{
-		ShareKeys: encodedShares,
+		ShareKeys:          encodedShares,
+		SlashingProtection: slashingProtection,
 	}
 
 	var resp web3signer.ImportKeystoreResponse
 	var errStr string
-	err = requests.
-		URL(c.baseURL).
-		Client(c.httpClient).
+	err = c.request().
 		Path(pathValidators).
 		BodyJSON(req).
 		Post().
@@ -148,7 +173,17 @@ func (c *Client) AddValidators(ctx context.Context, shares ...ShareKeys) (status
 	return statuses, nil
 }
 
-func (c *Client) RemoveValidators(ctx context.Context, pubKeys ...phase0.BLSPubKey) (statuses []web3signer.Status, err error) {
+func (c *Client) RemoveValidators(ctx context.Context, pubKeys ...phase0.BLSPubKey) ([]web3signer.Status, error) {
+	statuses, _, err := c.RemoveValidatorsWithSlashingProtection(ctx, pubKeys...)
+	return statuses, err
+}
+
+// RemoveValidatorsWithSlashingProtection removes shares from the remote signer and returns their signing history
+// exported by the remote signer in the EIP-3076 interchange format.
+func (c *Client) RemoveValidatorsWithSlashingProtection(
+	ctx context.Context,
+	pubKeys ...phase0.BLSPubKey,
+) (statuses []web3signer.Status, slashingProtection string, err error) {
 	start := time.Now()
 	defer func() {
 		duration := time.Since(start)
@@ -160,20 +195,18 @@ func (c *Client) RemoveValidators(ctx context.Context, pubKeys ...phase0.BLSPubK
 	}
 
 	var resp web3signer.DeleteKeystoreResponse
-	err = requests.
-		URL(c.baseURL).
-		Client(c.httpClient).
+	err = c.request().
 		Path(pathValidators).
 		BodyJSON(req).
 		Delete().
 		ToJSON(&resp).
 		Fetch(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, "", fmt.Errorf("request failed: %w", err)
 	}
 
 	if len(resp.Data) != len(pubKeys) {
-		return nil, fmt.Errorf("unexpected statuses length, got %d, expected %d", len(resp.Data), len(pubKeys))
+		return nil, "", fmt.Errorf("unexpected statuses length, got %d, expected %d", len(resp.Data), len(pubKeys))
 	}
 
 	statuses = make([]web3signer.Status, 0, len(resp.Data))
@@ -181,7 +214,77 @@ func (c *Client) RemoveValidators(ctx context.Context, pubKeys ...phase0.BLSPubK
 		statuses = append(statuses, data.Status)
 	}
 
-	return statuses, nil
+	return statuses, resp.SlashingProtection, nil
+}
+
+// ExportSlashingProtection returns the signing history the remote signer keeps for the given shares
+// (or for all its shares, if none are given) in the EIP-3076 interchange format.
+func (c *Client) ExportSlashingProtection(
+	ctx context.Context,
+	genesisValidatorsRoot phase0.Root,
+	pubKeys ...phase0.BLSPubKey,
+) (doc *interchange.Interchange, err error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		recordClientRequest(ctx, opExportSlashingProtection, err, duration)
+		c.logger.Debug("requested to export slashing protection data from remote signer", fields.Count(len(pubKeys)), zap.Duration("duration", duration), zap.Error(err))
+	}()
+
+	pubKeyStrings := make([]string, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		pubKeyStrings = append(pubKeyStrings, pubKey.String())
+	}
+
+	builder := requests.
+		URL(c.baseURL).
+		Client(c.httpClient).
+		Path(pathSlashingProtectionInterchange).
+		Param("genesis_validators_root", genesisValidatorsRoot.String())
+	if len(pubKeyStrings) != 0 {
+		builder = builder.Param("pubkeys", strings.Join(pubKeyStrings, ","))
+	}
+
+	var respBuf bytes.Buffer
+	if err = builder.ToBytesBuffer(&respBuf).Fetch(ctx); err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	doc, err = interchange.Parse(respBuf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+
+	return doc, nil
+}
+
+// ImportSlashingProtection merges the EIP-3076 interchange document into the signing history the remote signer keeps.
+func (c *Client) ImportSlashingProtection(ctx context.Context, doc *interchange.Interchange) (err error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		recordClientRequest(ctx, opImportSlashingProtection, err, duration)
+		c.logger.Debug("requested to import slashing protection data to remote signer", fields.Count(len(doc.Data)), zap.Duration("duration", duration), zap.Error(err))
+	}()
+
+	body, err := doc.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshal interchange: %w", err)
+	}
+
+	err = requests.
+		URL(c.baseURL).
+		Client(c.httpClient).
+		Path(pathSlashingProtectionInterchange).
+		BodyBytes(body).
+		ContentType("application/json").
+		Post().
+		Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+
+	return nil
 }
 
 func (c *Client) Sign(ctx context.Context, sharePubKey phase0.BLSPubKey, payload web3signer.SignRequest) (signature phase0.BLSSignature, err error) {
@@ -192,9 +295,7 @@ func (c *Client) Sign(ctx context.Context, sharePubKey phase0.BLSPubKey, payload
 		recordClientRequest(ctx, opSignValidator, err, duration)
 		c.logger.Debug("requested to sign with share key", fields.PubKey(sharePubKey[:]), zap.Duration("duration", duration), zap.Error(err))
 	}()
-	err = requests.
-		URL(c.baseURL).
-		Client(c.httpClient).
+	err = c.request().
 		Path(pathValidatorsSign + sharePubKey.String()).
 		BodyJSON(payload).
 		Post().
@@ -215,9 +316,7 @@ func (c *Client) OperatorIdentity(ctx context.Context) (pubKeyBase64 string, err
 		recordClientRequest(ctx, opOperatorIdentity, err, duration)
 		c.logger.Debug("requested operator identity", zap.Duration("duration", duration), zap.Error(err))
 	}()
-	err = requests.
-		URL(c.baseURL).
-		Client(c.httpClient).
+	err = c.request().
 		Path(pathOperatorIdentity).
 		ToString(&resp).
 		Fetch(ctx)
@@ -236,9 +335,7 @@ func (c *Client) OperatorSign(ctx context.Context, payload []byte) (signature []
 		recordClientRequest(ctx, opSignOperator, err, duration)
 		c.logger.Debug("requested to sign with operator key", zap.Duration("duration", duration), zap.Error(err))
 	}()
-	err = requests.
-		URL(c.baseURL).
-		Client(c.httpClient).
+	err = c.request().
 		Path(pathOperatorSign).
 		BodyBytes(payload).
 		Post().
@@ -251,6 +348,52 @@ func (c *Client) OperatorSign(ctx context.Context, payload []byte) (signature []
 	return respBuf.Bytes(), nil
 }
 
+// GetSlashingProtection returns the highest attestation and proposal recorded for the share
+// by the remote signer's own slashing protection database.
+func (c *Client) GetSlashingProtection(ctx context.Context, sharePubKey phase0.BLSPubKey) (data SlashingProtectionData, err error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		recordClientRequest(ctx, opGetSlashingProtection, err, duration)
+		c.logger.Debug("requested slashing protection data", fields.PubKey(sharePubKey[:]), zap.Duration("duration", duration), zap.Error(err))
+	}()
+	err = c.request().
+		Path(pathSlashingProtection + sharePubKey.String()).
+		ToJSON(&data).
+		Fetch(ctx)
+	if err != nil {
+		return SlashingProtectionData{}, fmt.Errorf("request failed: %w", err)
+	}
+
+	return data, nil
+}
+
+// SetSlashingProtection raises the highest attestation and/or proposal recorded for the share
+// by the remote signer and returns the resulting data. Values lower than the recorded ones are ignored.
+func (c *Client) SetSlashingProtection(
+	ctx context.Context,
+	sharePubKey phase0.BLSPubKey,
+	data SlashingProtectionData,
+) (resp SlashingProtectionData, err error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		recordClientRequest(ctx, opSetSlashingProtection, err, duration)
+		c.logger.Debug("requested to set slashing protection data", fields.PubKey(sharePubKey[:]), zap.Duration("duration", duration), zap.Error(err))
+	}()
+	err = c.request().
+		Path(pathSlashingProtection + sharePubKey.String()).
+		BodyJSON(data).
+		Post().
+		ToJSON(&resp).
+		Fetch(ctx)
+	if err != nil {
+		return SlashingProtectionData{}, fmt.Errorf("request failed: %w", err)
+	}
+
+	return resp, nil
+}
+
 func (c *Client) MissingKeys(ctx context.Context, localKeys []phase0.BLSPubKey) ([]phase0.BLSPubKey, error) {
 	remoteKeys, err := c.ListValidators(ctx)
 	if err != nil {
@@ -272,6 +415,20 @@ func (c *Client) MissingKeys(ctx context.Context, localKeys []phase0.BLSPubKey)
 	return missing, nil
 }
 
+// request starts building a request to the remote signer on behalf of the client's operator.
+func (c *Client) request() *requests.Builder {
+	rb := requests.
+		URL(c.baseURL).
+		Client(c.httpClient)
+	if c.operator != "" {
+		rb = rb.Header(HeaderOperator, c.operator)
+	}
+	if c.token != "" {
+		rb = rb.Header(HeaderOperatorToken, c.token)
+	}
+	return rb
+}
+
 // applyTLSConfig applies the given TLS configuration to the HTTP client.
 // This method ensures that the HTTP client's transport is properly configured for TLS communication.
 func (c *Client) applyTLSConfig(tlsConfig *tls.Config) {
@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
 	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
 )
 
@@ -600,6 +601,72 @@ func (s *SSVSignerClientSuite) TestOperatorSign() {
 // TestMissingKeys tests the MissingKeys method which identifies keys present in local storage
 // but missing from the remote SSV signer service. It verifies proper key difference calculation
 // and error handling for various key combinations and server response scenarios.
+func (s *SSVSignerClientSuite) TestSlashingProtectionInterchange() {
+	t := s.T()
+
+	gvr := phase0.Root{1, 2, 3}
+	pubKey := phase0.BLSPubKey{4, 5, 6}
+	doc := interchange.New(gvr)
+	doc.Data = append(doc.Data, interchange.Validator{
+		PubKey:             pubKey,
+		SignedBlocks:       []interchange.SignedBlock{{Slot: 10}},
+		SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+	})
+
+	t.Run("export", func(t *testing.T) {
+		s.resetMux()
+		s.mux.HandleFunc(pathSlashingProtectionInterchange, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, gvr.String(), r.URL.Query().Get("genesis_validators_root"))
+			assert.Equal(t, pubKey.String(), r.URL.Query().Get("pubkeys"))
+			writeJSONResponse(w, http.StatusOK, doc)
+		})
+
+		exported, err := s.client.ExportSlashingProtection(context.Background(), gvr, pubKey)
+		s.assertErrorResult(err, false, false, t)
+		assert.Equal(t, doc, exported)
+	})
+
+	t.Run("export error", func(t *testing.T) {
+		s.resetMux()
+		s.mux.HandleFunc(pathSlashingProtectionInterchange, func(w http.ResponseWriter, r *http.Request) {
+			writeJSONResponse(w, http.StatusInternalServerError, nil)
+		})
+
+		_, err := s.client.ExportSlashingProtection(context.Background(), gvr)
+		s.assertErrorResult(err, true, false, t)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		s.resetMux()
+		s.mux.HandleFunc(pathSlashingProtectionInterchange, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			imported, err := interchange.Parse(body)
+			require.NoError(t, err)
+			assert.Equal(t, doc, imported)
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		err := s.client.ImportSlashingProtection(context.Background(), doc)
+		s.assertErrorResult(err, false, false, t)
+	})
+
+	t.Run("import error", func(t *testing.T) {
+		s.resetMux()
+		s.mux.HandleFunc(pathSlashingProtectionInterchange, func(w http.ResponseWriter, r *http.Request) {
+			writeJSONResponse(w, http.StatusBadRequest, nil)
+		})
+
+		err := s.client.ImportSlashingProtection(context.Background(), doc)
+		s.assertErrorResult(err, true, false, t)
+	})
+}
+
 func (s *SSVSignerClientSuite) TestMissingKeys() {
 	t := s.T()
 
@@ -948,3 +1015,24 @@ func TestNewClient_TrimsTrailingSlashFromURL(t *testing.T) {
 	require.NotNil(t, client)
 	assert.Equal(t, expectedURL, client.baseURL)
 }
+
+func TestClient_WithOperator(t *testing.T) {
+	t.Parallel()
+
+	var headers, tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get(HeaderOperator))
+		tokens = append(tokens, r.Header.Get(HeaderOperatorToken))
+		w.Write([]byte("operator_identity_key"))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL).OperatorIdentity(context.Background())
+	require.NoError(t, err)
+
+	_, err = NewClient(server.URL, WithOperator("42"), WithOperatorToken("secret")).OperatorIdentity(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", "42"}, headers)
+	assert.Equal(t, []string{"", "secret"}, tokens)
+}
@@ -3,25 +3,34 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"go.uber.org/zap"
 
+	"github.com/ssvlabs/ssv/networkconfig"
 	"github.com/ssvlabs/ssv/ssvsigner/cmd/internal/logger"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
 
 	"github.com/ssvlabs/ssv/ssvsigner"
 	"github.com/ssvlabs/ssv/ssvsigner/cmd/internal/validation"
+	"github.com/ssvlabs/ssv/ssvsigner/ekm"
 	"github.com/ssvlabs/ssv/ssvsigner/keys"
 	"github.com/ssvlabs/ssv/ssvsigner/keystore"
+	"github.com/ssvlabs/ssv/ssvsigner/localsigner"
 	"github.com/ssvlabs/ssv/ssvsigner/tls"
 	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
 )
 
+const backendLocal = "local"
+
 type CLI struct {
 	ListenAddr         string        `env:"LISTEN_ADDR" default:":8080" required:"" help:"The address and port to listen on (e.g. :8080)"` // TODO: finalize port
-	Web3SignerEndpoint string        `env:"WEB3SIGNER_ENDPOINT" required:"" help:"URL of the web3signer service" name:"web3signer-endpoint"`
+	Web3SignerEndpoint string        `env:"WEB3SIGNER_ENDPOINT" help:"URL of the web3signer service; required by the web3signer backend" name:"web3signer-endpoint"`
 	PrivateKey         string        `env:"PRIVATE_KEY" xor:"keys" required:"" help:"Base64‑encoded PEM blob (RSA PRIVATE KEY) for operator; exclusive with PRIVATE_KEY_FILE"`
 	PrivateKeyFile     string        `env:"PRIVATE_KEY_FILE" xor:"keys" and:"files" help:"Path to an encrypted keystore JSON file (v4 format) containing an RSA private key; exclusive with PRIVATE_KEY"`
 	PasswordFile       string        `env:"PASSWORD_FILE" and:"files" help:"Path to file containing the password used to decrypt the keystore JSON file"`
@@ -37,6 +46,21 @@ type CLI struct {
 	KeystorePasswordFile string `env:"KEYSTORE_PASSWORD_FILE" env-description:"Path to file containing the password for server keystore file"`
 	KnownClientsFile     string `env:"KNOWN_CLIENTS_FILE" env-description:"Path to known clients file for authenticating clients"`
 
+	// Operators hosted by the same ssv-signer, selected by clients with the X-SSV-Operator header
+	OperatorID          uint64   `env:"OPERATOR_ID" help:"ID of the operator, lets clients select it by ID besides its public key"`
+	OperatorTokenFile   string   `env:"OPERATOR_TOKEN_FILE" help:"Path to file containing the token clients must present to act on behalf of the operator; any client can if empty"`
+	AdditionalOperators []string `env:"ADDITIONAL_OPERATORS" sep:"," help:"Additional operators to host, each as <keystore_file>:<password_file>[:<operator_id>[:<token_file>]]; requires DB_PATH"`
+
+	// Backend storing the shares and signing with them
+	Backend                    string `env:"BACKEND" default:"web3signer" enum:"web3signer,local" help:"Where shares are stored and signed with: web3signer, or local encrypted keystores (requires DB_PATH)"`
+	LocalKeystoresDir          string `env:"LOCAL_KEYSTORES_DIR" help:"Directory of the share keystores of the local backend"`
+	LocalKeystoresPasswordFile string `env:"LOCAL_KEYSTORES_PASSWORD_FILE" help:"Path to file containing the password the local backend encrypts share keystores with"`
+
+	// Slashing protection kept by ssv-signer in addition to web3signer's one
+	DBPath                    string `env:"DB_PATH" help:"Path to the database of ssv-signer's own slashing protection and signing history; disabled if empty"`
+	Network                   string `env:"NETWORK" default:"mainnet" help:"Network the shares belong to, used to compute the minimal slashing protection of new shares"`
+	EnforceSlashingProtection bool   `env:"ENFORCE_SLASHING_PROTECTION" default:"false" help:"Reject slashable attestations and blocks before passing them to web3signer; requires DB_PATH"`
+
 	// Client TLS configuration (for connecting to Web3Signer)
 	Web3SignerKeystoreFile         string `env:"WEB3SIGNER_KEYSTORE_FILE" env-description:"Path to PKCS12 keystore file for TLS connection to Web3Signer"`
 	Web3SignerKeystorePasswordFile string `env:"WEB3SIGNER_KEYSTORE_PASSWORD_FILE" env-description:"Path to file containing the password for client keystore file"`
@@ -64,6 +88,7 @@ func main() {
 func run(logger *zap.Logger, cli CLI) error {
 	logger.Debug("starting ssv-signer",
 		zap.String("listen_addr", cli.ListenAddr),
+		zap.String("backend", cli.Backend),
 		zap.String("web3signer_endpoint", cli.Web3SignerEndpoint),
 		zap.Bool("got_private_key", cli.PrivateKey != ""),
 		zap.String("log_level", cli.LogLevel),
@@ -72,6 +97,12 @@ func run(logger *zap.Logger, cli CLI) error {
 		zap.Bool("server_tls_enabled", cli.KeystoreFile != ""),
 		zap.Bool("client_tls_enabled", cli.Web3SignerKeystoreFile != ""),
 		zap.Bool("allow_insecure_http", cli.AllowInsecureHTTP),
+		zap.Uint64("operator_id", cli.OperatorID),
+		zap.Bool("operator_token_enabled", cli.OperatorTokenFile != ""),
+		zap.Int("additional_operators", len(cli.AdditionalOperators)),
+		zap.String("db_path", cli.DBPath),
+		zap.String("network", cli.Network),
+		zap.Bool("enforce_slashing_protection", cli.EnforceSlashingProtection),
 	)
 
 	if cli.AllowInsecureHTTP {
@@ -92,6 +123,23 @@ func run(logger *zap.Logger, cli CLI) error {
 		return err
 	}
 
+	var operatorToken string
+	if cli.OperatorTokenFile != "" {
+		operatorToken, err = loadOperatorToken(cli.OperatorTokenFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	additionalOperators := make([]ssvsigner.OperatorKey, 0, len(cli.AdditionalOperators))
+	for _, entry := range cli.AdditionalOperators {
+		operator, err := loadAdditionalOperator(entry)
+		if err != nil {
+			return fmt.Errorf("invalid ADDITIONAL_OPERATORS entry %q: %w", entry, err)
+		}
+		additionalOperators = append(additionalOperators, operator)
+	}
+
 	tlsConfig := tls.Config{
 		ServerKeystoreFile:         cli.KeystoreFile,
 		ServerKeystorePasswordFile: cli.KeystorePasswordFile,
@@ -102,12 +150,55 @@ func run(logger *zap.Logger, cli CLI) error {
 		ClientServerCertFile:       cli.Web3SignerServerCertFile,
 	}
 
-	web3SignerClient, err := setupWeb3SignerClient(cli.Web3SignerEndpoint, cli.RequestTimeout, tlsConfig)
-	if err != nil {
-		return err
+	opts := []ssvsigner.Option{
+		ssvsigner.WithOperatorID(cli.OperatorID),
+		ssvsigner.WithServerOperatorToken(operatorToken),
+		ssvsigner.WithAdditionalOperators(additionalOperators...),
+	}
+
+	var slashingProtection *ekm.SignerSlashingProtection
+	if cli.DBPath != "" {
+		db, sp, err := setupSlashingProtection(logger, cli.DBPath, cli.Network)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := db.Close(); err != nil {
+				logger.Error("failed to close db", zap.Error(err))
+			}
+		}()
+
+		slashingProtection = sp
+		opts = append(opts,
+			ssvsigner.WithInterchangeStore(sp),
+			ssvsigner.WithShareOwners(ssvsigner.NewShareOwners(db)),
+		)
 	}
 
-	return startServer(logger, cli.ListenAddr, operatorPrivateKey, web3SignerClient, tlsConfig)
+	var remoteSigner web3signer.RemoteSigner
+	switch cli.Backend {
+	case backendLocal:
+		localSigner, err := setupLocalSigner(logger, cli.LocalKeystoresDir, cli.LocalKeystoresPasswordFile, cli.Network, slashingProtection)
+		if err != nil {
+			return err
+		}
+		remoteSigner = localSigner
+
+		// The local backend checks every request itself, so the server must not check them once more.
+		opts = append(opts, ssvsigner.WithSlashingProtection(slashingProtection, false))
+	default:
+		web3SignerClient, err := setupWeb3SignerClient(cli.Web3SignerEndpoint, cli.RequestTimeout, tlsConfig)
+		if err != nil {
+			return err
+		}
+		remoteSigner = web3SignerClient
+
+		if slashingProtection != nil {
+			opts = append(opts, ssvsigner.WithSlashingProtection(slashingProtection, cli.EnforceSlashingProtection))
+		}
+	}
+
+	return startServer(logger, cli.ListenAddr, operatorPrivateKey, remoteSigner, tlsConfig, opts...)
 }
 
 func validateConfig(cli CLI) error {
@@ -115,8 +206,30 @@ func validateConfig(cli CLI) error {
 		return fmt.Errorf("neither private key nor keystore provided")
 	}
 
-	if err := validation.ValidateWeb3SignerEndpoint(cli.Web3SignerEndpoint); err != nil {
-		return fmt.Errorf("invalid WEB3SIGNER_ENDPOINT: %w", err)
+	switch cli.Backend {
+	case backendLocal:
+		if cli.DBPath == "" {
+			return fmt.Errorf("local backend requires DB_PATH")
+		}
+		if cli.LocalKeystoresDir == "" {
+			return fmt.Errorf("local backend requires LOCAL_KEYSTORES_DIR")
+		}
+		if cli.LocalKeystoresPasswordFile == "" {
+			return fmt.Errorf("local backend requires LOCAL_KEYSTORES_PASSWORD_FILE")
+		}
+	default:
+		if err := validation.ValidateWeb3SignerEndpoint(cli.Web3SignerEndpoint); err != nil {
+			return fmt.Errorf("invalid WEB3SIGNER_ENDPOINT: %w", err)
+		}
+	}
+
+	if cli.EnforceSlashingProtection && cli.DBPath == "" {
+		return fmt.Errorf("ENFORCE_SLASHING_PROTECTION requires DB_PATH")
+	}
+
+	// Shares must be kept apart per operator, which requires persisting their owners.
+	if len(cli.AdditionalOperators) > 0 && cli.DBPath == "" {
+		return fmt.Errorf("ADDITIONAL_OPERATORS requires DB_PATH")
 	}
 
 	if cli.AllowInsecureHTTP {
@@ -150,14 +263,17 @@ func validateConfig(cli CLI) error {
 			return fmt.Errorf("known clients file is required for client authentication")
 		}
 
-		if cli.Web3SignerKeystoreFile == "" {
-			return fmt.Errorf("web3signer TLS keystore file is required")
-		}
-		if cli.Web3SignerKeystorePasswordFile == "" {
-			return fmt.Errorf("web3signer TLS keystore password file is required")
-		}
-		if cli.Web3SignerServerCertFile == "" {
-			return fmt.Errorf("web3signer server cert file is required")
+		// The local backend doesn't connect to web3signer.
+		if cli.Backend != backendLocal {
+			if cli.Web3SignerKeystoreFile == "" {
+				return fmt.Errorf("web3signer TLS keystore file is required")
+			}
+			if cli.Web3SignerKeystorePasswordFile == "" {
+				return fmt.Errorf("web3signer TLS keystore password file is required")
+			}
+			if cli.Web3SignerServerCertFile == "" {
+				return fmt.Errorf("web3signer server cert file is required")
+			}
 		}
 	}
 
@@ -180,6 +296,54 @@ func loadOperatorKey(privateKeyStr, privateKeyFile, passwordFile string) (keys.O
 	return pk, nil
 }
 
+// loadAdditionalOperator loads an operator given as <keystore_file>:<password_file>[:<operator_id>[:<token_file>]].
+// The operator ID may be left empty when only the token file is given.
+func loadAdditionalOperator(entry string) (ssvsigner.OperatorKey, error) {
+	parts := strings.Split(entry, ":")
+	if len(parts) < 2 || len(parts) > 4 {
+		return ssvsigner.OperatorKey{}, fmt.Errorf("expected <keystore_file>:<password_file>[:<operator_id>[:<token_file>]]")
+	}
+
+	var operator ssvsigner.OperatorKey
+	if len(parts) >= 3 && parts[2] != "" {
+		id, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil {
+			return ssvsigner.OperatorKey{}, fmt.Errorf("parse operator ID: %w", err)
+		}
+		operator.ID = id
+	}
+
+	if len(parts) == 4 {
+		token, err := loadOperatorToken(parts[3])
+		if err != nil {
+			return ssvsigner.OperatorKey{}, err
+		}
+		operator.Token = token
+	}
+
+	privKey, err := loadOperatorKey("", parts[0], parts[1])
+	if err != nil {
+		return ssvsigner.OperatorKey{}, err
+	}
+	operator.PrivKey = privKey
+
+	return operator, nil
+}
+
+// loadOperatorToken reads an operator's token from a file, ignoring surrounding whitespace.
+func loadOperatorToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read operator token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("operator token file %q is empty", path)
+	}
+	return token, nil
+}
+
 func setupWeb3SignerClient(endpoint string, timeout time.Duration, tlsConfig tls.Config) (*web3signer.Web3Signer, error) {
 	if tlsConfig.ClientKeystoreFile != "" || tlsConfig.ClientServerCertFile != "" {
 		config, err := tlsConfig.LoadClientTLSConfig()
@@ -202,13 +366,59 @@ func setupWeb3SignerClient(endpoint string, timeout time.Duration, tlsConfig tls
 	), nil
 }
 
-func startServer(logger *zap.Logger, listenAddr string, operatorKey keys.OperatorPrivateKey, web3SignerClient *web3signer.Web3Signer, tlsConfig tls.Config) error {
+func setupSlashingProtection(logger *zap.Logger, dbPath, network string) (basedb.Database, *ekm.SignerSlashingProtection, error) {
+	netCfg, err := networkconfig.GetNetworkConfigByName(network)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get network config: %w", err)
+	}
+
+	db, err := kv.New(logger, basedb.Options{Path: dbPath})
+	if err != nil {
+		return nil, nil, fmt.Errorf("open slashing protection db: %w", err)
+	}
+
+	return db, ekm.NewSignerSlashingProtection(logger, db, netCfg.Beacon), nil
+}
+
+func setupLocalSigner(
+	logger *zap.Logger,
+	dir string,
+	passwordFile string,
+	network string,
+	slashingProtection *ekm.SignerSlashingProtection,
+) (*localsigner.Signer, error) {
+	netCfg, err := networkconfig.GetNetworkConfigByName(network)
+	if err != nil {
+		return nil, fmt.Errorf("get network config: %w", err)
+	}
+
+	//nolint: gosec
+	password, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return nil, fmt.Errorf("read local keystores password file: %w", err)
+	}
+
+	signer, err := localsigner.New(logger, dir, strings.TrimSpace(string(password)), netCfg.Beacon, slashingProtection)
+	if err != nil {
+		return nil, fmt.Errorf("setup local signer: %w", err)
+	}
+
+	return signer, nil
+}
+
+func startServer(
+	logger *zap.Logger,
+	listenAddr string,
+	operatorKey keys.OperatorPrivateKey,
+	remoteSigner web3signer.RemoteSigner,
+	tlsConfig tls.Config,
+	opts ...ssvsigner.Option,
+) error {
 	logger.Info("starting ssv-signer server",
 		zap.String("addr", listenAddr),
 		zap.Bool("tls_enabled", tlsConfig.ServerKeystoreFile != ""),
 	)
 
-	var opts []ssvsigner.Option
 	if tlsConfig.ServerKeystoreFile != "" {
 		config, err := tlsConfig.LoadServerTLSConfig()
 		if err != nil {
@@ -218,6 +428,6 @@ func startServer(logger *zap.Logger, listenAddr string, operatorKey keys.Operato
 		opts = append(opts, ssvsigner.WithTLS(config))
 	}
 
-	srv := ssvsigner.NewServer(logger, operatorKey, web3SignerClient, opts...)
+	srv := ssvsigner.NewServer(logger, operatorKey, remoteSigner, opts...)
 	return srv.ListenAndServe(listenAddr)
 }
@@ -2,6 +2,8 @@ package main
 
 import (
 	"encoding/base64"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/alecthomas/kong"
@@ -73,6 +75,96 @@ func TestRun_MissingPrivateKey(t *testing.T) {
 	require.ErrorContains(t, err, "neither private key nor keystore provided", "Error message should indicate missing keys")
 }
 
+func TestRun_EnforceSlashingProtectionWithoutDB(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	cli := CLI{
+		ListenAddr:                ":8080",
+		Web3SignerEndpoint:        "https://ssvlabs.io/",
+		PrivateKey:                base64.StdEncoding.EncodeToString([]byte(rsatesting.PrivKeyPEM)),
+		AllowInsecureHTTP:         true,
+		EnforceSlashingProtection: true,
+	}
+
+	err := run(logger, cli)
+	require.ErrorContains(t, err, "ENFORCE_SLASHING_PROTECTION requires DB_PATH")
+}
+
+func TestRun_AdditionalOperatorsWithoutDB(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	cli := CLI{
+		ListenAddr:          ":8080",
+		Web3SignerEndpoint:  "https://ssvlabs.io/",
+		PrivateKey:          base64.StdEncoding.EncodeToString([]byte(rsatesting.PrivKeyPEM)),
+		AllowInsecureHTTP:   true,
+		AdditionalOperators: []string{"/path/to/keystore.json:/path/to/password.txt"},
+	}
+
+	err := run(logger, cli)
+	require.ErrorContains(t, err, "ADDITIONAL_OPERATORS requires DB_PATH")
+}
+
+func TestRun_LocalBackend(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	cli := CLI{
+		ListenAddr:        ":8080",
+		Backend:           backendLocal,
+		PrivateKey:        base64.StdEncoding.EncodeToString([]byte(rsatesting.PrivKeyPEM)),
+		AllowInsecureHTTP: true,
+		Network:           "mainnet",
+	}
+
+	err := run(logger, cli)
+	require.ErrorContains(t, err, "local backend requires DB_PATH")
+
+	cli.DBPath = t.TempDir()
+	err = run(logger, cli)
+	require.ErrorContains(t, err, "local backend requires LOCAL_KEYSTORES_DIR")
+
+	cli.LocalKeystoresDir = t.TempDir()
+	err = run(logger, cli)
+	require.ErrorContains(t, err, "local backend requires LOCAL_KEYSTORES_PASSWORD_FILE")
+
+	cli.LocalKeystoresPasswordFile = "/nonexistent/password"
+	err = run(logger, cli)
+	require.ErrorContains(t, err, "read local keystores password file")
+}
+
+func TestLoadAdditionalOperator(t *testing.T) {
+	for _, entry := range []string{
+		"/path/to/keystore.json",
+		"/path/to/keystore.json:/path/to/password.txt:invalid",
+		"/path/to/keystore.json:/path/to/password.txt:1:/path/to/token.txt:extra",
+		"/path/to/keystore.json:/path/to/password.txt:1:/nonexistent/token.txt",
+	} {
+		_, err := loadAdditionalOperator(entry)
+		require.Error(t, err, entry)
+	}
+
+	_, err := loadAdditionalOperator("/nonexistent/keystore.json:/nonexistent/password.txt:1")
+	require.ErrorContains(t, err, "failed to load operator key from file")
+}
+
+func TestLoadOperatorToken(t *testing.T) {
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token.txt")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+	token, err := loadOperatorToken(tokenFile)
+	require.NoError(t, err)
+	require.Equal(t, "secret", token)
+
+	emptyFile := filepath.Join(dir, "empty.txt")
+	require.NoError(t, os.WriteFile(emptyFile, []byte(" \n"), 0o600))
+	_, err = loadOperatorToken(emptyFile)
+	require.ErrorContains(t, err, "is empty")
+
+	_, err = loadOperatorToken(filepath.Join(dir, "nonexistent.txt"))
+	require.ErrorContains(t, err, "failed to read operator token file")
+}
+
 func TestRun_InvalidPrivateKeyFormat(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 
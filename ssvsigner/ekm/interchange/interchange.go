@@ -0,0 +1,163 @@
+// Package interchange implements the slashing protection interchange format
+// defined by EIP-3076 (https://eips.ethereum.org/EIPS/eip-3076).
+//
+// Both the minimal and the complete variants of the format are supported when
+// parsing. Since SSV only keeps the highest attestation and proposal per share,
+// exported documents always use the minimal variant: a single signed block and
+// a single signed attestation per public key.
+package interchange
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// FormatVersion is the only interchange format version supported.
+const FormatVersion = "5"
+
+var (
+	ErrUnsupportedVersion            = errors.New("unsupported interchange format version")
+	ErrGenesisValidatorsRootMismatch = errors.New("genesis validators root mismatch")
+	ErrDuplicatePubKey               = errors.New("duplicate public key")
+	ErrInvalidAttestation            = errors.New("invalid attestation: source epoch is greater than target epoch")
+)
+
+// Interchange is an EIP-3076 slashing protection interchange document.
+type Interchange struct {
+	Metadata Metadata    `json:"metadata"`
+	Data     []Validator `json:"data"`
+}
+
+// Metadata holds the interchange format version and the chain it belongs to.
+type Metadata struct {
+	InterchangeFormatVersion string      `json:"interchange_format_version"`
+	GenesisValidatorsRoot    phase0.Root `json:"genesis_validators_root"`
+}
+
+// Validator holds the signing history of a single public key.
+type Validator struct {
+	PubKey             phase0.BLSPubKey    `json:"pubkey"`
+	SignedBlocks       []SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []SignedAttestation `json:"signed_attestations"`
+}
+
+// SignedBlock is a block proposal record.
+type SignedBlock struct {
+	Slot        phase0.Slot  `json:"slot,string"`
+	SigningRoot *phase0.Root `json:"signing_root,omitempty"`
+}
+
+// SignedAttestation is an attestation record.
+type SignedAttestation struct {
+	SourceEpoch phase0.Epoch `json:"source_epoch,string"`
+	TargetEpoch phase0.Epoch `json:"target_epoch,string"`
+	SigningRoot *phase0.Root `json:"signing_root,omitempty"`
+}
+
+// New returns an empty interchange document for the given chain.
+func New(genesisValidatorsRoot phase0.Root) *Interchange {
+	return &Interchange{
+		Metadata: Metadata{
+			InterchangeFormatVersion: FormatVersion,
+			GenesisValidatorsRoot:    genesisValidatorsRoot,
+		},
+		Data: []Validator{},
+	}
+}
+
+// Parse decodes an interchange document and validates its structure.
+func Parse(data []byte) (*Interchange, error) {
+	var i Interchange
+	if err := json.Unmarshal(data, &i); err != nil {
+		return nil, fmt.Errorf("unmarshal interchange: %w", err)
+	}
+
+	if err := i.validate(); err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
+
+// Marshal encodes the interchange document as JSON.
+func (i *Interchange) Marshal() ([]byte, error) {
+	return json.MarshalIndent(i, "", "  ")
+}
+
+// Verify checks that the document belongs to the chain with the given genesis validators root.
+func (i *Interchange) Verify(genesisValidatorsRoot phase0.Root) error {
+	if i.Metadata.GenesisValidatorsRoot != genesisValidatorsRoot {
+		return fmt.Errorf("%w: expected %s, got %s",
+			ErrGenesisValidatorsRootMismatch,
+			genesisValidatorsRoot.String(),
+			i.Metadata.GenesisValidatorsRoot.String(),
+		)
+	}
+
+	return nil
+}
+
+// PubKeys returns the public keys present in the document.
+func (i *Interchange) PubKeys() []phase0.BLSPubKey {
+	pubKeys := make([]phase0.BLSPubKey, 0, len(i.Data))
+	for _, v := range i.Data {
+		pubKeys = append(pubKeys, v.PubKey)
+	}
+	return pubKeys
+}
+
+func (i *Interchange) validate() error {
+	if i.Metadata.InterchangeFormatVersion != FormatVersion {
+		return fmt.Errorf("%w: %q", ErrUnsupportedVersion, i.Metadata.InterchangeFormatVersion)
+	}
+
+	seen := make(map[phase0.BLSPubKey]struct{}, len(i.Data))
+	for _, v := range i.Data {
+		if _, ok := seen[v.PubKey]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicatePubKey, v.PubKey.String())
+		}
+		seen[v.PubKey] = struct{}{}
+
+		for _, att := range v.SignedAttestations {
+			if att.SourceEpoch > att.TargetEpoch {
+				return fmt.Errorf("%w: %s (source %d, target %d)",
+					ErrInvalidAttestation, v.PubKey.String(), att.SourceEpoch, att.TargetEpoch)
+			}
+		}
+	}
+
+	return nil
+}
+
+// HighestProposal returns the highest slot among the signed blocks.
+// The boolean is false if there are no signed blocks.
+func (v Validator) HighestProposal() (phase0.Slot, bool) {
+	if len(v.SignedBlocks) == 0 {
+		return 0, false
+	}
+
+	var highest phase0.Slot
+	for _, block := range v.SignedBlocks {
+		highest = max(highest, block.Slot)
+	}
+	return highest, true
+}
+
+// HighestAttestation returns the highest source and target epochs among the signed attestations.
+// As recommended by EIP-3076, the two maximums are computed independently, which is the safest
+// (most restrictive) way to condense a complete history into a single record.
+// The boolean is false if there are no signed attestations.
+func (v Validator) HighestAttestation() (source, target phase0.Epoch, found bool) {
+	if len(v.SignedAttestations) == 0 {
+		return 0, 0, false
+	}
+
+	for _, att := range v.SignedAttestations {
+		source = max(source, att.SourceEpoch)
+		target = max(target, att.TargetEpoch)
+	}
+	return source, target, true
+}
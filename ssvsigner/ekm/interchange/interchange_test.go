@@ -0,0 +1,110 @@
+package interchange
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+const completeInterchange = `{
+  "metadata": {
+    "interchange_format_version": "5",
+    "genesis_validators_root": "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+  },
+  "data": [
+    {
+      "pubkey": "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed",
+      "signed_blocks": [
+        {"slot": "81952", "signing_root": "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"},
+        {"slot": "81951"}
+      ],
+      "signed_attestations": [
+        {"source_epoch": "2290", "target_epoch": "3007", "signing_root": "0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"},
+        {"source_epoch": "2291", "target_epoch": "3001"}
+      ]
+    }
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	doc, err := Parse([]byte(completeInterchange))
+	require.NoError(t, err)
+	require.Len(t, doc.Data, 1)
+	require.Len(t, doc.PubKeys(), 1)
+
+	slot, found := doc.Data[0].HighestProposal()
+	require.True(t, found)
+	require.Equal(t, phase0.Slot(81952), slot)
+
+	source, target, found := doc.Data[0].HighestAttestation()
+	require.True(t, found)
+	require.Equal(t, phase0.Epoch(2291), source)
+	require.Equal(t, phase0.Epoch(3007), target)
+
+	require.NoError(t, doc.Verify(doc.Metadata.GenesisValidatorsRoot))
+	require.ErrorIs(t, doc.Verify(phase0.Root{1}), ErrGenesisValidatorsRootMismatch)
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := Parse([]byte(`{"metadata":{"interchange_format_version":"4","genesis_validators_root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"data":[]}`))
+		require.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("duplicate pubkey", func(t *testing.T) {
+		doc := New(phase0.Root{})
+		doc.Data = append(doc.Data, Validator{PubKey: phase0.BLSPubKey{1}}, Validator{PubKey: phase0.BLSPubKey{1}})
+		data, err := doc.Marshal()
+		require.NoError(t, err)
+
+		_, err = Parse(data)
+		require.ErrorIs(t, err, ErrDuplicatePubKey)
+	})
+
+	t.Run("source after target", func(t *testing.T) {
+		doc := New(phase0.Root{})
+		doc.Data = append(doc.Data, Validator{
+			PubKey:             phase0.BLSPubKey{1},
+			SignedAttestations: []SignedAttestation{{SourceEpoch: 10, TargetEpoch: 9}},
+		})
+		data, err := doc.Marshal()
+		require.NoError(t, err)
+
+		_, err = Parse(data)
+		require.ErrorIs(t, err, ErrInvalidAttestation)
+	})
+
+	t.Run("malformed json", func(t *testing.T) {
+		_, err := Parse([]byte(`{`))
+		require.Error(t, err)
+	})
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	doc := New(phase0.Root{0xaa})
+	doc.Data = append(doc.Data, Validator{
+		PubKey:             phase0.BLSPubKey{1, 2, 3},
+		SignedBlocks:       []SignedBlock{{Slot: 100}},
+		SignedAttestations: []SignedAttestation{{SourceEpoch: 2, TargetEpoch: 3}},
+	})
+
+	data, err := doc.Marshal()
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"slot": "100"`)
+	require.Contains(t, string(data), `"source_epoch": "2"`)
+
+	parsed, err := Parse(data)
+	require.NoError(t, err)
+	require.Equal(t, doc, parsed)
+}
+
+func TestEmptyHistory(t *testing.T) {
+	v := Validator{}
+
+	_, found := v.HighestProposal()
+	require.False(t, found)
+
+	_, _, found = v.HighestAttestation()
+	require.False(t, found)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ssvlabs/ssv/storage/basedb"
 
 	ssvclient "github.com/ssvlabs/ssv/ssvsigner"
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
 	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
 )
 
@@ -18,8 +19,8 @@ type MockRemoteSigner struct {
 	mock.Mock
 }
 
-func (m *MockRemoteSigner) AddValidators(ctx context.Context, shares ...ssvclient.ShareKeys) ([]web3signer.Status, error) {
-	args := m.Called(ctx, shares[0])
+func (m *MockRemoteSigner) AddValidatorsWithSlashingProtection(ctx context.Context, slashingProtection string, shares ...ssvclient.ShareKeys) ([]web3signer.Status, error) {
+	args := m.Called(ctx, slashingProtection, shares[0])
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -119,6 +120,10 @@ func (m *MockDatabase) GetAll(prefix []byte, handler func(int, basedb.Obj) error
 	return nil
 }
 
+func (m *MockDatabase) GetRange(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	return nil
+}
+
 func (m *MockDatabase) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
 	return nil
 }
@@ -179,6 +184,10 @@ func (m *MockTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) err
 	return nil
 }
 
+func (m *MockTxn) GetRange(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	return nil
+}
+
 func (m *MockTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
 	return nil
 }
@@ -204,6 +213,10 @@ func (m *MockReadTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error)
 	return nil
 }
 
+func (m *MockReadTxn) GetRange(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	return nil
+}
+
 type MockOperatorPublicKey struct {
 	mock.Mock
 }
@@ -273,6 +286,14 @@ func (m *MockSlashingProtector) BumpSlashingProtectionTxn(txn basedb.Txn, pubKey
 	return args.Error(0)
 }
 
+func (m *MockSlashingProtector) ExportSlashingProtectionTxn(txn basedb.Txn, genesisValidatorsRoot phase0.Root, pubKey phase0.BLSPubKey) (*interchange.Interchange, error) {
+	args := m.Called(txn, genesisValidatorsRoot, pubKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interchange.Interchange), args.Error(1)
+}
+
 func (m *MockSlashingProtector) RemoveHighestAttestationTxn(txn basedb.Txn, pubKey phase0.BLSPubKey) error {
 	args := m.Called(txn, pubKey)
 	return args.Error(0)
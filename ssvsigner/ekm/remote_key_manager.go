@@ -51,7 +51,7 @@ type RemoteKeyManager struct {
 }
 
 type signerClient interface {
-	AddValidators(ctx context.Context, shares ...ssvsigner.ShareKeys) ([]web3signer.Status, error)
+	AddValidatorsWithSlashingProtection(ctx context.Context, slashingProtection string, shares ...ssvsigner.ShareKeys) ([]web3signer.Status, error)
 	RemoveValidators(ctx context.Context, pubKeys ...phase0.BLSPubKey) (statuses []web3signer.Status, err error)
 	Sign(ctx context.Context, sharePubKey phase0.BLSPubKey, payload web3signer.SignRequest) (phase0.BLSSignature, error)
 	OperatorIdentity(ctx context.Context) (string, error)
@@ -66,6 +66,8 @@ type consensusClient interface {
 // NewRemoteKeyManager returns a RemoteKeyManager that fetches the operator's public
 // identity from the signerClient, sets up local slashing protection, and uses
 // the provided consensusClient to get the current fork/genesis for sign requests.
+// If the remote signer hosts several operators, the identity is the one the signerClient
+// is configured for (see ssvsigner.WithOperator).
 func NewRemoteKeyManager(
 	ctx context.Context,
 	logger *zap.Logger,
@@ -100,9 +102,10 @@ func NewRemoteKeyManager(
 	}, nil
 }
 
-// AddShare registers a validator share with the remote service via signerClient.AddValidators
-// and then calls BumpSlashingProtectionTxn on the local store. If remote or local operations
-// fail, returns an error.
+// AddShare calls BumpSlashingProtectionTxn on the local store and then registers a validator share
+// with the remote service via signerClient.AddValidatorsWithSlashingProtection, passing the local
+// slashing protection record along, so the remote signer starts from the same history.
+// If remote or local operations fail, returns an error.
 func (km *RemoteKeyManager) AddShare(
 	ctx context.Context,
 	txn basedb.Txn,
@@ -113,6 +116,11 @@ func (km *RemoteKeyManager) AddShare(
 		return fmt.Errorf("could not bump slashing protection: %w", err)
 	}
 
+	slashingProtection, err := km.exportSlashingProtection(ctx, txn, pubKey)
+	if err != nil {
+		return fmt.Errorf("could not export slashing protection: %w", err)
+	}
+
 	shareKeys := ssvsigner.ShareKeys{
 		EncryptedPrivKey: hexutil.Bytes(encryptedPrivKey),
 		PubKey:           pubKey,
@@ -122,8 +130,8 @@ func (km *RemoteKeyManager) AddShare(
 	// there will be some inconsistency between syncer state and remote signer.
 	// However, syncer crashes node on an error and restarts the sync process from the failing block,
 	// so it will attempt to save the same share again, which won't be an issue
-	// because AddValidators doesn't fail if the same share exists.
-	statuses, err := km.signerClient.AddValidators(ctx, shareKeys)
+	// because AddValidatorsWithSlashingProtection doesn't fail if the same share exists.
+	statuses, err := km.signerClient.AddValidatorsWithSlashingProtection(ctx, slashingProtection, shareKeys)
 	if err != nil {
 		return fmt.Errorf("add validator: %w", err)
 	}
@@ -221,6 +229,25 @@ func (km *RemoteKeyManager) BumpSlashingProtection(txn basedb.Txn, pubKey phase0
 	return km.slashingProtector.BumpSlashingProtectionTxn(txn, pubKey)
 }
 
+func (km *RemoteKeyManager) exportSlashingProtection(ctx context.Context, txn basedb.Txn, pubKey phase0.BLSPubKey) (string, error) {
+	genesis, err := km.consensusClient.Genesis(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get genesis: %w", err)
+	}
+
+	doc, err := km.slashingProtector.ExportSlashingProtectionTxn(txn, genesis.GenesisValidatorsRoot, pubKey)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := doc.Marshal()
+	if err != nil {
+		return "", fmt.Errorf("marshal interchange: %w", err)
+	}
+
+	return string(data), nil
+}
+
 func (km *RemoteKeyManager) removeHighestAttestation(txn basedb.Txn, pubKey phase0.BLSPubKey) error {
 	attLock := km.lock(pubKey, lockAttestation)
 	attLock.Lock()
@@ -28,6 +28,7 @@ import (
 	"github.com/ssvlabs/ssv/networkconfig"
 
 	"github.com/ssvlabs/ssv/ssvsigner"
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
 	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
 )
 
@@ -71,14 +72,26 @@ func (s *RemoteKeyManagerTestSuite) TestRemoteKeyManagerWithMockedOperatorKey()
 	pubKey := phase0.BLSPubKey{1, 2, 3}
 	encShare := []byte("encrypted_share_data")
 
+	genesis := &eth2api.Genesis{GenesisValidatorsRoot: phase0.Root{9, 8, 7}}
+	doc := interchange.New(genesis.GenesisValidatorsRoot)
+	doc.Data = append(doc.Data, interchange.Validator{
+		PubKey:             pubKey,
+		SignedBlocks:       []interchange.SignedBlock{{Slot: 100}},
+		SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: 2, TargetEpoch: 3}},
+	})
+	slashingProtection, err := doc.Marshal()
+	s.Require().NoError(err)
+
 	mockSlashingProtector.On("BumpSlashingProtectionTxn", nil, pubKey).Return(nil)
+	mockSlashingProtector.On("ExportSlashingProtectionTxn", nil, genesis.GenesisValidatorsRoot, pubKey).Return(doc, nil)
+	s.consensusClient.On("Genesis", mock.Anything).Return(genesis, nil)
 
-	s.client.On("AddValidators", mock.Anything, ssvsigner.ShareKeys{
+	s.client.On("AddValidatorsWithSlashingProtection", mock.Anything, string(slashingProtection), ssvsigner.ShareKeys{
 		PubKey:           pubKey,
 		EncryptedPrivKey: encShare,
 	}).Return([]web3signer.Status{web3signer.StatusImported}, nil)
 
-	err := rm.AddShare(context.Background(), nil, encShare, pubKey)
+	err = rm.AddShare(context.Background(), nil, encShare, pubKey)
 
 	s.NoError(err)
 	s.client.AssertExpectations(s.T())
@@ -1492,13 +1505,14 @@ func (s *RemoteKeyManagerTestSuite) TestSignBeaconObjectErrorCases() {
 func (s *RemoteKeyManagerTestSuite) TestAddShareErrorCases() {
 	s.Run("AddValidatorsError", func() {
 		clientMock := new(MockRemoteSigner)
+		consensusMock := new(MockConsensusClient)
 		slashingMock := new(MockSlashingProtector)
 
 		rmTest := &RemoteKeyManager{
 			logger:            s.logger,
 			netCfg:            testNetCfg,
 			signerClient:      clientMock,
-			consensusClient:   s.consensusClient,
+			consensusClient:   consensusMock,
 			getOperatorId:     func() spectypes.OperatorID { return 1 },
 			operatorPubKey:    &MockOperatorPublicKey{},
 			slashingProtector: slashingMock,
@@ -1509,8 +1523,10 @@ func (s *RemoteKeyManagerTestSuite) TestAddShareErrorCases() {
 		encShare := []byte("encrypted_share_data")
 
 		slashingMock.On("BumpSlashingProtectionTxn", nil, pubKey).Return(nil).Once()
+		slashingMock.On("ExportSlashingProtectionTxn", nil, phase0.Root{}, pubKey).Return(interchange.New(phase0.Root{}), nil).Once()
+		consensusMock.On("Genesis", mock.Anything).Return(&eth2api.Genesis{}, nil).Once()
 
-		clientMock.On("AddValidators", mock.Anything, ssvsigner.ShareKeys{
+		clientMock.On("AddValidatorsWithSlashingProtection", mock.Anything, mock.Anything, ssvsigner.ShareKeys{
 			PubKey:           pubKey,
 			EncryptedPrivKey: encShare,
 		}).Return([]web3signer.Status{web3signer.StatusImported}, errors.New("add validators error")).Once()
@@ -1548,14 +1564,45 @@ func (s *RemoteKeyManagerTestSuite) TestAddShareErrorCases() {
 		slashingMock.AssertExpectations(s.T())
 	})
 
+	s.Run("GenesisError", func() {
+		clientMock := new(MockRemoteSigner)
+		consensusMock := new(MockConsensusClient)
+		slashingMock := new(MockSlashingProtector)
+
+		rmTest := &RemoteKeyManager{
+			logger:            s.logger,
+			netCfg:            testNetCfg,
+			signerClient:      clientMock,
+			consensusClient:   consensusMock,
+			getOperatorId:     func() spectypes.OperatorID { return 1 },
+			operatorPubKey:    &MockOperatorPublicKey{},
+			slashingProtector: slashingMock,
+			signLocks:         map[signKey]*sync.RWMutex{},
+		}
+
+		pubKey := phase0.BLSPubKey{1, 2, 3}
+		encShare := []byte("encrypted_share_data")
+
+		slashingMock.On("BumpSlashingProtectionTxn", nil, pubKey).Return(nil).Once()
+		consensusMock.On("Genesis", mock.Anything).Return(nil, errors.New("genesis error")).Once()
+
+		err := rmTest.AddShare(context.Background(), nil, encShare, pubKey)
+
+		s.ErrorContains(err, "could not export slashing protection")
+		clientMock.AssertExpectations(s.T())
+		slashingMock.AssertExpectations(s.T())
+	})
+
 	s.Run("UnexpectedStatus", func() {
 		clientMock := new(MockRemoteSigner)
+		consensusMock := new(MockConsensusClient)
 		slashingMock := new(MockSlashingProtector)
 
 		rmTest := &RemoteKeyManager{
 			logger:            s.logger,
 			netCfg:            testNetCfg,
 			signerClient:      clientMock,
+			consensusClient:   consensusMock,
 			getOperatorId:     func() spectypes.OperatorID { return 1 },
 			operatorPubKey:    &MockOperatorPublicKey{},
 			slashingProtector: slashingMock,
@@ -1566,8 +1613,10 @@ func (s *RemoteKeyManagerTestSuite) TestAddShareErrorCases() {
 		encShare := []byte("encrypted_share_data")
 
 		slashingMock.On("BumpSlashingProtectionTxn", nil, pubKey).Return(nil).Once()
+		slashingMock.On("ExportSlashingProtectionTxn", nil, phase0.Root{}, pubKey).Return(interchange.New(phase0.Root{}), nil).Once()
+		consensusMock.On("Genesis", mock.Anything).Return(&eth2api.Genesis{}, nil).Once()
 
-		clientMock.On("AddValidators", mock.Anything, ssvsigner.ShareKeys{
+		clientMock.On("AddValidatorsWithSlashingProtection", mock.Anything, mock.Anything, ssvsigner.ShareKeys{
 			PubKey:           pubKey,
 			EncryptedPrivKey: encShare,
 		}).Return([]web3signer.Status{web3signer.StatusError}, nil).Once()
@@ -0,0 +1,161 @@
+package ekm
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	slashingprotection "github.com/ssvlabs/eth2-key-manager/slashing_protection"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/storage/basedb"
+
+	"github.com/ssvlabs/ssv/ssvsigner"
+)
+
+// signer_slashing_protection.go provides SignerSlashingProtection, which lets
+// ssv-signer keep its own slashing protection database using the same
+// storage layout and rules as the SSV node.
+
+var (
+	_ ssvsigner.SlashingProtection = &SignerSlashingProtection{}
+	_ ssvsigner.InterchangeStore   = &SignerSlashingProtection{}
+)
+
+// SignerSlashingProtection implements ssvsigner.SlashingProtection on top of SlashingProtector.
+// It also implements ssvsigner.InterchangeStore over the same records, since imports only ever raise them.
+type SignerSlashingProtection struct {
+	*InterchangeStore
+
+	db        basedb.Database
+	protector *SlashingProtector
+
+	// mu makes each check and the update recording it atomic,
+	// so concurrent requests can't both pass the check for the same slot or epoch.
+	mu sync.Mutex
+}
+
+// NewSignerSlashingProtection returns a SignerSlashingProtection storing its data in db.
+func NewSignerSlashingProtection(logger *zap.Logger, db basedb.Database, network beacon.BeaconNetwork) *SignerSlashingProtection {
+	signerStore := NewSignerStorage(db, network, logger)
+	protection := slashingprotection.NewNormalProtection(signerStore)
+
+	return &SignerSlashingProtection{
+		InterchangeStore: NewInterchangeStore(db, signerStore),
+		db:               db,
+		protector:        NewSlashingProtector(logger, signerStore, protection),
+	}
+}
+
+func (sp *SignerSlashingProtection) HighestSigned(pubKey phase0.BLSPubKey) (ssvsigner.SlashingProtectionData, error) {
+	var data ssvsigner.SlashingProtectionData
+
+	att, found, err := sp.protector.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return ssvsigner.SlashingProtectionData{}, fmt.Errorf("could not retrieve highest attestation: %w", err)
+	}
+	if found && att != nil {
+		data.HighestAttestation = &ssvsigner.HighestAttestation{
+			SourceEpoch: att.Source.Epoch,
+			TargetEpoch: att.Target.Epoch,
+		}
+	}
+
+	slot, found, err := sp.protector.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return ssvsigner.SlashingProtectionData{}, fmt.Errorf("could not retrieve highest proposal: %w", err)
+	}
+	if found {
+		data.HighestProposalSlot = &slot
+	}
+
+	return data, nil
+}
+
+func (sp *SignerSlashingProtection) RaiseHighestSigned(pubKey phase0.BLSPubKey, data ssvsigner.SlashingProtectionData) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	// UpdateHighestAttestation and UpdateHighestProposal never lower the recorded values.
+	if data.HighestAttestation != nil {
+		attData := &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: data.HighestAttestation.SourceEpoch},
+			Target: &phase0.Checkpoint{Epoch: data.HighestAttestation.TargetEpoch},
+		}
+		if err := sp.protector.UpdateHighestAttestation(pubKey, attData); err != nil {
+			return fmt.Errorf("could not update highest attestation: %w", err)
+		}
+	}
+
+	if data.HighestProposalSlot != nil {
+		if err := sp.protector.UpdateHighestProposal(pubKey, *data.HighestProposalSlot); err != nil {
+			return fmt.Errorf("could not update highest proposal: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (sp *SignerSlashingProtection) InitShare(pubKey phase0.BLSPubKey) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	return sp.initShare(pubKey)
+}
+
+func (sp *SignerSlashingProtection) CheckAttestation(pubKey phase0.BLSPubKey, data *phase0.AttestationData) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	// Shares added before slashing protection was enabled have no history yet.
+	if _, found, err := sp.protector.RetrieveHighestAttestation(pubKey); err != nil {
+		return fmt.Errorf("could not retrieve highest attestation: %w", err)
+	} else if !found {
+		if err := sp.initShare(pubKey); err != nil {
+			return err
+		}
+	}
+
+	if err := sp.protector.IsAttestationSlashable(pubKey, data); err != nil {
+		return fmt.Errorf("%w: %w", ssvsigner.ErrSlashable, err)
+	}
+
+	if err := sp.protector.UpdateHighestAttestation(pubKey, data); err != nil {
+		return fmt.Errorf("could not update highest attestation: %w", err)
+	}
+
+	return nil
+}
+
+func (sp *SignerSlashingProtection) CheckProposal(pubKey phase0.BLSPubKey, slot phase0.Slot) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	// Shares added before slashing protection was enabled have no history yet.
+	if _, found, err := sp.protector.RetrieveHighestProposal(pubKey); err != nil {
+		return fmt.Errorf("could not retrieve highest proposal: %w", err)
+	} else if !found {
+		if err := sp.initShare(pubKey); err != nil {
+			return err
+		}
+	}
+
+	if err := sp.protector.IsBeaconBlockSlashable(pubKey, slot); err != nil {
+		return fmt.Errorf("%w: %w", ssvsigner.ErrSlashable, err)
+	}
+
+	if err := sp.protector.UpdateHighestProposal(pubKey, slot); err != nil {
+		return fmt.Errorf("could not update highest proposal: %w", err)
+	}
+
+	return nil
+}
+
+// initShare bumps the share's slashing protection to the minimal one for the current slot,
+// which is a no-op if the share already has a higher one.
+func (sp *SignerSlashingProtection) initShare(pubKey phase0.BLSPubKey) error {
+	return sp.db.Update(func(txn basedb.Txn) error {
+		return sp.protector.BumpSlashingProtectionTxn(txn, pubKey)
+	})
+}
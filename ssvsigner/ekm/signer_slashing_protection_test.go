@@ -0,0 +1,108 @@
+package ekm
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/utils"
+
+	"github.com/ssvlabs/ssv/ssvsigner"
+)
+
+func TestSignerSlashingProtection(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	defer db.Close()
+
+	mockBeacon := utils.SetupMockBeaconNetwork(t, nil)
+	sp := NewSignerSlashingProtection(logger, db, mockBeacon)
+
+	sharePubKey := phase0.BLSPubKey{1, 2, 3}
+	currentSlot := mockBeacon.EstimatedCurrentSlot()
+	currentEpoch := mockBeacon.EstimatedEpochAtSlot(currentSlot)
+
+	t.Run("no history", func(t *testing.T) {
+		data, err := sp.HighestSigned(sharePubKey)
+		require.NoError(t, err)
+		require.Nil(t, data.HighestAttestation)
+		require.Nil(t, data.HighestProposalSlot)
+	})
+
+	t.Run("init share", func(t *testing.T) {
+		require.NoError(t, sp.InitShare(sharePubKey))
+
+		data, err := sp.HighestSigned(sharePubKey)
+		require.NoError(t, err)
+		require.NotNil(t, data.HighestAttestation)
+		require.Equal(t, currentEpoch, data.HighestAttestation.TargetEpoch)
+		require.NotNil(t, data.HighestProposalSlot)
+		require.Equal(t, currentSlot, *data.HighestProposalSlot)
+	})
+
+	t.Run("raise", func(t *testing.T) {
+		higherSlot := currentSlot + 10
+		lowerSlot := phase0.Slot(1)
+
+		require.NoError(t, sp.RaiseHighestSigned(sharePubKey, ssvsigner.SlashingProtectionData{
+			HighestAttestation:  &ssvsigner.HighestAttestation{SourceEpoch: currentEpoch + 1, TargetEpoch: currentEpoch + 2},
+			HighestProposalSlot: &higherSlot,
+		}))
+
+		// Lower values must be ignored.
+		require.NoError(t, sp.RaiseHighestSigned(sharePubKey, ssvsigner.SlashingProtectionData{
+			HighestAttestation:  &ssvsigner.HighestAttestation{SourceEpoch: 0, TargetEpoch: 1},
+			HighestProposalSlot: &lowerSlot,
+		}))
+
+		data, err := sp.HighestSigned(sharePubKey)
+		require.NoError(t, err)
+		require.Equal(t, &ssvsigner.HighestAttestation{SourceEpoch: currentEpoch + 1, TargetEpoch: currentEpoch + 2}, data.HighestAttestation)
+		require.Equal(t, higherSlot, *data.HighestProposalSlot)
+	})
+
+	t.Run("check attestation", func(t *testing.T) {
+		attData := &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: currentEpoch + 2},
+			Target: &phase0.Checkpoint{Epoch: currentEpoch + 3},
+		}
+		require.NoError(t, sp.CheckAttestation(sharePubKey, attData))
+
+		// Double vote.
+		require.ErrorIs(t, sp.CheckAttestation(sharePubKey, attData), ssvsigner.ErrSlashable)
+
+		data, err := sp.HighestSigned(sharePubKey)
+		require.NoError(t, err)
+		require.Equal(t, currentEpoch+3, data.HighestAttestation.TargetEpoch)
+	})
+
+	t.Run("check proposal", func(t *testing.T) {
+		slot := currentSlot + 11
+		require.NoError(t, sp.CheckProposal(sharePubKey, slot))
+
+		// Double proposal.
+		require.ErrorIs(t, sp.CheckProposal(sharePubKey, slot), ssvsigner.ErrSlashable)
+		require.ErrorIs(t, sp.CheckProposal(sharePubKey, slot-1), ssvsigner.ErrSlashable)
+	})
+
+	t.Run("check without history", func(t *testing.T) {
+		otherPubKey := phase0.BLSPubKey{4, 5, 6}
+
+		// The share gets the minimal protection, so the current epoch and slot can't be signed.
+		err := sp.CheckAttestation(otherPubKey, &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: currentEpoch - 1},
+			Target: &phase0.Checkpoint{Epoch: currentEpoch},
+		})
+		require.ErrorIs(t, err, ssvsigner.ErrSlashable)
+		require.ErrorIs(t, sp.CheckProposal(otherPubKey, currentSlot), ssvsigner.ErrSlashable)
+
+		require.NoError(t, sp.CheckAttestation(otherPubKey, &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: currentEpoch},
+			Target: &phase0.Checkpoint{Epoch: currentEpoch + 1},
+		}))
+		require.NoError(t, sp.CheckProposal(otherPubKey, currentSlot+1))
+	})
+}
@@ -0,0 +1,171 @@
+package ekm
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
+)
+
+// slashing_interchange.go converts the highest attestation/proposal records
+// kept in Storage to and from the EIP-3076 slashing protection interchange format.
+
+// InterchangeStore keeps the signing history of the shares added to ssv-signer in Storage,
+// so it can be exported and imported in the interchange format.
+type InterchangeStore struct {
+	db    basedb.Database
+	store Storage
+}
+
+// NewInterchangeStore returns an InterchangeStore keeping the signing history in the given storage.
+func NewInterchangeStore(db basedb.Database, store Storage) *InterchangeStore {
+	return &InterchangeStore{
+		db:    db,
+		store: store,
+	}
+}
+
+// ExportInterchange returns the stored signing history of the given shares.
+func (s *InterchangeStore) ExportInterchange(
+	genesisValidatorsRoot phase0.Root,
+	pubKeys []phase0.BLSPubKey,
+) (*interchange.Interchange, error) {
+	return ExportSlashingProtection(nil, s.store, genesisValidatorsRoot, pubKeys)
+}
+
+// ImportInterchange merges the document into the stored signing history in a single transaction.
+// The document is trusted to belong to the chain named in its metadata.
+func (s *InterchangeStore) ImportInterchange(doc *interchange.Interchange) error {
+	return s.db.Update(func(txn basedb.Txn) error {
+		return ImportSlashingProtection(txn, s.store, doc.Metadata.GenesisValidatorsRoot, doc)
+	})
+}
+
+// ExportSlashingProtection builds an interchange document from the slashing protection
+// records stored for the given share public keys. Keys without any stored record are skipped.
+func ExportSlashingProtection(
+	r basedb.Reader,
+	store SlashingStoreTxn,
+	genesisValidatorsRoot phase0.Root,
+	pubKeys []phase0.BLSPubKey,
+) (*interchange.Interchange, error) {
+	doc := interchange.New(genesisValidatorsRoot)
+
+	for _, pubKey := range pubKeys {
+		v := interchange.Validator{
+			PubKey:             pubKey,
+			SignedBlocks:       []interchange.SignedBlock{},
+			SignedAttestations: []interchange.SignedAttestation{},
+		}
+
+		highestAtt, foundAtt, err := store.RetrieveHighestAttestationTxn(r, pubKey[:])
+		if err != nil {
+			return nil, fmt.Errorf("retrieve highest attestation for %s: %w", pubKey.String(), err)
+		}
+		if foundAtt && highestAtt != nil && highestAtt.Source != nil && highestAtt.Target != nil {
+			v.SignedAttestations = append(v.SignedAttestations, interchange.SignedAttestation{
+				SourceEpoch: highestAtt.Source.Epoch,
+				TargetEpoch: highestAtt.Target.Epoch,
+			})
+		}
+
+		highestProposal, foundProposal, err := store.RetrieveHighestProposalTxn(r, pubKey[:])
+		if err != nil {
+			return nil, fmt.Errorf("retrieve highest proposal for %s: %w", pubKey.String(), err)
+		}
+		if foundProposal && highestProposal != 0 {
+			v.SignedBlocks = append(v.SignedBlocks, interchange.SignedBlock{
+				Slot: highestProposal,
+			})
+		}
+
+		if len(v.SignedAttestations) == 0 && len(v.SignedBlocks) == 0 {
+			continue
+		}
+
+		doc.Data = append(doc.Data, v)
+	}
+
+	return doc, nil
+}
+
+// ImportSlashingProtection merges an interchange document into the stored slashing protection records.
+// Complete documents are condensed into a single record per key, and existing records are only ever
+// raised, never lowered, so importing can't make a previously protected signature signable again.
+func ImportSlashingProtection(
+	rw basedb.ReadWriter,
+	store SlashingStoreTxn,
+	genesisValidatorsRoot phase0.Root,
+	doc *interchange.Interchange,
+) error {
+	if err := doc.Verify(genesisValidatorsRoot); err != nil {
+		return err
+	}
+
+	for _, v := range doc.Data {
+		if err := importHighestAttestation(rw, store, v); err != nil {
+			return fmt.Errorf("import attestations for %s: %w", v.PubKey.String(), err)
+		}
+
+		if err := importHighestProposal(rw, store, v); err != nil {
+			return fmt.Errorf("import blocks for %s: %w", v.PubKey.String(), err)
+		}
+	}
+
+	return nil
+}
+
+func importHighestAttestation(rw basedb.ReadWriter, store SlashingStoreTxn, v interchange.Validator) error {
+	source, target, found := v.HighestAttestation()
+	if !found {
+		return nil
+	}
+
+	existing, foundExisting, err := store.RetrieveHighestAttestationTxn(rw, v.PubKey[:])
+	if err != nil {
+		return fmt.Errorf("retrieve highest attestation: %w", err)
+	}
+	if foundExisting && existing != nil && existing.Source != nil && existing.Target != nil {
+		source = max(source, existing.Source.Epoch)
+		target = max(target, existing.Target.Epoch)
+	}
+
+	highest := &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: source},
+		Target: &phase0.Checkpoint{Epoch: target},
+	}
+	if err := store.SaveHighestAttestationTxn(rw, v.PubKey[:], highest); err != nil {
+		return fmt.Errorf("save highest attestation: %w", err)
+	}
+
+	return nil
+}
+
+func importHighestProposal(rw basedb.ReadWriter, store SlashingStoreTxn, v interchange.Validator) error {
+	slot, found := v.HighestProposal()
+	if !found {
+		return nil
+	}
+
+	existing, foundExisting, err := store.RetrieveHighestProposalTxn(rw, v.PubKey[:])
+	if err != nil {
+		return fmt.Errorf("retrieve highest proposal: %w", err)
+	}
+	if foundExisting {
+		slot = max(slot, existing)
+	}
+
+	// Slot 0 can't be stored and doesn't protect anything on top of an empty record.
+	if slot == 0 {
+		return nil
+	}
+
+	if err := store.SaveHighestProposalTxn(rw, v.PubKey[:], slot); err != nil {
+		return fmt.Errorf("save highest proposal: %w", err)
+	}
+
+	return nil
+}
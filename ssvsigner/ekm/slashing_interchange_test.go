@@ -0,0 +1,142 @@
+package ekm
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
+)
+
+func TestSlashingProtectionInterchange(t *testing.T) {
+	genesisValidatorsRoot := phase0.Root{0x01}
+	pubKey1 := phase0.BLSPubKey{1}
+	pubKey2 := phase0.BLSPubKey{2}
+	pubKey3 := phase0.BLSPubKey{3}
+
+	t.Run("export", func(t *testing.T) {
+		store, done := newStorageForTest(t)
+		defer done()
+
+		require.NoError(t, store.SaveHighestAttestation(pubKey1[:], &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: 10},
+			Target: &phase0.Checkpoint{Epoch: 11},
+		}))
+		require.NoError(t, store.SaveHighestProposal(pubKey1[:], 100))
+		require.NoError(t, store.SaveHighestProposal(pubKey2[:], 200))
+
+		doc, err := ExportSlashingProtection(nil, store, genesisValidatorsRoot, []phase0.BLSPubKey{pubKey1, pubKey2, pubKey3})
+		require.NoError(t, err)
+		require.Equal(t, genesisValidatorsRoot, doc.Metadata.GenesisValidatorsRoot)
+		require.Equal(t, interchange.FormatVersion, doc.Metadata.InterchangeFormatVersion)
+		require.Len(t, doc.Data, 2, "keys without records must be skipped")
+
+		require.Equal(t, pubKey1, doc.Data[0].PubKey)
+		require.Equal(t, []interchange.SignedAttestation{{SourceEpoch: 10, TargetEpoch: 11}}, doc.Data[0].SignedAttestations)
+		require.Equal(t, []interchange.SignedBlock{{Slot: 100}}, doc.Data[0].SignedBlocks)
+
+		require.Equal(t, pubKey2, doc.Data[1].PubKey)
+		require.Empty(t, doc.Data[1].SignedAttestations)
+		require.Equal(t, []interchange.SignedBlock{{Slot: 200}}, doc.Data[1].SignedBlocks)
+	})
+
+	t.Run("import complete history", func(t *testing.T) {
+		store, done := newStorageForTest(t)
+		defer done()
+
+		doc := interchange.New(genesisValidatorsRoot)
+		doc.Data = append(doc.Data, interchange.Validator{
+			PubKey: pubKey1,
+			SignedBlocks: []interchange.SignedBlock{
+				{Slot: 50},
+				{Slot: 70},
+			},
+			SignedAttestations: []interchange.SignedAttestation{
+				{SourceEpoch: 5, TargetEpoch: 9},
+				{SourceEpoch: 7, TargetEpoch: 8},
+			},
+		})
+
+		require.NoError(t, ImportSlashingProtection(nil, store, genesisValidatorsRoot, doc))
+
+		att, found, err := store.RetrieveHighestAttestation(pubKey1[:])
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, phase0.Epoch(7), att.Source.Epoch)
+		require.Equal(t, phase0.Epoch(9), att.Target.Epoch)
+
+		slot, found, err := store.RetrieveHighestProposal(pubKey1[:])
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, phase0.Slot(70), slot)
+	})
+
+	t.Run("import never lowers existing records", func(t *testing.T) {
+		store, done := newStorageForTest(t)
+		defer done()
+
+		require.NoError(t, store.SaveHighestAttestation(pubKey1[:], &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: 20},
+			Target: &phase0.Checkpoint{Epoch: 21},
+		}))
+		require.NoError(t, store.SaveHighestProposal(pubKey1[:], 500))
+
+		doc := interchange.New(genesisValidatorsRoot)
+		doc.Data = append(doc.Data, interchange.Validator{
+			PubKey:             pubKey1,
+			SignedBlocks:       []interchange.SignedBlock{{Slot: 10}},
+			SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 30}},
+		})
+
+		require.NoError(t, ImportSlashingProtection(nil, store, genesisValidatorsRoot, doc))
+
+		att, found, err := store.RetrieveHighestAttestation(pubKey1[:])
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, phase0.Epoch(20), att.Source.Epoch)
+		require.Equal(t, phase0.Epoch(30), att.Target.Epoch)
+
+		slot, found, err := store.RetrieveHighestProposal(pubKey1[:])
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, phase0.Slot(500), slot)
+	})
+
+	t.Run("import rejects other chains", func(t *testing.T) {
+		store, done := newStorageForTest(t)
+		defer done()
+
+		doc := interchange.New(phase0.Root{0x02})
+		err := ImportSlashingProtection(nil, store, genesisValidatorsRoot, doc)
+		require.ErrorIs(t, err, interchange.ErrGenesisValidatorsRootMismatch)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		source, done := newStorageForTest(t)
+		defer done()
+
+		require.NoError(t, source.SaveHighestAttestation(pubKey1[:], &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: 3},
+			Target: &phase0.Checkpoint{Epoch: 4},
+		}))
+		require.NoError(t, source.SaveHighestProposal(pubKey1[:], 42))
+
+		doc, err := ExportSlashingProtection(nil, source, genesisValidatorsRoot, []phase0.BLSPubKey{pubKey1})
+		require.NoError(t, err)
+
+		data, err := doc.Marshal()
+		require.NoError(t, err)
+		parsed, err := interchange.Parse(data)
+		require.NoError(t, err)
+
+		target, done2 := newStorageForTest(t)
+		defer done2()
+
+		require.NoError(t, ImportSlashingProtection(nil, target, genesisValidatorsRoot, parsed))
+
+		exported, err := ExportSlashingProtection(nil, target, genesisValidatorsRoot, []phase0.BLSPubKey{pubKey1})
+		require.NoError(t, err)
+		require.Equal(t, doc, exported)
+	})
+}
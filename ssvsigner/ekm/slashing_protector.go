@@ -9,6 +9,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/storage/basedb"
+
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
 )
 
 // slashing_protector.go provides SlashingProtector, a wrapper around
@@ -39,6 +41,7 @@ type slashingProtector interface {
 	IsAttestationSlashable(pubKey phase0.BLSPubKey, attData *phase0.AttestationData) error
 	IsBeaconBlockSlashable(pubKey phase0.BLSPubKey, slot phase0.Slot) error
 	BumpSlashingProtectionTxn(txn basedb.Txn, pubKey phase0.BLSPubKey) error
+	ExportSlashingProtectionTxn(txn basedb.Txn, genesisValidatorsRoot phase0.Root, pubKey phase0.BLSPubKey) (*interchange.Interchange, error)
 }
 
 // SlashingProtector manages both the local store for highest attestation/proposal
@@ -128,6 +131,15 @@ func (sp *SlashingProtector) BumpSlashingProtectionTxn(txn basedb.Txn, pubKey ph
 	return nil
 }
 
+// ExportSlashingProtectionTxn returns the stored record of the share in the interchange format.
+func (sp *SlashingProtector) ExportSlashingProtectionTxn(
+	txn basedb.Txn,
+	genesisValidatorsRoot phase0.Root,
+	pubKey phase0.BLSPubKey,
+) (*interchange.Interchange, error) {
+	return ExportSlashingProtection(txn, sp.signerStore, genesisValidatorsRoot, []phase0.BLSPubKey{pubKey})
+}
+
 // updateHighestAttestation updates the highest attestation data for slashing protection.
 func (sp *SlashingProtector) updateHighestAttestation(txn basedb.Txn, pubKey phase0.BLSPubKey, slot phase0.Slot) error {
 	// Retrieve the highest attestation data stored for the given public key.
@@ -0,0 +1,17 @@
+package ssvsigner
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
+)
+
+// InterchangeStore is the signing history ssv-signer keeps for its shares, which can be exported
+// and imported in the EIP-3076 interchange format. It records the history passed along with added shares
+// and the history web3signer exports for removed shares, so shares can be moved between signers with it.
+type InterchangeStore interface {
+	// ExportInterchange returns the recorded history of the given shares, skipping shares without any.
+	ExportInterchange(genesisValidatorsRoot phase0.Root, pubKeys []phase0.BLSPubKey) (*interchange.Interchange, error)
+	// ImportInterchange merges the document into the recorded history, never lowering existing records.
+	ImportInterchange(doc *interchange.Interchange) error
+}
@@ -104,6 +104,7 @@ type TestRemoteSigner struct {
 	ListKeysError  error
 	ImportResult   web3signer.ImportKeystoreResponse
 	ImportError    error
+	ImportRequest  web3signer.ImportKeystoreRequest
 	DeleteResult   web3signer.DeleteKeystoreResponse
 	DeleteError    error
 	SignResult     web3signer.SignResponse
@@ -120,7 +121,9 @@ func (t *TestRemoteSigner) ListKeys(context.Context) (web3signer.ListKeysRespons
 }
 
 // ImportKeystore mocks importing a keystore to the remote signer.
-func (t *TestRemoteSigner) ImportKeystore(context.Context, web3signer.ImportKeystoreRequest) (web3signer.ImportKeystoreResponse, error) {
+func (t *TestRemoteSigner) ImportKeystore(_ context.Context, req web3signer.ImportKeystoreRequest) (web3signer.ImportKeystoreResponse, error) {
+	t.ImportRequest = req
+
 	if t.ImportError != nil {
 		return web3signer.ImportKeystoreResponse{}, t.ImportError
 	}
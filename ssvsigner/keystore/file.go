@@ -111,3 +111,36 @@ func GenerateShareKeystore(sharePrivateKey *bls.SecretKey, sharePublicKey phase0
 		"path":    keystoreDerivationPath,
 	}, nil
 }
+
+// DecryptShareKeystore decrypts a keystore generated by GenerateShareKeystore
+// and makes sure the share private key matches the keystore's public key.
+func DecryptShareKeystore(keystoreJSON []byte, passphrase string) (*bls.SecretKey, error) {
+	var data struct {
+		Crypto map[string]any `json:"crypto"`
+		PubKey string         `json:"pubkey"`
+	}
+	if err := json.Unmarshal(keystoreJSON, &data); err != nil {
+		return nil, fmt.Errorf("parse JSON data: %w", err)
+	}
+	if data.Crypto == nil {
+		return nil, errors.New("keystore has no crypto section")
+	}
+
+	decryptedBytes, err := keystorev4.New().Decrypt(data.Crypto, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt private key: %w", err)
+	}
+
+	sharePrivateKey := new(bls.SecretKey)
+	if err := sharePrivateKey.Deserialize(decryptedBytes); err != nil {
+		return nil, fmt.Errorf("deserialize private key: %w", err)
+	}
+
+	var sharePublicKey phase0.BLSPubKey
+	copy(sharePublicKey[:], sharePrivateKey.GetPublicKey().Serialize())
+	if !strings.EqualFold(strings.TrimPrefix(data.PubKey, "0x"), strings.TrimPrefix(sharePublicKey.String(), "0x")) {
+		return nil, errors.New("private key does not match the keystore public key")
+	}
+
+	return sharePrivateKey, nil
+}
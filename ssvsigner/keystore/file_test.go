@@ -230,6 +230,65 @@ func TestGenerateShareKeystore(t *testing.T) {
 	})
 }
 
+func TestDecryptShareKeystore(t *testing.T) {
+	t.Parallel()
+
+	sharePrivateKey := new(bls.SecretKey)
+	sharePrivateKey.SetByCSPRNG()
+
+	var sharePublicKey phase0.BLSPubKey
+	copy(sharePublicKey[:], sharePrivateKey.GetPublicKey().Serialize())
+
+	passphrase := "supersecretpassphrase"
+
+	t.Run("succeeds with generated keystore", func(t *testing.T) {
+		t.Parallel()
+
+		ks, err := GenerateShareKeystore(sharePrivateKey, sharePublicKey, passphrase)
+		require.NoError(t, err)
+
+		ksJSON, err := json.Marshal(ks)
+		require.NoError(t, err)
+
+		decrypted, err := DecryptShareKeystore(ksJSON, passphrase)
+		require.NoError(t, err)
+		require.True(t, sharePrivateKey.IsEqual(decrypted))
+	})
+
+	t.Run("fails with wrong passphrase", func(t *testing.T) {
+		t.Parallel()
+
+		ks, err := GenerateShareKeystore(sharePrivateKey, sharePublicKey, passphrase)
+		require.NoError(t, err)
+
+		ksJSON, err := json.Marshal(ks)
+		require.NoError(t, err)
+
+		_, err = DecryptShareKeystore(ksJSON, "wrong")
+		require.ErrorContains(t, err, "decrypt private key")
+	})
+
+	t.Run("fails with mismatching public key", func(t *testing.T) {
+		t.Parallel()
+
+		ks, err := GenerateShareKeystore(sharePrivateKey, phase0.BLSPubKey{0x12, 0x34, 0x56}, passphrase)
+		require.NoError(t, err)
+
+		ksJSON, err := json.Marshal(ks)
+		require.NoError(t, err)
+
+		_, err = DecryptShareKeystore(ksJSON, passphrase)
+		require.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("fails without crypto section", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DecryptShareKeystore([]byte(`{"pubkey":"0x1234"}`), passphrase)
+		require.ErrorContains(t, err, "no crypto section")
+	})
+}
+
 func createTempFile(t *testing.T, prefix, suffix string, data []byte) string {
 	t.Helper()
 
@@ -0,0 +1,435 @@
+// Package localsigner implements web3signer.RemoteSigner without web3signer.
+// Shares are kept in encrypted keystores on disk and signed with directly,
+// protected by ssv-signer's own slashing protection database.
+package localsigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+
+	"github.com/ssvlabs/ssv/ssvsigner"
+	"github.com/ssvlabs/ssv/ssvsigner/keystore"
+	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
+)
+
+var _ web3signer.RemoteSigner = &Signer{}
+
+const keystoreFileSuffix = ".json"
+
+// capellaForkVersions are the Capella fork versions of the known networks.
+// EIP-7044 requires signing voluntary exits with the Capella fork version regardless of the current fork:
+// https://eips.ethereum.org/EIPS/eip-7044
+var capellaForkVersions = map[spectypes.BeaconNetwork]phase0.Version{
+	spectypes.MainNetwork:    {0x03, 0x00, 0x00, 0x00},
+	spectypes.PraterNetwork:  {0x03, 0x00, 0x10, 0x20},
+	spectypes.HoleskyNetwork: {0x04, 0x01, 0x70, 0x00},
+	spectypes.SepoliaNetwork: {0x90, 0x00, 0x00, 0x72},
+	spectypes.HoodiNetwork:   {0x40, 0x00, 0x09, 0x10},
+}
+
+// Signer keeps shares in keystores encrypted with its own password, one file per share in dir,
+// and computes the signing root of each request itself instead of trusting the one sent by the client.
+type Signer struct {
+	logger             *zap.Logger
+	dir                string
+	password           string
+	network            beacon.BeaconNetwork
+	slashingProtection ssvsigner.SlashingProtection
+
+	mu     sync.RWMutex
+	shares map[phase0.BLSPubKey]*bls.SecretKey
+}
+
+// New returns a Signer with the shares already stored in dir, creating dir if it doesn't exist.
+func New(
+	logger *zap.Logger,
+	dir string,
+	password string,
+	network beacon.BeaconNetwork,
+	slashingProtection ssvsigner.SlashingProtection,
+) (*Signer, error) {
+	if strings.TrimSpace(password) == "" {
+		return nil, errors.New("keystores password is empty")
+	}
+
+	s := &Signer{
+		logger:             logger,
+		dir:                dir,
+		password:           password,
+		network:            network,
+		slashingProtection: slashingProtection,
+		shares:             make(map[phase0.BLSPubKey]*bls.SecretKey),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	logger.Info("loaded share keystores", zap.String("dir", dir), zap.Int("count", len(s.shares)))
+	return s, nil
+}
+
+func (s *Signer) load() error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("create keystores dir: %w", err)
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("read keystores dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), keystoreFileSuffix) {
+			continue
+		}
+
+		//nolint: gosec
+		keystoreJSON, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("read keystore %s: %w", entry.Name(), err)
+		}
+
+		sharePrivKey, err := keystore.DecryptShareKeystore(keystoreJSON, s.password)
+		if err != nil {
+			return fmt.Errorf("decrypt keystore %s: %w", entry.Name(), err)
+		}
+
+		s.shares[sharePubKey(sharePrivKey)] = sharePrivKey
+	}
+
+	return nil
+}
+
+// ListKeys returns the public keys of the stored shares.
+func (s *Signer) ListKeys(_ context.Context) (web3signer.ListKeysResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resp := make(web3signer.ListKeysResponse, 0, len(s.shares))
+	for pubKey := range s.shares {
+		resp = append(resp, pubKey)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return bytes.Compare(resp[i][:], resp[j][:]) < 0
+	})
+
+	return resp, nil
+}
+
+// ImportKeystore decrypts the keystores, re-encrypts them with the signer's password and stores them.
+// Like web3signer, failures of single keystores are reported in their statuses.
+func (s *Signer) ImportKeystore(_ context.Context, req web3signer.ImportKeystoreRequest) (web3signer.ImportKeystoreResponse, error) {
+	if len(req.Keystores) != len(req.Passwords) {
+		return web3signer.ImportKeystoreResponse{}, web3signer.HTTPResponseError{
+			Err:    errors.New("keystores and passwords count mismatch"),
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	var history map[phase0.BLSPubKey]ssvsigner.SlashingProtectionData
+	if req.SlashingProtection != "" {
+		var err error
+		history, err = ssvsigner.SlashingProtectionFromInterchange([]byte(req.SlashingProtection))
+		if err != nil {
+			return web3signer.ImportKeystoreResponse{}, web3signer.HTTPResponseError{
+				Err:    fmt.Errorf("parse slashing protection: %w", err),
+				Status: http.StatusBadRequest,
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var resp web3signer.ImportKeystoreResponse
+	for i, keystoreJSON := range req.Keystores {
+		status, err := s.importKeystore([]byte(keystoreJSON), req.Passwords[i], history)
+		data := web3signer.KeyManagerResponseData{Status: status}
+		if err != nil {
+			s.logger.Warn("failed to import keystore", zap.Int("index", i), zap.Error(err))
+			data.Message = err.Error()
+		}
+		resp.Data = append(resp.Data, data)
+	}
+
+	return resp, nil
+}
+
+func (s *Signer) importKeystore(
+	keystoreJSON []byte,
+	password string,
+	history map[phase0.BLSPubKey]ssvsigner.SlashingProtectionData,
+) (web3signer.Status, error) {
+	sharePrivKey, err := keystore.DecryptShareKeystore(keystoreJSON, password)
+	if err != nil {
+		return web3signer.StatusError, err
+	}
+
+	pubKey := sharePubKey(sharePrivKey)
+
+	// The history must be in place before the share can be signed with.
+	if err := s.slashingProtection.InitShare(pubKey); err != nil {
+		return web3signer.StatusError, fmt.Errorf("init slashing protection: %w", err)
+	}
+	if h, ok := history[pubKey]; ok && (h.HighestAttestation != nil || h.HighestProposalSlot != nil) {
+		if err := s.slashingProtection.RaiseHighestSigned(pubKey, h); err != nil {
+			return web3signer.StatusError, fmt.Errorf("import slashing protection: %w", err)
+		}
+	}
+
+	if _, ok := s.shares[pubKey]; ok {
+		return web3signer.StatusDuplicated, nil
+	}
+
+	if err := s.writeKeystore(pubKey, sharePrivKey); err != nil {
+		return web3signer.StatusError, err
+	}
+
+	s.shares[pubKey] = sharePrivKey
+	return web3signer.StatusImported, nil
+}
+
+// writeKeystore writes the keystore to a temporary file first,
+// so a crash can't leave a truncated keystore behind.
+func (s *Signer) writeKeystore(pubKey phase0.BLSPubKey, sharePrivKey *bls.SecretKey) error {
+	ks, err := keystore.GenerateShareKeystore(sharePrivKey, pubKey, s.password)
+	if err != nil {
+		return fmt.Errorf("generate keystore: %w", err)
+	}
+
+	keystoreJSON, err := json.Marshal(ks)
+	if err != nil {
+		return fmt.Errorf("marshal keystore: %w", err)
+	}
+
+	path := s.keystorePath(pubKey)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, keystoreJSON, 0o600); err != nil {
+		return fmt.Errorf("write keystore: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename keystore: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteKeystore removes the keystores of the shares.
+// Their slashing protection history is kept, so the response carries no interchange document.
+func (s *Signer) DeleteKeystore(_ context.Context, req web3signer.DeleteKeystoreRequest) (web3signer.DeleteKeystoreResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var resp web3signer.DeleteKeystoreResponse
+	for _, pubKey := range req.Pubkeys {
+		if _, ok := s.shares[pubKey]; !ok {
+			resp.Data = append(resp.Data, web3signer.KeyManagerResponseData{Status: web3signer.StatusNotFound})
+			continue
+		}
+
+		if err := os.Remove(s.keystorePath(pubKey)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Warn("failed to remove keystore", zap.String("pubkey", pubKey.String()), zap.Error(err))
+			resp.Data = append(resp.Data, web3signer.KeyManagerResponseData{
+				Status:  web3signer.StatusError,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		delete(s.shares, pubKey)
+		resp.Data = append(resp.Data, web3signer.KeyManagerResponseData{Status: web3signer.StatusDeleted})
+	}
+
+	return resp, nil
+}
+
+// Sign signs the signing root computed from the request. A request carrying a different signing root is rejected.
+// Errors are HTTPResponseError with the status web3signer would respond with.
+func (s *Signer) Sign(_ context.Context, pubKey phase0.BLSPubKey, req web3signer.SignRequest) (web3signer.SignResponse, error) {
+	s.mu.RLock()
+	sharePrivKey, ok := s.shares[pubKey]
+	s.mu.RUnlock()
+	if !ok {
+		return web3signer.SignResponse{}, web3signer.HTTPResponseError{
+			Err:    errors.New("share not found"),
+			Status: http.StatusNotFound,
+		}
+	}
+
+	root, err := s.signingRoot(req)
+	if err != nil {
+		return web3signer.SignResponse{}, web3signer.HTTPResponseError{
+			Err:    fmt.Errorf("compute signing root: %w", err),
+			Status: http.StatusBadRequest,
+		}
+	}
+	if req.SigningRoot != (phase0.Root{}) && req.SigningRoot != root {
+		return web3signer.SignResponse{}, web3signer.HTTPResponseError{
+			Err:    fmt.Errorf("signing root mismatch: expected %x, got %x", root, req.SigningRoot),
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	if err := ssvsigner.CheckSignRequest(s.slashingProtection, pubKey, req); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ssvsigner.ErrSlashable) {
+			status = http.StatusPreconditionFailed
+		}
+		return web3signer.SignResponse{}, web3signer.HTTPResponseError{Err: err, Status: status}
+	}
+
+	var resp web3signer.SignResponse
+	copy(resp.Signature[:], sharePrivKey.SignByte(root[:]).Serialize())
+	return resp, nil
+}
+
+// signingRoot computes the signing root of the object in the request the same way the SSV node does.
+func (s *Signer) signingRoot(req web3signer.SignRequest) (phase0.Root, error) {
+	var (
+		obj        ssz.HashRoot
+		domainType phase0.DomainType
+		epoch      phase0.Epoch
+	)
+
+	switch req.Type {
+	case web3signer.TypeAttestation:
+		if req.Attestation == nil || req.Attestation.Target == nil {
+			return phase0.Root{}, errors.New("attestation data is missing")
+		}
+		obj, domainType, epoch = req.Attestation, spectypes.DomainAttester, req.Attestation.Target.Epoch
+
+	case web3signer.TypeBlockV2:
+		if req.BeaconBlock == nil || req.BeaconBlock.BlockHeader == nil {
+			return phase0.Root{}, errors.New("block header is missing")
+		}
+		// The header has the same root as the block it was made from.
+		header := req.BeaconBlock.BlockHeader
+		obj, domainType, epoch = header, spectypes.DomainProposer, s.network.EstimatedEpochAtSlot(header.Slot)
+
+	case web3signer.TypeAggregationSlot:
+		if req.AggregationSlot == nil {
+			return phase0.Root{}, errors.New("aggregation slot is missing")
+		}
+		slot := req.AggregationSlot.Slot
+		obj, domainType, epoch = spectypes.SSZUint64(slot), spectypes.DomainSelectionProof, s.network.EstimatedEpochAtSlot(slot)
+
+	case web3signer.TypeAggregateAndProof:
+		if req.AggregateAndProof == nil {
+			return phase0.Root{}, errors.New("aggregate and proof is missing")
+		}
+		switch {
+		case req.AggregateAndProof.Phase0 != nil && req.AggregateAndProof.Phase0.Aggregate != nil && req.AggregateAndProof.Phase0.Aggregate.Data != nil:
+			obj, epoch = req.AggregateAndProof.Phase0, s.network.EstimatedEpochAtSlot(req.AggregateAndProof.Phase0.Aggregate.Data.Slot)
+		case req.AggregateAndProof.Electra != nil && req.AggregateAndProof.Electra.Aggregate != nil && req.AggregateAndProof.Electra.Aggregate.Data != nil:
+			obj, epoch = req.AggregateAndProof.Electra, s.network.EstimatedEpochAtSlot(req.AggregateAndProof.Electra.Aggregate.Data.Slot)
+		default:
+			return phase0.Root{}, errors.New("aggregate data is missing")
+		}
+		domainType = spectypes.DomainAggregateAndProof
+
+	case web3signer.TypeRandaoReveal:
+		if req.RandaoReveal == nil {
+			return phase0.Root{}, errors.New("randao reveal is missing")
+		}
+		obj, domainType, epoch = spectypes.SSZUint64(req.RandaoReveal.Epoch), spectypes.DomainRandao, req.RandaoReveal.Epoch
+
+	case web3signer.TypeSyncCommitteeMessage:
+		if req.SyncCommitteeMessage == nil {
+			return phase0.Root{}, errors.New("sync committee message is missing")
+		}
+		msg := req.SyncCommitteeMessage
+		obj, domainType, epoch = spectypes.SSZBytes(msg.BeaconBlockRoot[:]), spectypes.DomainSyncCommittee, s.network.EstimatedEpochAtSlot(msg.Slot)
+
+	case web3signer.TypeSyncCommitteeSelectionProof:
+		if req.SyncAggregatorSelectionData == nil {
+			return phase0.Root{}, errors.New("sync aggregator selection data is missing")
+		}
+		data := req.SyncAggregatorSelectionData
+		obj = &altair.SyncAggregatorSelectionData{
+			Slot:              data.Slot,
+			SubcommitteeIndex: uint64(data.SubcommitteeIndex),
+		}
+		domainType, epoch = spectypes.DomainSyncCommitteeSelectionProof, s.network.EstimatedEpochAtSlot(data.Slot)
+
+	case web3signer.TypeSyncCommitteeContributionAndProof:
+		if req.ContributionAndProof == nil || req.ContributionAndProof.Contribution == nil {
+			return phase0.Root{}, errors.New("contribution and proof is missing")
+		}
+		contribution := req.ContributionAndProof
+		obj, domainType, epoch = contribution, spectypes.DomainContributionAndProof, s.network.EstimatedEpochAtSlot(contribution.Contribution.Slot)
+
+	case web3signer.TypeValidatorRegistration:
+		if req.ValidatorRegistration == nil {
+			return phase0.Root{}, errors.New("validator registration is missing")
+		}
+		// Builder domain doesn't depend on the fork, it's always computed with the genesis fork version and a zero root.
+		domain, err := spectypes.ComputeETHDomain(spectypes.DomainApplicationBuilder, s.network.ForkVersion(), phase0.Root{})
+		if err != nil {
+			return phase0.Root{}, fmt.Errorf("compute domain: %w", err)
+		}
+		return spectypes.ComputeETHSigningRoot(req.ValidatorRegistration, domain)
+
+	case web3signer.TypeVoluntaryExit:
+		if req.VoluntaryExit == nil {
+			return phase0.Root{}, errors.New("voluntary exit is missing")
+		}
+		capellaForkVersion, ok := capellaForkVersions[s.network.GetBeaconNetwork()]
+		if !ok {
+			return phase0.Root{}, fmt.Errorf("voluntary exits are not supported on network %s", s.network.GetBeaconNetwork())
+		}
+		domain, err := spectypes.ComputeETHDomain(spectypes.DomainVoluntaryExit, capellaForkVersion, req.ForkInfo.GenesisValidatorsRoot)
+		if err != nil {
+			return phase0.Root{}, fmt.Errorf("compute domain: %w", err)
+		}
+		return spectypes.ComputeETHSigningRoot(req.VoluntaryExit, domain)
+
+	default:
+		return phase0.Root{}, fmt.Errorf("unsupported type %s", req.Type)
+	}
+
+	if req.ForkInfo.Fork == nil {
+		return phase0.Root{}, errors.New("fork info is missing")
+	}
+
+	forkVersion := req.ForkInfo.Fork.CurrentVersion
+	if epoch < req.ForkInfo.Fork.Epoch {
+		forkVersion = req.ForkInfo.Fork.PreviousVersion
+	}
+
+	domain, err := spectypes.ComputeETHDomain(domainType, forkVersion, req.ForkInfo.GenesisValidatorsRoot)
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("compute domain: %w", err)
+	}
+
+	return spectypes.ComputeETHSigningRoot(obj, domain)
+}
+
+func (s *Signer) keystorePath(pubKey phase0.BLSPubKey) string {
+	return filepath.Join(s.dir, pubKey.String()+keystoreFileSuffix)
+}
+
+func sharePubKey(sharePrivKey *bls.SecretKey) phase0.BLSPubKey {
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], sharePrivKey.GetPublicKey().Serialize())
+	return pubKey
+}
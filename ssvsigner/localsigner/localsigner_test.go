@@ -0,0 +1,206 @@
+package localsigner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+	"github.com/ssvlabs/ssv/utils"
+
+	"github.com/ssvlabs/ssv/ssvsigner/ekm"
+	"github.com/ssvlabs/ssv/ssvsigner/keystore"
+	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
+)
+
+const testPassword = "password"
+
+func TestMain(m *testing.M) {
+	if err := bls.Init(bls.BLS12_381); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize BLS: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func TestSigner(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctx := context.Background()
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	network := utils.SetupMockBeaconNetwork(t, nil)
+	slashingProtection := ekm.NewSignerSlashingProtection(logger, db, network)
+
+	dir := filepath.Join(t.TempDir(), "keystores")
+	signer, err := New(logger, dir, testPassword, network, slashingProtection)
+	require.NoError(t, err)
+
+	sharePrivKey := new(bls.SecretKey)
+	sharePrivKey.SetByCSPRNG()
+	pubKey := sharePubKey(sharePrivKey)
+
+	importReq := web3signer.ImportKeystoreRequest{
+		Keystores: []string{testKeystore(t, sharePrivKey, "import-password")},
+		Passwords: []string{"import-password"},
+	}
+
+	t.Run("import", func(t *testing.T) {
+		resp, err := signer.ImportKeystore(ctx, importReq)
+		require.NoError(t, err)
+		require.Len(t, resp.Data, 1)
+		require.Equal(t, web3signer.StatusImported, resp.Data[0].Status)
+
+		resp, err = signer.ImportKeystore(ctx, importReq)
+		require.NoError(t, err)
+		require.Equal(t, web3signer.StatusDuplicated, resp.Data[0].Status)
+
+		resp, err = signer.ImportKeystore(ctx, web3signer.ImportKeystoreRequest{
+			Keystores: importReq.Keystores,
+			Passwords: []string{"wrong"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, web3signer.StatusError, resp.Data[0].Status)
+
+		keys, err := signer.ListKeys(ctx)
+		require.NoError(t, err)
+		require.Equal(t, web3signer.ListKeysResponse{pubKey}, keys)
+
+		// The slashing protection is initialized on import.
+		data, err := slashingProtection.HighestSigned(pubKey)
+		require.NoError(t, err)
+		require.NotNil(t, data.HighestAttestation)
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		reloaded, err := New(logger, dir, testPassword, network, slashingProtection)
+		require.NoError(t, err)
+
+		keys, err := reloaded.ListKeys(ctx)
+		require.NoError(t, err)
+		require.Equal(t, web3signer.ListKeysResponse{pubKey}, keys)
+
+		_, err = New(logger, dir, "wrong", network, slashingProtection)
+		require.ErrorContains(t, err, "decrypt keystore")
+	})
+
+	currentEpoch := network.EstimatedCurrentEpoch()
+	forkInfo := web3signer.ForkInfo{
+		Fork: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x01},
+			CurrentVersion:  phase0.Version{0x02},
+			Epoch:           0,
+		},
+		GenesisValidatorsRoot: phase0.Root{0x03},
+	}
+
+	t.Run("sign attestation", func(t *testing.T) {
+		attData := &phase0.AttestationData{
+			Slot:            network.FirstSlotAtEpoch(currentEpoch + 1),
+			BeaconBlockRoot: phase0.Root{0x04},
+			Source:          &phase0.Checkpoint{Epoch: currentEpoch},
+			Target:          &phase0.Checkpoint{Epoch: currentEpoch + 1},
+		}
+
+		domain, err := spectypes.ComputeETHDomain(spectypes.DomainAttester, forkInfo.Fork.CurrentVersion, forkInfo.GenesisValidatorsRoot)
+		require.NoError(t, err)
+		root, err := spectypes.ComputeETHSigningRoot(attData, domain)
+		require.NoError(t, err)
+
+		req := web3signer.SignRequest{
+			ForkInfo:    forkInfo,
+			SigningRoot: root,
+			Type:        web3signer.TypeAttestation,
+			Attestation: attData,
+		}
+
+		resp, err := signer.Sign(ctx, pubKey, req)
+		require.NoError(t, err)
+
+		sig := new(bls.Sign)
+		require.NoError(t, sig.Deserialize(resp.Signature[:]))
+		require.True(t, sig.VerifyByte(sharePrivKey.GetPublicKey(), root[:]))
+
+		// Double vote.
+		_, err = signer.Sign(ctx, pubKey, req)
+		requireStatus(t, err, http.StatusPreconditionFailed)
+	})
+
+	t.Run("signing root mismatch", func(t *testing.T) {
+		_, err := signer.Sign(ctx, pubKey, web3signer.SignRequest{
+			ForkInfo:     forkInfo,
+			SigningRoot:  phase0.Root{0x05},
+			Type:         web3signer.TypeRandaoReveal,
+			RandaoReveal: &web3signer.RandaoReveal{Epoch: currentEpoch},
+		})
+		requireStatus(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := signer.Sign(ctx, pubKey, web3signer.SignRequest{
+			ForkInfo: forkInfo,
+			Type:     web3signer.TypeDeposit,
+		})
+		requireStatus(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("unknown share", func(t *testing.T) {
+		_, err := signer.Sign(ctx, phase0.BLSPubKey{1, 2, 3}, web3signer.SignRequest{
+			ForkInfo:     forkInfo,
+			Type:         web3signer.TypeRandaoReveal,
+			RandaoReveal: &web3signer.RandaoReveal{Epoch: currentEpoch},
+		})
+		requireStatus(t, err, http.StatusNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := signer.DeleteKeystore(ctx, web3signer.DeleteKeystoreRequest{
+			Pubkeys: []phase0.BLSPubKey{pubKey, {1, 2, 3}},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Data, 2)
+		require.Equal(t, web3signer.StatusDeleted, resp.Data[0].Status)
+		require.Equal(t, web3signer.StatusNotFound, resp.Data[1].Status)
+
+		keys, err := signer.ListKeys(ctx)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+
+		_, err = os.Stat(signer.keystorePath(pubKey))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func testKeystore(t *testing.T, sharePrivKey *bls.SecretKey, password string) string {
+	t.Helper()
+
+	ks, err := keystore.GenerateShareKeystore(sharePrivKey, sharePubKey(sharePrivKey), password)
+	require.NoError(t, err)
+
+	ksJSON, err := json.Marshal(ks)
+	require.NoError(t, err)
+
+	return string(ksJSON)
+}
+
+func requireStatus(t *testing.T, err error, status int) {
+	t.Helper()
+
+	var respErr web3signer.HTTPResponseError
+	require.True(t, errors.As(err, &respErr), "unexpected error: %v", err)
+	require.Equal(t, status, respErr.Status)
+}
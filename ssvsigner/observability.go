@@ -20,12 +20,17 @@ const (
 )
 
 const (
-	opListValidators   = "list_validators"
-	opAddValidator     = "add_validator"
-	opRemoveValidator  = "remove_validator"
-	opSignValidator    = "sign_validator"
-	opOperatorIdentity = "operator_identity"
-	opSignOperator     = "sign_operator"
+	opListValidators        = "list_validators"
+	opAddValidator          = "add_validator"
+	opRemoveValidator       = "remove_validator"
+	opSignValidator         = "sign_validator"
+	opOperatorIdentity      = "operator_identity"
+	opSignOperator          = "sign_operator"
+	opGetSlashingProtection = "get_slashing_protection"
+	opSetSlashingProtection = "set_slashing_protection"
+
+	opExportSlashingProtection = "export_slashing_protection"
+	opImportSlashingProtection = "import_slashing_protection"
 
 	// Remote Signer operations called by server
 	opRemoteSignerListKeys       = "remote_signer_list_keys"
@@ -0,0 +1,101 @@
+package ssvsigner
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+
+	"github.com/ssvlabs/ssv/ssvsigner/keys"
+)
+
+const (
+	// HeaderOperator selects the operator a request is made on behalf of, either by its ID or by its base64 public key.
+	// Requests without it are made on behalf of the server's primary operator.
+	HeaderOperator = "X-SSV-Operator"
+	// HeaderOperatorToken holds the token of the selected operator, if it has one.
+	// Without a token, any client able to reach the server can act on behalf of the operator.
+	HeaderOperatorToken = "X-SSV-Operator-Token"
+)
+
+var (
+	errUnknownOperator      = errors.New("unknown operator")
+	errOperatorUnauthorized = errors.New("missing or invalid operator token")
+)
+
+var shareOwnersPrefix = []byte("ssv_signer-share_owner-")
+
+// OperatorKey is an additional operator hosted by the server.
+type OperatorKey struct {
+	// ID is the operator's ID in the SSV network. It's optional and only lets clients select the operator by ID.
+	ID      uint64
+	PrivKey keys.OperatorPrivateKey
+	// Token is optional, if set, requests on behalf of the operator must present it in HeaderOperatorToken.
+	Token string
+}
+
+// operator is an operator hosted by the server.
+type operator struct {
+	id      uint64
+	pubKey  string
+	privKey keys.OperatorPrivateKey
+	token   string
+}
+
+// authorized reports whether the token presented by a request grants access to the operator.
+func (o *operator) authorized(token []byte) bool {
+	if o.token == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare(token, []byte(o.token)) == 1
+}
+
+func (o *operator) String() string {
+	if o.id != 0 {
+		return strconv.FormatUint(o.id, 10)
+	}
+	return o.pubKey
+}
+
+// ShareOwners records which operator each share was added by, so that operators hosted by the same server
+// only see and use their own shares.
+type ShareOwners struct {
+	db basedb.Database
+}
+
+// NewShareOwners returns a ShareOwners storing its data in db.
+func NewShareOwners(db basedb.Database) *ShareOwners {
+	return &ShareOwners{db: db}
+}
+
+// Owner returns the base64 public key of the operator the share was added by.
+func (o *ShareOwners) Owner(pubKey phase0.BLSPubKey) (string, bool, error) {
+	obj, found, err := o.db.Get(shareOwnersPrefix, pubKey[:])
+	if err != nil {
+		return "", false, fmt.Errorf("get share owner: %w", err)
+	}
+	if !found {
+		return "", false, nil
+	}
+	return string(obj.Value), true, nil
+}
+
+// SetOwner records the base64 public key of the operator the share was added by.
+func (o *ShareOwners) SetOwner(pubKey phase0.BLSPubKey, owner string) error {
+	if err := o.db.Set(shareOwnersPrefix, pubKey[:], []byte(owner)); err != nil {
+		return fmt.Errorf("set share owner: %w", err)
+	}
+	return nil
+}
+
+// RemoveOwner forgets the owner of a removed share.
+func (o *ShareOwners) RemoveOwner(pubKey phase0.BLSPubKey) error {
+	if err := o.db.Delete(shareOwnersPrefix, pubKey[:]); err != nil {
+		return fmt.Errorf("remove share owner: %w", err)
+	}
+	return nil
+}
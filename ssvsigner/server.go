@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 
 	"github.com/ssvlabs/ssv/logging/fields"
 
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
 	"github.com/ssvlabs/ssv/ssvsigner/keys"
 	"github.com/ssvlabs/ssv/ssvsigner/keystore"
 	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
@@ -37,6 +39,10 @@ const (
 	pathValidatorsSign   = "/v1/validators/sign/"  // TODO: /api/v1/eth2/sign/ ?
 	pathOperatorIdentity = "/v1/operator/identity" // TODO: /api/v1/ssv/identity ?
 	pathOperatorSign     = "/v1/operator/sign"     // TODO: /api/v1/ssv/sign ?
+	// pathSlashingProtection isn't nested under pathValidators as its routes would clash with pathValidatorsSign.
+	pathSlashingProtection = "/v1/slashing-protection/"
+	// pathSlashingProtectionInterchange is separate from pathSlashingProtection for the same reason.
+	pathSlashingProtectionInterchange = "/v1/slashing-protection-interchange"
 )
 
 const (
@@ -44,12 +50,26 @@ const (
 	addShareLimit = 10
 )
 
+var errSlashingProtectionHistoryDisabled = errors.New("slashing protection history is not kept, DB_PATH is not set")
+
 type Server struct {
-	logger          *zap.Logger
-	operatorPrivKey keys.OperatorPrivateKey
-	remoteSigner    web3signer.RemoteSigner
-	router          *router.Router
-	tlsConfig       *tls.Config
+	logger       *zap.Logger
+	remoteSigner web3signer.RemoteSigner
+	router       *router.Router
+	tlsConfig    *tls.Config
+
+	// primaryOperator serves requests which don't select an operator.
+	primaryOperator      *operator
+	primaryOperatorID    uint64
+	primaryOperatorToken string
+	additionalOperators  []OperatorKey
+	operatorsByID        map[uint64]*operator
+	operatorsByPubKey    map[string]*operator
+	shareOwners          *ShareOwners
+
+	slashingProtection        SlashingProtection
+	enforceSlashingProtection bool
+	interchangeStore          InterchangeStore
 }
 
 func NewServer(
@@ -61,24 +81,40 @@ func NewServer(
 	r := router.New()
 
 	server := &Server{
-		logger:          logger,
-		operatorPrivKey: operatorPrivKey,
-		remoteSigner:    remoteSigner,
-		router:          r,
+		logger:            logger,
+		remoteSigner:      remoteSigner,
+		router:            r,
+		operatorsByID:     make(map[uint64]*operator),
+		operatorsByPubKey: make(map[string]*operator),
 	}
 
 	for _, opt := range opts {
 		opt(server)
 	}
 
+	server.primaryOperator = server.addOperator(OperatorKey{
+		ID:      server.primaryOperatorID,
+		PrivKey: operatorPrivKey,
+		Token:   server.primaryOperatorToken,
+	})
+	for _, op := range server.additionalOperators {
+		server.addOperator(op)
+	}
+
 	r.GET(pathValidators, server.handleListValidators)
 	r.POST(pathValidators, server.handleAddValidator)
 	r.DELETE(pathValidators, server.handleRemoveValidator)
 	r.POST(pathValidatorsSign+"{identifier}", server.handleSignValidator)
 
+	r.GET(pathSlashingProtection+"{identifier}", server.handleGetSlashingProtection)
+	r.POST(pathSlashingProtection+"{identifier}", server.handleSetSlashingProtection)
+
 	r.GET(pathOperatorIdentity, server.handleOperatorIdentity)
 	r.POST(pathOperatorSign, server.handleSignOperator)
 
+	r.GET(pathSlashingProtectionInterchange, server.handleExportSlashingProtection)
+	r.POST(pathSlashingProtectionInterchange, server.handleImportSlashingProtection)
+
 	return server
 }
 
@@ -97,6 +133,167 @@ func WithTLS(tlsConfig *tls.Config) func(*Server) {
 	}
 }
 
+// WithInterchangeStore makes the server record the signing history of its shares in the given store,
+// and enables the endpoints exporting and importing it in the EIP-3076 interchange format.
+func WithInterchangeStore(store InterchangeStore) func(*Server) {
+	return func(s *Server) {
+		s.interchangeStore = store
+	}
+}
+
+// WithSlashingProtection makes the server keep its own slashing protection database
+// and exposes it through the slashing protection endpoints.
+//
+// If enforce is set, attestations and blocks are checked against it before being passed to web3signer,
+// and requests to sign slashable ones are rejected.
+func WithSlashingProtection(sp SlashingProtection, enforce bool) func(*Server) {
+	return func(s *Server) {
+		s.slashingProtection = sp
+		s.enforceSlashingProtection = enforce
+	}
+}
+
+// WithOperatorID sets the ID of the primary operator, so that clients can select it by ID as well.
+func WithOperatorID(id uint64) func(*Server) {
+	return func(s *Server) {
+		s.primaryOperatorID = id
+	}
+}
+
+// WithServerOperatorToken makes the server require the token from requests made on behalf of the primary operator,
+// including the ones which don't select an operator.
+func WithServerOperatorToken(token string) func(*Server) {
+	return func(s *Server) {
+		s.primaryOperatorToken = token
+	}
+}
+
+// WithAdditionalOperators makes the server host more operators besides the primary one.
+// Clients select the operator with HeaderOperator.
+func WithAdditionalOperators(operators ...OperatorKey) func(*Server) {
+	return func(s *Server) {
+		s.additionalOperators = append(s.additionalOperators, operators...)
+	}
+}
+
+// WithShareOwners makes the server record the operator each share is added by,
+// and scope listing, removing and signing with shares to the operator owning them.
+// Shares added before are considered to be owned by the primary operator.
+func WithShareOwners(shareOwners *ShareOwners) func(*Server) {
+	return func(s *Server) {
+		s.shareOwners = shareOwners
+	}
+}
+
+func (s *Server) addOperator(key OperatorKey) *operator {
+	op := &operator{
+		id:      key.ID,
+		privKey: key.PrivKey,
+		token:   key.Token,
+	}
+
+	pubKey, err := key.PrivKey.Public().Base64()
+	if err != nil {
+		s.logger.Error("failed to encode operator public key, it can't be selected by public key", zap.Error(err))
+	} else {
+		op.pubKey = pubKey
+		s.operatorsByPubKey[pubKey] = op
+	}
+
+	if key.ID != 0 {
+		s.operatorsByID[key.ID] = op
+	}
+
+	return op
+}
+
+// requestOperator returns the operator selected by the request's HeaderOperator, or the primary one if it's not set.
+// If the operator has a token, the request must present it in HeaderOperatorToken.
+func (s *Server) requestOperator(ctx *fasthttp.RequestCtx) (*operator, error) {
+	op, err := s.selectOperator(string(ctx.Request.Header.Peek(HeaderOperator)))
+	if err != nil {
+		return nil, err
+	}
+
+	if !op.authorized(ctx.Request.Header.Peek(HeaderOperatorToken)) {
+		return nil, fmt.Errorf("%w: %s", errOperatorUnauthorized, op)
+	}
+
+	return op, nil
+}
+
+func (s *Server) selectOperator(selector string) (*operator, error) {
+	if selector == "" {
+		return s.primaryOperator, nil
+	}
+
+	if id, err := strconv.ParseUint(selector, 10, 64); err == nil {
+		if op, ok := s.operatorsByID[id]; ok {
+			return op, nil
+		}
+	}
+	if op, ok := s.operatorsByPubKey[selector]; ok {
+		return op, nil
+	}
+
+	return nil, fmt.Errorf("%w %q", errUnknownOperator, selector)
+}
+
+// requestOperatorErrStatus returns the status to respond with to a request requestOperator failed for.
+func requestOperatorErrStatus(err error) int {
+	if errors.Is(err, errOperatorUnauthorized) {
+		return fasthttp.StatusUnauthorized
+	}
+	return fasthttp.StatusNotFound
+}
+
+// requestShare makes sure the share belongs to the request's operator,
+// and writes the error response otherwise.
+func (s *Server) requestShare(ctx *fasthttp.RequestCtx, logger *zap.Logger, pubKey phase0.BLSPubKey) bool {
+	op, err := s.requestOperator(ctx)
+	if err != nil {
+		logger.Warn("failed to select operator", zap.Error(err))
+		s.writeJSONErr(ctx, logger, requestOperatorErrStatus(err), err)
+		return false
+	}
+
+	return s.checkShareOwner(ctx, logger, op, pubKey)
+}
+
+// checkShareOwner makes sure the share belongs to the operator, and writes the error response otherwise.
+func (s *Server) checkShareOwner(ctx *fasthttp.RequestCtx, logger *zap.Logger, op *operator, pubKey phase0.BLSPubKey) bool {
+	owned, err := s.ownsShare(op, pubKey)
+	if err != nil {
+		logger.Error("failed to get share owner", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, err)
+		return false
+	}
+	if !owned {
+		logger.Warn("share belongs to another operator", zap.Stringer("operator", op))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusNotFound, errors.New("share not found"))
+		return false
+	}
+
+	return true
+}
+
+// ownsShare reports whether the share belongs to the operator.
+// Without ShareOwners, all operators share all shares.
+func (s *Server) ownsShare(op *operator, pubKey phase0.BLSPubKey) (bool, error) {
+	if s.shareOwners == nil {
+		return true, nil
+	}
+
+	owner, found, err := s.shareOwners.Owner(pubKey)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return op == s.primaryOperator, nil
+	}
+	return owner == op.pubKey, nil
+}
+
 func (s *Server) Handler() func(ctx *fasthttp.RequestCtx) {
 	return func(ctx *fasthttp.RequestCtx) {
 		start := time.Now()
@@ -148,6 +345,15 @@ func (s *Server) handleListValidators(ctx *fasthttp.RequestCtx) {
 	logger := s.logger.With(zap.String("method", "handleListValidators"))
 	logger.Debug("received request")
 
+	op, err := s.requestOperator(ctx)
+	if err != nil {
+		logger.Warn("failed to select operator", zap.Error(err))
+		s.writeJSONErr(ctx, logger, requestOperatorErrStatus(err), err)
+		return
+	}
+
+	logger = logger.With(zap.Stringer("operator", op))
+
 	start := time.Now()
 	resp, err := s.remoteSigner.ListKeys(ctx)
 	recordRemoteSignerOperation(ctx, opRemoteSignerListKeys, err, time.Since(start))
@@ -157,6 +363,22 @@ func (s *Server) handleListValidators(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	if s.shareOwners != nil {
+		owned := make(web3signer.ListKeysResponse, 0, len(resp))
+		for _, pubKey := range resp {
+			ok, err := s.ownsShare(op, pubKey)
+			if err != nil {
+				logger.Error("failed to get share owner", zap.Error(err))
+				s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, err)
+				return
+			}
+			if ok {
+				owned = append(owned, pubKey)
+			}
+		}
+		resp = owned
+	}
+
 	logger.Info("request finished successfully", fields.Count(len(resp)))
 	s.writeJSON(ctx, logger, resp)
 }
@@ -165,6 +387,15 @@ func (s *Server) handleAddValidator(ctx *fasthttp.RequestCtx) {
 	logger := s.logger.With(zap.String("method", "handleAddValidator"))
 	logger.Debug("received request")
 
+	op, err := s.requestOperator(ctx)
+	if err != nil {
+		logger.Warn("failed to select operator", zap.Error(err))
+		s.writeJSONErr(ctx, logger, requestOperatorErrStatus(err), err)
+		return
+	}
+
+	logger = logger.With(zap.Stringer("operator", op))
+
 	var req AddValidatorRequest
 	if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
 		logger.Warn("failed to unmarshal request body", zap.Error(err))
@@ -181,7 +412,26 @@ func (s *Server) handleAddValidator(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	var importKeystoreReq web3signer.ImportKeystoreRequest
+	if req.SlashingProtection != "" {
+		doc, err := s.validateSlashingProtection(req)
+		if err != nil {
+			logger.Warn("invalid slashing protection data", zap.Error(err))
+			s.writeJSONErr(ctx, logger, fasthttp.StatusBadRequest, fmt.Errorf("invalid slashing protection data: %w", err))
+			return
+		}
+
+		if s.interchangeStore != nil {
+			if err := s.interchangeStore.ImportInterchange(doc); err != nil {
+				logger.Error("failed to record slashing protection data", zap.Error(err))
+				s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, fmt.Errorf("record slashing protection data: %w", err))
+				return
+			}
+		}
+	}
+
+	importKeystoreReq := web3signer.ImportKeystoreRequest{
+		SlashingProtection: req.SlashingProtection,
+	}
 	for i, share := range req.ShareKeys {
 		logger := logger.With(zap.Stringer("share_pubkey", share.PubKey))
 
@@ -200,6 +450,7 @@ func (s *Server) handleAddValidator(ctx *fasthttp.RequestCtx) {
 		}
 
 		keystoreJSON, err := s.keystoreJSONFromEncryptedShare(
+			op.privKey,
 			share.EncryptedPrivKey,
 			share.PubKey,
 			keystorePassword,
@@ -242,18 +493,96 @@ func (s *Server) handleAddValidator(ctx *fasthttp.RequestCtx) {
 		}
 	}
 
+	if s.shareOwners != nil {
+		for i, data := range resp.Data {
+			if data.Status != web3signer.StatusImported && data.Status != web3signer.StatusDuplicated {
+				continue
+			}
+			// The share could be decrypted with the operator's key, so it belongs to the operator.
+			if err := s.shareOwners.SetOwner(req.ShareKeys[i].PubKey, op.pubKey); err != nil {
+				logger.Error("failed to set share owner", zap.Error(err))
+				s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+
+	if s.slashingProtection != nil {
+		if err := s.initSlashingProtection(req, resp); err != nil {
+			// The keystores are already in web3signer, so the shares must not be signed with until this is fixed.
+			logger.Error("failed to initialize slashing protection", zap.Error(err))
+			s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, fmt.Errorf("initialize slashing protection: %w", err))
+			return
+		}
+	}
+
 	logger.Info("request finished successfully", zap.Int("imported_count", importedCount))
 	s.writeJSON(ctx, logger, resp)
 }
 
+// validateSlashingProtection makes sure the request's interchange document is well-formed
+// and only carries history for the shares being added.
+func (s *Server) validateSlashingProtection(req AddValidatorRequest) (*interchange.Interchange, error) {
+	doc, err := interchange.Parse([]byte(req.SlashingProtection))
+	if err != nil {
+		return nil, err
+	}
+
+	requested := make(map[phase0.BLSPubKey]struct{}, len(req.ShareKeys))
+	for _, share := range req.ShareKeys {
+		requested[share.PubKey] = struct{}{}
+	}
+
+	for _, pubKey := range doc.PubKeys() {
+		if _, ok := requested[pubKey]; !ok {
+			return nil, fmt.Errorf("share %s is not being added", pubKey.String())
+		}
+	}
+
+	return doc, nil
+}
+
+// initSlashingProtection sets up the slashing protection of the shares web3signer has accepted,
+// raising it to the history from the request's interchange document, if any.
+func (s *Server) initSlashingProtection(req AddValidatorRequest, resp web3signer.ImportKeystoreResponse) error {
+	var history map[phase0.BLSPubKey]SlashingProtectionData
+	if req.SlashingProtection != "" {
+		var err error
+		history, err = SlashingProtectionFromInterchange([]byte(req.SlashingProtection))
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, data := range resp.Data {
+		if data.Status != web3signer.StatusImported && data.Status != web3signer.StatusDuplicated {
+			continue
+		}
+
+		pubKey := req.ShareKeys[i].PubKey
+		if err := s.slashingProtection.InitShare(pubKey); err != nil {
+			return fmt.Errorf("share %s: %w", pubKey.String(), err)
+		}
+
+		if h, ok := history[pubKey]; ok && (h.HighestAttestation != nil || h.HighestProposalSlot != nil) {
+			if err := s.slashingProtection.RaiseHighestSigned(pubKey, h); err != nil {
+				return fmt.Errorf("share %s: %w", pubKey.String(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // keystoreJSONFromEncryptedShare doesn't pass errors through intentionally
 // to prevent exposing information related to private key.
 func (s *Server) keystoreJSONFromEncryptedShare(
+	operatorPrivKey keys.OperatorDecrypter,
 	encryptedPrivKey hexutil.Bytes,
 	sharePubKey phase0.BLSPubKey,
 	keystorePassword string,
 ) (string, error) {
-	sharePrivKeyHex, err := s.operatorPrivKey.Decrypt(encryptedPrivKey)
+	sharePrivKeyHex, err := operatorPrivKey.Decrypt(encryptedPrivKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to decrypt share")
 	}
@@ -303,6 +632,15 @@ func (s *Server) handleRemoveValidator(ctx *fasthttp.RequestCtx) {
 	logger := s.logger.With(zap.String("method", "handleRemoveValidator"))
 	logger.Debug("received request")
 
+	op, err := s.requestOperator(ctx)
+	if err != nil {
+		logger.Warn("failed to select operator", zap.Error(err))
+		s.writeJSONErr(ctx, logger, requestOperatorErrStatus(err), err)
+		return
+	}
+
+	logger = logger.With(zap.Stringer("operator", op))
+
 	var req web3signer.DeleteKeystoreRequest
 	if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
 		logger.Warn("failed to unmarshal request body", zap.Error(err))
@@ -312,9 +650,14 @@ func (s *Server) handleRemoveValidator(ctx *fasthttp.RequestCtx) {
 
 	logger = logger.With(zap.Int("req_count", len(req.Pubkeys)))
 
-	start := time.Now()
-	resp, err := s.remoteSigner.DeleteKeystore(ctx, req)
-	recordRemoteSignerOperation(ctx, opRemoteSignerDeleteKeystore, err, time.Since(start))
+	var resp web3signer.DeleteKeystoreResponse
+	if s.shareOwners != nil {
+		resp, err = s.deleteOwnedKeystores(ctx, op, req)
+	} else {
+		start := time.Now()
+		resp, err = s.remoteSigner.DeleteKeystore(ctx, req)
+		recordRemoteSignerOperation(ctx, opRemoteSignerDeleteKeystore, err, time.Since(start))
+	}
 	if err != nil {
 		s.handleWeb3SignerErr(ctx, logger, resp, err)
 		return
@@ -322,6 +665,17 @@ func (s *Server) handleRemoveValidator(ctx *fasthttp.RequestCtx) {
 
 	logger = logger.With(zap.Int("resp_count", len(resp.Data)))
 
+	// Keep the history of the removed shares, so it can still be exported once they're gone from web3signer.
+	if s.interchangeStore != nil && resp.SlashingProtection != "" {
+		doc, err := interchange.Parse([]byte(resp.SlashingProtection))
+		if err == nil {
+			err = s.interchangeStore.ImportInterchange(doc)
+		}
+		if err != nil {
+			logger.Error("failed to record slashing protection data of removed shares", zap.Error(err))
+		}
+	}
+
 	var deletedCount int
 	for i, data := range resp.Data {
 		if data.Status != web3signer.StatusDeleted {
@@ -339,6 +693,68 @@ func (s *Server) handleRemoveValidator(ctx *fasthttp.RequestCtx) {
 	s.writeJSON(ctx, logger, resp)
 }
 
+// deleteOwnedKeystores deletes only the keystores of the shares owned by the operator,
+// the others are reported as not found.
+func (s *Server) deleteOwnedKeystores(
+	ctx *fasthttp.RequestCtx,
+	op *operator,
+	req web3signer.DeleteKeystoreRequest,
+) (web3signer.DeleteKeystoreResponse, error) {
+	owned := make([]bool, len(req.Pubkeys))
+	var ownedReq web3signer.DeleteKeystoreRequest
+	for i, pubKey := range req.Pubkeys {
+		ok, err := s.ownsShare(op, pubKey)
+		if err != nil {
+			return web3signer.DeleteKeystoreResponse{}, fmt.Errorf("get share owner: %w", err)
+		}
+		owned[i] = ok
+		if ok {
+			ownedReq.Pubkeys = append(ownedReq.Pubkeys, pubKey)
+		}
+	}
+
+	var ownedResp web3signer.DeleteKeystoreResponse
+	if len(ownedReq.Pubkeys) > 0 {
+		start := time.Now()
+		var err error
+		ownedResp, err = s.remoteSigner.DeleteKeystore(ctx, ownedReq)
+		recordRemoteSignerOperation(ctx, opRemoteSignerDeleteKeystore, err, time.Since(start))
+		if err != nil {
+			return ownedResp, err
+		}
+		if len(ownedResp.Data) != len(ownedReq.Pubkeys) {
+			return web3signer.DeleteKeystoreResponse{}, fmt.Errorf("unexpected statuses length, got %d, expected %d",
+				len(ownedResp.Data), len(ownedReq.Pubkeys))
+		}
+	}
+
+	resp := web3signer.DeleteKeystoreResponse{
+		Data:               make([]web3signer.KeyManagerResponseData, 0, len(req.Pubkeys)),
+		SlashingProtection: ownedResp.SlashingProtection,
+	}
+	next := 0
+	for i, pubKey := range req.Pubkeys {
+		if !owned[i] {
+			resp.Data = append(resp.Data, web3signer.KeyManagerResponseData{
+				Status:  web3signer.StatusNotFound,
+				Message: "share belongs to another operator",
+			})
+			continue
+		}
+
+		data := ownedResp.Data[next]
+		next++
+		if data.Status == web3signer.StatusDeleted || data.Status == web3signer.StatusNotFound {
+			if err := s.shareOwners.RemoveOwner(pubKey); err != nil {
+				return web3signer.DeleteKeystoreResponse{}, err
+			}
+		}
+		resp.Data = append(resp.Data, data)
+	}
+
+	return resp, nil
+}
+
 func (s *Server) handleSignValidator(ctx *fasthttp.RequestCtx) {
 	logger := s.logger.With(zap.String("method", "handleSignValidator"))
 	logger.Debug("received request")
@@ -353,6 +769,10 @@ func (s *Server) handleSignValidator(ctx *fasthttp.RequestCtx) {
 
 	logger = logger.With(fields.PubKey(blsPubKey[:]))
 
+	if !s.requestShare(ctx, logger, blsPubKey) {
+		return
+	}
+
 	var req web3signer.SignRequest
 	if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
 		logger.Warn("failed to unmarshal request body", zap.Error(err))
@@ -362,6 +782,20 @@ func (s *Server) handleSignValidator(ctx *fasthttp.RequestCtx) {
 
 	logger = logger.With(zap.String("type", string(req.Type)))
 
+	if s.enforceSlashingProtection {
+		if err := CheckSignRequest(s.slashingProtection, blsPubKey, req); err != nil {
+			if errors.Is(err, ErrSlashable) {
+				// web3signer responds with the same status to slashable requests.
+				logger.Warn("rejected slashable request", zap.Error(err))
+				s.writeJSONErr(ctx, logger, fasthttp.StatusPreconditionFailed, err)
+				return
+			}
+			logger.Error("failed to check slashing protection", zap.Error(err))
+			s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, fmt.Errorf("check slashing protection: %w", err))
+			return
+		}
+	}
+
 	start := time.Now()
 	resp, err := s.remoteSigner.Sign(ctx, blsPubKey, req)
 	recordRemoteSignerOperation(ctx, opRemoteSignerValidatorSign, err, time.Since(start))
@@ -374,6 +808,91 @@ func (s *Server) handleSignValidator(ctx *fasthttp.RequestCtx) {
 	s.writeJSON(ctx, logger, resp)
 }
 
+func (s *Server) handleGetSlashingProtection(ctx *fasthttp.RequestCtx) {
+	logger := s.logger.With(zap.String("method", "handleGetSlashingProtection"))
+	logger.Debug("received request")
+
+	if s.slashingProtection == nil {
+		s.writeJSONErr(ctx, logger, fasthttp.StatusNotFound, errors.New("slashing protection is not enabled"))
+		return
+	}
+
+	blsPubKey, err := s.extractShareKey(ctx.UserValue("identifier"))
+	if err != nil {
+		logger.Warn("failed to extract share key", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusBadRequest, fmt.Errorf("extract share key: %w", err))
+		return
+	}
+
+	logger = logger.With(fields.PubKey(blsPubKey[:]))
+
+	if !s.requestShare(ctx, logger, blsPubKey) {
+		return
+	}
+
+	data, err := s.slashingProtection.HighestSigned(blsPubKey)
+	if err != nil {
+		logger.Error("failed to get slashing protection data", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, err)
+		return
+	}
+
+	logger.Info("request finished successfully")
+	s.writeJSON(ctx, logger, data)
+}
+
+func (s *Server) handleSetSlashingProtection(ctx *fasthttp.RequestCtx) {
+	logger := s.logger.With(zap.String("method", "handleSetSlashingProtection"))
+	logger.Debug("received request")
+
+	if s.slashingProtection == nil {
+		s.writeJSONErr(ctx, logger, fasthttp.StatusNotFound, errors.New("slashing protection is not enabled"))
+		return
+	}
+
+	blsPubKey, err := s.extractShareKey(ctx.UserValue("identifier"))
+	if err != nil {
+		logger.Warn("failed to extract share key", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusBadRequest, fmt.Errorf("extract share key: %w", err))
+		return
+	}
+
+	logger = logger.With(fields.PubKey(blsPubKey[:]))
+
+	if !s.requestShare(ctx, logger, blsPubKey) {
+		return
+	}
+
+	var req SlashingProtectionData
+	if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+		logger.Warn("failed to unmarshal request body", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusBadRequest, fmt.Errorf("failed to parse request: %w", err))
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		logger.Warn("invalid slashing protection data", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusBadRequest, fmt.Errorf("invalid slashing protection data: %w", err))
+		return
+	}
+
+	if err := s.slashingProtection.RaiseHighestSigned(blsPubKey, req); err != nil {
+		logger.Error("failed to set slashing protection data", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, err)
+		return
+	}
+
+	data, err := s.slashingProtection.HighestSigned(blsPubKey)
+	if err != nil {
+		logger.Error("failed to get slashing protection data", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, err)
+		return
+	}
+
+	logger.Info("request finished successfully")
+	s.writeJSON(ctx, logger, data)
+}
+
 func (s *Server) extractShareKey(identifierValue any) (phase0.BLSPubKey, error) {
 	sharePubKeyHex, ok := identifierValue.(string)
 	if !ok {
@@ -396,7 +915,14 @@ func (s *Server) handleOperatorIdentity(ctx *fasthttp.RequestCtx) {
 	logger := s.logger.With(zap.String("method", "handleOperatorIdentity"))
 	logger.Debug("received request")
 
-	pubKeyB64, err := s.operatorPrivKey.Public().Base64()
+	op, err := s.requestOperator(ctx)
+	if err != nil {
+		logger.Warn("failed to select operator", zap.Error(err))
+		s.writeJSONErr(ctx, logger, requestOperatorErrStatus(err), err)
+		return
+	}
+
+	pubKeyB64, err := op.privKey.Public().Base64()
 	if err != nil {
 		logger.Error("request failed", zap.Error(err))
 		s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, err)
@@ -424,7 +950,16 @@ func (s *Server) handleSignOperator(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	signature, err := s.operatorPrivKey.Sign(payload)
+	op, err := s.requestOperator(ctx)
+	if err != nil {
+		logger.Warn("failed to select operator", zap.Error(err))
+		s.writeJSONErr(ctx, logger, requestOperatorErrStatus(err), err)
+		return
+	}
+
+	logger = logger.With(zap.Stringer("operator", op))
+
+	signature, err := op.privKey.Sign(payload)
 	if err != nil {
 		logger.Error("request failed", zap.Error(err))
 		s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, err)
@@ -436,6 +971,132 @@ func (s *Server) handleSignOperator(ctx *fasthttp.RequestCtx) {
 	s.writeBytes(ctx, logger, signature)
 }
 
+func (s *Server) handleExportSlashingProtection(ctx *fasthttp.RequestCtx) {
+	logger := s.logger.With(zap.String("method", "handleExportSlashingProtection"))
+	logger.Debug("received request")
+
+	if s.interchangeStore == nil {
+		s.writeJSONErr(ctx, logger, fasthttp.StatusNotFound, errSlashingProtectionHistoryDisabled)
+		return
+	}
+
+	op, err := s.requestOperator(ctx)
+	if err != nil {
+		logger.Warn("failed to select operator", zap.Error(err))
+		s.writeJSONErr(ctx, logger, requestOperatorErrStatus(err), err)
+		return
+	}
+
+	logger = logger.With(zap.Stringer("operator", op))
+
+	args := ctx.QueryArgs()
+
+	genesisValidatorsRoot, err := parseRoot(string(args.Peek("genesis_validators_root")))
+	if err != nil {
+		logger.Warn("invalid genesis validators root", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusBadRequest, fmt.Errorf("invalid genesis validators root: %w", err))
+		return
+	}
+
+	var pubKeys []phase0.BLSPubKey
+	if pubKeysParam := string(args.Peek("pubkeys")); pubKeysParam != "" {
+		for _, pubKeyHex := range strings.Split(pubKeysParam, ",") {
+			pubKey, err := s.extractShareKey(pubKeyHex)
+			if err != nil {
+				logger.Warn("invalid share public key", zap.Error(err))
+				s.writeJSONErr(ctx, logger, fasthttp.StatusBadRequest, fmt.Errorf("extract share key: %w", err))
+				return
+			}
+			if !s.checkShareOwner(ctx, logger, op, pubKey) {
+				return
+			}
+			pubKeys = append(pubKeys, pubKey)
+		}
+	} else {
+		start := time.Now()
+		listResp, err := s.remoteSigner.ListKeys(ctx)
+		recordRemoteSignerOperation(ctx, opRemoteSignerListKeys, err, time.Since(start))
+		if err != nil {
+			s.handleWeb3SignerErr(ctx, logger, listResp, err)
+			return
+		}
+		for _, pubKey := range listResp {
+			ok, err := s.ownsShare(op, pubKey)
+			if err != nil {
+				logger.Error("failed to get share owner", zap.Error(err))
+				s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, err)
+				return
+			}
+			if ok {
+				pubKeys = append(pubKeys, pubKey)
+			}
+		}
+	}
+
+	doc, err := s.interchangeStore.ExportInterchange(genesisValidatorsRoot, pubKeys)
+	if err != nil {
+		logger.Error("failed to export slashing protection data", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, fmt.Errorf("export slashing protection data: %w", err))
+		return
+	}
+
+	logger.Info("request finished successfully", fields.Count(len(doc.Data)))
+	s.writeJSON(ctx, logger, doc)
+}
+
+func (s *Server) handleImportSlashingProtection(ctx *fasthttp.RequestCtx) {
+	logger := s.logger.With(zap.String("method", "handleImportSlashingProtection"))
+	logger.Debug("received request")
+
+	if s.interchangeStore == nil {
+		s.writeJSONErr(ctx, logger, fasthttp.StatusNotFound, errSlashingProtectionHistoryDisabled)
+		return
+	}
+
+	op, err := s.requestOperator(ctx)
+	if err != nil {
+		logger.Warn("failed to select operator", zap.Error(err))
+		s.writeJSONErr(ctx, logger, requestOperatorErrStatus(err), err)
+		return
+	}
+
+	logger = logger.With(zap.Stringer("operator", op))
+
+	doc, err := interchange.Parse(ctx.PostBody())
+	if err != nil {
+		logger.Warn("invalid slashing protection data", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusBadRequest, fmt.Errorf("invalid slashing protection data: %w", err))
+		return
+	}
+
+	// Only the operator owning a share may raise its history, otherwise it could stop other operators from signing.
+	for _, data := range doc.Data {
+		if !s.checkShareOwner(ctx, logger, op, data.PubKey) {
+			return
+		}
+	}
+
+	if err := s.interchangeStore.ImportInterchange(doc); err != nil {
+		logger.Error("failed to import slashing protection data", zap.Error(err))
+		s.writeJSONErr(ctx, logger, fasthttp.StatusInternalServerError, fmt.Errorf("import slashing protection data: %w", err))
+		return
+	}
+
+	logger.Info("request finished successfully", fields.Count(len(doc.Data)))
+	ctx.SetStatusCode(fasthttp.StatusNoContent)
+}
+
+func parseRoot(rootHex string) (phase0.Root, error) {
+	root, err := hex.DecodeString(strings.TrimPrefix(rootHex, "0x"))
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("decode hex: %w", err)
+	}
+	if len(root) != len(phase0.Root{}) {
+		return phase0.Root{}, fmt.Errorf("invalid length %d, expected %d", len(root), len(phase0.Root{}))
+	}
+	return phase0.Root(root), nil
+}
+
 func (s *Server) handleWeb3SignerErr(ctx *fasthttp.RequestCtx, logger *zap.Logger, resp any, err error) {
 	statusCode := fasthttp.StatusInternalServerError
 	if he := new(web3signer.HTTPResponseError); errors.As(err, &he) {
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"unicode"
 
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/assert"
@@ -17,8 +18,12 @@ import (
 	"github.com/valyala/fasthttp"
 	"go.uber.org/zap"
 
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+
 	"github.com/ssvlabs/ssv/ssvsigner/internal/mocks"
 
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
 	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
 )
 
@@ -79,12 +84,27 @@ func (s *ServerTestSuite) SetupTest() {
 }
 
 func (s *ServerTestSuite) ServeHTTP(method, path string, body []byte) (*fasthttp.Response, error) {
+	return s.ServeHTTPAsOperator("", method, path, body)
+}
+
+func (s *ServerTestSuite) ServeHTTPAsOperator(operator, method, path string, body []byte) (*fasthttp.Response, error) {
+	return s.ServeHTTPAsOperatorWithToken(operator, "", method, path, body)
+}
+
+func (s *ServerTestSuite) ServeHTTPAsOperatorWithToken(operator, token, method, path string, body []byte) (*fasthttp.Response, error) {
 	req := fasthttp.AcquireRequest()
 	defer fasthttp.ReleaseRequest(req)
 
 	req.Header.SetMethod(method)
 	req.SetRequestURI(path)
 
+	if operator != "" {
+		req.Header.Set(HeaderOperator, operator)
+	}
+	if token != "" {
+		req.Header.Set(HeaderOperatorToken, token)
+	}
+
 	if len(body) > 0 {
 		req.SetBody(body)
 		req.Header.SetContentType("application/json")
@@ -256,6 +276,56 @@ func (s *ServerTestSuite) TestAddValidator() {
 		s.remoteSigner.ImportError = nil
 	})
 
+	t.Run("with slashing protection", func(t *testing.T) {
+		doc := interchange.New(phase0.Root{1})
+		doc.Data = append(doc.Data, interchange.Validator{
+			PubKey:       phase0.BLSPubKey(pubKey),
+			SignedBlocks: []interchange.SignedBlock{{Slot: 10}},
+		})
+		docJSON, err := doc.Marshal()
+		require.NoError(t, err)
+
+		withSP := request
+		withSP.SlashingProtection = string(docJSON)
+		withSPBody, err := json.Marshal(withSP)
+		require.NoError(t, err)
+
+		resp, err := s.ServeHTTP("POST", pathValidators, withSPBody)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+		assert.Equal(t, string(docJSON), s.remoteSigner.ImportRequest.SlashingProtection)
+	})
+
+	t.Run("slashing protection for unknown share", func(t *testing.T) {
+		doc := interchange.New(phase0.Root{1})
+		doc.Data = append(doc.Data, interchange.Validator{
+			PubKey:       phase0.BLSPubKey{1, 2, 3},
+			SignedBlocks: []interchange.SignedBlock{{Slot: 10}},
+		})
+		docJSON, err := doc.Marshal()
+		require.NoError(t, err)
+
+		withSP := request
+		withSP.SlashingProtection = string(docJSON)
+		withSPBody, err := json.Marshal(withSP)
+		require.NoError(t, err)
+
+		resp, err := s.ServeHTTP("POST", pathValidators, withSPBody)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("malformed slashing protection", func(t *testing.T) {
+		withSP := request
+		withSP.SlashingProtection = "{invalid"
+		withSPBody, err := json.Marshal(withSP)
+		require.NoError(t, err)
+
+		resp, err := s.ServeHTTP("POST", pathValidators, withSPBody)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusBadRequest, resp.StatusCode())
+	})
+
 	t.Run("too many shares", func(t *testing.T) {
 		tooBigRequest := request
 		for i := 0; i < addShareLimit*2; i++ {
@@ -426,6 +496,501 @@ func (s *ServerTestSuite) TestOperatorSign() {
 	})
 }
 
+type testInterchangeStore struct {
+	docs      map[phase0.BLSPubKey]interchange.Validator
+	importErr error
+}
+
+func (t *testInterchangeStore) ExportInterchange(gvr phase0.Root, pubKeys []phase0.BLSPubKey) (*interchange.Interchange, error) {
+	doc := interchange.New(gvr)
+	for _, pubKey := range pubKeys {
+		if v, ok := t.docs[pubKey]; ok {
+			doc.Data = append(doc.Data, v)
+		}
+	}
+	return doc, nil
+}
+
+func (t *testInterchangeStore) ImportInterchange(doc *interchange.Interchange) error {
+	if t.importErr != nil {
+		return t.importErr
+	}
+	for _, v := range doc.Data {
+		t.docs[v.PubKey] = v
+	}
+	return nil
+}
+
+func (s *ServerTestSuite) TestSlashingProtectionInterchange() {
+	t := s.T()
+
+	gvr := phase0.Root{1, 2, 3}
+	doc := interchange.New(gvr)
+	doc.Data = append(doc.Data, interchange.Validator{
+		PubKey:             phase0.BLSPubKey{1, 2, 3},
+		SignedBlocks:       []interchange.SignedBlock{{Slot: 10}},
+		SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+	})
+	body, err := doc.Marshal()
+	require.NoError(t, err)
+
+	exportPath := pathSlashingProtectionInterchange + "?genesis_validators_root=" + gvr.String()
+
+	t.Run("disabled", func(t *testing.T) {
+		resp, err := s.ServeHTTP("GET", exportPath, nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusNotFound, resp.StatusCode())
+
+		resp, err = s.ServeHTTP("POST", pathSlashingProtectionInterchange, body)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusNotFound, resp.StatusCode())
+	})
+
+	store := &testInterchangeStore{docs: map[phase0.BLSPubKey]interchange.Validator{}}
+	s.server = NewServer(s.logger, s.operatorPrivKey, s.remoteSigner, WithInterchangeStore(store))
+
+	t.Run("import", func(t *testing.T) {
+		resp, err := s.ServeHTTP("POST", pathSlashingProtectionInterchange, body)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusNoContent, resp.StatusCode())
+		assert.Contains(t, store.docs, phase0.BLSPubKey{1, 2, 3})
+	})
+
+	t.Run("import invalid document", func(t *testing.T) {
+		resp, err := s.ServeHTTP("POST", pathSlashingProtectionInterchange, []byte(`{"metadata":{"interchange_format_version":"4"}}`))
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("import store error", func(t *testing.T) {
+		store.importErr = errors.New("store error")
+		defer func() { store.importErr = nil }()
+
+		resp, err := s.ServeHTTP("POST", pathSlashingProtectionInterchange, body)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusInternalServerError, resp.StatusCode())
+	})
+
+	t.Run("export all shares", func(t *testing.T) {
+		resp, err := s.ServeHTTP("GET", exportPath, nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+
+		exported, err := interchange.Parse(resp.Body())
+		require.NoError(t, err)
+		assert.Equal(t, gvr, exported.Metadata.GenesisValidatorsRoot)
+		assert.Equal(t, doc.Data, exported.Data)
+	})
+
+	t.Run("export selected shares", func(t *testing.T) {
+		resp, err := s.ServeHTTP("GET", exportPath+"&pubkeys="+phase0.BLSPubKey{4, 5, 6}.String(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+
+		exported, err := interchange.Parse(resp.Body())
+		require.NoError(t, err)
+		assert.Empty(t, exported.Data)
+	})
+
+	t.Run("export invalid genesis validators root", func(t *testing.T) {
+		resp, err := s.ServeHTTP("GET", pathSlashingProtectionInterchange+"?genesis_validators_root=0x1234", nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("export invalid pubkey", func(t *testing.T) {
+		resp, err := s.ServeHTTP("GET", exportPath+"&pubkeys=0x1234", nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusBadRequest, resp.StatusCode())
+	})
+}
+
+func (s *ServerTestSuite) TestSlashingProtection() {
+	t := s.T()
+
+	pubKeyHex := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	t.Run("not enabled", func(t *testing.T) {
+		resp, err := s.ServeHTTP("GET", pathSlashingProtection+pubKeyHex, nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusNotFound, resp.StatusCode())
+	})
+
+	sp := newTestSlashingProtection()
+	s.server = NewServer(s.logger, s.operatorPrivKey, s.remoteSigner, WithSlashingProtection(sp, true))
+
+	t.Run("get empty", func(t *testing.T) {
+		resp, err := s.ServeHTTP("GET", pathSlashingProtection+pubKeyHex, nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+		assert.JSONEq(t, `{}`, string(resp.Body()))
+	})
+
+	t.Run("set", func(t *testing.T) {
+		resp, err := s.ServeHTTP("POST", pathSlashingProtection+pubKeyHex,
+			[]byte(`{"highest_attestation":{"source_epoch":9,"target_epoch":10},"highest_proposal_slot":100}`))
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+		assert.JSONEq(t, `{"highest_attestation":{"source_epoch":9,"target_epoch":10},"highest_proposal_slot":100}`, string(resp.Body()))
+
+		// Lower values don't weaken the protection.
+		resp, err = s.ServeHTTP("POST", pathSlashingProtection+pubKeyHex, []byte(`{"highest_proposal_slot":50}`))
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+		assert.JSONEq(t, `{"highest_attestation":{"source_epoch":9,"target_epoch":10},"highest_proposal_slot":100}`, string(resp.Body()))
+	})
+
+	t.Run("set invalid", func(t *testing.T) {
+		for _, body := range []string{
+			`{invalid json}`,
+			`{}`,
+			`{"highest_proposal_slot":0}`,
+			`{"highest_attestation":{"source_epoch":11,"target_epoch":10}}`,
+		} {
+			resp, err := s.ServeHTTP("POST", pathSlashingProtection+pubKeyHex, []byte(body))
+			require.NoError(t, err)
+			assert.Equal(t, fasthttp.StatusBadRequest, resp.StatusCode(), body)
+		}
+	})
+
+	t.Run("invalid public key", func(t *testing.T) {
+		resp, err := s.ServeHTTP("GET", pathSlashingProtection+"invalid", nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusBadRequest, resp.StatusCode())
+	})
+
+	sign := func(req web3signer.SignRequest) int {
+		reqBody, err := json.Marshal(req)
+		require.NoError(t, err)
+		resp, err := s.ServeHTTP("POST", pathValidatorsSign+pubKeyHex, reqBody)
+		require.NoError(t, err)
+		return resp.StatusCode()
+	}
+
+	t.Run("enforce attestation", func(t *testing.T) {
+		req := web3signer.SignRequest{
+			Type: web3signer.TypeAttestation,
+			Attestation: &phase0.AttestationData{
+				Source: &phase0.Checkpoint{Epoch: 10},
+				Target: &phase0.Checkpoint{Epoch: 11},
+			},
+		}
+		assert.Equal(t, fasthttp.StatusOK, sign(req))
+		assert.Equal(t, fasthttp.StatusPreconditionFailed, sign(req))
+
+		req.Attestation = nil
+		assert.Equal(t, fasthttp.StatusPreconditionFailed, sign(req))
+	})
+
+	t.Run("enforce block", func(t *testing.T) {
+		req := web3signer.SignRequest{
+			Type: web3signer.TypeBlockV2,
+			BeaconBlock: &web3signer.BeaconBlockData{
+				Version:     web3signer.DataVersion(spec.DataVersionDeneb),
+				BlockHeader: &phase0.BeaconBlockHeader{Slot: 101},
+			},
+		}
+		assert.Equal(t, fasthttp.StatusOK, sign(req))
+		assert.Equal(t, fasthttp.StatusPreconditionFailed, sign(req))
+	})
+
+	t.Run("not slashable type", func(t *testing.T) {
+		assert.Equal(t, fasthttp.StatusOK, sign(web3signer.SignRequest{Type: web3signer.TypeRandaoReveal}))
+	})
+
+	t.Run("add validator", func(t *testing.T) {
+		sk := new(bls.SecretKey)
+		sk.SetByCSPRNG()
+		sharePubKey := phase0.BLSPubKey(sk.GetPublicKey().Serialize())
+		s.operatorPrivKey.DecryptResult = []byte("0x" + hex.EncodeToString(sk.Serialize()))
+
+		doc := interchange.New(phase0.Root{})
+		doc.Data = append(doc.Data, interchange.Validator{
+			PubKey:       sharePubKey,
+			SignedBlocks: []interchange.SignedBlock{{Slot: 200}},
+		})
+		docJSON, err := doc.Marshal()
+		require.NoError(t, err)
+
+		reqBody, err := json.Marshal(AddValidatorRequest{
+			ShareKeys:          []ShareKeys{{EncryptedPrivKey: []byte("encrypted_key"), PubKey: sharePubKey}},
+			SlashingProtection: string(docJSON),
+		})
+		require.NoError(t, err)
+
+		resp, err := s.ServeHTTP("POST", pathValidators, reqBody)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+
+		assert.Contains(t, sp.initialized, sharePubKey)
+		require.NotNil(t, sp.data[sharePubKey].HighestProposalSlot)
+		assert.EqualValues(t, 200, *sp.data[sharePubKey].HighestProposalSlot)
+	})
+}
+
+func (s *ServerTestSuite) TestOperators() {
+	t := s.T()
+
+	db, err := kv.NewInMemory(s.logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	otherOperatorKey := &mocks.TestOperatorPrivateKey{
+		PublicKey:  &mocks.TestOperatorPublicKey{PubKeyBase64: "other_pubkey_base64"},
+		SignResult: []byte("other_signature_bytes"),
+	}
+
+	sp := newTestSlashingProtection()
+	store := &testInterchangeStore{docs: map[phase0.BLSPubKey]interchange.Validator{}}
+	s.server = NewServer(s.logger, s.operatorPrivKey, s.remoteSigner,
+		WithOperatorID(1),
+		WithAdditionalOperators(OperatorKey{ID: 2, PrivKey: otherOperatorKey}),
+		WithShareOwners(NewShareOwners(db)),
+		WithSlashingProtection(sp, false),
+		WithInterchangeStore(store),
+	)
+
+	t.Run("identity", func(t *testing.T) {
+		for operator, expected := range map[string]string{
+			"":                    "test_pubkey_base64",
+			"1":                   "test_pubkey_base64",
+			"2":                   "other_pubkey_base64",
+			"other_pubkey_base64": "other_pubkey_base64",
+		} {
+			resp, err := s.ServeHTTPAsOperator(operator, "GET", pathOperatorIdentity, nil)
+			require.NoError(t, err)
+			assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+			assert.Equal(t, expected, string(resp.Body()))
+		}
+	})
+
+	t.Run("sign", func(t *testing.T) {
+		resp, err := s.ServeHTTPAsOperator("2", "POST", pathOperatorSign, []byte("payload"))
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+		assert.Equal(t, "other_signature_bytes", string(resp.Body()))
+	})
+
+	t.Run("unknown operator", func(t *testing.T) {
+		resp, err := s.ServeHTTPAsOperator("3", "GET", pathOperatorIdentity, nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusNotFound, resp.StatusCode())
+	})
+
+	sk := new(bls.SecretKey)
+	sk.SetByCSPRNG()
+	sharePubKey := phase0.BLSPubKey(sk.GetPublicKey().Serialize())
+	otherOperatorKey.DecryptResult = []byte("0x" + hex.EncodeToString(sk.Serialize()))
+
+	// A share added before ownership was tracked belongs to the primary operator.
+	legacyPubKey := phase0.BLSPubKey{1, 2, 3}
+	s.remoteSigner.ListKeysResult = []phase0.BLSPubKey{legacyPubKey, sharePubKey}
+
+	t.Run("add validator", func(t *testing.T) {
+		reqBody, err := json.Marshal(AddValidatorRequest{
+			ShareKeys: []ShareKeys{{EncryptedPrivKey: []byte("encrypted_key"), PubKey: sharePubKey}},
+		})
+		require.NoError(t, err)
+
+		resp, err := s.ServeHTTPAsOperator("2", "POST", pathValidators, reqBody)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+	})
+
+	t.Run("list validators", func(t *testing.T) {
+		for operator, expected := range map[string][]phase0.BLSPubKey{
+			"1": {legacyPubKey},
+			"2": {sharePubKey},
+		} {
+			resp, err := s.ServeHTTPAsOperator(operator, "GET", pathValidators, nil)
+			require.NoError(t, err)
+			assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+
+			var listed []phase0.BLSPubKey
+			require.NoError(t, json.Unmarshal(resp.Body(), &listed))
+			assert.Equal(t, expected, listed)
+		}
+	})
+
+	t.Run("sign validator", func(t *testing.T) {
+		reqBody, err := json.Marshal(web3signer.SignRequest{Type: web3signer.TypeRandaoReveal})
+		require.NoError(t, err)
+
+		resp, err := s.ServeHTTPAsOperator("1", "POST", pathValidatorsSign+sharePubKey.String(), reqBody)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusNotFound, resp.StatusCode())
+
+		resp, err = s.ServeHTTPAsOperator("2", "POST", pathValidatorsSign+sharePubKey.String(), reqBody)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+	})
+
+	t.Run("slashing protection", func(t *testing.T) {
+		for _, method := range []string{"GET", "POST"} {
+			resp, err := s.ServeHTTPAsOperator("1", method, pathSlashingProtection+sharePubKey.String(), []byte(`{"highest_proposal_slot":100}`))
+			require.NoError(t, err)
+			assert.Equal(t, fasthttp.StatusNotFound, resp.StatusCode(), method)
+
+			resp, err = s.ServeHTTPAsOperator("2", method, pathSlashingProtection+sharePubKey.String(), []byte(`{"highest_proposal_slot":100}`))
+			require.NoError(t, err)
+			assert.Equal(t, fasthttp.StatusOK, resp.StatusCode(), method)
+		}
+	})
+
+	t.Run("slashing protection interchange", func(t *testing.T) {
+		gvr := phase0.Root{1, 2, 3}
+		doc := interchange.New(gvr)
+		doc.Data = append(doc.Data, interchange.Validator{
+			PubKey:       sharePubKey,
+			SignedBlocks: []interchange.SignedBlock{{Slot: 10}},
+		})
+		body, err := doc.Marshal()
+		require.NoError(t, err)
+
+		resp, err := s.ServeHTTPAsOperator("1", "POST", pathSlashingProtectionInterchange, body)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusNotFound, resp.StatusCode())
+		assert.NotContains(t, store.docs, sharePubKey)
+
+		resp, err = s.ServeHTTPAsOperator("2", "POST", pathSlashingProtectionInterchange, body)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusNoContent, resp.StatusCode())
+
+		exportPath := pathSlashingProtectionInterchange + "?genesis_validators_root=" + gvr.String()
+
+		resp, err = s.ServeHTTPAsOperator("1", "GET", exportPath+"&pubkeys="+sharePubKey.String(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusNotFound, resp.StatusCode())
+
+		// Without pubkeys, only the operator's own shares are exported.
+		resp, err = s.ServeHTTPAsOperator("1", "GET", exportPath, nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+		exported, err := interchange.Parse(resp.Body())
+		require.NoError(t, err)
+		assert.Empty(t, exported.Data)
+
+		resp, err = s.ServeHTTPAsOperator("2", "GET", exportPath, nil)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+		exported, err = interchange.Parse(resp.Body())
+		require.NoError(t, err)
+		assert.Equal(t, doc.Data, exported.Data)
+	})
+
+	t.Run("remove validator", func(t *testing.T) {
+		reqBody, err := json.Marshal(web3signer.DeleteKeystoreRequest{Pubkeys: []phase0.BLSPubKey{legacyPubKey, sharePubKey}})
+		require.NoError(t, err)
+
+		resp, err := s.ServeHTTPAsOperator("2", "DELETE", pathValidators, reqBody)
+		require.NoError(t, err)
+		assert.Equal(t, fasthttp.StatusOK, resp.StatusCode())
+
+		var response web3signer.DeleteKeystoreResponse
+		require.NoError(t, json.Unmarshal(resp.Body(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, web3signer.StatusNotFound, response.Data[0].Status)
+		assert.Equal(t, web3signer.StatusDeleted, response.Data[1].Status)
+
+		_, found, err := NewShareOwners(db).Owner(sharePubKey)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func (s *ServerTestSuite) TestOperatorTokens() {
+	t := s.T()
+
+	otherOperatorKey := &mocks.TestOperatorPrivateKey{
+		PublicKey: &mocks.TestOperatorPublicKey{PubKeyBase64: "other_pubkey_base64"},
+	}
+
+	s.server = NewServer(s.logger, s.operatorPrivKey, s.remoteSigner,
+		WithOperatorID(1),
+		WithServerOperatorToken("primary_token"),
+		WithAdditionalOperators(
+			OperatorKey{ID: 2, PrivKey: otherOperatorKey, Token: "other_token"},
+			OperatorKey{ID: 3, PrivKey: &mocks.TestOperatorPrivateKey{
+				PublicKey: &mocks.TestOperatorPublicKey{PubKeyBase64: "open_pubkey_base64"},
+			}},
+		),
+	)
+
+	for _, tc := range []struct {
+		operator string
+		token    string
+		status   int
+	}{
+		{operator: "", token: "", status: fasthttp.StatusUnauthorized},
+		{operator: "", token: "primary_token", status: fasthttp.StatusOK},
+		{operator: "1", token: "other_token", status: fasthttp.StatusUnauthorized},
+		{operator: "2", token: "", status: fasthttp.StatusUnauthorized},
+		{operator: "2", token: "primary_token", status: fasthttp.StatusUnauthorized},
+		{operator: "2", token: "other_token", status: fasthttp.StatusOK},
+		{operator: "3", token: "", status: fasthttp.StatusOK},
+		{operator: "4", token: "other_token", status: fasthttp.StatusNotFound},
+	} {
+		resp, err := s.ServeHTTPAsOperatorWithToken(tc.operator, tc.token, "GET", pathOperatorIdentity, nil)
+		require.NoError(t, err)
+		assert.Equal(t, tc.status, resp.StatusCode(), "operator %q, token %q", tc.operator, tc.token)
+	}
+}
+
+// testSlashingProtection is a minimal in-memory SlashingProtection.
+type testSlashingProtection struct {
+	data        map[phase0.BLSPubKey]SlashingProtectionData
+	initialized map[phase0.BLSPubKey]struct{}
+}
+
+func newTestSlashingProtection() *testSlashingProtection {
+	return &testSlashingProtection{
+		data:        make(map[phase0.BLSPubKey]SlashingProtectionData),
+		initialized: make(map[phase0.BLSPubKey]struct{}),
+	}
+}
+
+func (sp *testSlashingProtection) HighestSigned(pubKey phase0.BLSPubKey) (SlashingProtectionData, error) {
+	return sp.data[pubKey], nil
+}
+
+func (sp *testSlashingProtection) RaiseHighestSigned(pubKey phase0.BLSPubKey, data SlashingProtectionData) error {
+	current := sp.data[pubKey]
+	if att := data.HighestAttestation; att != nil {
+		if current.HighestAttestation == nil {
+			current.HighestAttestation = &HighestAttestation{}
+		}
+		current.HighestAttestation.SourceEpoch = max(current.HighestAttestation.SourceEpoch, att.SourceEpoch)
+		current.HighestAttestation.TargetEpoch = max(current.HighestAttestation.TargetEpoch, att.TargetEpoch)
+	}
+	if slot := data.HighestProposalSlot; slot != nil && (current.HighestProposalSlot == nil || *slot > *current.HighestProposalSlot) {
+		current.HighestProposalSlot = slot
+	}
+	sp.data[pubKey] = current
+	return nil
+}
+
+func (sp *testSlashingProtection) InitShare(pubKey phase0.BLSPubKey) error {
+	sp.initialized[pubKey] = struct{}{}
+	return nil
+}
+
+func (sp *testSlashingProtection) CheckAttestation(pubKey phase0.BLSPubKey, data *phase0.AttestationData) error {
+	if highest := sp.data[pubKey].HighestAttestation; highest != nil &&
+		(data.Source.Epoch < highest.SourceEpoch || data.Target.Epoch <= highest.TargetEpoch) {
+		return ErrSlashable
+	}
+	return sp.RaiseHighestSigned(pubKey, SlashingProtectionData{
+		HighestAttestation: &HighestAttestation{SourceEpoch: data.Source.Epoch, TargetEpoch: data.Target.Epoch},
+	})
+}
+
+func (sp *testSlashingProtection) CheckProposal(pubKey phase0.BLSPubKey, slot phase0.Slot) error {
+	if highest := sp.data[pubKey].HighestProposalSlot; highest != nil && slot <= *highest {
+		return ErrSlashable
+	}
+	return sp.RaiseHighestSigned(pubKey, SlashingProtectionData{HighestProposalSlot: &slot})
+}
+
 func (s *ServerTestSuite) TestRouting() {
 	t := s.T()
 
@@ -0,0 +1,147 @@
+package ssvsigner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/ssvsigner/ekm/interchange"
+	"github.com/ssvlabs/ssv/ssvsigner/web3signer"
+)
+
+// ErrSlashable is returned by SlashingProtection when signing the requested object could get the validator slashed.
+var ErrSlashable = errors.New("slashable")
+
+// SlashingProtection is a slashing protection database kept by ssv-signer in addition to web3signer's one.
+// It lets operators inspect and adjust the signing history of their shares without touching web3signer,
+// and optionally rejects slashable requests before they reach web3signer.
+type SlashingProtection interface {
+	// HighestSigned returns the highest attestation and proposal recorded for the share.
+	HighestSigned(pubKey phase0.BLSPubKey) (SlashingProtectionData, error)
+	// RaiseHighestSigned raises the highest attestation and proposal recorded for the share.
+	// Values lower than the recorded ones are ignored, so the protection can never be weakened.
+	RaiseHighestSigned(pubKey phase0.BLSPubKey, data SlashingProtectionData) error
+	// InitShare sets the minimal slashing protection for the current slot if the share has none yet.
+	InitShare(pubKey phase0.BLSPubKey) error
+	// CheckAttestation returns ErrSlashable if the attestation is slashable, otherwise records it as signed.
+	CheckAttestation(pubKey phase0.BLSPubKey, data *phase0.AttestationData) error
+	// CheckProposal returns ErrSlashable if a proposal at the slot is slashable, otherwise records it as signed.
+	CheckProposal(pubKey phase0.BLSPubKey, slot phase0.Slot) error
+}
+
+// SlashingProtectionData is the highest attestation and proposal signed by a share.
+// Fields are nil when nothing was recorded.
+type SlashingProtectionData struct {
+	HighestAttestation  *HighestAttestation `json:"highest_attestation,omitempty"`
+	HighestProposalSlot *phase0.Slot        `json:"highest_proposal_slot,omitempty"`
+}
+
+type HighestAttestation struct {
+	SourceEpoch phase0.Epoch `json:"source_epoch"`
+	TargetEpoch phase0.Epoch `json:"target_epoch"`
+}
+
+// slashingProtectionJSON is the wire format of SlashingProtectionData.
+// Unlike the beacon API, epochs and slots are plain numbers rather than quoted strings.
+type slashingProtectionJSON struct {
+	HighestAttestation  *highestAttestationJSON `json:"highest_attestation,omitempty"`
+	HighestProposalSlot *uint64                 `json:"highest_proposal_slot,omitempty"`
+}
+
+type highestAttestationJSON struct {
+	SourceEpoch uint64 `json:"source_epoch"`
+	TargetEpoch uint64 `json:"target_epoch"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d SlashingProtectionData) MarshalJSON() ([]byte, error) {
+	var data slashingProtectionJSON
+	if d.HighestAttestation != nil {
+		data.HighestAttestation = &highestAttestationJSON{
+			SourceEpoch: uint64(d.HighestAttestation.SourceEpoch),
+			TargetEpoch: uint64(d.HighestAttestation.TargetEpoch),
+		}
+	}
+	if d.HighestProposalSlot != nil {
+		slot := uint64(*d.HighestProposalSlot)
+		data.HighestProposalSlot = &slot
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *SlashingProtectionData) UnmarshalJSON(input []byte) error {
+	var data slashingProtectionJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+	*d = SlashingProtectionData{}
+	if data.HighestAttestation != nil {
+		d.HighestAttestation = &HighestAttestation{
+			SourceEpoch: phase0.Epoch(data.HighestAttestation.SourceEpoch),
+			TargetEpoch: phase0.Epoch(data.HighestAttestation.TargetEpoch),
+		}
+	}
+	if data.HighestProposalSlot != nil {
+		slot := phase0.Slot(*data.HighestProposalSlot)
+		d.HighestProposalSlot = &slot
+	}
+	return nil
+}
+
+func (d SlashingProtectionData) validate() error {
+	if d.HighestAttestation == nil && d.HighestProposalSlot == nil {
+		return errors.New("neither highest attestation nor highest proposal provided")
+	}
+	if d.HighestAttestation != nil && d.HighestAttestation.SourceEpoch > d.HighestAttestation.TargetEpoch {
+		return errors.New("source epoch is greater than target epoch")
+	}
+	if d.HighestProposalSlot != nil && *d.HighestProposalSlot == 0 {
+		return errors.New("proposal slot can not be 0")
+	}
+	return nil
+}
+
+// SlashingProtectionFromInterchange returns the highest attestation and proposal
+// of each validator in an EIP-3076 interchange document.
+func SlashingProtectionFromInterchange(document []byte) (map[phase0.BLSPubKey]SlashingProtectionData, error) {
+	doc, err := interchange.Parse(document)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[phase0.BLSPubKey]SlashingProtectionData, len(doc.Data))
+	for _, validator := range doc.Data {
+		var data SlashingProtectionData
+		if source, target, ok := validator.HighestAttestation(); ok {
+			data.HighestAttestation = &HighestAttestation{SourceEpoch: source, TargetEpoch: target}
+		}
+		if slot, ok := validator.HighestProposal(); ok && slot > 0 {
+			data.HighestProposalSlot = &slot
+		}
+		history[validator.PubKey] = data
+	}
+
+	return history, nil
+}
+
+// CheckSignRequest checks attestations and blocks against the slashing protection database
+// and records them as signed. Other types can't be slashed, so they're let through.
+func CheckSignRequest(sp SlashingProtection, pubKey phase0.BLSPubKey, req web3signer.SignRequest) error {
+	switch req.Type {
+	case web3signer.TypeAttestation:
+		if req.Attestation == nil || req.Attestation.Source == nil || req.Attestation.Target == nil {
+			return fmt.Errorf("%w: attestation data is missing", ErrSlashable)
+		}
+		return sp.CheckAttestation(pubKey, req.Attestation)
+	case web3signer.TypeBlockV2:
+		if req.BeaconBlock == nil || req.BeaconBlock.BlockHeader == nil {
+			return fmt.Errorf("%w: block header is missing", ErrSlashable)
+		}
+		return sp.CheckProposal(pubKey, req.BeaconBlock.BlockHeader.Slot)
+	default:
+		return nil
+	}
+}
@@ -9,6 +9,9 @@ type ShareDecryptionError error
 
 type AddValidatorRequest struct {
 	ShareKeys []ShareKeys `json:"share_keys"`
+	// SlashingProtection is an optional EIP-3076 interchange document with the signing history of the shares.
+	// It's passed to web3signer, which imports it into its slashing protection database along with the keystores.
+	SlashingProtection string `json:"slashing_protection,omitempty"`
 }
 
 type ShareKeys struct {
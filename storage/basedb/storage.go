@@ -5,12 +5,39 @@ import (
 	"time"
 )
 
+// Engine is the name of a storage engine implementing Database.
+type Engine string
+
+const (
+	EngineBadger Engine = "badger"
+	EnginePebble Engine = "pebble"
+)
+
 // Options for creating all db type
 type Options struct {
 	Ctx        context.Context
+	Engine     Engine        `yaml:"Engine" env:"DB_ENGINE" env-default:"badger" env-description:"Database storage engine (badger or pebble)"`
 	Path       string        `yaml:"Path" env:"DB_PATH" env-default:"./data/db" env-description:"Database storage directory path"`
 	Reporting  bool          `yaml:"Reporting" env:"DB_REPORTING" env-default:"false" env-description:"Enable database size reporting"`
 	GCInterval time.Duration `yaml:"GCInterval" env:"DB_GC_INTERVAL" env-default:"6m" env-description:"Interval between garbage collection runs (0 to disable)"`
+
+	// ReadOnly opens the database without write access, for inspecting it from another process.
+	ReadOnly bool `yaml:"-"`
+}
+
+// RangeOptions bounds and orders an iteration over the keys under a prefix.
+// Start and End don't include the prefix.
+type RangeOptions struct {
+	// Start is the first key of the range (inclusive). Empty starts from the first key under the prefix.
+	Start []byte
+	// End is the key ending the range (exclusive). Empty ends after the last key under the prefix.
+	End []byte
+	// Reverse iterates from the end of the range to its start.
+	Reverse bool
+	// Limit stops the iteration after that many items. Zero means no limit.
+	Limit int
+	// KeysOnly skips reading the values, leaving Obj.Value nil.
+	KeysOnly bool
 }
 
 // Reader is a read-only accessor to the database.
@@ -18,6 +45,9 @@ type Reader interface {
 	Get(prefix []byte, key []byte) (Obj, bool, error)
 	GetMany(prefix []byte, keys [][]byte, iterator func(Obj) error) error
 	GetAll(prefix []byte, handler func(int, Obj) error) error
+	// GetRange iterates over the items under prefix within the range given by opts, in key order.
+	// The keys passed to handler don't include the prefix. Iteration stops at the first error returned by handler.
+	GetRange(prefix []byte, opts RangeOptions, handler func(Obj) error) error
 }
 
 // ReadWriter is a read-write accessor to the database.
@@ -31,7 +61,6 @@ type ReadWriter interface {
 // Txn is a read-write transaction.
 type Txn interface {
 	ReadWriter
-	// TODO: add iterator
 	Commit() error
 	Discard()
 }
@@ -0,0 +1,122 @@
+package kv
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// maxPendingRestoreWrites limits the number of writes buffered in memory while restoring a backup.
+const maxPendingRestoreWrites = 256
+
+// maxRestoreBatchSize limits the size of the batches written to Pebble while restoring a backup.
+const maxRestoreBatchSize = 32 << 20
+
+// Backup writes a full backup of the database to w while the database stays online.
+// The backup is a consistent snapshot as of the time the backup started.
+// It returns the version of the snapshot.
+func (b *BadgerDB) Backup(w io.Writer) (uint64, error) {
+	version, err := b.db.Backup(w, 0)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to backup badger")
+	}
+	return version, nil
+}
+
+// Restore loads a backup created by Backup into the database.
+// Keys present in both the database and the backup are overwritten.
+func (b *BadgerDB) Restore(r io.Reader) error {
+	if err := b.db.Load(r, maxPendingRestoreWrites); err != nil {
+		return errors.Wrap(err, "failed to restore badger")
+	}
+	return nil
+}
+
+// Backup writes a full backup of the database to w while the database stays online.
+// The backup is a consistent snapshot as of the time the backup started, written as
+// a sequence of length-prefixed keys and values.
+// Pebble doesn't expose the sequence number of snapshots, so the returned version is always 0.
+func (p *PebbleDB) Backup(w io.Writer) (uint64, error) {
+	snapshot := p.db.NewSnapshot()
+	defer snapshot.Close()
+
+	bw := bufio.NewWriter(w)
+	var buf []byte
+	err := pebbleRange(snapshot, nil, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+		buf = binary.AppendUvarint(buf[:0], uint64(len(obj.Key)))
+		buf = append(buf, obj.Key...)
+		buf = binary.AppendUvarint(buf, uint64(len(obj.Value)))
+		buf = append(buf, obj.Value...)
+		_, err := bw.Write(buf)
+		return err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to backup pebble")
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, errors.Wrap(err, "failed to backup pebble")
+	}
+	return 0, nil
+}
+
+// Restore loads a backup created by Backup into the database.
+// Keys present in both the database and the backup are overwritten.
+func (p *PebbleDB) Restore(r io.Reader) error {
+	br := bufio.NewReader(r)
+	batch := p.db.NewBatch()
+	defer func() { _ = batch.Close() }()
+
+	for {
+		key, err := readBackupChunk(br)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read backup key")
+		}
+		value, err := readBackupChunk(br)
+		if err != nil {
+			return errors.Wrap(err, "failed to read backup value")
+		}
+
+		if err := batch.Set(key, value, nil); err != nil {
+			return errors.Wrap(err, "failed to restore pebble")
+		}
+		if batch.Len() >= maxRestoreBatchSize {
+			if err := batch.Commit(pebble.NoSync); err != nil {
+				return errors.Wrap(err, "failed to restore pebble")
+			}
+			_ = batch.Close()
+			batch = p.db.NewBatch()
+		}
+	}
+
+	// The last batch is synced, which makes the whole restore durable.
+	if err := batch.Commit(pebbleWriteOptions); err != nil {
+		return errors.Wrap(err, "failed to restore pebble")
+	}
+	return nil
+}
+
+// readBackupChunk reads a length-prefixed chunk written by PebbleDB.Backup.
+// It returns io.EOF only if r ended before the chunk started.
+func readBackupChunk(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxRestoreBatchSize {
+		return nil, fmt.Errorf("chunk size %d exceeds the limit", size)
+	}
+	chunk := make([]byte, size)
+	if _, err := io.ReadFull(r, chunk); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
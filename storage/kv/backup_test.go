@@ -0,0 +1,41 @@
+package kv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+func TestBadgerDB_BackupRestore(t *testing.T) {
+	source := setupDB(t, basedb.Options{})
+	prefix := []byte("prefix")
+	setupDataset(t, source, prefix, 100)
+	require.NoError(t, source.Set([]byte("other"), []byte("key"), []byte("value")))
+
+	var buf bytes.Buffer
+	version, err := source.Backup(&buf)
+	require.NoError(t, err)
+	require.NotZero(t, version)
+
+	// Writes after the backup aren't included.
+	require.NoError(t, source.Set([]byte("other"), []byte("late"), []byte("value")))
+
+	target := setupDB(t, basedb.Options{})
+	require.NoError(t, target.Restore(&buf))
+
+	count, err := target.CountPrefix(prefix)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, count)
+
+	obj, found, err := target.Get([]byte("other"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+
+	_, found, err = target.Get([]byte("other"), []byte("late"))
+	require.NoError(t, err)
+	require.False(t, found)
+}
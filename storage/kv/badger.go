@@ -49,6 +49,7 @@ func createDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*Badge
 		opt.Dir = ""
 		opt.ValueDir = ""
 	}
+	opt.ReadOnly = options.ReadOnly
 
 	// TODO: we should set the default logger here to log Error and higher levels
 	opt.Logger = newLogger(zap.NewNop())
@@ -93,6 +94,11 @@ func createDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*Badge
 	return &badgerDB, nil
 }
 
+// Engine returns basedb.EngineBadger.
+func (b *BadgerDB) Engine() basedb.Engine {
+	return basedb.EngineBadger
+}
+
 // Badger returns the underlying badger.DB
 func (b *BadgerDB) Badger() *badger.DB {
 	return b.db
@@ -182,6 +188,23 @@ func (b *BadgerDB) CountPrefix(prefix []byte) (int64, error) {
 	return res, err
 }
 
+// SizePrefix returns the estimated on-disk size of all keys and values under the specified prefix.
+func (b *BadgerDB) SizePrefix(prefix []byte) (int64, error) {
+	var res int64
+	err := b.db.View(func(txn *badger.Txn) error {
+		opt := badger.DefaultIteratorOptions
+		opt.Prefix = prefix
+		opt.PrefetchValues = false
+		it := txn.NewIterator(opt)
+		defer it.Close()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			res += it.Item().EstimatedSize()
+		}
+		return nil
+	})
+	return res, err
+}
+
 // DropPrefix cleans all items in a collection
 func (b *BadgerDB) DropPrefix(prefix []byte) error {
 	return b.db.DropPrefix(prefix)
@@ -38,6 +38,48 @@ func setupDB(t *testing.T, options basedb.Options) *BadgerDB {
 	return db
 }
 
+// setupDBFunc creates a database for testing with given options and handles cleanup.
+type setupDBFunc func(t *testing.T, options basedb.Options) DB
+
+// testEngines creates in-memory databases of each storage engine.
+var testEngines = []struct {
+	name        string
+	newInMemory func(logger *zap.Logger, options basedb.Options) (DB, error)
+}{
+	{
+		name: string(basedb.EngineBadger),
+		newInMemory: func(logger *zap.Logger, options basedb.Options) (DB, error) {
+			return NewInMemory(logger, options)
+		},
+	},
+	{
+		name: string(basedb.EnginePebble),
+		newInMemory: func(logger *zap.Logger, options basedb.Options) (DB, error) {
+			return NewPebbleInMemory(logger, options)
+		},
+	},
+}
+
+// forEachEngine runs test against each storage engine.
+func forEachEngine(t *testing.T, test func(t *testing.T, setupDB setupDBFunc)) {
+	for _, engine := range testEngines {
+		t.Run(engine.name, func(t *testing.T) {
+			test(t, func(t *testing.T, options basedb.Options) DB {
+				t.Helper()
+
+				db, err := engine.newInMemory(logging.TestLogger(t), options)
+				require.NoError(t, err)
+
+				t.Cleanup(func() {
+					assert.NoError(t, db.Close())
+				})
+
+				return db
+			})
+		})
+	}
+}
+
 // setupTempDir creates a temporary directory for disk-based DB tests.
 func setupTempDir(t *testing.T, prefix string) string {
 	t.Helper()
@@ -52,7 +94,7 @@ func setupTempDir(t *testing.T, prefix string) string {
 }
 
 // setupDataset populates a database with test data of specified size.
-func setupDataset(t *testing.T, db *BadgerDB, prefix []byte, count int) {
+func setupDataset(t *testing.T, db basedb.Database, prefix []byte, count int) {
 	t.Helper()
 	for i := 0; i < count; i++ {
 		id := fmt.Sprintf("test-%d", i)
@@ -78,86 +120,88 @@ func TestBadger(t *testing.T) {
 func TestBasicOperations(t *testing.T) {
 	t.Parallel()
 
-	db := setupDB(t, basedb.Options{})
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
 
-	t.Run("set and get", func(t *testing.T) {
-		prefix := []byte("test-prefix")
-		key := []byte("test-key")
-		value := []byte("test-value")
+		t.Run("set and get", func(t *testing.T) {
+			prefix := []byte("test-prefix")
+			key := []byte("test-key")
+			value := []byte("test-value")
 
-		require.NoError(t, db.Set(prefix, key, value))
+			require.NoError(t, db.Set(prefix, key, value))
 
-		obj, found, err := db.Get(prefix, key)
+			obj, found, err := db.Get(prefix, key)
 
-		require.NoError(t, err)
-		require.True(t, found)
-		assert.Equal(t, key, obj.Key)
-		assert.Equal(t, value, obj.Value)
-	})
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, key, obj.Key)
+			assert.Equal(t, value, obj.Value)
+		})
 
-	t.Run("get non-existent", func(t *testing.T) {
-		prefix := []byte("missing-prefix")
-		key := []byte("missing-key")
+		t.Run("get non-existent", func(t *testing.T) {
+			prefix := []byte("missing-prefix")
+			key := []byte("missing-key")
 
-		obj, found, err := db.Get(prefix, key)
+			obj, found, err := db.Get(prefix, key)
 
-		require.NoError(t, err)
-		require.False(t, found)
-		assert.Empty(t, obj.Value)
-	})
+			require.NoError(t, err)
+			require.False(t, found)
+			assert.Empty(t, obj.Value)
+		})
 
-	t.Run("delete", func(t *testing.T) {
-		prefix := []byte("delete-prefix")
-		key := []byte("delete-key")
-		value := []byte("delete-value")
+		t.Run("delete", func(t *testing.T) {
+			prefix := []byte("delete-prefix")
+			key := []byte("delete-key")
+			value := []byte("delete-value")
 
-		require.NoError(t, db.Set(prefix, key, value))
+			require.NoError(t, db.Set(prefix, key, value))
 
-		_, found, err := db.Get(prefix, key)
+			_, found, err := db.Get(prefix, key)
 
-		require.NoError(t, err)
-		require.True(t, found)
+			require.NoError(t, err)
+			require.True(t, found)
 
-		require.NoError(t, db.Delete(prefix, key))
+			require.NoError(t, db.Delete(prefix, key))
 
-		_, found, err = db.Get(prefix, key)
+			_, found, err = db.Get(prefix, key)
 
-		require.NoError(t, err)
-		require.False(t, found)
-	})
+			require.NoError(t, err)
+			require.False(t, found)
+		})
 
-	t.Run("drop prefix", func(t *testing.T) {
-		prefix := []byte("drop-prefix")
-		itemCount := 5
+		t.Run("drop prefix", func(t *testing.T) {
+			prefix := []byte("drop-prefix")
+			itemCount := 5
 
-		for i := 0; i < itemCount; i++ {
-			key := []byte(fmt.Sprintf("key-%d", i))
-			value := []byte(fmt.Sprintf("value-%d", i))
+			for i := 0; i < itemCount; i++ {
+				key := []byte(fmt.Sprintf("key-%d", i))
+				value := []byte(fmt.Sprintf("value-%d", i))
 
-			require.NoError(t, db.Set(prefix, key, value))
-		}
+				require.NoError(t, db.Set(prefix, key, value))
+			}
 
-		count := 0
-		err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
-			count++
+			count := 0
+			err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+				count++
 
-			return nil
-		})
+				return nil
+			})
 
-		require.NoError(t, err)
-		require.Equal(t, itemCount, count)
+			require.NoError(t, err)
+			require.Equal(t, itemCount, count)
 
-		require.NoError(t, db.DropPrefix(prefix))
+			require.NoError(t, db.DropPrefix(prefix))
 
-		count = 0
-		err = db.GetAll(prefix, func(i int, obj basedb.Obj) error {
-			count++
+			count = 0
+			err = db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+				count++
 
-			return nil
-		})
+				return nil
+			})
 
-		require.NoError(t, err)
-		require.Equal(t, 0, count)
+			require.NoError(t, err)
+			require.Equal(t, 0, count)
+		})
 	})
 }
 
@@ -165,72 +209,74 @@ func TestBasicOperations(t *testing.T) {
 func TestGetAll(t *testing.T) {
 	t.Parallel()
 
-	testCases := []struct {
-		name      string
-		itemCount int
-	}{
-		{"small dataset (100 items)", 100},
-		{"medium dataset (1K items)", 1000},
-		{"large dataset (5K items)", 5000},
-	}
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		testCases := []struct {
+			name      string
+			itemCount int
+		}{
+			{"small dataset (100 items)", 100},
+			{"medium dataset (1K items)", 1000},
+			{"large dataset (5K items)", 5000},
+		}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			db := setupDB(t, basedb.Options{})
-			prefix := []byte("test")
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				db := setupDB(t, basedb.Options{})
+				prefix := []byte("test")
 
-			setupDataset(t, db, prefix, tc.itemCount)
-			time.Sleep(time.Millisecond)
+				setupDataset(t, db, prefix, tc.itemCount)
+				time.Sleep(time.Millisecond)
 
-			var all []basedb.Obj
-			err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
-				all = append(all, obj)
+				var all []basedb.Obj
+				err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+					all = append(all, obj)
 
-				return nil
-			})
+					return nil
+				})
 
-			require.NoError(t, err)
-			assert.Equal(t, tc.itemCount, len(all))
+				require.NoError(t, err)
+				assert.Equal(t, tc.itemCount, len(all))
 
-			visited := make(map[string]struct{}, tc.itemCount)
-			for _, item := range all {
-				visited[string(item.Key)] = struct{}{}
-			}
+				visited := make(map[string]struct{}, tc.itemCount)
+				for _, item := range all {
+					visited[string(item.Key)] = struct{}{}
+				}
 
-			assert.Equal(t, tc.itemCount, len(visited))
-		})
-	}
+				assert.Equal(t, tc.itemCount, len(visited))
+			})
+		}
 
-	t.Run("getAll with error", func(t *testing.T) {
-		db := setupDB(t, basedb.Options{})
-		prefix := []byte("error-prefix")
-		setupDataset(t, db, prefix, 10)
+		t.Run("getAll with error", func(t *testing.T) {
+			db := setupDB(t, basedb.Options{})
+			prefix := []byte("error-prefix")
+			setupDataset(t, db, prefix, 10)
 
-		expectedErr := errors.New("handler error")
-		err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
-			if i > 5 {
-				return expectedErr
-			}
+			expectedErr := errors.New("handler error")
+			err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+				if i > 5 {
+					return expectedErr
+				}
+
+				return nil
+			})
 
-			return nil
+			assert.Equal(t, expectedErr, err)
 		})
 
-		assert.Equal(t, expectedErr, err)
-	})
+		t.Run("getAll empty prefix", func(t *testing.T) {
+			db := setupDB(t, basedb.Options{})
+			prefix := []byte("empty-prefix")
 
-	t.Run("getAll empty prefix", func(t *testing.T) {
-		db := setupDB(t, basedb.Options{})
-		prefix := []byte("empty-prefix")
+			var all []basedb.Obj
+			err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+				all = append(all, obj)
 
-		var all []basedb.Obj
-		err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
-			all = append(all, obj)
+				return nil
+			})
 
-			return nil
+			require.NoError(t, err)
+			assert.Empty(t, all)
 		})
-
-		require.NoError(t, err)
-		assert.Empty(t, all)
 	})
 }
 
@@ -238,61 +284,63 @@ func TestGetAll(t *testing.T) {
 func TestGetMany(t *testing.T) {
 	t.Parallel()
 
-	db := setupDB(t, basedb.Options{})
-	prefix := []byte("prefix")
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
+		prefix := []byte("prefix")
 
-	for i := uint64(0); i < 100; i++ {
-		require.NoError(t, db.Set(prefix, encodeUint64(i+1), encodeUint64(i+1)))
-	}
+		for i := uint64(0); i < 100; i++ {
+			require.NoError(t, db.Set(prefix, encodeUint64(i+1), encodeUint64(i+1)))
+		}
+
+		t.Run("get multiple keys", func(t *testing.T) {
+			results := make([]basedb.Obj, 0)
+			err := db.GetMany(prefix, [][]byte{
+				encodeUint64(1),
+				encodeUint64(2),
+				encodeUint64(5),
+				encodeUint64(10),
+			}, func(obj basedb.Obj) error {
 
-	t.Run("get multiple keys", func(t *testing.T) {
-		results := make([]basedb.Obj, 0)
-		err := db.GetMany(prefix, [][]byte{
-			encodeUint64(1),
-			encodeUint64(2),
-			encodeUint64(5),
-			encodeUint64(10),
-		}, func(obj basedb.Obj) error {
+				require.True(t, bytes.Equal(obj.Key, obj.Value))
+				results = append(results, obj)
 
-			require.True(t, bytes.Equal(obj.Key, obj.Value))
-			results = append(results, obj)
+				return nil
+			})
 
-			return nil
+			require.NoError(t, err)
+			require.Equal(t, 4, len(results))
 		})
 
-		require.NoError(t, err)
-		require.Equal(t, 4, len(results))
-	})
+		t.Run("empty keys array", func(t *testing.T) {
+			results := make([]basedb.Obj, 0)
+			err := db.GetMany(prefix, [][]byte{}, func(obj basedb.Obj) error {
+				results = append(results, obj)
+				return nil
+			})
 
-	t.Run("empty keys array", func(t *testing.T) {
-		results := make([]basedb.Obj, 0)
-		err := db.GetMany(prefix, [][]byte{}, func(obj basedb.Obj) error {
-			results = append(results, obj)
-			return nil
+			require.NoError(t, err)
+			require.Empty(t, results)
 		})
 
-		require.NoError(t, err)
-		require.Empty(t, results)
-	})
+		t.Run("non-existent key", func(t *testing.T) {
+			results := make([]basedb.Obj, 0)
+			err := db.GetMany(prefix, [][]byte{encodeUint64(999)}, func(obj basedb.Obj) error {
+				results = append(results, obj)
+				return nil
+			})
 
-	t.Run("non-existent key", func(t *testing.T) {
-		results := make([]basedb.Obj, 0)
-		err := db.GetMany(prefix, [][]byte{encodeUint64(999)}, func(obj basedb.Obj) error {
-			results = append(results, obj)
-			return nil
+			require.NoError(t, err)
+			require.Empty(t, results)
 		})
 
-		require.NoError(t, err)
-		require.Empty(t, results)
-	})
+		t.Run("iterator error", func(t *testing.T) {
+			expectedErr := errors.New("iterator error")
+			err := db.GetMany(prefix, [][]byte{encodeUint64(1)}, func(obj basedb.Obj) error {
+				return expectedErr
+			})
 
-	t.Run("iterator error", func(t *testing.T) {
-		expectedErr := errors.New("iterator error")
-		err := db.GetMany(prefix, [][]byte{encodeUint64(1)}, func(obj basedb.Obj) error {
-			return expectedErr
+			assert.Equal(t, expectedErr, err)
 		})
-
-		assert.Equal(t, expectedErr, err)
 	})
 }
 
@@ -300,40 +348,42 @@ func TestGetMany(t *testing.T) {
 func TestSetMany(t *testing.T) {
 	t.Parallel()
 
-	db := setupDB(t, basedb.Options{})
-	prefix := []byte("prefix")
-
-	t.Run("set multiple items", func(t *testing.T) {
-		var values [][]byte
-		err := db.SetMany(prefix, 10, func(i int) (basedb.Obj, error) {
-			seq := uint64(i + 1)
-			values = append(values, encodeUint64(seq))
-			return basedb.Obj{Key: encodeUint64(seq), Value: encodeUint64(seq)}, nil
-		})
-
-		require.NoError(t, err)
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
+		prefix := []byte("prefix")
+
+		t.Run("set multiple items", func(t *testing.T) {
+			var values [][]byte
+			err := db.SetMany(prefix, 10, func(i int) (basedb.Obj, error) {
+				seq := uint64(i + 1)
+				values = append(values, encodeUint64(seq))
+				return basedb.Obj{Key: encodeUint64(seq), Value: encodeUint64(seq)}, nil
+			})
 
-		for i := 0; i < 10; i++ {
-			seq := uint64(i + 1)
-			obj, found, err := db.Get(prefix, encodeUint64(seq))
+			require.NoError(t, err)
 
-			require.NoError(t, err, "should find item %d", i)
-			require.True(t, found, "should find item %d", i)
-			require.True(t, bytes.Equal(obj.Value, values[i]), "item %d wrong value", i)
-		}
-	})
+			for i := 0; i < 10; i++ {
+				seq := uint64(i + 1)
+				obj, found, err := db.Get(prefix, encodeUint64(seq))
 
-	t.Run("error in next function", func(t *testing.T) {
-		expectedErr := errors.New("next error")
-		err := db.SetMany(prefix, 10, func(i int) (basedb.Obj, error) {
-			if i > 2 {
-				return basedb.Obj{}, expectedErr
+				require.NoError(t, err, "should find item %d", i)
+				require.True(t, found, "should find item %d", i)
+				require.True(t, bytes.Equal(obj.Value, values[i]), "item %d wrong value", i)
 			}
-
-			return basedb.Obj{Key: encodeUint64(uint64(i)), Value: encodeUint64(uint64(i))}, nil
 		})
 
-		assert.Equal(t, expectedErr, err)
+		t.Run("error in next function", func(t *testing.T) {
+			expectedErr := errors.New("next error")
+			err := db.SetMany(prefix, 10, func(i int) (basedb.Obj, error) {
+				if i > 2 {
+					return basedb.Obj{}, expectedErr
+				}
+
+				return basedb.Obj{Key: encodeUint64(uint64(i)), Value: encodeUint64(uint64(i))}, nil
+			})
+
+			assert.Equal(t, expectedErr, err)
+		})
 	})
 }
 
@@ -365,25 +415,55 @@ func TestSetMany_SetError(t *testing.T) {
 func TestCountPrefix(t *testing.T) {
 	t.Parallel()
 
-	db := setupDB(t, basedb.Options{})
-	prefix := []byte("count-prefix")
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
+		prefix := []byte("count-prefix")
 
-	t.Run("count existing prefix", func(t *testing.T) {
-		for i := uint64(0); i < 100; i++ {
-			require.NoError(t, db.Set(prefix, encodeUint64(i+1), encodeUint64(i+1)))
-		}
+		t.Run("count existing prefix", func(t *testing.T) {
+			for i := uint64(0); i < 100; i++ {
+				require.NoError(t, db.Set(prefix, encodeUint64(i+1), encodeUint64(i+1)))
+			}
 
-		n, err := db.CountPrefix(prefix)
+			n, err := db.CountPrefix(prefix)
 
-		require.NoError(t, err)
-		require.Equal(t, int64(100), n)
+			require.NoError(t, err)
+			require.Equal(t, int64(100), n)
+		})
+
+		t.Run("count non-existent prefix", func(t *testing.T) {
+			n, err := db.CountPrefix([]byte("nonexistent"))
+
+			require.NoError(t, err)
+			require.Equal(t, int64(0), n)
+		})
 	})
+}
+
+// TestSizePrefix verifies the SizePrefix method grows with the items under a given prefix.
+func TestSizePrefix(t *testing.T) {
+	t.Parallel()
+
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
+		prefix := []byte("size-prefix")
+
+		n, err := db.SizePrefix(prefix)
+		require.NoError(t, err)
+		require.Zero(t, n)
 
-	t.Run("count non-existent prefix", func(t *testing.T) {
-		n, err := db.CountPrefix([]byte("nonexistent"))
+		require.NoError(t, db.Set(prefix, []byte("small"), make([]byte, 10)))
+		small, err := db.SizePrefix(prefix)
+		require.NoError(t, err)
+		require.Positive(t, small)
+
+		require.NoError(t, db.Set(prefix, []byte("large"), make([]byte, 1000)))
+		large, err := db.SizePrefix(prefix)
+		require.NoError(t, err)
+		require.Greater(t, large, small+1000)
 
+		n, err = db.SizePrefix([]byte("nonexistent"))
 		require.NoError(t, err)
-		require.Equal(t, int64(0), n)
+		require.Zero(t, n)
 	})
 }
 
@@ -391,35 +471,37 @@ func TestCountPrefix(t *testing.T) {
 func TestUpdate(t *testing.T) {
 	t.Parallel()
 
-	db := setupDB(t, basedb.Options{})
-	prefix := []byte("update-prefix")
-	key := []byte("update-key")
-	value := []byte("original-value")
-
-	require.NoError(t, db.Set(prefix, key, value))
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
+		prefix := []byte("update-prefix")
+		key := []byte("update-key")
+		value := []byte("original-value")
 
-	t.Run("successful update", func(t *testing.T) {
-		newValue := []byte("updated-value")
-		err := db.Update(func(txn basedb.Txn) error {
-			return txn.Set(prefix, key, newValue)
-		})
+		require.NoError(t, db.Set(prefix, key, value))
 
-		require.NoError(t, err)
+		t.Run("successful update", func(t *testing.T) {
+			newValue := []byte("updated-value")
+			err := db.Update(func(txn basedb.Txn) error {
+				return txn.Set(prefix, key, newValue)
+			})
 
-		obj, found, err := db.Get(prefix, key)
+			require.NoError(t, err)
 
-		require.NoError(t, err)
-		require.True(t, found)
-		assert.Equal(t, newValue, obj.Value)
-	})
+			obj, found, err := db.Get(prefix, key)
 
-	t.Run("error in transaction", func(t *testing.T) {
-		expectedErr := errors.New("transaction error")
-		err := db.Update(func(txn basedb.Txn) error {
-			return expectedErr
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, newValue, obj.Value)
 		})
 
-		assert.Equal(t, expectedErr, err)
+		t.Run("error in transaction", func(t *testing.T) {
+			expectedErr := errors.New("transaction error")
+			err := db.Update(func(txn basedb.Txn) error {
+				return expectedErr
+			})
+
+			assert.Equal(t, expectedErr, err)
+		})
 	})
 }
 
@@ -427,114 +509,116 @@ func TestUpdate(t *testing.T) {
 func TestTransactions(t *testing.T) {
 	t.Parallel()
 
-	t.Run("begin and Commit", func(t *testing.T) {
-		db := setupDB(t, basedb.Options{})
-		prefix := []byte("txn-prefix")
-		key := []byte("txn-key")
-		value := []byte("txn-value")
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		t.Run("begin and Commit", func(t *testing.T) {
+			db := setupDB(t, basedb.Options{})
+			prefix := []byte("txn-prefix")
+			key := []byte("txn-key")
+			value := []byte("txn-value")
 
-		txn := db.Begin()
+			txn := db.Begin()
 
-		require.NotNil(t, txn)
+			require.NotNil(t, txn)
 
-		defer txn.Discard()
+			defer txn.Discard()
 
-		err := txn.Set(prefix, key, value)
+			err := txn.Set(prefix, key, value)
 
-		require.NoError(t, err)
+			require.NoError(t, err)
 
-		_, found, err := db.Get(prefix, key)
+			_, found, err := db.Get(prefix, key)
 
-		require.NoError(t, err)
-		require.False(t, found)
+			require.NoError(t, err)
+			require.False(t, found)
 
-		err = txn.Commit()
+			err = txn.Commit()
 
-		require.NoError(t, err)
+			require.NoError(t, err)
 
-		obj, found, err := db.Get(prefix, key)
+			obj, found, err := db.Get(prefix, key)
 
-		require.NoError(t, err)
-		require.True(t, found)
-		assert.Equal(t, value, obj.Value)
-	})
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, value, obj.Value)
+		})
 
-	t.Run("begin and discard", func(t *testing.T) {
-		db := setupDB(t, basedb.Options{})
-		prefix := []byte("discard-prefix")
-		key := []byte("discard-key")
-		value := []byte("discard-value")
+		t.Run("begin and discard", func(t *testing.T) {
+			db := setupDB(t, basedb.Options{})
+			prefix := []byte("discard-prefix")
+			key := []byte("discard-key")
+			value := []byte("discard-value")
 
-		txn := db.Begin()
+			txn := db.Begin()
 
-		require.NotNil(t, txn)
+			require.NotNil(t, txn)
 
-		err := txn.Set(prefix, key, value)
+			err := txn.Set(prefix, key, value)
 
-		require.NoError(t, err)
+			require.NoError(t, err)
 
-		txn.Discard()
+			txn.Discard()
 
-		_, found, err := db.Get(prefix, key)
+			_, found, err := db.Get(prefix, key)
 
-		require.NoError(t, err)
-		require.False(t, found)
-	})
+			require.NoError(t, err)
+			require.False(t, found)
+		})
 
-	t.Run("begin read", func(t *testing.T) {
-		db := setupDB(t, basedb.Options{})
-		prefix := []byte("read-prefix")
-		key := []byte("read-key")
-		value := []byte("read-value")
+		t.Run("begin read", func(t *testing.T) {
+			db := setupDB(t, basedb.Options{})
+			prefix := []byte("read-prefix")
+			key := []byte("read-key")
+			value := []byte("read-value")
 
-		require.NoError(t, db.Set(prefix, key, value))
+			require.NoError(t, db.Set(prefix, key, value))
 
-		txn := db.BeginRead()
+			txn := db.BeginRead()
 
-		require.NotNil(t, txn)
+			require.NotNil(t, txn)
 
-		defer txn.Discard()
+			defer txn.Discard()
 
-		obj, found, err := txn.Get(prefix, key)
+			obj, found, err := txn.Get(prefix, key)
 
-		require.NoError(t, err)
-		require.True(t, found)
-		assert.Equal(t, value, obj.Value)
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, value, obj.Value)
 
-		newKey := []byte("new-key")
-		newValue := []byte("new-value")
+			newKey := []byte("new-key")
+			newValue := []byte("new-value")
 
-		require.NoError(t, db.Set(prefix, newKey, newValue))
+			require.NoError(t, db.Set(prefix, newKey, newValue))
 
-		_, found, err = txn.Get(prefix, newKey)
+			_, found, err = txn.Get(prefix, newKey)
 
-		require.NoError(t, err)
-		require.False(t, found)
-	})
+			require.NoError(t, err)
+			require.False(t, found)
+		})
 
-	t.Run("transaction get all", func(t *testing.T) {
-		db := setupDB(t, basedb.Options{})
-		prefix := []byte("txn-getall-prefix")
-		itemCount := 10
+		t.Run("transaction get all", func(t *testing.T) {
+			db := setupDB(t, basedb.Options{})
+			prefix := []byte("txn-getall-prefix")
+			itemCount := 10
 
-		for i := 0; i < itemCount; i++ {
-			key := []byte(fmt.Sprintf("key%d", i))
-			value := []byte(fmt.Sprintf("value%d", i))
+			for i := 0; i < itemCount; i++ {
+				key := []byte(fmt.Sprintf("key%d", i))
+				value := []byte(fmt.Sprintf("value%d", i))
 
-			require.NoError(t, db.Set(prefix, key, value))
-		}
+				require.NoError(t, db.Set(prefix, key, value))
+			}
 
-		txn := db.Begin()
-		defer txn.Discard()
+			txn := db.Begin()
+			defer txn.Discard()
 
-		var items []basedb.Obj
-		err := txn.GetAll(prefix, func(i int, obj basedb.Obj) error {
-			items = append(items, obj)
-			return nil
-		})
+			var items []basedb.Obj
+			err := txn.GetAll(prefix, func(i int, obj basedb.Obj) error {
+				items = append(items, obj)
+				return nil
+			})
 
-		require.NoError(t, err)
-		require.Equal(t, itemCount, len(items))
+			require.NoError(t, err)
+			require.Equal(t, itemCount, len(items))
+		})
 	})
 }
 
@@ -646,27 +730,29 @@ func TestCreationDB_OpenError(t *testing.T) {
 func TestHelperFunctions(t *testing.T) {
 	t.Parallel()
 
-	db1 := setupDB(t, basedb.Options{})
-	db2 := setupDB(t, basedb.Options{})
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db1 := setupDB(t, basedb.Options{})
+		db2 := setupDB(t, basedb.Options{})
 
-	t.Run("using with nil", func(t *testing.T) {
-		rw := db1.Using(nil)
-		require.Equal(t, db1, rw)
-	})
+		t.Run("using with nil", func(t *testing.T) {
+			rw := db1.Using(nil)
+			require.Equal(t, db1, rw)
+		})
 
-	t.Run("using with another DB", func(t *testing.T) {
-		rw := db1.Using(db2)
-		require.Equal(t, db2, rw)
-	})
+		t.Run("using with another DB", func(t *testing.T) {
+			rw := db1.Using(db2)
+			require.Equal(t, db2, rw)
+		})
 
-	t.Run("usingReader with nil", func(t *testing.T) {
-		r := db1.UsingReader(nil)
-		require.Equal(t, db1, r)
-	})
+		t.Run("usingReader with nil", func(t *testing.T) {
+			r := db1.UsingReader(nil)
+			require.Equal(t, db1, r)
+		})
 
-	t.Run("usingReader with another DB", func(t *testing.T) {
-		r := db1.UsingReader(db2)
-		require.Equal(t, db2, r)
+		t.Run("usingReader with another DB", func(t *testing.T) {
+			r := db1.UsingReader(db2)
+			require.Equal(t, db2, r)
+		})
 	})
 }
 
@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// convertBatchSize is the number of items written per batch when converting a database.
+const convertBatchSize = 10_000
+
+// Convert copies all items of src into dst and returns how many were copied.
+// dst is expected to be empty and neither database may be written to meanwhile.
+func Convert(ctx context.Context, src basedb.Reader, dst basedb.Database) (int64, error) {
+	var copied int64
+	batch := make([]basedb.Obj, 0, convertBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := dst.SetMany(nil, len(batch), func(i int) (basedb.Obj, error) {
+			return batch[i], nil
+		})
+		if err != nil {
+			return fmt.Errorf("write batch: %w", err)
+		}
+		copied += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	err := src.GetRange(nil, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch = append(batch, obj)
+		if len(batch) >= convertBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return copied, err
+	}
+	if err := flush(); err != nil {
+		return copied, err
+	}
+
+	count, err := dst.CountPrefix(nil)
+	if err != nil {
+		return copied, fmt.Errorf("count converted items: %w", err)
+	}
+	if count != copied {
+		return copied, fmt.Errorf("converted database has %d items, expected %d", count, copied)
+	}
+
+	return copied, nil
+}
@@ -0,0 +1,83 @@
+package kv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// DB is a storage engine holding the node database.
+type DB interface {
+	basedb.Database
+	basedb.GarbageCollector
+
+	// Engine returns the name of the storage engine.
+	Engine() basedb.Engine
+	// SizePrefix returns the estimated size of all keys and values under the specified prefix.
+	SizePrefix(prefix []byte) (int64, error)
+	// Backup writes a full backup of the database to w while the database stays online.
+	Backup(w io.Writer) (uint64, error)
+	// Restore loads a backup created by Backup of the same engine into the database.
+	Restore(r io.Reader) error
+}
+
+var (
+	_ DB = (*BadgerDB)(nil)
+	_ DB = (*PebbleDB)(nil)
+)
+
+// Open opens a persistent DB instance using the storage engine selected in options.
+// It refuses to open a directory holding the data of another engine.
+func Open(logger *zap.Logger, options basedb.Options) (DB, error) {
+	engine := options.Engine
+	if engine == "" {
+		engine = basedb.EngineBadger
+	}
+
+	existing, err := DetectEngine(options.Path)
+	if err != nil {
+		return nil, err
+	}
+	if existing != "" && existing != engine {
+		return nil, fmt.Errorf("database at %s was created by %s, but %s is configured (use `db convert` to migrate it)",
+			options.Path, existing, engine)
+	}
+
+	switch engine {
+	case basedb.EngineBadger:
+		return New(logger, options)
+	case basedb.EnginePebble:
+		return NewPebble(logger, options)
+	default:
+		return nil, fmt.Errorf("unknown storage engine %q", engine)
+	}
+}
+
+// DetectEngine returns the engine which created the database at path,
+// or an empty string if path doesn't exist or holds no database.
+func DetectEngine(path string) (basedb.Engine, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read database directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case name == "KEYREGISTRY":
+			return basedb.EngineBadger, nil
+		case name == "CURRENT", strings.HasPrefix(name, "OPTIONS-"):
+			return basedb.EnginePebble, nil
+		}
+	}
+
+	return "", nil
+}
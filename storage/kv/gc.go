@@ -60,3 +60,32 @@ func (b *BadgerDB) FullGC(ctx context.Context) error {
 	}
 	return nil
 }
+
+// QuickGC does nothing, since Pebble reclaims disk space with its background compactions.
+func (p *PebbleDB) QuickGC(ctx context.Context) error {
+	return nil
+}
+
+// FullGC compacts the whole key space, reclaiming the space of all deleted and overwritten items.
+// Designed to be called when the database is not being used.
+func (p *PebbleDB) FullGC(ctx context.Context) error {
+	p.gcMutex.Lock()
+	defer p.gcMutex.Unlock()
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	first, last, ok, err := p.keySpan()
+	if err != nil {
+		return errors.Wrap(err, "failed to find key span")
+	}
+	if !ok {
+		// Nothing to compact.
+		return nil
+	}
+	if err := p.db.Compact(first, append(last, 0), true); err != nil {
+		return errors.Wrap(err, "failed to compact")
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package kv
 import (
 	"fmt"
 
+	"github.com/cockroachdb/pebble"
 	"github.com/dgraph-io/badger/v4"
 	"go.uber.org/zap"
 
@@ -38,3 +39,23 @@ func (bl *badgerLogger) Infof(s string, i ...interface{}) {
 func (bl *badgerLogger) Debugf(s string, i ...interface{}) {
 	bl.logger.Debug(fmt.Sprintf(s, i...))
 }
+
+// pebbleLogger is a wrapper for pebble.Logger
+type pebbleLogger struct {
+	logger *zap.Logger
+}
+
+// newPebbleLogger creates a new instance of logger
+func newPebbleLogger(l *zap.Logger) pebble.Logger {
+	return &pebbleLogger{l.Named(logging.NamePebbleDBLog)}
+}
+
+// Infof implements pebble.Logger
+func (pl *pebbleLogger) Infof(s string, i ...interface{}) {
+	pl.logger.Info(fmt.Sprintf(s, i...))
+}
+
+// Fatalf implements pebble.Logger
+func (pl *pebbleLogger) Fatalf(s string, i ...interface{}) {
+	pl.logger.Fatal(fmt.Sprintf(s, i...))
+}
@@ -0,0 +1,394 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// PebbleDB is a database backed by Pebble.
+// Unlike Badger, Pebble keeps values inside its LSM tree, so disk space is reclaimed
+// by its background compactions rather than by collecting a separate value log.
+type PebbleDB struct {
+	logger *zap.Logger
+
+	db *pebble.DB
+	// conflicts detects conflicts between read-write transactions, see pebbleTxn.
+	conflicts *conflictTracker
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// gcMutex is used to ensure that only one GC cycle is running at a time.
+	gcMutex sync.Mutex
+}
+
+// NewPebble creates a persistent Pebble DB instance.
+func NewPebble(logger *zap.Logger, options basedb.Options) (*PebbleDB, error) {
+	return createPebbleDB(logger, options, false)
+}
+
+// NewPebbleInMemory creates an in-memory Pebble DB instance.
+func NewPebbleInMemory(logger *zap.Logger, options basedb.Options) (*PebbleDB, error) {
+	return createPebbleDB(logger, options, true)
+}
+
+func createPebbleDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*PebbleDB, error) {
+	opt := &pebble.Options{
+		ReadOnly: options.ReadOnly,
+		Logger:   newPebbleLogger(zap.NewNop()),
+	}
+	if logger != nil && options.Reporting {
+		opt.Logger = newPebbleLogger(logger)
+	}
+
+	path := options.Path
+	if inMemory {
+		opt.FS = vfs.NewMem()
+		path = ""
+	}
+
+	db, err := pebble.Open(path, opt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open pebble")
+	}
+
+	// Set up context/cancel to control background goroutines.
+	parentCtx := options.Ctx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parentCtx)
+
+	pebbleDB := PebbleDB{
+		logger:    logger,
+		db:        db,
+		conflicts: newConflictTracker(),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	// Start periodic reporting.
+	if options.Reporting && options.Ctx != nil {
+		pebbleDB.wg.Add(1)
+		go pebbleDB.periodicallyReport(1 * time.Minute)
+	}
+
+	// Pebble compacts in the background, so there's no periodic GC to run.
+
+	return &pebbleDB, nil
+}
+
+// Engine returns basedb.EnginePebble.
+func (p *PebbleDB) Engine() basedb.Engine {
+	return basedb.EnginePebble
+}
+
+// Pebble returns the underlying pebble.DB
+func (p *PebbleDB) Pebble() *pebble.DB {
+	return p.db
+}
+
+// Begin creates a read-write transaction.
+func (p *PebbleDB) Begin() basedb.Txn {
+	return newPebbleTxn(p, true)
+}
+
+// BeginRead creates a read-only transaction.
+func (p *PebbleDB) BeginRead() basedb.ReadTxn {
+	return newPebbleTxn(p, false)
+}
+
+// Set save value with key to storage
+func (p *PebbleDB) Set(prefix []byte, key []byte, value []byte) error {
+	fullKey := joinKey(prefix, key)
+	return p.conflicts.write(writtenKeys(fullKey), func() error {
+		return p.db.Set(fullKey, value, pebbleWriteOptions)
+	})
+}
+
+// SetMany save many values with the given keys in a single batch
+func (p *PebbleDB) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	batch := p.db.NewBatch()
+	defer batch.Close()
+
+	keys := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		item, err := next(i)
+		if err != nil {
+			return err
+		}
+		fullKey := joinKey(prefix, item.Key)
+		if err := batch.Set(fullKey, item.Value, nil); err != nil {
+			return err
+		}
+		keys = append(keys, fullKey)
+	}
+	return p.conflicts.write(writtenKeys(keys...), func() error {
+		return batch.Commit(pebbleWriteOptions)
+	})
+}
+
+// Get return value for specified key
+func (p *PebbleDB) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	return pebbleGet(p.db, prefix, key)
+}
+
+// GetMany return values for the given keys
+func (p *PebbleDB) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	txn := newPebbleTxn(p, false)
+	defer txn.Discard()
+	return txn.GetMany(prefix, keys, iterator)
+}
+
+// Delete key in specific prefix
+func (p *PebbleDB) Delete(prefix []byte, key []byte) error {
+	fullKey := joinKey(prefix, key)
+	return p.conflicts.write(writtenKeys(fullKey), func() error {
+		return p.db.Delete(fullKey, pebbleWriteOptions)
+	})
+}
+
+// GetAll returns all the items of a given collection
+func (p *PebbleDB) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	return pebbleGetAll(p, prefix, handler)
+}
+
+// GetRange iterates over the items under prefix within the range given by opts, in key order.
+func (p *PebbleDB) GetRange(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	return pebbleRange(p.db, prefix, opts, handler)
+}
+
+// CountPrefix return the object count for all keys under specified prefix(bucket)
+func (p *PebbleDB) CountPrefix(prefix []byte) (int64, error) {
+	var res int64
+	err := pebbleRange(p.db, prefix, basedb.RangeOptions{KeysOnly: true}, func(basedb.Obj) error {
+		res++
+		return nil
+	})
+	return res, err
+}
+
+// SizePrefix returns the size of all keys and values under the specified prefix.
+// Unlike Badger's estimate, it's the uncompressed size, so it's usually larger than the actual size on disk.
+func (p *PebbleDB) SizePrefix(prefix []byte) (int64, error) {
+	var res int64
+	err := pebbleRange(p.db, prefix, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+		res += int64(len(prefix) + len(obj.Key) + len(obj.Value))
+		return nil
+	})
+	return res, err
+}
+
+// DropPrefix cleans all items in a collection
+func (p *PebbleDB) DropPrefix(prefix []byte) error {
+	lower, upper := rangeBounds(prefix, basedb.RangeOptions{})
+	if upper == nil {
+		// An empty prefix has no upper bound, so the range ends right after the last key.
+		_, last, ok, err := p.keySpan()
+		if err != nil || !ok {
+			return err
+		}
+		upper = append(last, 0)
+	}
+	writes := committedWrites{ranges: [][2][]byte{{lower, upper}}}
+	return p.conflicts.write(writes, func() error {
+		return p.db.DeleteRange(lower, upper, pebbleWriteOptions)
+	})
+}
+
+// Update creates a read-write transaction, runs fn on it and commits it if fn succeeds.
+func (p *PebbleDB) Update(fn func(basedb.Txn) error) error {
+	txn := newPebbleTxn(p, true)
+	defer txn.Discard()
+
+	if err := fn(txn); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// Close closes the database.
+func (p *PebbleDB) Close() error {
+	// Stop & wait for background goroutines.
+	p.cancel()
+	p.wg.Wait()
+
+	// Close the database.
+	if err := p.db.Close(); err != nil {
+		return errors.Wrap(err, "failed to close pebble")
+	}
+	return nil
+}
+
+// Using returns the given ReadWriter, falling back to the database if it's nil.
+func (p *PebbleDB) Using(rw basedb.ReadWriter) basedb.ReadWriter {
+	if rw == nil {
+		return p
+	}
+	return rw
+}
+
+// UsingReader returns the given Reader, falling back to the database if it's nil.
+func (p *PebbleDB) UsingReader(r basedb.Reader) basedb.Reader {
+	if r == nil {
+		return p
+	}
+	return r
+}
+
+// keySpan returns the first and last keys of the database, if it has any.
+func (p *PebbleDB) keySpan() (first, last []byte, ok bool, err error) {
+	it, err := p.db.NewIter(nil)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	defer it.Close()
+
+	if !it.First() {
+		return nil, nil, false, it.Error()
+	}
+	first = bytes.Clone(it.Key())
+	if !it.Last() {
+		return nil, nil, false, it.Error()
+	}
+	last = bytes.Clone(it.Key())
+	return first, last, true, nil
+}
+
+// report the db size and metrics
+func (p *PebbleDB) report() {
+	logger := p.logger.Named(logging.NamePebbleDBReporting)
+	metrics := p.db.Metrics()
+
+	logger.Debug("PebbleDBReport",
+		zap.Uint64("disk_usage", metrics.DiskSpaceUsage()),
+		zap.Uint64("memtable_size", metrics.MemTable.Size),
+		zap.Int64("compactions", metrics.Compact.Count),
+		zap.Int64("block_cache_size", metrics.BlockCache.Size),
+		zap.Int64("block_cache_hits", metrics.BlockCache.Hits),
+		zap.Int64("block_cache_misses", metrics.BlockCache.Misses),
+	)
+}
+
+func (p *PebbleDB) periodicallyReport(interval time.Duration) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.report()
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+func pebbleGet(r pebble.Reader, prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	value, closer, err := r.Get(joinKey(prefix, key))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) { // in order to couple the not found errors together
+			return basedb.Obj{}, false, nil
+		}
+		return basedb.Obj{}, true, err
+	}
+	defer closer.Close()
+
+	return basedb.Obj{
+		Key:   key,
+		Value: bytes.Clone(value),
+	}, true, nil
+}
+
+func pebbleGetMany(r basedb.Reader, prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	for _, k := range keys {
+		obj, found, err := r.Get(prefix, k)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		if err := iterator(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pebbleGetAll(r basedb.Reader, prefix []byte, handler func(int, basedb.Obj) error) error {
+	i := 0
+	return r.GetRange(prefix, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+		if err := handler(i, obj); err != nil {
+			return err
+		}
+		i++
+		return nil
+	})
+}
+
+func pebbleRange(r pebble.Reader, prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	lower, upper := rangeBounds(prefix, opts)
+	it, err := r.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	valid, next := it.First(), it.Next
+	if opts.Reverse {
+		valid, next = it.Last(), it.Prev
+	}
+
+	count := 0
+	for ; valid; valid = next() {
+		obj := basedb.Obj{
+			Key: bytes.Clone(it.Key()[len(prefix):]),
+		}
+		if !opts.KeysOnly {
+			value, err := it.ValueAndErr()
+			if err != nil {
+				return err
+			}
+			obj.Value = bytes.Clone(value)
+		}
+		if err := handler(obj); err != nil {
+			return err
+		}
+
+		count++
+		if opts.Limit > 0 && count >= opts.Limit {
+			break
+		}
+	}
+
+	return it.Error()
+}
+
+// rangeBounds returns the inclusive lower and exclusive upper bounds of the range given by opts under prefix.
+// The upper bound is nil if the range is unbounded.
+func rangeBounds(prefix []byte, opts basedb.RangeOptions) (lower, upper []byte) {
+	lower = joinKey(prefix, opts.Start)
+	switch {
+	case len(opts.End) > 0:
+		upper = joinKey(prefix, opts.End)
+	case len(prefix) > 0:
+		upper = prefixUpperBound(prefix)
+	}
+	return lower, upper
+}
@@ -0,0 +1,142 @@
+package kv
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// ErrConflict is returned by the commit of a read-write transaction that read a key
+// another transaction wrote and committed since it began. It's the same error Badger returns,
+// so callers handle conflicts the same way with both engines.
+var ErrConflict = badger.ErrConflict
+
+// pebbleWriteOptions makes every write durable once it returns, as slashing protection relies on it.
+var pebbleWriteOptions = pebble.Sync
+
+// conflictTracker detects conflicts between read-write transactions, like Badger does:
+// a transaction fails to commit if a key it read was written by a commit that happened after it began.
+// Writes made outside of transactions count as commits as well.
+type conflictTracker struct {
+	mu sync.Mutex
+	// seq is incremented by every commit.
+	seq uint64
+	// pending counts the read-write transactions still pending per the seq they began at.
+	pending map[uint64]int
+	// commits holds the writes pending transactions may conflict with, in commit order.
+	commits []committedWrites
+}
+
+type committedWrites struct {
+	seq  uint64
+	keys map[string]struct{}
+	// ranges are the [lower, upper) ranges deleted by the commit.
+	ranges [][2][]byte
+}
+
+func newConflictTracker() *conflictTracker {
+	return &conflictTracker{
+		pending: make(map[uint64]int),
+	}
+}
+
+// begin registers a read-write transaction and returns the seq it began at.
+// takeSnapshot is called under the lock, so the snapshot holds exactly the commits up to the seq.
+func (c *conflictTracker) begin(takeSnapshot func()) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	takeSnapshot()
+	c.pending[c.seq]++
+	return c.seq
+}
+
+// end unregisters a read-write transaction that began at seq.
+func (c *conflictTracker) end(seq uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.endLocked(seq)
+}
+
+func (c *conflictTracker) endLocked(seq uint64) {
+	c.pending[seq]--
+	if c.pending[seq] == 0 {
+		delete(c.pending, seq)
+	}
+
+	// Commits no pending transaction began before can't conflict anymore.
+	if len(c.pending) == 0 {
+		c.commits = nil
+		return
+	}
+	oldest := c.seq
+	for seq := range c.pending {
+		oldest = min(oldest, seq)
+	}
+	i := 0
+	for i < len(c.commits) && c.commits[i].seq <= oldest {
+		i++
+	}
+	c.commits = c.commits[i:]
+}
+
+// commit applies the writes, unless a key in reads was written by a commit after readSeq.
+func (c *conflictTracker) commit(readSeq uint64, reads map[string]struct{}, writes committedWrites, apply func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer c.endLocked(readSeq)
+
+	for _, commit := range c.commits {
+		if commit.seq > readSeq && commit.conflicts(reads) {
+			return ErrConflict
+		}
+	}
+
+	return c.applyLocked(writes, apply)
+}
+
+// write applies writes made outside of transactions.
+func (c *conflictTracker) write(writes committedWrites, apply func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.applyLocked(writes, apply)
+}
+
+func (c *conflictTracker) applyLocked(writes committedWrites, apply func() error) error {
+	if err := apply(); err != nil {
+		return err
+	}
+
+	c.seq++
+	if len(c.pending) > 0 {
+		writes.seq = c.seq
+		c.commits = append(c.commits, writes)
+	}
+	return nil
+}
+
+func (w committedWrites) conflicts(reads map[string]struct{}) bool {
+	for key := range reads {
+		if _, ok := w.keys[key]; ok {
+			return true
+		}
+		for _, r := range w.ranges {
+			if bytes.Compare([]byte(key), r[0]) >= 0 && bytes.Compare([]byte(key), r[1]) < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writtenKeys(keys ...[]byte) committedWrites {
+	w := committedWrites{keys: make(map[string]struct{}, len(keys))}
+	for _, key := range keys {
+		w.keys[string(key)] = struct{}{}
+	}
+	return w
+}
@@ -0,0 +1,195 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+func TestPebbleDB_Persistence(t *testing.T) {
+	logger := logging.TestLogger(t)
+	dir := t.TempDir()
+
+	db, err := NewPebble(logger, basedb.Options{Path: dir})
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("prefix/"), []byte("key"), []byte("value")))
+	require.NoError(t, db.Close())
+
+	db, err = NewPebble(logger, basedb.Options{Path: dir, ReadOnly: true})
+	require.NoError(t, err)
+	defer db.Close()
+
+	obj, found, err := db.Get([]byte("prefix/"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+
+	require.Error(t, db.Set([]byte("prefix/"), []byte("other"), []byte("value")))
+}
+
+func TestPebbleDB_BackupRestore(t *testing.T) {
+	source := setupPebbleDB(t)
+	prefix := []byte("prefix")
+	setupDataset(t, source, prefix, 100)
+	require.NoError(t, source.Set([]byte("other"), []byte("key"), []byte("value")))
+	require.NoError(t, source.Set([]byte("other"), []byte("empty"), nil))
+
+	var buf bytes.Buffer
+	_, err := source.Backup(&buf)
+	require.NoError(t, err)
+
+	// Writes after the backup aren't included.
+	require.NoError(t, source.Set([]byte("other"), []byte("late"), []byte("value")))
+
+	target := setupPebbleDB(t)
+	require.NoError(t, target.Restore(&buf))
+
+	count, err := target.CountPrefix(prefix)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, count)
+
+	obj, found, err := target.Get([]byte("other"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+
+	_, found, err = target.Get([]byte("other"), []byte("empty"))
+	require.NoError(t, err)
+	require.True(t, found)
+
+	_, found, err = target.Get([]byte("other"), []byte("late"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	t.Run("truncated", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := source.Backup(&buf)
+		require.NoError(t, err)
+
+		truncated := buf.Bytes()[:buf.Len()-1]
+		require.Error(t, setupPebbleDB(t).Restore(bytes.NewReader(truncated)))
+	})
+}
+
+func TestPebbleDB_FullGC(t *testing.T) {
+	db := setupPebbleDB(t)
+
+	// Empty database.
+	require.NoError(t, db.FullGC(context.Background()))
+
+	prefix := []byte("gc")
+	setupDataset(t, db, prefix, 1000)
+	require.NoError(t, db.DropPrefix(prefix))
+	require.NoError(t, db.QuickGC(context.Background()))
+	require.NoError(t, db.FullGC(context.Background()))
+
+	count, err := db.CountPrefix(prefix)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestPebbleDB_DropAll(t *testing.T) {
+	db := setupPebbleDB(t)
+	setupDataset(t, db, []byte("a"), 10)
+	setupDataset(t, db, []byte{0xff, 0xff}, 10)
+
+	require.NoError(t, db.DropPrefix(nil))
+
+	count, err := db.CountPrefix(nil)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestOpen(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	t.Run("unknown engine", func(t *testing.T) {
+		_, err := Open(logger, basedb.Options{Path: t.TempDir(), Engine: "unknown"})
+		require.ErrorContains(t, err, "unknown storage engine")
+	})
+
+	for _, engine := range []basedb.Engine{basedb.EngineBadger, basedb.EnginePebble} {
+		t.Run(string(engine), func(t *testing.T) {
+			dir := t.TempDir()
+
+			detected, err := DetectEngine(dir)
+			require.NoError(t, err)
+			require.Empty(t, detected)
+
+			db, err := Open(logger, basedb.Options{Path: dir, Engine: engine})
+			require.NoError(t, err)
+			require.Equal(t, engine, db.Engine())
+			require.NoError(t, db.Close())
+
+			detected, err = DetectEngine(dir)
+			require.NoError(t, err)
+			require.Equal(t, engine, detected)
+
+			other := basedb.EnginePebble
+			if engine == basedb.EnginePebble {
+				other = basedb.EngineBadger
+			}
+			_, err = Open(logger, basedb.Options{Path: dir, Engine: other})
+			require.ErrorContains(t, err, "db convert")
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	for _, engine := range testEngines {
+		t.Run(engine.name, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+
+			src, err := engine.newInMemory(logger, basedb.Options{})
+			require.NoError(t, err)
+			defer src.Close()
+
+			setupDataset(t, src, []byte("a/"), convertBatchSize+10)
+			setupDataset(t, src, []byte("b/"), 10)
+
+			for _, target := range testEngines {
+				dst, err := target.newInMemory(logger, basedb.Options{})
+				require.NoError(t, err)
+
+				count, err := Convert(context.Background(), src, dst)
+				require.NoError(t, err)
+				require.EqualValues(t, convertBatchSize+20, count)
+
+				obj, found, err := dst.Get([]byte("b/"), []byte("test-3"))
+				require.NoError(t, err)
+				require.True(t, found)
+				require.Equal(t, []byte("test-3-data"), obj.Value)
+
+				require.NoError(t, dst.Close())
+			}
+		})
+	}
+
+	t.Run("not empty", func(t *testing.T) {
+		src := setupPebbleDB(t)
+		setupDataset(t, src, []byte("a/"), 10)
+		dst := setupPebbleDB(t)
+		setupDataset(t, dst, []byte("b/"), 10)
+
+		_, err := Convert(context.Background(), src, dst)
+		require.ErrorContains(t, err, "expected 10")
+	})
+}
+
+func setupPebbleDB(t *testing.T) *PebbleDB {
+	t.Helper()
+
+	db, err := NewPebbleInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return db
+}
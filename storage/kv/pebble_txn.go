@@ -0,0 +1,280 @@
+package kv
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var (
+	errReadOnlyTxn = errors.New("transaction is read-only")
+	errDoneTxn     = errors.New("transaction has been committed or discarded")
+)
+
+// pebbleTxn reads from a snapshot of the database taken when the transaction began,
+// overlaid with its own pending writes, which are applied atomically on commit.
+//
+// Pebble doesn't detect conflicts between transactions, so read-write transactions keep track
+// of the keys they read and fail to commit with ErrConflict if any of them was written since
+// they began, like Badger transactions do.
+type pebbleTxn struct {
+	db       *PebbleDB
+	snapshot *pebble.Snapshot
+
+	// batch holds the pending writes, it's nil for read-only transactions.
+	batch *pebble.Batch
+	// writes indexes the pending writes by key, so that reads can see them.
+	writes map[string]pendingWrite
+	// reads holds the keys read by a read-write transaction, readSeq is the commit it began after.
+	reads   map[string]struct{}
+	readSeq uint64
+	done    bool
+}
+
+type pendingWrite struct {
+	value   []byte
+	deleted bool
+}
+
+func newPebbleTxn(db *PebbleDB, update bool) *pebbleTxn {
+	txn := &pebbleTxn{
+		db: db,
+	}
+	if !update {
+		txn.snapshot = db.db.NewSnapshot()
+		return txn
+	}
+
+	txn.batch = db.db.NewBatch()
+	txn.writes = make(map[string]pendingWrite)
+	txn.reads = make(map[string]struct{})
+	txn.readSeq = db.conflicts.begin(func() {
+		txn.snapshot = db.db.NewSnapshot()
+	})
+	return txn
+}
+
+func (t *pebbleTxn) Commit() error {
+	if t.done {
+		return errDoneTxn
+	}
+	defer t.Discard()
+
+	if t.batch == nil || t.batch.Empty() {
+		return nil
+	}
+
+	writes := committedWrites{keys: make(map[string]struct{}, len(t.writes))}
+	for key := range t.writes {
+		writes.keys[key] = struct{}{}
+	}
+
+	// The commit ends the transaction in the conflict tracker, so Discard doesn't.
+	t.done = true
+	defer t.close()
+
+	return t.db.conflicts.commit(t.readSeq, t.reads, writes, func() error {
+		return t.db.db.Apply(t.batch, pebbleWriteOptions)
+	})
+}
+
+func (t *pebbleTxn) Discard() {
+	if t.done {
+		return
+	}
+	t.done = true
+
+	if t.batch != nil {
+		t.db.conflicts.end(t.readSeq)
+	}
+	t.close()
+}
+
+func (t *pebbleTxn) close() {
+	_ = t.snapshot.Close()
+	if t.batch != nil {
+		_ = t.batch.Close()
+	}
+}
+
+func (t *pebbleTxn) Set(prefix []byte, key []byte, value []byte) error {
+	if err := t.checkWritable(); err != nil {
+		return err
+	}
+
+	fullKey := joinKey(prefix, key)
+	if err := t.batch.Set(fullKey, value, nil); err != nil {
+		return err
+	}
+	t.writes[string(fullKey)] = pendingWrite{value: bytes.Clone(value)}
+	return nil
+}
+
+func (t *pebbleTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	for i := 0; i < n; i++ {
+		item, err := next(i)
+		if err != nil {
+			return err
+		}
+
+		if err := t.Set(prefix, item.Key, item.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *pebbleTxn) Delete(prefix []byte, key []byte) error {
+	if err := t.checkWritable(); err != nil {
+		return err
+	}
+
+	fullKey := joinKey(prefix, key)
+	if err := t.batch.Delete(fullKey, nil); err != nil {
+		return err
+	}
+	t.writes[string(fullKey)] = pendingWrite{deleted: true}
+	return nil
+}
+
+func (t *pebbleTxn) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	if t.done {
+		return basedb.Obj{}, true, errDoneTxn
+	}
+	fullKey := joinKey(prefix, key)
+	if write, ok := t.writes[string(fullKey)]; ok {
+		if write.deleted {
+			return basedb.Obj{}, false, nil
+		}
+		return basedb.Obj{Key: key, Value: bytes.Clone(write.value)}, true, nil
+	}
+	t.trackRead(fullKey)
+	return pebbleGet(t.snapshot, prefix, key)
+}
+
+func (t *pebbleTxn) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	return pebbleGetMany(t, prefix, keys, iterator)
+}
+
+func (t *pebbleTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	return pebbleGetAll(t, prefix, handler)
+}
+
+func (t *pebbleTxn) GetRange(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	if t.done {
+		return errDoneTxn
+	}
+	if len(t.writes) == 0 {
+		return pebbleRange(t.snapshot, prefix, opts, func(obj basedb.Obj) error {
+			t.trackRead(joinKey(prefix, obj.Key))
+			return handler(obj)
+		})
+	}
+
+	// Merge the pending writes within the range into the iteration over the snapshot.
+	lower, upper := rangeBounds(prefix, opts)
+	pending := t.pendingKeys(lower, upper)
+	if opts.Reverse {
+		slices.Reverse(pending)
+	}
+
+	it, err := t.snapshot.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	valid, next := it.First(), it.Next
+	if opts.Reverse {
+		valid, next = it.Last(), it.Prev
+	}
+
+	count := 0
+	for valid || len(pending) > 0 {
+		fromPending := len(pending) > 0
+		if fromPending && valid {
+			cmp := bytes.Compare(pending[0], it.Key())
+			if opts.Reverse {
+				cmp = -cmp
+			}
+			if cmp == 0 {
+				// The pending write shadows the item in the snapshot.
+				valid = next()
+			}
+			fromPending = cmp <= 0
+		}
+
+		var obj basedb.Obj
+		if fromPending {
+			key := pending[0]
+			pending = pending[1:]
+
+			write := t.writes[string(key)]
+			if write.deleted {
+				continue
+			}
+			obj.Key = key[len(prefix):]
+			if !opts.KeysOnly {
+				obj.Value = bytes.Clone(write.value)
+			}
+		} else {
+			t.trackRead(it.Key())
+			obj.Key = bytes.Clone(it.Key()[len(prefix):])
+			if !opts.KeysOnly {
+				value, err := it.ValueAndErr()
+				if err != nil {
+					return err
+				}
+				obj.Value = bytes.Clone(value)
+			}
+			valid = next()
+		}
+
+		if err := handler(obj); err != nil {
+			return err
+		}
+
+		count++
+		if opts.Limit > 0 && count >= opts.Limit {
+			break
+		}
+	}
+
+	return it.Error()
+}
+
+// pendingKeys returns the sorted keys of the pending writes within the given bounds.
+func (t *pebbleTxn) pendingKeys(lower, upper []byte) [][]byte {
+	var keys [][]byte
+	for key := range t.writes {
+		k := []byte(key)
+		if bytes.Compare(k, lower) < 0 || (upper != nil && bytes.Compare(k, upper) >= 0) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	return keys
+}
+
+// trackRead records a key read from the snapshot by a read-write transaction for conflict detection.
+func (t *pebbleTxn) trackRead(fullKey []byte) {
+	if t.reads != nil {
+		t.reads[string(fullKey)] = struct{}{}
+	}
+}
+
+func (t *pebbleTxn) checkWritable() error {
+	if t.done {
+		return errDoneTxn
+	}
+	if t.batch == nil {
+		return errReadOnlyTxn
+	}
+	return nil
+}
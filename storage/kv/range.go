@@ -0,0 +1,122 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// GetRange iterates over the items under prefix within the range given by opts, in key order.
+func (b *BadgerDB) GetRange(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	return b.db.View(func(txn *badger.Txn) error {
+		return iterateRange(txn, prefix, opts, handler)
+	})
+}
+
+func iterateRange(txn *badger.Txn, prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	itOpts := badger.DefaultIteratorOptions
+	itOpts.Reverse = opts.Reverse
+	if !opts.Reverse {
+		// In reverse, the iteration starts by seeking past the prefix, which the Prefix option would rule out.
+		itOpts.Prefix = prefix
+	}
+	itOpts.PrefetchValues = !opts.KeysOnly
+	if opts.Limit > 0 && opts.Limit < itOpts.PrefetchSize {
+		itOpts.PrefetchSize = opts.Limit
+	}
+
+	it := txn.NewIterator(itOpts)
+	defer it.Close()
+
+	var start, end []byte
+	if len(opts.Start) > 0 {
+		start = joinKey(prefix, opts.Start)
+	}
+	if len(opts.End) > 0 {
+		end = joinKey(prefix, opts.End)
+	}
+
+	inRange := func(key []byte) bool {
+		if !bytes.HasPrefix(key, prefix) {
+			return false
+		}
+		if start != nil && bytes.Compare(key, start) < 0 {
+			return false
+		}
+		if end != nil && bytes.Compare(key, end) >= 0 {
+			return false
+		}
+		return true
+	}
+
+	if !opts.Reverse {
+		seek := prefix
+		if start != nil {
+			seek = start
+		}
+		it.Seek(seek)
+	} else {
+		// In reverse, Seek lands on the greatest key lower than or equal to the given key,
+		// so the seek key must be the exclusive upper bound of the range.
+		seek := end
+		if seek == nil && len(prefix) > 0 {
+			seek = prefixUpperBound(prefix)
+		}
+		it.Seek(seek) // Seeking to nil rewinds to the greatest key.
+		if seek != nil && it.Valid() && bytes.Equal(it.Item().Key(), seek) {
+			it.Next()
+		}
+	}
+
+	count := 0
+	for ; it.Valid(); it.Next() {
+		item := it.Item()
+		key := item.Key()
+		if !inRange(key) {
+			break
+		}
+
+		obj := basedb.Obj{
+			Key: bytes.Clone(key[len(prefix):]),
+		}
+		if !opts.KeysOnly {
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			obj.Value = value
+		}
+		if err := handler(obj); err != nil {
+			return err
+		}
+
+		count++
+		if opts.Limit > 0 && count >= opts.Limit {
+			break
+		}
+	}
+
+	return nil
+}
+
+// prefixUpperBound returns the smallest key greater than all keys starting with prefix.
+// If there is no such key (prefix is all 0xff), it returns a key greater than all keys
+// starting with prefix which are at most 256 bytes longer than it.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			bound := bytes.Clone(prefix[:i+1])
+			bound[i]++
+			return bound
+		}
+	}
+	return append(bytes.Clone(prefix), bytes.Repeat([]byte{0xff}, 256)...)
+}
+
+func joinKey(prefix, key []byte) []byte {
+	joined := make([]byte, 0, len(prefix)+len(key))
+	joined = append(joined, prefix...)
+	return append(joined, key...)
+}
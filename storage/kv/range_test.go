@@ -0,0 +1,189 @@
+package kv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+func collectRange(t *testing.T, r basedb.Reader, prefix []byte, opts basedb.RangeOptions) ([]string, []string) {
+	t.Helper()
+	var keys, values []string
+	err := r.GetRange(prefix, opts, func(obj basedb.Obj) error {
+		keys = append(keys, string(obj.Key))
+		if obj.Value != nil {
+			values = append(values, string(obj.Value))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return keys, values
+}
+
+func TestGetRange(t *testing.T) {
+	t.Parallel()
+
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
+		prefix := []byte("range/")
+		for _, key := range []string{"a", "b", "c", "d", "e"} {
+			require.NoError(t, db.Set(prefix, []byte(key), []byte("value-"+key)))
+		}
+		// Keys right before and after the prefix must never be included.
+		require.NoError(t, db.Set([]byte("range."), []byte("z"), []byte("before")))
+		require.NoError(t, db.Set([]byte("range0"), nil, []byte("after")))
+
+		tests := []struct {
+			name   string
+			opts   basedb.RangeOptions
+			keys   []string
+			values []string
+		}{
+			{
+				name:   "all",
+				opts:   basedb.RangeOptions{},
+				keys:   []string{"a", "b", "c", "d", "e"},
+				values: []string{"value-a", "value-b", "value-c", "value-d", "value-e"},
+			},
+			{
+				name:   "start and end",
+				opts:   basedb.RangeOptions{Start: []byte("b"), End: []byte("d")},
+				keys:   []string{"b", "c"},
+				values: []string{"value-b", "value-c"},
+			},
+			{
+				name:   "start between keys",
+				opts:   basedb.RangeOptions{Start: []byte("bb")},
+				keys:   []string{"c", "d", "e"},
+				values: []string{"value-c", "value-d", "value-e"},
+			},
+			{
+				name:   "reverse",
+				opts:   basedb.RangeOptions{Reverse: true},
+				keys:   []string{"e", "d", "c", "b", "a"},
+				values: []string{"value-e", "value-d", "value-c", "value-b", "value-a"},
+			},
+			{
+				name:   "reverse with start and end",
+				opts:   basedb.RangeOptions{Start: []byte("b"), End: []byte("d"), Reverse: true},
+				keys:   []string{"c", "b"},
+				values: []string{"value-c", "value-b"},
+			},
+			{
+				name:   "limit",
+				opts:   basedb.RangeOptions{Limit: 2},
+				keys:   []string{"a", "b"},
+				values: []string{"value-a", "value-b"},
+			},
+			{
+				name:   "reverse with limit",
+				opts:   basedb.RangeOptions{Reverse: true, Limit: 2},
+				keys:   []string{"e", "d"},
+				values: []string{"value-e", "value-d"},
+			},
+			{
+				name: "keys only",
+				opts: basedb.RangeOptions{Start: []byte("d"), KeysOnly: true},
+				keys: []string{"d", "e"},
+			},
+			{
+				name: "empty range",
+				opts: basedb.RangeOptions{Start: []byte("c"), End: []byte("c")},
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				keys, values := collectRange(t, db, prefix, tc.opts)
+				require.Equal(t, tc.keys, keys)
+				require.Equal(t, tc.values, values)
+
+				txn := db.BeginRead()
+				defer txn.Discard()
+				keys, values = collectRange(t, txn, prefix, tc.opts)
+				require.Equal(t, tc.keys, keys)
+				require.Equal(t, tc.values, values)
+			})
+		}
+	})
+}
+
+func TestGetRange_PrefixBoundaries(t *testing.T) {
+	t.Parallel()
+
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
+		prefix := []byte{0x01, 0xff}
+		require.NoError(t, db.Set(prefix, []byte{0x00}, []byte("first")))
+		require.NoError(t, db.Set(prefix, []byte{0xff, 0xff}, []byte("last")))
+		// The smallest key after the prefix.
+		require.NoError(t, db.Set([]byte{0x02}, nil, []byte("outside")))
+
+		keys, _ := collectRange(t, db, prefix, basedb.RangeOptions{Reverse: true})
+		require.Equal(t, []string{string([]byte{0xff, 0xff}), string([]byte{0x00})}, keys)
+
+		// An empty prefix iterates over the whole database.
+		keys, _ = collectRange(t, db, nil, basedb.RangeOptions{Reverse: true, KeysOnly: true})
+		require.Len(t, keys, 3)
+		require.Equal(t, string([]byte{0x02}), keys[0])
+	})
+}
+
+func TestGetRange_HandlerError(t *testing.T) {
+	t.Parallel()
+
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
+		prefix := []byte("range/")
+		for _, key := range []string{"a", "b", "c"} {
+			require.NoError(t, db.Set(prefix, []byte(key), nil))
+		}
+
+		var visited int
+		errStop := errors.New("stop")
+		err := db.GetRange(prefix, basedb.RangeOptions{}, func(basedb.Obj) error {
+			visited++
+			return errStop
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Equal(t, 1, visited)
+	})
+}
+
+func TestGetRange_PendingWrites(t *testing.T) {
+	t.Parallel()
+
+	forEachEngine(t, func(t *testing.T, setupDB setupDBFunc) {
+		db := setupDB(t, basedb.Options{})
+		prefix := []byte("pending/")
+		for _, key := range []string{"a", "c", "e"} {
+			require.NoError(t, db.Set(prefix, []byte(key), []byte("stored-"+key)))
+		}
+
+		txn := db.Begin()
+		defer txn.Discard()
+		require.NoError(t, txn.Set(prefix, []byte("b"), []byte("pending-b")))
+		require.NoError(t, txn.Set(prefix, []byte("c"), []byte("pending-c")))
+		require.NoError(t, txn.Delete(prefix, []byte("e")))
+		require.NoError(t, txn.Set([]byte("other/"), []byte("x"), []byte("pending-x")))
+
+		keys, values := collectRange(t, txn, prefix, basedb.RangeOptions{})
+		require.Equal(t, []string{"a", "b", "c"}, keys)
+		require.Equal(t, []string{"stored-a", "pending-b", "pending-c"}, values)
+
+		keys, _ = collectRange(t, txn, prefix, basedb.RangeOptions{Reverse: true, Limit: 2})
+		require.Equal(t, []string{"c", "b"}, keys)
+
+		// The pending writes aren't visible outside the transaction until it's committed.
+		keys, _ = collectRange(t, db, prefix, basedb.RangeOptions{})
+		require.Equal(t, []string{"a", "c", "e"}, keys)
+
+		require.NoError(t, txn.Commit())
+		keys, values = collectRange(t, db, prefix, basedb.RangeOptions{})
+		require.Equal(t, []string{"a", "b", "c"}, keys)
+		require.Equal(t, []string{"stored-a", "pending-b", "pending-c"}, values)
+	})
+}
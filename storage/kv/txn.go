@@ -81,3 +81,7 @@ func (t badgerTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) er
 func (t badgerTxn) Delete(prefix []byte, key []byte) error {
 	return t.txn.Delete(append(prefix, key...))
 }
+
+func (t badgerTxn) GetRange(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	return iterateRange(t.txn, prefix, opts, handler)
+}